@@ -0,0 +1,195 @@
+// Package bootstrap wires the dependencies shared by the commands of the
+// service.
+package bootstrap
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/config"
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/auth"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/imaging"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/jaeger"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/kafka"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/gorm"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/storage"
+	"github.com/EdlanioJ/kbu-store/app/usecases"
+	"github.com/go-playground/validator/v10"
+	"github.com/opentracing/opentracing-go"
+	log "github.com/sirupsen/logrus"
+	gormio "gorm.io/gorm"
+)
+
+// A Container holds a single instance of each dependency of the service,
+// built from the config and shared by every component run in the process
+type Container struct {
+	Config          *config.Config
+	Database        *gormio.DB
+	Validate        *validator.Validate
+	StoreUsecase    *usecases.StoreUsecase
+	CategoryUsecase *usecases.CategoryUsecase
+	InboxUsecase    *usecases.InboxUsecase
+	OutboxUsecase   *usecases.OutboxUsecase
+	HealthUsecase   *usecases.HealthUsecase
+	KafkaHealth     *kafka.HealthChecker
+
+	producer *kafka.KafkaProducer
+	verifier *auth.JWTVerifier
+	closers  []io.Closer
+}
+
+// NewContainer connects to the database and builds the usecases on top of it
+func NewContainer(cfg *config.Config) *Container {
+	database := repository.GORMConnection(cfg)
+
+	tc := time.Duration(cfg.Timeout) * time.Second
+	storeRepo := gorm.NewStoreRepository(database)
+	accountRepo := gorm.NewAccountRepository(database)
+	ledgerRepo := gorm.NewLedgerRepository(database)
+	categoryRepo := gorm.NewCategoryRepository(database)
+	outboxRepo := gorm.NewOutboxRepository(database)
+	inboxRepo := gorm.NewInboxRepository(database)
+	historyRepo := gorm.NewStoreStatusHistoryRepository(database)
+	hoursRepo := gorm.NewStoreHoursRepository(database)
+	txManager := gorm.NewTxManager(database)
+
+	blobStorage, err := storage.NewBlobStorage(cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	storeUsecase := usecases.NewStoreUsecase(
+		storeRepo,
+		accountRepo,
+		ledgerRepo,
+		historyRepo,
+		hoursRepo,
+		categoryRepo,
+		outboxRepo,
+		txManager,
+		blobStorage,
+		imaging.NewProcessor(),
+		tc,
+	)
+	storeUsecase.NewStoreTopic = cfg.Kafka.NewStoreTopic
+	storeUsecase.UpdateStoreTopic = cfg.Kafka.UpdateStoreTopic
+	storeUsecase.DeleteStoreTopic = cfg.Kafka.DeleteStoreTopic
+	storeUsecase.BalanceUpdatedTopic = cfg.Kafka.BalanceUpdatedTopic
+	storeUsecase.CursorSecret = []byte(cfg.CursorSecret)
+	storeUsecase.ExpiryBatchSize = cfg.Moderation.BatchSize
+	storeUsecase.PurgeBatchSize = cfg.Purge.BatchSize
+	storeUsecase.MaxImageSize = cfg.Storage.MaxImageSize
+	storeUsecase.UploadTimeout = time.Duration(cfg.Storage.UploadTimeout) * time.Second
+
+	categoryUsecase := usecases.NewCategoryUsecase(categoryRepo, storeRepo, historyRepo, outboxRepo, txManager, tc)
+	categoryUsecase.UpdateStoreTopic = cfg.Kafka.UpdateStoreTopic
+	categoryUsecase.Cascade = domain.CategoryCascadePolicy{
+		Mode:    cfg.Category.Cascade,
+		Restore: cfg.Category.Restore,
+	}
+
+	kafkaHealth := kafka.NewHealthChecker(cfg)
+
+	healthUsecase := usecases.NewHealthUsecase(tc)
+	healthUsecase.Register("postgres", repository.GORMHealthCheck(database))
+	healthUsecase.Register("kafka", kafkaHealth.CheckBrokers)
+
+	return &Container{
+		Config:          cfg,
+		Database:        database,
+		Validate:        validator.New(),
+		StoreUsecase:    storeUsecase,
+		CategoryUsecase: categoryUsecase,
+		InboxUsecase:    usecases.NewInboxUsecase(inboxRepo, txManager, tc),
+		HealthUsecase:   healthUsecase,
+		KafkaHealth:     kafkaHealth,
+	}
+}
+
+// InitTracer sets the jaeger tracer as the global tracer. It is flushed on
+// Close.
+func (c *Container) InitTracer() error {
+	tracer, closer, err := jaeger.InitJaeger(c.Config)
+	if err != nil {
+		return err
+	}
+
+	opentracing.SetGlobalTracer(tracer)
+	c.closers = append(c.closers, closer)
+	return nil
+}
+
+// Producer returns the kafka producer, connecting it on first use. Its
+// buffered messages are flushed on Close.
+func (c *Container) Producer() *kafka.KafkaProducer {
+	if c.producer == nil {
+		c.producer = kafka.NewKafkaProducer(c.Config)
+	}
+	return c.producer
+}
+
+// Verifier returns the verifier of the access tokens, failing when no
+// token key is configured
+func (c *Container) Verifier() (*auth.JWTVerifier, error) {
+	if c.verifier == nil {
+		verifier, err := auth.NewJWTVerifier(c.Config)
+		if err != nil {
+			return nil, err
+		}
+		c.verifier = verifier
+	}
+	return c.verifier, nil
+}
+
+// CheckCursorSecret fails when no secret is configured to sign the page
+// cursors of the store listings with
+func (c *Container) CheckCursorSecret() error {
+	if c.Config.CursorSecret == "" {
+		return errors.New("no cursor secret configured")
+	}
+	return nil
+}
+
+// Outbox returns the outbox usecase relaying through Producer
+func (c *Container) Outbox() *usecases.OutboxUsecase {
+	if c.OutboxUsecase == nil {
+		tc := time.Duration(c.Config.Timeout) * time.Second
+		c.OutboxUsecase = usecases.NewOutboxUsecase(gorm.NewOutboxRepository(c.Database), gorm.NewTxManager(c.Database), c.Producer(), tc)
+		c.OutboxUsecase.BatchSize = c.Config.Outbox.BatchSize
+		c.OutboxUsecase.Backoff = time.Duration(c.Config.Outbox.Backoff) * time.Second
+		c.OutboxUsecase.MaxBackoff = time.Duration(c.Config.Outbox.MaxBackoff) * time.Second
+	}
+	return c.OutboxUsecase
+}
+
+// Consumer returns a new kafka consumer of the category topics
+func (c *Container) Consumer() *kafka.KafkaConsumer {
+	consumer := kafka.NewKafkaConsumer(c.Config)
+	consumer.CategoryUsecase = c.CategoryUsecase
+	consumer.InboxUsecase = c.InboxUsecase
+	return consumer
+}
+
+// Close flushes the producer and the tracer, and closes the database pool
+func (c *Container) Close() {
+	if c.producer != nil {
+		c.producer.Close()
+	}
+
+	sqlDB, err := c.Database.DB()
+	if err == nil {
+		err = sqlDB.Close()
+	}
+	if err != nil {
+		log.Error(err)
+	}
+
+	for i := len(c.closers) - 1; i >= 0; i-- {
+		if err := c.closers[i].Close(); err != nil {
+			log.Error(err)
+		}
+	}
+}
@@ -0,0 +1,20 @@
+package bootstrap_test
+
+import (
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/bootstrap"
+	"github.com/EdlanioJ/kbu-store/app/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContainer_CheckCursorSecret(t *testing.T) {
+	t.Run("empty_secret", func(t *testing.T) {
+		container := &bootstrap.Container{Config: &config.Config{}}
+		assert.Error(t, container.CheckCursorSecret())
+	})
+	t.Run("secret", func(t *testing.T) {
+		container := &bootstrap.Container{Config: &config.Config{CursorSecret: "secret"}}
+		assert.NoError(t, container.CheckCursorSecret())
+	})
+}
@@ -0,0 +1,79 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Readiness tracks the components of the process that are not up yet. It is
+// ready once every one of them was marked ready.
+type Readiness struct {
+	mu      sync.Mutex
+	pending map[string]bool
+	done    chan struct{}
+}
+
+// NewReadiness waits for each of components to be marked ready
+func NewReadiness(components ...string) *Readiness {
+	r := &Readiness{
+		pending: make(map[string]bool),
+		done:    make(chan struct{}),
+	}
+	for _, component := range components {
+		r.pending[component] = true
+	}
+	if len(r.pending) == 0 {
+		close(r.done)
+	}
+	return r
+}
+
+// MarkReady marks component as up. Unknown components are ignored.
+func (r *Readiness) MarkReady(component string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.pending[component] {
+		return
+	}
+
+	delete(r.pending, component)
+	if len(r.pending) == 0 {
+		close(r.done)
+	}
+}
+
+// Ready reports whether every component is up
+func (r *Readiness) Ready() bool {
+	select {
+	case <-r.done:
+		return true
+	default:
+		return false
+	}
+}
+
+// Done returns a channel closed once every component is up
+func (r *Readiness) Done() <-chan struct{} {
+	return r.done
+}
+
+// Check fails with the components that are not up yet
+func (r *Readiness) Check(context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.pending) == 0 {
+		return nil
+	}
+
+	pending := make([]string, 0, len(r.pending))
+	for component := range r.pending {
+		pending = append(pending, component)
+	}
+	sort.Strings(pending)
+	return fmt.Errorf("waiting for %s", strings.Join(pending, ", "))
+}
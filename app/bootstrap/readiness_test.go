@@ -0,0 +1,58 @@
+package bootstrap_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/bootstrap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadiness(t *testing.T) {
+	testCases := []struct {
+		name       string
+		components []string
+		marked     []string
+		ready      bool
+	}{
+		{
+			name:  "no_components",
+			ready: true,
+		},
+		{
+			name:       "pending_components",
+			components: []string{"http", "grpc"},
+			marked:     []string{"http", "consumer"},
+		},
+		{
+			name:       "every_component_ready",
+			components: []string{"http", "grpc"},
+			marked:     []string{"grpc", "http", "http"},
+			ready:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			r := bootstrap.NewReadiness(tc.components...)
+			for _, component := range tc.marked {
+				r.MarkReady(component)
+			}
+
+			assert.Equal(t, tc.ready, r.Ready())
+			select {
+			case <-r.Done():
+				assert.True(t, tc.ready)
+			default:
+				assert.False(t, tc.ready)
+			}
+			if tc.ready {
+				assert.NoError(t, r.Check(context.TODO()))
+			} else {
+				assert.EqualError(t, r.Check(context.TODO()), "waiting for grpc")
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/EdlanioJ/kbu-store/app/bootstrap"
+	"github.com/EdlanioJ/kbu-store/app/config"
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportOutput string
+	exportFormat string
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write every store as CSV or NDJSON",
+	RunE: func(*cobra.Command, []string) error {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			panic(err)
+		}
+
+		ctx, stop := rootContext()
+		defer stop()
+
+		var w io.Writer = os.Stdout
+		if exportOutput != "" && exportOutput != "-" {
+			file, err := os.Create(exportOutput)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			w = file
+		}
+
+		container := bootstrap.NewContainer(cfg)
+		defer container.Close()
+
+		format := fileFormat(exportFormat, exportOutput)
+		log.Infof("\u001b[92mExporting stores as %s...\u001b[0m", format)
+		ctx = domain.ContextWithClaims(ctx, &domain.Claims{Roles: []string{domain.RoleAdmin}})
+		return container.StoreUsecase.Export(ctx, format, w)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "file to write the stores to (default stdout)")
+	exportCmd.Flags().StringVar(&exportFormat, "format", "", "csv or ndjson (default from the output extension, else ndjson)")
+}
@@ -1,14 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"time"
 
+	"github.com/EdlanioJ/kbu-store/app/bootstrap"
 	"github.com/EdlanioJ/kbu-store/app/config"
 	"github.com/EdlanioJ/kbu-store/app/infrastructure/grpc"
-	"github.com/EdlanioJ/kbu-store/app/infrastructure/kafka"
-	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository"
-	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/gorm"
-	"github.com/EdlanioJ/kbu-store/app/usecases"
 	"github.com/spf13/cobra"
 )
 
@@ -18,44 +16,53 @@ var port int
 var grpcCmd = &cobra.Command{
 	Use:   "grpc",
 	Short: "start grpc server",
-	Run: func(*cobra.Command, []string) {
+	RunE: func(*cobra.Command, []string) error {
 		cfg, err := config.LoadConfig(".")
 		if err != nil {
 			panic(err)
 		}
 
-		database := repository.GORMConnection(cfg)
+		ctx, stop := rootContext()
+		defer stop()
 
-		grpcServer := grpc.NewGrpcServer()
+		container := bootstrap.NewContainer(cfg)
+		defer container.Close()
 
-		grpcServer.Port = cfg.Grpc.Port
-		if port != 0 {
-			grpcServer.Port = port
-		}
+		return runGRPC(ctx, container, port, nil)
+	},
+}
 
-		tc := time.Duration(cfg.Timeout) * time.Second
-		kafkaProducer := kafka.NewKafkaProducer(cfg)
-		storeRepo := gorm.NewStoreRepository(database)
-		accountRepo := gorm.NewAccountRepository(database)
-		categoryRepo := gorm.NewCategoryRepository(database)
+// runGRPC serves the grpc api until ctx is done
+func runGRPC(ctx context.Context, container *bootstrap.Container, port int, onReady func()) error {
+	cfg := container.Config
 
-		grpcServer.MetricPort = cfg.Grpc.MetricPort
-		storeUsecase := usecases.NewStoreUsecase(
-			storeRepo,
-			accountRepo,
-			categoryRepo,
-			kafkaProducer,
-			tc,
-		)
+	verifier, err := container.Verifier()
+	if err != nil {
+		return err
+	}
 
-		storeUsecase.NewStoreTopic = cfg.Kafka.NewStoreTopic
-		storeUsecase.UpdateStoreTopic = cfg.Kafka.UpdateStoreTopic
-		storeUsecase.DeleteStoreTopic = cfg.Kafka.DeleteStoreTopic
+	err = container.CheckCursorSecret()
+	if err != nil {
+		return err
+	}
 
-		grpcServer.StoreUsecase = storeUsecase
+	grpcServer := grpc.NewGrpcServer()
 
-		grpcServer.Serve()
-	},
+	grpcServer.Port = cfg.Grpc.Port
+	if port != 0 {
+		grpcServer.Port = port
+	}
+
+	grpcServer.MetricPort = cfg.Grpc.MetricPort
+	grpcServer.ShutdownTimeout = time.Duration(cfg.ShutdownTimeout) * time.Second
+	grpcServer.OnReady = onReady
+	grpcServer.StoreUsecase = container.StoreUsecase
+	grpcServer.CategoryUsecase = container.CategoryUsecase
+	grpcServer.HealthUsecase = container.HealthUsecase
+	grpcServer.TokenVerifier = verifier
+	grpcServer.Validate = container.Validate
+
+	return grpcServer.Serve(ctx)
 }
 
 func init() {
@@ -1,18 +1,13 @@
 package cmd
 
 import (
+	"context"
+	"fmt"
 	"time"
 
+	"github.com/EdlanioJ/kbu-store/app/bootstrap"
 	"github.com/EdlanioJ/kbu-store/app/config"
 	"github.com/EdlanioJ/kbu-store/app/infrastructure/http"
-	"github.com/EdlanioJ/kbu-store/app/infrastructure/jaeger"
-	"github.com/EdlanioJ/kbu-store/app/infrastructure/kafka"
-	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository"
-	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/gorm"
-	"github.com/EdlanioJ/kbu-store/app/usecases"
-	"github.com/go-playground/validator/v10"
-	"github.com/opentracing/opentracing-go"
-	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
@@ -22,56 +17,61 @@ var httpPort int
 var httpCmd = &cobra.Command{
 	Use:   "http",
 	Short: "start http server",
-	Run: func(*cobra.Command, []string) {
+	RunE: func(*cobra.Command, []string) error {
 		cfg, err := config.LoadConfig()
 		if err != nil {
 			panic(err)
 		}
 
-		tracer, closer, err := jaeger.InitJaeger(cfg)
-		if err != nil {
-			log.Fatal("cannot create tracer ", err)
-		}
-
-		opentracing.SetGlobalTracer(tracer)
-		defer closer.Close()
+		ctx, stop := rootContext()
+		defer stop()
 
-		database := repository.GORMConnection(cfg)
+		container := bootstrap.NewContainer(cfg)
+		defer container.Close()
 
-		httpServer := http.NewHttpServer()
-
-		httpServer.Port = cfg.Port
-		if httpPort != 0 {
-			httpServer.Port = httpPort
+		err = container.InitTracer()
+		if err != nil {
+			return fmt.Errorf("cannot create tracer: %w", err)
 		}
 
-		kafkaProducer := kafka.NewKafkaProducer(cfg)
-		defer kafkaProducer.Close()
-
-		tc := time.Duration(cfg.Timeout) * time.Second
-		storeRepo := gorm.NewStoreRepository(database)
-		accountRepo := gorm.NewAccountRepository(database)
-		categoryRepo := gorm.NewCategoryRepository(database)
-
-		storeUsecase := usecases.NewStoreUsecase(
-			storeRepo,
-			accountRepo,
-			categoryRepo,
-			kafkaProducer,
-			tc,
-		)
-
-		storeUsecase.NewStoreTopic = cfg.Kafka.NewStoreTopic
-		storeUsecase.UpdateStoreTopic = cfg.Kafka.UpdateStoreTopic
-		storeUsecase.DeleteStoreTopic = cfg.Kafka.DeleteStoreTopic
-
-		httpServer.StoreUsecase = storeUsecase
-		httpServer.Validate = validator.New()
-
-		httpServer.Serve()
+		return runHTTP(ctx, container, httpPort, nil)
 	},
 }
 
+// runHTTP serves the http api until ctx is done
+func runHTTP(ctx context.Context, container *bootstrap.Container, port int, onReady func()) error {
+	cfg := container.Config
+
+	verifier, err := container.Verifier()
+	if err != nil {
+		return err
+	}
+
+	err = container.CheckCursorSecret()
+	if err != nil {
+		return err
+	}
+
+	httpServer := http.NewHttpServer()
+
+	httpServer.Port = cfg.Port
+	if port != 0 {
+		httpServer.Port = port
+	}
+
+	httpServer.ShutdownTimeout = time.Duration(cfg.ShutdownTimeout) * time.Second
+	httpServer.OnReady = onReady
+	// room for the multipart encoding around the largest accepted image
+	httpServer.BodyLimit = int(cfg.Storage.MaxImageSize) + 1<<20
+	httpServer.StoreUsecase = container.StoreUsecase
+	httpServer.CategoryUsecase = container.CategoryUsecase
+	httpServer.HealthUsecase = container.HealthUsecase
+	httpServer.TokenVerifier = verifier
+	httpServer.Validate = container.Validate
+
+	return httpServer.Serve(ctx)
+}
+
 func init() {
 	rootCmd.AddCommand(httpCmd)
 	httpCmd.Flags().IntVarP(&httpPort, "port", "p", 0, "http server port")
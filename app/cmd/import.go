@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/EdlanioJ/kbu-store/app/bootstrap"
+	"github.com/EdlanioJ/kbu-store/app/config"
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/go-playground/validator/v10"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	importFile   string
+	importFormat string
+	importOwner  string
+	importDryRun bool
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Create the stores of a CSV or NDJSON file",
+	RunE: func(*cobra.Command, []string) error {
+		if err := validator.New().Var(importOwner, "uuid4"); err != nil {
+			return fmt.Errorf("--owner %q is not a user id", importOwner)
+		}
+
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			panic(err)
+		}
+
+		ctx, stop := rootContext()
+		defer stop()
+
+		var body io.Reader = os.Stdin
+		if importFile != "" && importFile != "-" {
+			file, err := os.Open(importFile)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+			body = file
+		}
+
+		container := bootstrap.NewContainer(cfg)
+		defer container.Close()
+
+		log.Infof("\u001b[92mImporting stores for %s...\u001b[0m", importOwner)
+		ctx = domain.ContextWithClaims(ctx, &domain.Claims{UserID: importOwner})
+		report, err := container.StoreUsecase.Import(ctx, &domain.StoreImportRequest{
+			Format: fileFormat(importFormat, importFile),
+			Body:   body,
+			DryRun: importDryRun,
+		})
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+		log.Infof("imported %d of %d stores, %d failed", report.Imported, report.Rows, report.Failed)
+		return nil
+	},
+}
+
+// fileFormat returns format, or else the format of the extension of path,
+// NDJSON being the default
+func fileFormat(format, path string) string {
+	if format != "" {
+		return format
+	}
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return domain.StoreFormatCSV
+	}
+	return domain.StoreFormatNDJSON
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+	importCmd.Flags().StringVarP(&importFile, "file", "f", "", "file of the stores (default stdin)")
+	importCmd.Flags().StringVar(&importFormat, "format", "", "csv or ndjson (default from the file extension, else ndjson)")
+	importCmd.Flags().StringVar(&importOwner, "owner", "", "id of the user owning the imported stores")
+	importCmd.Flags().BoolVar(&importDryRun, "dry-run", false, "only check the rows, without creating the stores")
+	importCmd.MarkFlagRequired("owner")
+}
@@ -1,13 +1,10 @@
 package cmd
 
 import (
-	"time"
+	"context"
 
+	"github.com/EdlanioJ/kbu-store/app/bootstrap"
 	"github.com/EdlanioJ/kbu-store/app/config"
-	"github.com/EdlanioJ/kbu-store/app/infrastructure/kafka"
-	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository"
-	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/gorm"
-	"github.com/EdlanioJ/kbu-store/app/usecases"
 	"github.com/spf13/cobra"
 )
 
@@ -15,23 +12,28 @@ import (
 var kafkaCmd = &cobra.Command{
 	Use:   "kafka",
 	Short: "Start kafka consumer",
-	Run: func(*cobra.Command, []string) {
+	RunE: func(*cobra.Command, []string) error {
 		cfg, err := config.LoadConfig()
 		if err != nil {
 			panic(err)
 		}
 
-		database := repository.GORMConnection(cfg)
+		ctx, stop := rootContext()
+		defer stop()
 
-		tc := time.Duration(cfg.Timeout) * time.Second
+		container := bootstrap.NewContainer(cfg)
+		defer container.Close()
 
-		categoryRepo := gorm.NewCategoryRepository(database)
+		return runConsumer(ctx, container, nil)
+	},
+}
 
-		kafkaCosumer := kafka.NewKafkaConsumer(cfg)
-		kafkaCosumer.CategoryUsecase = usecases.NewCategoryUsecase(categoryRepo, tc)
+// runConsumer consumes the category topics until ctx is done
+func runConsumer(ctx context.Context, container *bootstrap.Container, onReady func()) error {
+	kafkaCosumer := container.Consumer()
+	kafkaCosumer.OnReady = onReady
 
-		kafkaCosumer.Consume()
-	},
+	return kafkaCosumer.Consume(ctx)
 }
 
 func init() {
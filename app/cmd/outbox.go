@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/bootstrap"
+	"github.com/EdlanioJ/kbu-store/app/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// outboxCmd represents the outbox command
+var outboxCmd = &cobra.Command{
+	Use:   "outbox",
+	Short: "Start outbox relay worker",
+	Run: func(*cobra.Command, []string) {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			panic(err)
+		}
+
+		ctx, stop := rootContext()
+		defer stop()
+
+		// closing flushes the messages still buffered by the producer
+		container := bootstrap.NewContainer(cfg)
+		defer container.Close()
+
+		outboxUsecase := container.Outbox()
+		interval := time.Duration(cfg.Outbox.Interval) * time.Second
+
+		log.Info("\u001b[92mStart Relaying...\u001b[0m")
+		for ctx.Err() == nil {
+			delivered, err := outboxUsecase.Relay(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Error(err)
+			}
+
+			// a full batch means more messages are probably waiting
+			if err != nil || delivered < outboxUsecase.BatchSize {
+				select {
+				case <-ctx.Done():
+				case <-time.After(interval):
+				}
+			}
+		}
+		log.Info("shutting down outbox relay...")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(outboxCmd)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/bootstrap"
+	"github.com/EdlanioJ/kbu-store/app/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var purgeOlderThan time.Duration
+
+// purgeCmd represents the purge command
+var purgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Permanently remove the stores deleted before the retention",
+	RunE: func(*cobra.Command, []string) error {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			panic(err)
+		}
+
+		ctx, stop := rootContext()
+		defer stop()
+
+		container := bootstrap.NewContainer(cfg)
+		defer container.Close()
+
+		storeUsecase := container.StoreUsecase
+		olderThan := purgeOlderThan
+		if olderThan == 0 {
+			olderThan = time.Duration(cfg.Purge.Retention) * 24 * time.Hour
+		}
+
+		log.Infof("\u001b[92mPurging stores deleted more than %s ago...\u001b[0m", olderThan)
+		total := 0
+		for ctx.Err() == nil {
+			purged, err := storeUsecase.Purge(ctx, olderThan)
+			total += purged
+			if err != nil {
+				return fmt.Errorf("purged %d stores, but: %w", total, err)
+			}
+
+			// a full batch means more stores are probably waiting
+			if purged < storeUsecase.PurgeBatchSize {
+				break
+			}
+		}
+		log.Infof("purged %d stores", total)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(purgeCmd)
+	purgeCmd.Flags().DurationVar(&purgeOlderThan, "older-than", 0, "purge the stores deleted more than this long ago (default PURGE.RETENTION days)")
+}
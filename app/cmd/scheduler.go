@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/bootstrap"
+	"github.com/EdlanioJ/kbu-store/app/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// schedulerCmd represents the scheduler command
+var schedulerCmd = &cobra.Command{
+	Use:   "scheduler",
+	Short: "Start scheduler lifting expired store blocks",
+	Run: func(*cobra.Command, []string) {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			panic(err)
+		}
+
+		ctx, stop := rootContext()
+		defer stop()
+
+		container := bootstrap.NewContainer(cfg)
+		defer container.Close()
+
+		storeUsecase := container.StoreUsecase
+		interval := time.Duration(cfg.Moderation.Interval) * time.Second
+
+		log.Info("\u001b[92mStart Scheduling...\u001b[0m")
+		for ctx.Err() == nil {
+			released, err := storeUsecase.ReleaseExpired(ctx)
+			if err != nil && ctx.Err() == nil {
+				log.Error(err)
+			}
+			if released > 0 {
+				log.Infof("released %d stores", released)
+			}
+
+			// a full batch means more blocks probably expired
+			if err != nil || released < storeUsecase.ExpiryBatchSize {
+				select {
+				case <-ctx.Done():
+				case <-time.After(interval):
+				}
+			}
+		}
+		log.Info("shutting down scheduler...")
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(schedulerCmd)
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/EdlanioJ/kbu-store/app/bootstrap"
+	"github.com/EdlanioJ/kbu-store/app/config"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveHTTP     bool
+	serveGRPC     bool
+	serveConsumer bool
+	serveHTTPPort int
+	serveGRPCPort int
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Start http server, grpc server and kafka consumer in one process",
+	RunE: func(*cobra.Command, []string) error {
+		cfg, err := config.LoadConfig()
+		if err != nil {
+			panic(err)
+		}
+
+		components := map[string]func(ctx context.Context, container *bootstrap.Container, onReady func()) error{}
+		if serveHTTP {
+			components["http"] = func(ctx context.Context, container *bootstrap.Container, onReady func()) error {
+				return runHTTP(ctx, container, serveHTTPPort, onReady)
+			}
+		}
+		if serveGRPC {
+			components["grpc"] = func(ctx context.Context, container *bootstrap.Container, onReady func()) error {
+				return runGRPC(ctx, container, serveGRPCPort, onReady)
+			}
+		}
+		if serveConsumer {
+			components["consumer"] = runConsumer
+		}
+		if len(components) == 0 {
+			return errors.New("select at least one of --http, --grpc or --consumer")
+		}
+
+		ctx, stop := rootContext()
+		defer stop()
+
+		container := bootstrap.NewContainer(cfg)
+		defer container.Close()
+
+		if serveHTTP {
+			err = container.InitTracer()
+			if err != nil {
+				return fmt.Errorf("cannot create tracer: %w", err)
+			}
+		}
+
+		names := make([]string, 0, len(components))
+		for name := range components {
+			names = append(names, name)
+		}
+		readiness := bootstrap.NewReadiness(names...)
+		container.HealthUsecase.Register("components", readiness.Check)
+		if serveConsumer {
+			container.HealthUsecase.Register("consumer_group", container.KafkaHealth.CheckConsumerGroup)
+		}
+
+		// a component that stops stops the others with it
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var wg sync.WaitGroup
+		failed := make(chan error, len(components))
+		for name, run := range components {
+			wg.Add(1)
+			go func(name string, run func(context.Context, *bootstrap.Container, func()) error) {
+				defer wg.Done()
+				defer cancel()
+
+				err := run(ctx, container, func() { readiness.MarkReady(name) })
+				if err != nil {
+					log.Errorf("%s: %v", name, err)
+					failed <- fmt.Errorf("%s: %w", name, err)
+				}
+			}(name, run)
+		}
+
+		select {
+		case <-readiness.Done():
+			log.Infof("\u001b[92mReady\u001b[0m serving %v", names)
+		case <-ctx.Done():
+		}
+
+		wg.Wait()
+		close(failed)
+		// the first component failing is the one that stopped the others
+		return <-failed
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().BoolVar(&serveHTTP, "http", false, "start the http server")
+	serveCmd.Flags().BoolVar(&serveGRPC, "grpc", false, "start the grpc server")
+	serveCmd.Flags().BoolVar(&serveConsumer, "consumer", false, "start the kafka consumer")
+	serveCmd.Flags().IntVar(&serveHTTPPort, "http-port", 0, "http server port")
+	serveCmd.Flags().IntVar(&serveGRPCPort, "grpc-port", 0, "grpc server port")
+}
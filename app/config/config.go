@@ -12,6 +12,11 @@ type Kafka struct {
 	NewStoreTopic       string   `mapstructure:"NEW_STORE_TOPIC"`
 	UpdateStoreTopic    string   `mapstructure:"UPDATE_STORE_TOPIC"`
 	DeleteStoreTopic    string   `mapstructure:"DELETE_STORE_TOPIC"`
+	BalanceUpdatedTopic string   `mapstructure:"BALANCE_UPDATED_TOPIC"`
+	DeadLetterTopic     string   `mapstructure:"DEAD_LETTER_TOPIC"`
+	MaxRetries          int      `mapstructure:"MAX_RETRIES"`
+	RetryBackoff        int      `mapstructure:"RETRY_BACKOFF"`
+	MaxRetryBackoff     int      `mapstructure:"MAX_RETRY_BACKOFF"`
 }
 
 type Grpc struct {
@@ -19,6 +24,48 @@ type Grpc struct {
 	MetricPort int `mapstructure:"METRIC_PORT"`
 }
 
+type Outbox struct {
+	Interval   int `mapstructure:"INTERVAL"`
+	BatchSize  int `mapstructure:"BATCH_SIZE"`
+	Backoff    int `mapstructure:"BACKOFF"`
+	MaxBackoff int `mapstructure:"MAX_BACKOFF"`
+}
+
+type Moderation struct {
+	Interval  int `mapstructure:"INTERVAL"`
+	BatchSize int `mapstructure:"BATCH_SIZE"`
+}
+
+type Purge struct {
+	Retention int `mapstructure:"RETENTION"`
+	BatchSize int `mapstructure:"BATCH_SIZE"`
+}
+
+type Category struct {
+	Cascade string `mapstructure:"CASCADE"`
+	Restore bool   `mapstructure:"RESTORE"`
+}
+
+type Auth struct {
+	Secret   string `mapstructure:"SECRET"`
+	JWKS     string `mapstructure:"JWKS"`
+	Issuer   string `mapstructure:"ISSUER"`
+	Audience string `mapstructure:"AUDIENCE"`
+}
+
+type Storage struct {
+	Driver        string `mapstructure:"DRIVER"`
+	Path          string `mapstructure:"PATH"`
+	Endpoint      string `mapstructure:"ENDPOINT"`
+	Region        string `mapstructure:"REGION"`
+	Bucket        string `mapstructure:"BUCKET"`
+	AccessKey     string `mapstructure:"ACCESS_KEY"`
+	SecretKey     string `mapstructure:"SECRET_KEY"`
+	PathStyle     bool   `mapstructure:"PATH_STYLE"`
+	MaxImageSize  int64  `mapstructure:"MAX_IMAGE_SIZE"`
+	UploadTimeout int    `mapstructure:"UPLOAD_TIMEOUT"`
+}
+
 type Jaeger struct {
 	Host        string `mapstructure:"HOST"`
 	ServiceName string `mapstructure:"SERVICE_NAME"`
@@ -35,14 +82,22 @@ type PG struct {
 }
 
 type Config struct {
-	Timeout int    `mapstructure:"TIMEOUT"`
-	Port    int    `mapstructure:"PORT"`
-	Env     string `mapstructure:"ENV"`
-	PG      PG     `mapstructure:"PG"`
-	DBTest  string `mapstructure:"DB_TEST"`
-	Kafka   Kafka  `mapstructure:"KAFKA"`
-	Grpc    Grpc   `mapstructure:"GRPC"`
-	Jaeger  Jaeger `mapstructure:"JEAGER"`
+	Timeout         int        `mapstructure:"TIMEOUT"`
+	ShutdownTimeout int        `mapstructure:"SHUTDOWN_TIMEOUT"`
+	Port            int        `mapstructure:"PORT"`
+	Env             string     `mapstructure:"ENV"`
+	CursorSecret    string     `mapstructure:"CURSOR_SECRET"`
+	PG              PG         `mapstructure:"PG"`
+	DBTest          string     `mapstructure:"DB_TEST"`
+	Kafka           Kafka      `mapstructure:"KAFKA"`
+	Grpc            Grpc       `mapstructure:"GRPC"`
+	Jaeger          Jaeger     `mapstructure:"JEAGER"`
+	Outbox          Outbox     `mapstructure:"OUTBOX"`
+	Category        Category   `mapstructure:"CATEGORY"`
+	Moderation      Moderation `mapstructure:"MODERATION"`
+	Purge           Purge      `mapstructure:"PURGE"`
+	Auth            Auth       `mapstructure:"AUTH"`
+	Storage         Storage    `mapstructure:"STORAGE"`
 }
 
 func LoadConfig(path ...string) (cfg *Config, err error) {
@@ -59,8 +114,27 @@ func LoadConfig(path ...string) (cfg *Config, err error) {
 	viper.AutomaticEnv()
 
 	viper.SetDefault("PORT", 3333)
+	viper.SetDefault("SHUTDOWN_TIMEOUT", 10)
 	viper.SetDefault("GRPC.PORT", 50051)
 	viper.SetDefault("GRPC.METRIC_PORT", 3330)
+	viper.SetDefault("KAFKA.MAX_RETRIES", 5)
+	viper.SetDefault("KAFKA.RETRY_BACKOFF", 1)
+	viper.SetDefault("KAFKA.MAX_RETRY_BACKOFF", 30)
+	viper.SetDefault("OUTBOX.INTERVAL", 1)
+	viper.SetDefault("OUTBOX.BATCH_SIZE", 100)
+	viper.SetDefault("OUTBOX.BACKOFF", 1)
+	viper.SetDefault("OUTBOX.MAX_BACKOFF", 300)
+	viper.SetDefault("CATEGORY.CASCADE", "suspend")
+	viper.SetDefault("CATEGORY.RESTORE", true)
+	viper.SetDefault("MODERATION.INTERVAL", 60)
+	viper.SetDefault("MODERATION.BATCH_SIZE", 100)
+	viper.SetDefault("PURGE.RETENTION", 30)
+	viper.SetDefault("PURGE.BATCH_SIZE", 100)
+	viper.SetDefault("STORAGE.DRIVER", "local")
+	viper.SetDefault("STORAGE.PATH", "./uploads")
+	viper.SetDefault("STORAGE.REGION", "us-east-1")
+	viper.SetDefault("STORAGE.MAX_IMAGE_SIZE", 5242880)
+	viper.SetDefault("STORAGE.UPLOAD_TIMEOUT", 30)
 	if err = viper.ReadInConfig(); err != nil {
 		return
 	}
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS outbox (
+  id uuid PRIMARY KEY,
+  created_at timestamp with time zone NULL,
+  updated_at timestamp with time zone NULL,
+  topic character varying NOT NULL,
+  payload text NOT NULL,
+  attempts integer NOT NULL DEFAULT 0,
+  last_error text NULL,
+  next_attempt_at timestamp with time zone NOT NULL,
+  delivered_at timestamp with time zone NULL
+);
+
+CREATE INDEX ON outbox (next_attempt_at, created_at) WHERE delivered_at IS NULL;
+
+COMMENT ON COLUMN outbox.delivered_at IS 'null until the message is relayed to kafka';
@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS account_transactions_append_only ON account_transactions;
+DROP FUNCTION IF EXISTS account_transactions_append_only();
+DROP TABLE IF EXISTS account_transactions;
@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS account_transactions (
+  id uuid PRIMARY KEY,
+  created_at timestamp with time zone NULL,
+  updated_at timestamp with time zone NULL,
+  account_id uuid NOT NULL,
+  amount numeric(20, 8) NOT NULL,
+  direction character varying(10) NOT NULL,
+  reason character varying(255) NULL,
+  reference_id character varying(255) NULL,
+  balance numeric(20, 8) NOT NULL
+);
+
+CREATE INDEX ON account_transactions (account_id, created_at);
+
+CREATE OR REPLACE FUNCTION account_transactions_append_only() RETURNS trigger AS $$
+BEGIN
+  RAISE EXCEPTION 'account_transactions is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER account_transactions_append_only
+  BEFORE UPDATE OR DELETE ON account_transactions
+  FOR EACH ROW EXECUTE PROCEDURE account_transactions_append_only();
+
+INSERT INTO account_transactions (id, created_at, updated_at, account_id, amount, direction, reason, balance)
+SELECT md5(id::text || 'opening balance')::uuid, now(), now(), id, balance, 'credit', 'opening balance', balance
+FROM accounts
+WHERE balance > 0;
+
+COMMENT ON COLUMN account_transactions.direction IS 'must be credit or debit';
+COMMENT ON COLUMN account_transactions.balance IS 'account balance after the transaction';
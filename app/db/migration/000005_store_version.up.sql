@@ -0,0 +1,3 @@
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN stores.version IS 'incremented on every update, used for optimistic locking';
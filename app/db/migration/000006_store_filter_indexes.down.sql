@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS stores_tags_idx;
+DROP INDEX IF EXISTS stores_created_at_idx;
+DROP INDEX IF EXISTS stores_status_created_at_idx;
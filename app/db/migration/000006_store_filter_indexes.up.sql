@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS stores_status_created_at_idx ON stores (status, created_at);
+CREATE INDEX IF NOT EXISTS stores_created_at_idx ON stores (created_at);
+CREATE INDEX IF NOT EXISTS stores_tags_idx ON stores USING GIN (tags);
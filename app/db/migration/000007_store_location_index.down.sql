@@ -0,0 +1 @@
+DROP INDEX IF EXISTS stores_location_idx;
@@ -0,0 +1,3 @@
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+CREATE INDEX IF NOT EXISTS stores_location_idx ON stores USING GIST (ll_to_earth(lat::float8, lng::float8));
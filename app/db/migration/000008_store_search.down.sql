@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS stores_search_vector_idx;
+DROP TRIGGER IF EXISTS stores_search_vector_trigger ON stores;
+DROP FUNCTION IF EXISTS stores_search_vector_update();
+ALTER TABLE stores DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,23 @@
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+CREATE OR REPLACE FUNCTION stores_search_vector_update() RETURNS trigger AS $$
+BEGIN
+  NEW.search_vector :=
+    setweight(to_tsvector('simple', coalesce(NEW.name, '')), 'A') ||
+    setweight(to_tsvector('simple', coalesce(array_to_string(NEW.tags, ' '), '')), 'B') ||
+    setweight(to_tsvector('simple', coalesce(NEW.description, '')), 'C');
+  RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS stores_search_vector_trigger ON stores;
+CREATE TRIGGER stores_search_vector_trigger
+  BEFORE INSERT OR UPDATE OF name, description, tags ON stores
+  FOR EACH ROW EXECUTE PROCEDURE stores_search_vector_update();
+
+UPDATE stores SET search_vector =
+  setweight(to_tsvector('simple', coalesce(name, '')), 'A') ||
+  setweight(to_tsvector('simple', coalesce(array_to_string(tags, ' '), '')), 'B') ||
+  setweight(to_tsvector('simple', coalesce(description, '')), 'C');
+
+CREATE INDEX IF NOT EXISTS stores_search_vector_idx ON stores USING GIN (search_vector);
@@ -0,0 +1,3 @@
+UPDATE stores SET status = 'disable' WHERE status = 'suspended';
+
+COMMENT ON COLUMN stores.status IS 'must be pending, active, disable or block';
@@ -0,0 +1 @@
+COMMENT ON COLUMN stores.status IS 'must be pending, active, disable, block or suspended';
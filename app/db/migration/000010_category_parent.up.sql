@@ -0,0 +1,5 @@
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id uuid NULL;
+
+ALTER TABLE categories ADD FOREIGN KEY (parent_id) REFERENCES categories (id) ON DELETE RESTRICT ON UPDATE CASCADE;
+
+CREATE INDEX IF NOT EXISTS categories_parent_id_idx ON categories (parent_id);
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS inbox (
+  id character varying PRIMARY KEY,
+  topic character varying NOT NULL,
+  created_at timestamp with time zone NOT NULL
+);
+
+COMMENT ON COLUMN inbox.id IS 'idempotency key of the consumed message';
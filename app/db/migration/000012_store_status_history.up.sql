@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS store_status_history (
+  id uuid PRIMARY KEY,
+  store_id uuid NOT NULL,
+  from_status character varying(20) NULL,
+  to_status character varying(20) NOT NULL,
+  actor_id character varying(255) NOT NULL,
+  reason character varying(255) NULL,
+  expires_at timestamp with time zone NULL,
+  created_at timestamp with time zone NOT NULL
+);
+
+ALTER TABLE store_status_history ADD FOREIGN KEY (store_id) REFERENCES stores (id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+CREATE INDEX ON store_status_history (store_id, created_at);
+CREATE INDEX ON store_status_history (expires_at) WHERE expires_at IS NOT NULL;
+
+COMMENT ON COLUMN store_status_history.actor_id IS 'user id of the caller, or system';
+COMMENT ON COLUMN store_status_history.expires_at IS 'the store is activated back once the change expires';
@@ -0,0 +1,3 @@
+UPDATE stores SET status = 'pending' WHERE status = 'rejected';
+
+COMMENT ON COLUMN stores.status IS 'must be pending, active, disable, block or suspended';
@@ -0,0 +1,3 @@
+COMMENT ON COLUMN stores.image IS NULL;
+
+ALTER TABLE stores DROP COLUMN IF EXISTS images;
@@ -0,0 +1,4 @@
+ALTER TABLE stores ADD COLUMN images jsonb NULL;
+
+COMMENT ON COLUMN stores.image IS 'blob key of the original variant of the uploaded image';
+COMMENT ON COLUMN stores.images IS 'blob key of each variant of the uploaded image';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS store_closures;
+DROP TABLE IF EXISTS store_opening_hours;
+DROP TABLE IF EXISTS store_hours;
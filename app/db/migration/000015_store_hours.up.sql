@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS store_hours (
+  store_id uuid PRIMARY KEY,
+  timezone character varying(64) NOT NULL,
+  updated_at timestamp with time zone NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS store_opening_hours (
+  store_id uuid NOT NULL,
+  weekday smallint NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+  opens time NOT NULL,
+  closes time NOT NULL CHECK (closes <> opens)
+);
+
+CREATE TABLE IF NOT EXISTS store_closures (
+  store_id uuid NOT NULL,
+  from_date date NOT NULL,
+  to_date date NOT NULL CHECK (to_date >= from_date),
+  reason character varying(255) NULL
+);
+
+ALTER TABLE store_hours ADD FOREIGN KEY (store_id) REFERENCES stores (id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE store_opening_hours ADD FOREIGN KEY (store_id) REFERENCES store_hours (store_id) ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE store_closures ADD FOREIGN KEY (store_id) REFERENCES store_hours (store_id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+CREATE INDEX ON store_opening_hours (store_id, weekday);
+CREATE INDEX ON store_closures (store_id, from_date);
+
+COMMENT ON COLUMN store_hours.timezone IS 'IANA timezone of the opening hours and closures';
+COMMENT ON COLUMN store_opening_hours.weekday IS '0 is sunday';
+COMMENT ON COLUMN store_opening_hours.closes IS 'an interval closing at or before it opens ends the next day';
+COMMENT ON COLUMN store_closures.from_date IS 'the intervals opening from from_date to to_date are cancelled';
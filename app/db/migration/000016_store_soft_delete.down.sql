@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS stores_deleted_at_idx;
+
+ALTER TABLE stores DROP COLUMN IF EXISTS deleted_at;
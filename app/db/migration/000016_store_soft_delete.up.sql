@@ -0,0 +1,5 @@
+ALTER TABLE stores ADD COLUMN IF NOT EXISTS deleted_at timestamptz NULL;
+
+CREATE INDEX IF NOT EXISTS stores_deleted_at_idx ON stores (deleted_at) WHERE deleted_at IS NOT NULL;
+
+COMMENT ON COLUMN stores.deleted_at IS 'set when the store is deleted, the row being purged after the retention';
@@ -0,0 +1,5 @@
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS locked_until timestamp with time zone NULL;
+
+CREATE INDEX IF NOT EXISTS outbox_pending_idx ON outbox (created_at, id) WHERE delivered_at IS NULL;
+
+COMMENT ON COLUMN outbox.locked_until IS 'set while a relay publishes the message; the messages after it wait for its delivery';
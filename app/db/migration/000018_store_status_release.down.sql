@@ -0,0 +1,2 @@
+ALTER TABLE store_status_history DROP COLUMN IF EXISTS release_retry_at;
+ALTER TABLE store_status_history DROP COLUMN IF EXISTS release_attempts;
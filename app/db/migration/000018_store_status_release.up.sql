@@ -0,0 +1,5 @@
+ALTER TABLE store_status_history ADD COLUMN IF NOT EXISTS release_attempts integer NOT NULL DEFAULT 0;
+ALTER TABLE store_status_history ADD COLUMN IF NOT EXISTS release_retry_at timestamp with time zone NULL;
+
+COMMENT ON COLUMN store_status_history.release_attempts IS 'failed attempts to move the store back once the change expired';
+COMMENT ON COLUMN store_status_history.release_retry_at IS 'the expired change is left out of the releases until then';
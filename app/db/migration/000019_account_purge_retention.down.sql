@@ -0,0 +1 @@
+COMMENT ON TABLE account_transactions IS NULL;
@@ -0,0 +1 @@
+COMMENT ON TABLE account_transactions IS 'append-only ledger, kept when the account is purged with its store: the last entry of a purged account is the "account purged" debit of its remaining balance, referencing the store';
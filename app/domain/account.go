@@ -2,24 +2,55 @@ package domain
 
 import (
 	"context"
-	"fmt"
-	"math"
+	"encoding/json"
 	"time"
 
 	uuid "github.com/satori/go.uuid"
 	"github.com/shopspring/decimal"
 )
 
+const (
+	// deposit account operation value
+	AccountOperationDeposit string = "deposit"
+	// withdraw account operation value
+	AccountOperationWithdraw string = "withdraw"
+)
+
+// AmountScale is the number of decimal places of the balances and amounts
+// kept by the numeric(20,8) columns
+const AmountScale = 8
+
 // Account struct
 type Account struct {
 	Base
 	Balance decimal.Decimal `json:"balance" gorm:"type:decimal(20,8)"`
 }
 
+type AccountOperationRequest struct {
+	ID          string          `json:"-" validate:"required,uuid4"`
+	Amount      decimal.Decimal `json:"amount" swaggertype:"number"`
+	Reason      string          `json:"reason" validate:"max=255"`
+	ReferenceID string          `json:"reference_id" validate:"max=255"`
+}
+
+// BalanceUpdate is the event published when a store's balance changes
+type BalanceUpdate struct {
+	StoreID       string          `json:"store_id"`
+	AccountID     string          `json:"account_id"`
+	TransactionID string          `json:"transaction_id"`
+	Operation     string          `json:"operation"`
+	Amount        decimal.Decimal `json:"amount"`
+	Balance       decimal.Decimal `json:"balance"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
 type (
 	AccountRepository interface {
 		Store(ctx context.Context, account *Account) error
 		FindByID(ctx context.Context, id string) (*Account, error)
+		// FindForUpdate finds the account and locks it until the end of the
+		// transaction of ctx, so concurrent balance changes wait their turn
+		FindForUpdate(ctx context.Context, id string) (*Account, error)
 		Update(ctx context.Context, account *Account) error
 		Delete(ctx context.Context, id string) error
 	}
@@ -34,25 +65,41 @@ func NewAccount() (account *Account) {
 	return
 }
 
-func (a *Account) Deposit(amount float64) (err error) {
-	if math.Signbit(amount) {
-		return fmt.Errorf("%f must be a positive number", amount)
+// Deposit adds amount to the account balance
+func (a *Account) Deposit(amount decimal.Decimal) (err error) {
+	if !validAmount(amount) {
+		return ErrInvalidAmount
 	}
-	a.Balance = a.Balance.Add(decimal.NewFromFloat(amount))
 
+	a.Balance = a.Balance.Add(amount)
+	a.UpdatedAt = time.Now()
 	return
 }
 
-func (a *Account) Withdow(amount float64) (err error) {
-	if math.Signbit(amount) {
-		return fmt.Errorf("%f must be a positive number", amount)
+// Withdraw subtracts amount from the account balance
+func (a *Account) Withdraw(amount decimal.Decimal) (err error) {
+	if !validAmount(amount) {
+		return ErrInvalidAmount
 	}
 
-	if a.Balance.LessThan(decimal.NewFromFloat(amount)) {
-		return fmt.Errorf("balance is less than amount: %f", amount)
+	if a.Balance.LessThan(amount) {
+		return ErrInsufficientBalance
 	}
 
-	a.Balance = a.Balance.Sub(decimal.NewFromFloat(amount))
+	a.Balance = a.Balance.Sub(amount)
+	a.UpdatedAt = time.Now()
+	return
+}
+
+// validAmount tells whether amount is positive and fits AmountScale, so the
+// balance stored is the one computed, to the last digit
+func validAmount(amount decimal.Decimal) bool {
+	return amount.IsPositive() && amount.Equal(amount.Truncate(AmountScale))
+}
+
+// ToJson returns the JSON encoding of BalanceUpdate
+func (b *BalanceUpdate) ToJson() (res []byte) {
+	res, _ = json.Marshal(b)
 
 	return
 }
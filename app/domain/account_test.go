@@ -20,42 +20,75 @@ func TestAccount(t *testing.T) {
 		t.Parallel()
 		t.Run("failure_negative_amount", func(t *testing.T) {
 			account := domain.NewAccount()
-			amount := -10.00
+			amount := decimal.NewFromFloat(-10)
 			err := account.Deposit(amount)
 			assert.Error(t, err)
+			assert.ErrorIs(t, err, domain.ErrInvalidAmount)
 			assert.True(t, account.Balance.Equal(decimal.NewFromFloat(0)))
 		})
 
+		t.Run("failure_amount_beyond_scale", func(t *testing.T) {
+			account := domain.NewAccount()
+			err := account.Deposit(decimal.RequireFromString("0.000000001"))
+			assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+			assert.True(t, account.Balance.IsZero())
+		})
+
+		t.Run("success_trailing_zeros_beyond_scale", func(t *testing.T) {
+			account := domain.NewAccount()
+			err := account.Deposit(decimal.RequireFromString("0.100000000"))
+			assert.NoError(t, err)
+			assert.True(t, account.Balance.Equal(decimal.RequireFromString("0.1")))
+		})
+
 		t.Run("success", func(t *testing.T) {
 			account := domain.NewAccount()
-			amount := 10.00
+			amount := decimal.NewFromFloat(10)
 			err := account.Deposit(amount)
 			assert.NoError(t, err)
 			assert.True(t, account.Balance.Equal(decimal.NewFromFloat(10)))
 		})
 	})
-	t.Run("withdow", func(t *testing.T) {
+	t.Run("deposit_keeps_decimal_precision", func(t *testing.T) {
+		account := domain.NewAccount()
+		for i := 0; i < 10; i++ {
+			err := account.Deposit(decimal.RequireFromString("0.1"))
+			assert.NoError(t, err)
+		}
+		assert.True(t, account.Balance.Equal(decimal.NewFromInt(1)))
+	})
+	t.Run("withdraw", func(t *testing.T) {
 		t.Parallel()
 		t.Run("failure_negative_amount", func(t *testing.T) {
 			account := domain.NewAccount()
-			amount := -10.00
-			err := account.Withdow(amount)
+			amount := decimal.NewFromFloat(-10)
+			err := account.Withdraw(amount)
 			assert.Error(t, err)
+			assert.ErrorIs(t, err, domain.ErrInvalidAmount)
 			assert.True(t, account.Balance.Equal(decimal.NewFromFloat(0)))
 		})
 
+		t.Run("failure_amount_beyond_scale", func(t *testing.T) {
+			account := domain.NewAccount()
+			account.Balance = decimal.NewFromFloat(100)
+			err := account.Withdraw(decimal.RequireFromString("0.123456789"))
+			assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+			assert.True(t, account.Balance.Equal(decimal.NewFromFloat(100)))
+		})
+
 		t.Run("failure_balance_less_than_amount", func(t *testing.T) {
 			account := domain.NewAccount()
-			amount := 10.00
-			err := account.Withdow(amount)
+			amount := decimal.NewFromFloat(10)
+			err := account.Withdraw(amount)
 			assert.Error(t, err)
+			assert.ErrorIs(t, err, domain.ErrInsufficientBalance)
 			assert.True(t, account.Balance.Equal(decimal.NewFromFloat(0)))
 		})
 		t.Run("success", func(t *testing.T) {
 			account := domain.NewAccount()
 			account.Balance = decimal.NewFromFloat(100)
-			amount := 10.00
-			err := account.Withdow(amount)
+			amount := decimal.NewFromFloat(10)
+			err := account.Withdraw(amount)
 			assert.NoError(t, err)
 			assert.True(t, account.Balance.Equal(decimal.NewFromFloat(90)))
 
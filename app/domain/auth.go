@@ -0,0 +1,64 @@
+package domain
+
+import "context"
+
+// RoleAdmin is the role allowed to moderate every store
+const RoleAdmin = "admin"
+
+// Claims identify the authenticated caller of a request
+type Claims struct {
+	UserID string
+	Roles  []string
+}
+
+// TokenVerifier represent the access token verifier contract
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*Claims, error)
+}
+
+type claimsKey struct{}
+
+// ContextWithClaims returns a copy of ctx carrying claims
+func ContextWithClaims(ctx context.Context, claims *Claims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext returns the claims carried by ctx, if any
+func ClaimsFromContext(ctx context.Context) (*Claims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(*Claims)
+	return claims, ok && claims != nil
+}
+
+// HasRole reports whether the caller was granted role
+func (c *Claims) HasRole(role string) bool {
+	for _, r := range c.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAdmin reports whether the caller has the admin role
+func (c *Claims) IsAdmin() bool {
+	return c.HasRole(RoleAdmin)
+}
+
+// CanManage reports whether the caller may change store: its owner or an
+// admin
+func (c *Claims) CanManage(store *Store) bool {
+	return c.IsAdmin() || (c.UserID != "" && c.UserID == store.UserID)
+}
+
+// CanApply reports whether the caller may move store through transition.
+// System transitions are never applied on behalf of a caller.
+func (c *Claims) CanApply(transition *StoreTransition, store *Store) bool {
+	switch transition.Actor {
+	case TransitionActorAdmin:
+		return c.IsAdmin()
+	case TransitionActorOwner:
+		return c.CanManage(store)
+	default:
+		return false
+	}
+}
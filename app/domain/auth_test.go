@@ -0,0 +1,77 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClaimsFromContext(t *testing.T) {
+	_, ok := domain.ClaimsFromContext(context.TODO())
+	assert.False(t, ok)
+
+	claims := &domain.Claims{UserID: "user"}
+	res, ok := domain.ClaimsFromContext(domain.ContextWithClaims(context.TODO(), claims))
+	assert.True(t, ok)
+	assert.Equal(t, claims, res)
+}
+
+func TestClaims_CanManage(t *testing.T) {
+	store := &domain.Store{UserID: "owner"}
+
+	testCases := []struct {
+		name      string
+		claims    *domain.Claims
+		canManage bool
+	}{
+		{
+			name:   "other_user",
+			claims: &domain.Claims{UserID: "other"},
+		},
+		{
+			name:   "anonymous_user",
+			claims: &domain.Claims{},
+		},
+		{
+			name:      "owner",
+			claims:    &domain.Claims{UserID: "owner"},
+			canManage: true,
+		},
+		{
+			name:      "admin",
+			claims:    &domain.Claims{UserID: "other", Roles: []string{domain.RoleAdmin}},
+			canManage: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.canManage, tc.claims.CanManage(store))
+		})
+	}
+}
+
+func TestClaims_CanApply(t *testing.T) {
+	store := &domain.Store{UserID: "owner"}
+	block, _ := domain.StoreLifecycle.Find(domain.StoreActionBlock)
+	disable, _ := domain.StoreLifecycle.Find(domain.StoreActionDisable)
+	activate, _ := domain.StoreLifecycle.Find(domain.StoreActionActivate)
+	suspend, _ := domain.StoreLifecycle.Find(domain.StoreActionSuspend)
+
+	owner := &domain.Claims{UserID: "owner"}
+	admin := &domain.Claims{UserID: "admin", Roles: []string{domain.RoleAdmin}}
+
+	assert.False(t, owner.CanApply(block, store))
+	assert.True(t, admin.CanApply(block, store))
+	assert.True(t, owner.CanApply(disable, store))
+	assert.False(t, (&domain.Claims{UserID: "other"}).CanApply(disable, store))
+	assert.True(t, admin.CanApply(disable, store))
+	assert.True(t, owner.CanApply(activate, store))
+	assert.False(t, (&domain.Claims{UserID: "other"}).CanApply(activate, store))
+	assert.True(t, admin.CanApply(activate, store))
+	assert.False(t, admin.CanApply(suspend, store))
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+	"io"
+)
+
+type (
+	// BlobStorage represent the contract of the storage holding the files
+	// uploaded to the service, addressed by key
+	BlobStorage interface {
+		Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+		Delete(ctx context.Context, key string) error
+	}
+)
@@ -12,24 +12,63 @@ const (
 	CategoryStatusDisable string = "disable"
 )
 
+const (
+	// CategoryCascadeNone leaves the stores untouched when their category is disabled
+	CategoryCascadeNone string = "none"
+	// CategoryCascadeSuspend suspends the active stores of a disabled category
+	// and of its sub-categories
+	CategoryCascadeSuspend string = "suspend"
+)
+
+// CategoryCascadePolicy tells what happens to the stores of a category when
+// its status changes. With Restore set, activating the category again resumes
+// the stores it suspended.
+type CategoryCascadePolicy struct {
+	Mode    string
+	Restore bool
+}
+
 // Category struct
 type Category struct {
 	Base
-	Name   string `json:"name" gorm:"column:name;type:varchar;not null"`
-	Status string `json:"status" gorm:"type:varchar(20)"`
+	Name     string  `json:"name" gorm:"column:name;type:varchar;not null"`
+	Status   string  `json:"status" gorm:"type:varchar(20)"`
+	ParentID *string `json:"parent_id" gorm:"column:parent_id;type:uuid"`
 }
 
+// Categories belong to the domain layer.
+type Categories []*Category
+
+// A CategoryNode is a category along with its sub-categories
+type CategoryNode struct {
+	*Category
+	Children []*CategoryNode `json:"children"`
+}
+
+// CategoryTree holds the top level categories, each with its sub-categories
+type CategoryTree []*CategoryNode
+
 type (
 	// CategoryRepository
 	CategoryRepository interface {
 		Store(ctx context.Context, Category *Category) error
 		FindByID(ctx context.Context, id string) (*Category, error)
+		FindAll(ctx context.Context, status string, limit, page int) (Categories, int64, error)
+		FindTree(ctx context.Context) (Categories, error)
+		CountChildren(ctx context.Context, id string) (int64, error)
 		Update(ctx context.Context, Category *Category) error
+		Delete(ctx context.Context, id string) error
 	}
 	// CategoryUsecase
 	CategoryUsecase interface {
 		Create(ctx context.Context, Category *Category) error
 		Update(ctx context.Context, Category *Category) error
+		Get(ctx context.Context, id string) (*Category, error)
+		List(ctx context.Context, status string, limit, page int) (Categories, int64, error)
+		Tree(ctx context.Context) (CategoryTree, error)
+		Activate(ctx context.Context, id string) error
+		Disable(ctx context.Context, id string) error
+		Delete(ctx context.Context, id string) error
 	}
 )
 
@@ -42,6 +81,51 @@ func NewCategory() (category *Category) {
 	return
 }
 
+// NewCategoryTree arranges categories under their parents, keeping their
+// order among siblings. Categories whose parent is not among them are at the
+// top level.
+func NewCategoryTree(categories Categories) (tree CategoryTree) {
+	nodes := make(map[string]*CategoryNode, len(categories))
+	for _, category := range categories {
+		nodes[category.ID] = &CategoryNode{Category: category, Children: make([]*CategoryNode, 0)}
+	}
+
+	tree = make(CategoryTree, 0)
+	for _, category := range categories {
+		node := nodes[category.ID]
+		if category.ParentID != nil {
+			if parent, ok := nodes[*category.ParentID]; ok {
+				parent.Children = append(parent.Children, node)
+				continue
+			}
+		}
+		tree = append(tree, node)
+	}
+	return
+}
+
+// Activate set category entity status to active
+func (c *Category) Activate() (err error) {
+	if c.Status == CategoryStatusActive {
+		return ErrActived
+	}
+
+	c.Status = CategoryStatusActive
+	c.UpdatedAt = time.Now()
+	return
+}
+
+// Disable set category entity status to disable
+func (c *Category) Disable() (err error) {
+	if c.Status == CategoryStatusDisable {
+		return ErrInactived
+	}
+
+	c.Status = CategoryStatusDisable
+	c.UpdatedAt = time.Now()
+	return
+}
+
 // Parses a JSON data and store in the Category Entity
 func (c *Category) ParseJson(data []byte) (err error) {
 	err = json.Unmarshal(data, c)
@@ -36,4 +36,68 @@ func TestCategory(t *testing.T) {
 		})
 
 	})
+	t.Run("activate", func(t *testing.T) {
+		t.Parallel()
+		t.Run("failure_already_actived", func(t *testing.T) {
+			category := domain.NewCategory()
+			category.Status = domain.CategoryStatusActive
+			err := category.Activate()
+
+			assert.ErrorIs(t, err, domain.ErrActived)
+		})
+
+		t.Run("success", func(t *testing.T) {
+			category := domain.NewCategory()
+			err := category.Activate()
+
+			assert.Nil(t, err)
+			assert.Equal(t, domain.CategoryStatusActive, category.Status)
+			assert.False(t, category.UpdatedAt.IsZero())
+		})
+	})
+	t.Run("disable", func(t *testing.T) {
+		t.Parallel()
+		t.Run("failure_already_disabled", func(t *testing.T) {
+			category := domain.NewCategory()
+			category.Status = domain.CategoryStatusDisable
+			err := category.Disable()
+
+			assert.ErrorIs(t, err, domain.ErrInactived)
+		})
+
+		t.Run("success", func(t *testing.T) {
+			category := domain.NewCategory()
+			category.Status = domain.CategoryStatusActive
+			err := category.Disable()
+
+			assert.Nil(t, err)
+			assert.Equal(t, domain.CategoryStatusDisable, category.Status)
+		})
+	})
+}
+
+func TestNewCategoryTree(t *testing.T) {
+	food := domain.NewCategory()
+	food.ID = uuid.NewV4().String()
+	bakery := domain.NewCategory()
+	bakery.ID = uuid.NewV4().String()
+	bakery.ParentID = &food.ID
+	bread := domain.NewCategory()
+	bread.ID = uuid.NewV4().String()
+	bread.ParentID = &bakery.ID
+	orphanParent := uuid.NewV4().String()
+	orphan := domain.NewCategory()
+	orphan.ID = uuid.NewV4().String()
+	orphan.ParentID = &orphanParent
+
+	tree := domain.NewCategoryTree(domain.Categories{bread, food, orphan, bakery})
+
+	assert.Len(t, tree, 2)
+	assert.Equal(t, food, tree[0].Category)
+	assert.Equal(t, orphan, tree[1].Category)
+	assert.Len(t, tree[0].Children, 1)
+	assert.Equal(t, bakery, tree[0].Children[0].Category)
+	assert.Len(t, tree[0].Children[0].Children, 1)
+	assert.Equal(t, bread, tree[0].Children[0].Children[0].Category)
+	assert.Empty(t, tree[1].Children)
 }
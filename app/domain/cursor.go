@@ -0,0 +1,115 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// storeTimeColumns are the sortable store columns holding a timestamp
+var storeTimeColumns = map[string]bool{"created_at": true, "updated_at": true}
+
+// A Cursor points at a row of a keyset paginated listing. It holds the sort
+// key and the id of the row, and the listing continues after it, or before it
+// when Backward.
+type Cursor struct {
+	Sort     string        `json:"s"`
+	Values   []interface{} `json:"v"`
+	ID       string        `json:"id"`
+	Backward bool          `json:"b,omitempty"`
+}
+
+// A StorePage is one page of a store listing
+type StorePage struct {
+	Stores     Stores `json:"data"`
+	Total      int64  `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
+}
+
+// NewStoreCursor creates a cursor pointing at store in a listing ordered by
+// sort
+func NewStoreCursor(store *Store, sort SortSpec, backward bool) *Cursor {
+	values := make([]interface{}, len(sort))
+	for i, field := range sort {
+		values[i] = store.sortValue(field.Column)
+	}
+
+	return &Cursor{
+		Sort:     sort.String(),
+		Values:   values,
+		ID:       store.ID,
+		Backward: backward,
+	}
+}
+
+func (s *Store) sortValue(column string) interface{} {
+	switch column {
+	case "name":
+		return s.Name
+	case "status":
+		return s.Status
+	case "created_at":
+		return s.CreatedAt
+	case "updated_at":
+		return s.UpdatedAt
+	}
+	return nil
+}
+
+// EncodeCursor returns the opaque token of cursor, signed with secret
+func EncodeCursor(cursor *Cursor, secret []byte) string {
+	payload, _ := json.Marshal(cursor)
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+
+	return encoded + "." + sign(encoded, secret)
+}
+
+// DecodeStoreCursor verifies the signature of token and returns the store
+// cursor it holds. Tokens not signed with secret or created for another sort
+// return ErrInvalidCursor.
+func DecodeStoreCursor(token string, sort SortSpec, secret []byte) (*Cursor, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 || !hmac.Equal([]byte(parts[1]), []byte(sign(parts[0], secret))) {
+		return nil, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	cursor := new(Cursor)
+	if err := json.Unmarshal(payload, cursor); err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	if cursor.Sort != sort.String() || len(cursor.Values) != len(sort) {
+		return nil, ErrInvalidCursor
+	}
+
+	for i, field := range sort {
+		value, ok := cursor.Values[i].(string)
+		if !ok {
+			return nil, ErrInvalidCursor
+		}
+
+		if storeTimeColumns[field.Column] {
+			t, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return nil, ErrInvalidCursor
+			}
+			cursor.Values[i] = t
+		}
+	}
+	return cursor, nil
+}
+
+func sign(payload string, secret []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
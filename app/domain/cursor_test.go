@@ -0,0 +1,51 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor(t *testing.T) {
+	t.Parallel()
+	secret := []byte("secret")
+	sort := domain.SortSpec{{Column: "name"}, {Column: "created_at", Desc: true}}
+	store := sample.NewStore()
+	token := domain.EncodeCursor(domain.NewStoreCursor(store, sort, true), secret)
+
+	t.Run("decode", func(t *testing.T) {
+		cursor, err := domain.DecodeStoreCursor(token, sort, secret)
+		assert.NoError(t, err)
+		assert.Equal(t, store.ID, cursor.ID)
+		assert.True(t, cursor.Backward)
+		assert.Equal(t, store.Name, cursor.Values[0])
+		createdAt, ok := cursor.Values[1].(time.Time)
+		assert.True(t, ok)
+		assert.True(t, store.CreatedAt.Equal(createdAt))
+	})
+
+	t.Run("failure_wrong_secret", func(t *testing.T) {
+		_, err := domain.DecodeStoreCursor(token, sort, []byte("other"))
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	})
+
+	t.Run("failure_tampered_payload", func(t *testing.T) {
+		other := domain.EncodeCursor(domain.NewStoreCursor(sample.NewStore(), sort, true), secret)
+		tampered := other[:len(other)/2] + token[len(token)/2:]
+		_, err := domain.DecodeStoreCursor(tampered, sort, secret)
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	})
+
+	t.Run("failure_another_sort", func(t *testing.T) {
+		_, err := domain.DecodeStoreCursor(token, domain.SortSpec{{Column: "name"}}, secret)
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	})
+
+	t.Run("failure_malformed", func(t *testing.T) {
+		_, err := domain.DecodeStoreCursor("abc", sort, secret)
+		assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+	})
+}
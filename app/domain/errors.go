@@ -5,16 +5,52 @@ import "errors"
 var (
 	// ErrNotFound not found
 	ErrNotFound = errors.New("entity not found")
-	// ErrBlocked entity is blocked
-	ErrBlocked = errors.New("entity is blocked")
-	// ErrIsPending entity is pending
-	ErrPending = errors.New("entity is pending")
 	// ErrActived entity is active
 	ErrActived = errors.New("entity is active")
 	// ErrInactived entity is inactive
 	ErrInactived = errors.New("entity is disable")
+	// ErrCategoryCycle category parent is the category itself or one of its descendants
+	ErrCategoryCycle = errors.New("category cannot be nested under itself or its descendants")
 	// ErrBadRequest bad request
 	ErrBadRequest = errors.New("bad request")
+	// ErrInvalidAmount amount is not a positive number of at most 8 decimal places
+	ErrInvalidAmount = errors.New("amount must be a positive number of at most 8 decimal places")
+	// ErrInsufficientBalance balance is less than amount
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrInvalidSort sort expression has an unknown or repeated column
+	ErrInvalidSort = errors.New("invalid sort expression")
+	// ErrInvalidCursor page cursor is malformed, tampered or was created for another sort
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrConflict entity was changed by someone else
+	ErrConflict = errors.New("entity was modified concurrently")
+	// ErrInUse entity is still referenced by others
+	ErrInUse = errors.New("entity is in use")
+	// ErrInvalidTransition store status does not allow the action
+	ErrInvalidTransition = errors.New("action not allowed in the store status")
+	// ErrUnknownAction action is not in the store lifecycle
+	ErrUnknownAction = errors.New("unknown store action")
+	// ErrNotExpirable status change cannot expire
+	ErrNotExpirable = errors.New("status change cannot expire")
+	// ErrReasonRequired status change has no reason
+	ErrReasonRequired = errors.New("reason is required")
+	// ErrInvalidExpiry status change expires in the past
+	ErrInvalidExpiry = errors.New("expiry must be in the future")
+	// ErrUnsupportedImage uploaded file is not an accepted image
+	ErrUnsupportedImage = errors.New("unsupported image, must be a jpeg, png or gif")
+	// ErrImageTooLarge uploaded image is over the size limit
+	ErrImageTooLarge = errors.New("image is too large")
+	// ErrInvalidTimezone timezone is not an IANA one
+	ErrInvalidTimezone = errors.New("invalid timezone, must be an IANA name such as Africa/Luanda")
+	// ErrInvalidHours opening hours or closures are malformed
+	ErrInvalidHours = errors.New("invalid opening hours, times must be HH:MM and dates YYYY-MM-DD")
+	// ErrUnknownFormat import or export format is neither csv nor ndjson
+	ErrUnknownFormat = errors.New("unknown format, must be csv or ndjson")
+	// ErrInvalidImportHeader csv import header misses a required column
+	ErrInvalidImportHeader = errors.New("csv header must have the name, description and category_id columns")
+	// ErrUnauthorized caller is not authenticated
+	ErrUnauthorized = errors.New("missing or invalid access token")
+	// ErrForbidden caller is not allowed to act on the entity
+	ErrForbidden = errors.New("not allowed to access entity")
 	// ErrInternal internal server error
 	ErrInternal = errors.New("internal server error")
 )
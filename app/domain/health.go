@@ -0,0 +1,36 @@
+package domain
+
+import "context"
+
+// Health statuses of the service and of each of its dependencies
+const (
+	HealthStatusUp   = "up"
+	HealthStatusDown = "down"
+)
+
+// A HealthCheck reports whether a dependency of the service is usable
+type HealthCheck func(ctx context.Context) error
+
+// A DependencyHealth is the result of the health check of one dependency
+type DependencyHealth struct {
+	Status  string `json:"status"`
+	Error   string `json:"error,omitempty"`
+	Latency string `json:"latency"`
+}
+
+// A HealthReport is the readiness of the service, up only when every one of
+// its dependencies is up
+type HealthReport struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]DependencyHealth `json:"dependencies"`
+}
+
+// HealthUsecase represent the health's usecase contract
+type HealthUsecase interface {
+	Readiness(ctx context.Context) *HealthReport
+}
+
+// Up reports whether every dependency is up
+func (r *HealthReport) Up() bool {
+	return r.Status == HealthStatusUp
+}
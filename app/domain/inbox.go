@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// An InboxMessage records a consumed message by its idempotency key, in the
+// same transaction as the changes it made, so that redeliveries are skipped.
+type InboxMessage struct {
+	ID        string    `json:"id" gorm:"column:id;type:varchar;primary key"`
+	Topic     string    `json:"topic" gorm:"column:topic;type:varchar;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type (
+	// InboxRepository represent the inbox's repository contract
+	InboxRepository interface {
+		Exists(ctx context.Context, id string) (bool, error)
+		Store(ctx context.Context, msg *InboxMessage) error
+	}
+
+	// InboxUsecase represent the inbox's usecase contract
+	InboxUsecase interface {
+		Process(ctx context.Context, key, topic string, handle func(ctx context.Context) error) (bool, error)
+	}
+)
+
+// NewInboxMessage creates an *InboxMessage for the message consumed from
+// topic with the idempotency key
+func NewInboxMessage(key, topic string) (msg *InboxMessage) {
+	msg = new(InboxMessage)
+	msg.ID = key
+	msg.Topic = topic
+	msg.CreatedAt = time.Now()
+	return
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	// credit ledger entry direction value
+	LedgerDirectionCredit string = "credit"
+	// debit ledger entry direction value
+	LedgerDirectionDebit string = "debit"
+)
+
+// LedgerReasonAccountPurged is the reason of the entry closing the ledger of
+// a purged account
+const LedgerReasonAccountPurged = "account purged"
+
+// LedgerEntries belong to the domain layer.
+type LedgerEntries []*LedgerEntry
+
+// A LedgerEntry records one balance change of an account. Entries are never
+// updated or deleted.
+type LedgerEntry struct {
+	Base
+	AccountID   string          `json:"account_id" gorm:"column:account_id;type:uuid;not null"`
+	Amount      decimal.Decimal `json:"amount" gorm:"column:amount;type:decimal(20,8);not null"`
+	Direction   string          `json:"direction" gorm:"column:direction;type:varchar(10);not null"`
+	Reason      string          `json:"reason" gorm:"column:reason;type:varchar(255)"`
+	ReferenceID string          `json:"reference_id" gorm:"column:reference_id;type:varchar(255)"`
+	Balance     decimal.Decimal `json:"balance" gorm:"column:balance;type:decimal(20,8);not null"`
+}
+
+// Reconciliation compares an account balance with the balance recomputed
+// from its ledger
+type Reconciliation struct {
+	AccountID     string          `json:"account_id"`
+	Balance       decimal.Decimal `json:"balance"`
+	LedgerBalance decimal.Decimal `json:"ledger_balance"`
+	Consistent    bool            `json:"consistent"`
+}
+
+type (
+	// LedgerRepository represent the ledger's repository contract
+	LedgerRepository interface {
+		Store(ctx context.Context, entry *LedgerEntry) error
+		FindByAccountID(ctx context.Context, accountID string, limit, page int) (LedgerEntries, int64, error)
+		SumByAccountID(ctx context.Context, accountID string) (decimal.Decimal, error)
+	}
+)
+
+// NewLedgerEntry creates a *LedgerEntry for a change of amount already
+// applied to account
+func NewLedgerEntry(account *Account, direction string, amount decimal.Decimal, reason, referenceID string) (entry *LedgerEntry) {
+	entry = new(LedgerEntry)
+	entry.ID = uuid.NewV4().String()
+	entry.CreatedAt = time.Now()
+	entry.AccountID = account.ID
+	entry.Amount = amount
+	entry.Direction = direction
+	entry.Reason = reason
+	entry.ReferenceID = referenceID
+	entry.Balance = account.Balance
+
+	return
+}
+
+// NewClosingLedgerEntry creates the *LedgerEntry closing the ledger of
+// account when its store, referenceID, is purged: a debit of its whole
+// balance, zero or not. The entries of the account are kept, this one last.
+func NewClosingLedgerEntry(account *Account, referenceID string) (entry *LedgerEntry) {
+	entry = NewLedgerEntry(account, LedgerDirectionDebit, account.Balance, LedgerReasonAccountPurged, referenceID)
+	entry.Balance = decimal.Zero
+
+	return
+}
+
+// NewReconciliation compares the balance of account with ledgerBalance
+func NewReconciliation(account *Account, ledgerBalance decimal.Decimal) *Reconciliation {
+	return &Reconciliation{
+		AccountID:     account.ID,
+		Balance:       account.Balance,
+		LedgerBalance: ledgerBalance,
+		Consistent:    account.Balance.Equal(ledgerBalance),
+	}
+}
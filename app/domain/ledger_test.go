@@ -0,0 +1,43 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerEntry(t *testing.T) {
+	t.Parallel()
+	t.Run("new_ledger_entry", func(t *testing.T) {
+		account := domain.NewAccount()
+		account.Balance = decimal.NewFromInt(25)
+		entry := domain.NewLedgerEntry(account, domain.LedgerDirectionCredit, decimal.NewFromInt(5), "deposit", "ref-001")
+		assert.NotEmpty(t, entry.ID)
+		assert.Equal(t, account.ID, entry.AccountID)
+		assert.Equal(t, domain.LedgerDirectionCredit, entry.Direction)
+		assert.True(t, entry.Amount.Equal(decimal.NewFromInt(5)))
+		assert.True(t, entry.Balance.Equal(decimal.NewFromInt(25)))
+		assert.Equal(t, "ref-001", entry.ReferenceID)
+	})
+
+	t.Run("new_closing_ledger_entry", func(t *testing.T) {
+		account := domain.NewAccount()
+		account.Balance = decimal.NewFromInt(25)
+		entry := domain.NewClosingLedgerEntry(account, "store-001")
+		assert.Equal(t, account.ID, entry.AccountID)
+		assert.Equal(t, domain.LedgerDirectionDebit, entry.Direction)
+		assert.Equal(t, domain.LedgerReasonAccountPurged, entry.Reason)
+		assert.True(t, entry.Amount.Equal(decimal.NewFromInt(25)))
+		assert.True(t, entry.Balance.IsZero())
+		assert.Equal(t, "store-001", entry.ReferenceID)
+	})
+
+	t.Run("reconciliation", func(t *testing.T) {
+		account := domain.NewAccount()
+		account.Balance = decimal.NewFromInt(25)
+		assert.True(t, domain.NewReconciliation(account, decimal.RequireFromString("25.00")).Consistent)
+		assert.False(t, domain.NewReconciliation(account, decimal.NewFromInt(20)).Consistent)
+	})
+}
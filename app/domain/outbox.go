@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// An OutboxMessage is an event recorded in the same transaction as the change
+// that produced it, waiting to be relayed to the message broker.
+type OutboxMessage struct {
+	Base
+	Topic         string     `json:"topic" gorm:"column:topic;type:varchar;not null"`
+	Payload       string     `json:"payload" gorm:"column:payload;type:text;not null"`
+	Attempts      int        `json:"attempts" gorm:"column:attempts"`
+	LastError     string     `json:"last_error" gorm:"column:last_error;type:text"`
+	NextAttemptAt time.Time  `json:"next_attempt_at" gorm:"column:next_attempt_at"`
+	DeliveredAt   *time.Time `json:"delivered_at" gorm:"column:delivered_at"`
+	LockedUntil   *time.Time `json:"locked_until" gorm:"column:locked_until"`
+}
+
+type (
+	// OutboxRepository represent the outbox's repository contract
+	OutboxRepository interface {
+		Store(ctx context.Context, msg *OutboxMessage) error
+		// FindPending finds the oldest undelivered messages, in the order
+		// they were stored, and locks them until the end of the transaction
+		// of ctx
+		FindPending(ctx context.Context, limit int) ([]*OutboxMessage, error)
+		Update(ctx context.Context, msg *OutboxMessage) error
+	}
+)
+
+// NewOutboxMessage creates an *OutboxMessage ready to be relayed
+func NewOutboxMessage(topic string, payload []byte) (msg *OutboxMessage) {
+	msg = new(OutboxMessage)
+	msg.ID = uuid.NewV4().String()
+	msg.Topic = topic
+	msg.Payload = string(payload)
+	msg.CreatedAt = time.Now()
+	msg.NextAttemptAt = msg.CreatedAt
+	return
+}
+
+// Due tells whether the message can be relayed at now: it is not delivered,
+// its next attempt has come and no relay holds a lease on it
+func (m *OutboxMessage) Due(now time.Time) bool {
+	return m.DeliveredAt == nil &&
+		!m.NextAttemptAt.After(now) &&
+		(m.LockedUntil == nil || !m.LockedUntil.After(now))
+}
+
+// Lease reserves the message to a relay until until
+func (m *OutboxMessage) Lease(until time.Time) {
+	m.LockedUntil = &until
+}
+
+// Deliver marks the message as relayed to the broker
+func (m *OutboxMessage) Deliver() {
+	now := time.Now()
+	m.DeliveredAt = &now
+	m.LastError = ""
+	m.LockedUntil = nil
+}
+
+// Fail records a failed relay attempt and schedules the next one after backoff
+func (m *OutboxMessage) Fail(err error, backoff time.Duration) {
+	m.Attempts++
+	m.LastError = err.Error()
+	m.NextAttemptAt = time.Now().Add(backoff)
+	m.LockedUntil = nil
+}
@@ -0,0 +1,57 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxMessage(t *testing.T) {
+	t.Parallel()
+	t.Run("new_outbox_message", func(t *testing.T) {
+		msg := domain.NewOutboxMessage("store.new", []byte(`{"id":"001"}`))
+		assert.NotNil(t, msg)
+		assert.NotEmpty(t, msg.ID)
+		assert.Equal(t, "store.new", msg.Topic)
+		assert.Equal(t, `{"id":"001"}`, msg.Payload)
+		assert.Nil(t, msg.DeliveredAt)
+		assert.Equal(t, msg.CreatedAt, msg.NextAttemptAt)
+	})
+
+	t.Run("deliver", func(t *testing.T) {
+		msg := domain.NewOutboxMessage("store.new", []byte(`{}`))
+		msg.LastError = "broker unavailable"
+		msg.Deliver()
+		assert.NotNil(t, msg.DeliveredAt)
+		assert.Empty(t, msg.LastError)
+	})
+
+	t.Run("due", func(t *testing.T) {
+		msg := domain.NewOutboxMessage("store.new", []byte(`{}`))
+		now := time.Now()
+		assert.True(t, msg.Due(now))
+
+		msg.Lease(now.Add(time.Minute))
+		assert.False(t, msg.Due(now))
+		assert.True(t, msg.Due(now.Add(time.Minute)))
+
+		msg.Fail(errors.New("broker unavailable"), time.Minute)
+		assert.Nil(t, msg.LockedUntil)
+		assert.False(t, msg.Due(now))
+
+		msg.Deliver()
+		assert.False(t, msg.Due(now.Add(time.Hour)))
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		msg := domain.NewOutboxMessage("store.new", []byte(`{}`))
+		msg.Fail(errors.New("broker unavailable"), time.Minute)
+		assert.Nil(t, msg.DeliveredAt)
+		assert.Equal(t, 1, msg.Attempts)
+		assert.Equal(t, "broker unavailable", msg.LastError)
+		assert.True(t, msg.NextAttemptAt.After(time.Now().Add(59*time.Second)))
+	})
+}
@@ -1,6 +1,42 @@
 package domain
 
+import "math"
+
+// earthRadiusKm is the mean radius of the earth
+const earthRadiusKm = 6371.0088
+
 type Position struct {
 	Lat float64 `json:"lat" gorm:"type:decimal(11,8)"`
 	Lng float64 `json:"lng" gorm:"type:decimal(11,8)"`
 }
+
+// DistanceTo returns the great-circle distance in km between p and to, using
+// the haversine formula
+func (p Position) DistanceTo(to Position) float64 {
+	lat1 := p.Lat * math.Pi / 180
+	lat2 := to.Lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (to.Lng - p.Lng) * math.Pi / 180
+
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// BoundingBox returns the south-west and north-east corners of a box holding
+// every position within radiusKm of p. Longitudes are not bounded, and span
+// -180 to 180, when the box crosses a pole or the antimeridian.
+func (p Position) BoundingBox(radiusKm float64) (min, max Position) {
+	angle := radiusKm / earthRadiusKm
+	dLat := angle * 180 / math.Pi
+	min.Lat = math.Max(p.Lat-dLat, -90)
+	max.Lat = math.Min(p.Lat+dLat, 90)
+
+	min.Lng, max.Lng = -180, 180
+	if min.Lat > -90 && max.Lat < 90 {
+		dLng := math.Asin(math.Sin(angle)/math.Cos(p.Lat*math.Pi/180)) * 180 / math.Pi
+		if p.Lng-dLng >= -180 && p.Lng+dLng <= 180 {
+			min.Lng, max.Lng = p.Lng-dLng, p.Lng+dLng
+		}
+	}
+	return
+}
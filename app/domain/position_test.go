@@ -0,0 +1,107 @@
+package domain_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPosition_DistanceTo(t *testing.T) {
+	luanda := domain.Position{Lat: -8.8383, Lng: 13.2344}
+	testCases := []struct {
+		name     string
+		to       domain.Position
+		expected float64
+	}{
+		{
+			name:     "same_position",
+			to:       luanda,
+			expected: 0,
+		},
+		{
+			name:     "benguela",
+			to:       domain.Position{Lat: -12.5763, Lng: 13.4055},
+			expected: 416.1,
+		},
+		{
+			name:     "lisbon",
+			to:       domain.Position{Lat: 38.7223, Lng: -9.1393},
+			expected: 5773.1,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.InDelta(t, tc.expected, luanda.DistanceTo(tc.to), 1)
+			assert.InDelta(t, tc.expected, tc.to.DistanceTo(luanda), 1)
+		})
+	}
+}
+
+func TestPosition_BoundingBox(t *testing.T) {
+	testCases := []struct {
+		name     string
+		position domain.Position
+		radiusKm float64
+		full     bool
+	}{
+		{
+			name:     "equator",
+			position: domain.Position{Lat: 0, Lng: 0},
+			radiusKm: 10,
+		},
+		{
+			name:     "south",
+			position: domain.Position{Lat: -8.8383, Lng: 13.2344},
+			radiusKm: 50,
+		},
+		{
+			name:     "antimeridian",
+			position: domain.Position{Lat: -17.7134, Lng: 179.9},
+			radiusKm: 50,
+			full:     true,
+		},
+		{
+			name:     "pole",
+			position: domain.Position{Lat: 89.99, Lng: 10},
+			radiusKm: 50,
+			full:     true,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			min, max := tc.position.BoundingBox(tc.radiusKm)
+			if tc.full {
+				assert.Equal(t, -180.0, min.Lng)
+				assert.Equal(t, 180.0, max.Lng)
+			}
+
+			for bearing := 0.0; bearing < 360; bearing += 15 {
+				corner := destination(tc.position, bearing, tc.radiusKm*0.9999)
+				assert.InDelta(t, tc.radiusKm, tc.position.DistanceTo(corner), tc.radiusKm*0.001)
+				assert.True(t, corner.Lat >= min.Lat && corner.Lat <= max.Lat, "latitude at bearing %v", bearing)
+				assert.True(t, corner.Lng >= min.Lng && corner.Lng <= max.Lng, "longitude at bearing %v", bearing)
+			}
+		})
+	}
+}
+
+// destination returns the position reached from p after km along bearing
+func destination(p domain.Position, bearing, km float64) domain.Position {
+	lat := p.Lat * math.Pi / 180
+	lng := p.Lng * math.Pi / 180
+	angle := km / 6371.0088
+	theta := bearing * math.Pi / 180
+
+	lat2 := math.Asin(math.Sin(lat)*math.Cos(angle) + math.Cos(lat)*math.Sin(angle)*math.Cos(theta))
+	lng2 := lng + math.Atan2(math.Sin(theta)*math.Sin(angle)*math.Cos(lat), math.Cos(angle)-math.Sin(lat)*math.Sin(lat2))
+	lng2 = math.Mod(lng2+3*math.Pi, 2*math.Pi) - math.Pi
+	return domain.Position{Lat: lat2 * 180 / math.Pi, Lng: lng2 * 180 / math.Pi}
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"strings"
+)
+
+// StoreSortColumns are the store columns a listing can be sorted by
+var StoreSortColumns = []string{"name", "status", "created_at", "updated_at"}
+
+// A SortField orders a listing by one column
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// SortSpec orders a listing by its fields, in order
+type SortSpec []SortField
+
+// ParseSort parses a comma separated sort expression such as
+// "name,-created_at". A leading "-" sorts the column in descending order and a
+// leading "+" in ascending order. Columns not in allowed return ErrInvalidSort.
+func ParseSort(expr string, allowed []string) (spec SortSpec, err error) {
+	columns := make(map[string]bool, len(allowed))
+	for _, column := range allowed {
+		columns[column] = true
+	}
+
+	seen := make(map[string]bool)
+	for _, item := range strings.Split(expr, ",") {
+		item = strings.TrimSpace(item)
+		field := SortField{Column: item}
+		switch {
+		case strings.HasPrefix(item, "-"):
+			field = SortField{Column: item[1:], Desc: true}
+		case strings.HasPrefix(item, "+"):
+			field = SortField{Column: item[1:]}
+		}
+
+		if !columns[field.Column] || seen[field.Column] {
+			return nil, ErrInvalidSort
+		}
+		seen[field.Column] = true
+		spec = append(spec, field)
+	}
+	return
+}
+
+// String returns the sort expression of s
+func (s SortSpec) String() string {
+	items := make([]string, len(s))
+	for i, field := range s {
+		items[i] = field.Column
+		if field.Desc {
+			items[i] = "-" + field.Column
+		}
+	}
+	return strings.Join(items, ",")
+}
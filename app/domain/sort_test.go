@@ -0,0 +1,64 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSort(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expr        string
+		expected    domain.SortSpec
+		expectedErr bool
+	}{
+		{
+			name:        "failure_empty_expression",
+			expr:        "",
+			expectedErr: true,
+		},
+		{
+			name:        "failure_unknown_column",
+			expr:        "name,-balance",
+			expectedErr: true,
+		},
+		{
+			name:        "failure_sql_injection",
+			expr:        "name; DROP TABLE stores",
+			expectedErr: true,
+		},
+		{
+			name:        "failure_legacy_direction_suffix",
+			expr:        "created_at DESC",
+			expectedErr: true,
+		},
+		{
+			name:        "failure_repeated_column",
+			expr:        "name,-name",
+			expectedErr: true,
+		},
+		{
+			name:     "success",
+			expr:     "name, -created_at,+status",
+			expected: domain.SortSpec{{Column: "name"}, {Column: "created_at", Desc: true}, {Column: "status"}},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			spec, err := domain.ParseSort(tc.expr, domain.StoreSortColumns)
+			if tc.expectedErr {
+				assert.ErrorIs(t, err, domain.ErrInvalidSort)
+				assert.Nil(t, spec)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, spec)
+				assert.Equal(t, "name,-created_at,status", spec.String())
+			}
+		})
+	}
+}
@@ -3,6 +3,9 @@ package domain
 import (
 	"context"
 	"encoding/json"
+	"html"
+	"io"
+	"strings"
 	"time"
 
 	"github.com/lib/pq"
@@ -18,6 +21,10 @@ const (
 	StoreStatusDisable string = "disable"
 	// block store status value
 	StoreStatusBlock string = "block"
+	// suspended store status value, set while the store category is disabled
+	StoreStatusSuspended string = "suspended"
+	// rejected store status value, set when a pending store is turned down
+	StoreStatusRejected string = "rejected"
 )
 
 // Stores belong to the domain layer.
@@ -33,29 +40,138 @@ type Store struct {
 	AccountID   string         `json:"account_id" gorm:"column:account_id;type:uuid"`
 	CategoryID  string         `json:"category_id" gorm:"column:category_id;type:uuid"`
 	Image       string         `json:"image" gorm:"column:image;type:varchar(255)"`
+	Images      StoreImages    `json:"images" swaggertype:"object,string" gorm:"column:images;type:jsonb"`
 	Tags        pq.StringArray `json:"tags" swaggertype:"array,string" gorm:"column:tags;type:text[]"`
 	Position    `json:"location"`
+	Version     int `json:"version" gorm:"column:version;not null;default:1"`
+	// DeletedAt is set while the store is deleted, until it is restored or
+	// purged
+	DeletedAt *time.Time `json:"deleted_at,omitempty" gorm:"column:deleted_at"`
+	// Hours, IsOpenNow and NextChangeAt are loaded from the store_hours
+	// tables, and computed when the store is read
+	Hours        *StoreHours `json:"hours,omitempty" gorm:"-"`
+	IsOpenNow    bool        `json:"is_open_now" gorm:"-"`
+	NextChangeAt *time.Time  `json:"next_change_at,omitempty" gorm:"-"`
 }
 
+// CreateStoreRequest holds a new store. Its UserID is the authenticated
+// caller, never taken from the request.
 type CreateStoreRequest struct {
 	Name        string   `json:"name" validate:"required,min=3,max=250"`
 	Description string   `json:"description" validate:"required,min=3,max=250"`
 	CategoryID  string   `json:"category_id" validate:"required,uuid4"`
-	UserID      string   `json:"user_id" validate:"required,uuid4"`
+	UserID      string   `json:"-"`
 	Tags        []string `json:"tags"`
 	Lat         float64  `json:"latitude" validate:"latitude"`
 	Lng         float64  `json:"longitude" validate:"longitude"`
 }
 
+// UpdateStoreRequest holds a partial update of a store. Nil fields are left
+// unchanged. The image is only set by an upload.
 type UpdateStoreRequest struct {
-	ID          string   `json:"-" validate:"required,uuid4"`
-	Name        string   `json:"name" validate:"min=3,max=250"`
-	Description string   `json:"description" validate:"min=3,max=250"`
-	CategoryID  string   `json:"category_id" validate:"uuid4"`
-	Image       string   `json:"image"`
-	Tags        []string `json:"tags"`
-	Lat         float64  `json:"latitude" validate:"latitude"`
-	Lng         float64  `json:"longitude" validate:"longitude"`
+	ID          string    `json:"-" validate:"required,uuid4"`
+	Version     int       `json:"-"`
+	Name        *string   `json:"name,omitempty" validate:"omitempty,min=3,max=250"`
+	Description *string   `json:"description,omitempty" validate:"omitempty,min=3,max=250"`
+	CategoryID  *string   `json:"category_id,omitempty" validate:"omitempty,uuid4"`
+	Tags        *[]string `json:"tags,omitempty"`
+	Lat         *float64  `json:"latitude,omitempty" validate:"omitempty,latitude"`
+	Lng         *float64  `json:"longitude,omitempty" validate:"omitempty,longitude"`
+}
+
+// StoreFilter narrows a store listing. Zero value fields are not applied.
+// With IncludeDescendants, CategoryID also matches the stores of its
+// sub-categories. OpenAt matches the stores open at that time. The deleted
+// stores are left out unless IncludeDeleted is set.
+type StoreFilter struct {
+	Status             string `validate:"omitempty,oneof=pending active disable block suspended rejected"`
+	CategoryID         string `validate:"omitempty,uuid4"`
+	IncludeDescendants bool
+	UserID             string   `validate:"omitempty,uuid4"`
+	AnyTags            []string `validate:"omitempty,dive,required"`
+	AllTags            []string `validate:"omitempty,dive,required"`
+	CreatedFrom        time.Time
+	CreatedTo          time.Time
+	NamePrefix         string `validate:"omitempty,max=250"`
+	OpenAt             time.Time
+	IncludeDeleted     bool
+}
+
+// A NearbyStore is a store found around a position, along with its distance
+// to it
+type NearbyStore struct {
+	*Store
+	Distance float64 `json:"distance_km"`
+}
+
+// NearbyStores belong to the domain layer.
+type NearbyStores []*NearbyStore
+
+// NearbyStoreRequest searches the stores within RadiusKm of a position,
+// nearest first
+type NearbyStoreRequest struct {
+	Lat      float64      `validate:"latitude"`
+	Lng      float64      `validate:"longitude"`
+	RadiusKm float64      `validate:"gt=0,lte=500"`
+	Limit    int          `validate:"gte=0,lte=100"`
+	Filter   *StoreFilter `validate:"omitempty"`
+}
+
+// A StoreSearchResult is a store matching a search, along with its relevance
+// and an excerpt with the matched terms highlighted
+type StoreSearchResult struct {
+	*Store
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// The repositories mark the matched terms of a raw snippet with
+// SnippetStartSel and SnippetStopSel, control characters HTML escaping
+// leaves alone
+const (
+	SnippetStartSel = "\x02"
+	SnippetStopSel  = "\x03"
+)
+
+var snippetTags = strings.NewReplacer(SnippetStartSel, "<b>", SnippetStopSel, "</b>")
+
+// NewSnippet returns a raw snippet as HTML: the stored text escaped, and
+// the matched terms wrapped in <b> tags
+func NewSnippet(raw string) string {
+	return snippetTags.Replace(html.EscapeString(raw))
+}
+
+// StoreSearchResults belong to the domain layer.
+type StoreSearchResults []*StoreSearchResult
+
+// A StoreSearchPage is one page of search results, most relevant first
+type StoreSearchPage struct {
+	Results StoreSearchResults `json:"data"`
+	Total   int64              `json:"total"`
+}
+
+// SearchStoreRequest searches the stores by name, description and tags
+type SearchStoreRequest struct {
+	Query  string       `validate:"required,max=200"`
+	Limit  int          `validate:"gte=0,lte=100"`
+	Page   int          `validate:"gte=0"`
+	Filter *StoreFilter `validate:"omitempty"`
+}
+
+// A FieldChange holds the previous and the new value of a store field
+type FieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// StoreChanges maps the json name of each changed store field to its change
+type StoreChanges map[string]FieldChange
+
+// A StoreUpdatedEvent is the store snapshot published after an update,
+// along with the fields that changed
+type StoreUpdatedEvent struct {
+	*Store
+	Changes StoreChanges `json:"changes"`
 }
 
 type (
@@ -64,7 +180,11 @@ type (
 		Create(ctx context.Context, store *Store) error
 		FindByID(ctx context.Context, id string) (*Store, error)
 		FindByName(ctx context.Context, name string) (*Store, error)
-		FindAll(ctx context.Context, sort string, limit, page int) (Stores, int64, error)
+		FindAll(ctx context.Context, filter *StoreFilter, sort SortSpec, cursor *Cursor, limit, page int) (Stores, int64, error)
+		FindNearby(ctx context.Context, position Position, radiusKm float64, filter *StoreFilter, limit int) (NearbyStores, error)
+		Search(ctx context.Context, query string, filter *StoreFilter, limit, page int) (StoreSearchResults, int64, error)
+		FindDeletedByID(ctx context.Context, id string) (*Store, error)
+		FindDeleted(ctx context.Context, before time.Time, limit int) (Stores, error)
 		Update(ctx context.Context, store *Store) error
 		Delete(ctx context.Context, id string) error
 	}
@@ -72,54 +192,28 @@ type (
 	// StoreUsecase represent the store's usecase contract
 	StoreUsecase interface {
 		Store(ctx context.Context, param *CreateStoreRequest) error
-		Index(ctx context.Context, sort string, limit, page int) (Stores, int64, error)
+		Index(ctx context.Context, filter *StoreFilter, sort, cursor string, limit, page int) (*StorePage, error)
+		Nearby(ctx context.Context, param *NearbyStoreRequest) (NearbyStores, error)
+		Search(ctx context.Context, param *SearchStoreRequest) (*StoreSearchPage, error)
 		Get(ctx context.Context, id string) (*Store, error)
 		Update(ctx context.Context, param *UpdateStoreRequest) error
 		Delete(ctx context.Context, id string) error
-		Block(ctx context.Context, id string) error
-		Active(ctx context.Context, id string) error
-		Disable(ctx context.Context, id string) error
+		Restore(ctx context.Context, id string) error
+		UploadImage(ctx context.Context, param *StoreImageRequest) (*Store, error)
+		SetHours(ctx context.Context, param *StoreHoursRequest) (*Store, error)
+		ChangeStatus(ctx context.Context, param *StoreStatusRequest) error
+		Actions(ctx context.Context, id string) (StoreTransitions, error)
+		StatusHistory(ctx context.Context, id string, limit, page int) (StoreStatusHistory, int64, error)
+		GetAccount(ctx context.Context, id string) (*Account, error)
+		Deposit(ctx context.Context, param *AccountOperationRequest) (*Account, error)
+		Withdraw(ctx context.Context, param *AccountOperationRequest) (*Account, error)
+		ListTransactions(ctx context.Context, id string, limit, page int) (LedgerEntries, int64, error)
+		ReconcileAccount(ctx context.Context, id string) (*Reconciliation, error)
+		Import(ctx context.Context, param *StoreImportRequest) (*StoreImportReport, error)
+		Export(ctx context.Context, format string, w io.Writer) error
 	}
 )
 
-// Block set store entity status to block
-func (s *Store) Block() (err error) {
-	if s.Status == StoreStatusBlock {
-		return ErrBlocked
-	}
-
-	if s.Status == StoreStatusPending {
-		return ErrPending
-	}
-
-	s.Status = StoreStatusBlock
-	return
-}
-
-// Activate set store entity status to active
-func (s *Store) Activate() (err error) {
-	if s.Status == StoreStatusActive {
-		return ErrActived
-	}
-
-	s.Status = StoreStatusActive
-	return
-}
-
-// Disable set store entity status to disable
-func (s *Store) Disable() (err error) {
-	if s.Status == StoreStatusDisable {
-		return ErrInactived
-	}
-
-	if s.Status == StoreStatusBlock {
-		return ErrBlocked
-	}
-
-	s.Status = StoreStatusDisable
-	return
-}
-
 // ToJson returns the JSON encoding of Store
 func (s *Store) ToJson() (res []byte) {
 	res, _ = json.Marshal(s)
@@ -140,18 +234,82 @@ func NewStore(param *CreateStoreRequest) (store *Store) {
 	store.Position.Lat = param.Lat
 	store.Position.Lng = param.Lng
 	store.Status = StoreStatusPending
+	store.Version = 1
 	store.CreatedAt = time.Now()
 	return
 }
 
-func (s *Store) FromUpdateRequest(param *UpdateStoreRequest) {
-	s.ID = param.ID
-	s.Name = param.Name
-	s.Description = param.Description
-	s.CategoryID = param.CategoryID
-	s.Image = param.Image
-	s.Tags = param.Tags
-	s.Position.Lat = param.Lat
-	s.Position.Lng = param.Lng
+// FromUpdateRequest merges the fields supplied in param into the store and
+// returns the ones whose value changed
+func (s *Store) FromUpdateRequest(param *UpdateStoreRequest) (changes StoreChanges) {
+	changes = make(StoreChanges)
+	if param.Name != nil && *param.Name != s.Name {
+		changes["name"] = FieldChange{From: s.Name, To: *param.Name}
+		s.Name = *param.Name
+	}
+	if param.Description != nil && *param.Description != s.Description {
+		changes["description"] = FieldChange{From: s.Description, To: *param.Description}
+		s.Description = *param.Description
+	}
+	if param.CategoryID != nil && *param.CategoryID != s.CategoryID {
+		changes["category_id"] = FieldChange{From: s.CategoryID, To: *param.CategoryID}
+		s.CategoryID = *param.CategoryID
+	}
+	if param.Tags != nil && !equalTags(s.Tags, *param.Tags) {
+		changes["tags"] = FieldChange{From: s.Tags, To: pq.StringArray(*param.Tags)}
+		s.Tags = *param.Tags
+	}
+	if param.Lat != nil && *param.Lat != s.Position.Lat {
+		changes["location.lat"] = FieldChange{From: s.Position.Lat, To: *param.Lat}
+		s.Position.Lat = *param.Lat
+	}
+	if param.Lng != nil && *param.Lng != s.Position.Lng {
+		changes["location.lng"] = FieldChange{From: s.Position.Lng, To: *param.Lng}
+		s.Position.Lng = *param.Lng
+	}
+
+	if len(changes) > 0 {
+		s.UpdatedAt = time.Now()
+	}
+	return
+}
+
+// MarkDeleted deletes the store at the given time, keeping its row until it
+// is purged
+func (s *Store) MarkDeleted(at time.Time) {
+	s.DeletedAt = &at
+	s.UpdatedAt = at
+}
+
+// Restore brings back a deleted store
+func (s *Store) Restore() {
+	s.DeletedAt = nil
 	s.UpdatedAt = time.Now()
 }
+
+func equalTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// NewStoreUpdatedEvent creates the update event of store
+func NewStoreUpdatedEvent(store *Store, changes StoreChanges) *StoreUpdatedEvent {
+	return &StoreUpdatedEvent{
+		Store:   store,
+		Changes: changes,
+	}
+}
+
+// ToJson returns the JSON encoding of StoreUpdatedEvent
+func (e *StoreUpdatedEvent) ToJson() (res []byte) {
+	res, _ = json.Marshal(e)
+
+	return
+}
@@ -0,0 +1,247 @@
+package domain
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+const (
+	clockLayout = "15:04"
+	dateLayout  = "2006-01-02"
+	// maxClosureDays bounds the length of a single closure
+	maxClosureDays = 366
+	// hoursHorizonDays bounds how far ahead the next opening is looked for
+	hoursHorizonDays = maxClosureDays + 7
+)
+
+// OpeningHours is an interval of the weekly schedule of a store, in its
+// timezone. Opens and Closes are HH:MM clock times, Closes being 24:00 for
+// midnight. An interval closing at or before its opening time runs past
+// midnight, into the next day.
+type OpeningHours struct {
+	StoreID string `json:"-" gorm:"column:store_id;type:uuid"`
+	Weekday int    `json:"weekday" validate:"min=0,max=6" gorm:"column:weekday"`
+	Opens   string `json:"opens" validate:"required" gorm:"column:opens"`
+	Closes  string `json:"closes" validate:"required" gorm:"column:closes"`
+}
+
+// A StoreClosure closes a store on the YYYY-MM-DD dates From to To, both
+// included, such as a holiday. The intervals opening on those dates are
+// cancelled.
+type StoreClosure struct {
+	StoreID string `json:"-" gorm:"column:store_id;type:uuid"`
+	From    string `json:"from" validate:"required" gorm:"column:from_date"`
+	To      string `json:"to" gorm:"column:to_date"`
+	Reason  string `json:"reason" validate:"max=255" gorm:"column:reason"`
+}
+
+// StoreHours holds the weekly schedule and the closures of a store, along
+// with the IANA timezone they are given in
+type StoreHours struct {
+	StoreID   string          `json:"-" gorm:"column:store_id;type:uuid;primary key"`
+	Timezone  string          `json:"timezone" gorm:"column:timezone"`
+	Weekly    []*OpeningHours `json:"weekly" gorm:"-"`
+	Closures  []*StoreClosure `json:"closures" gorm:"-"`
+	UpdatedAt time.Time       `json:"updated_at" gorm:"column:updated_at"`
+}
+
+// StoreHoursRequest replaces the opening hours of a store. Weekday 0 is
+// Sunday. An empty To closes the store on From only.
+type StoreHoursRequest struct {
+	ID       string          `json:"-" validate:"required,uuid4"`
+	Timezone string          `json:"timezone" validate:"required,max=64"`
+	Weekly   []*OpeningHours `json:"weekly" validate:"max=70,dive,required"`
+	Closures []*StoreClosure `json:"closures" validate:"max=100,dive,required"`
+}
+
+type (
+	// StoreHoursRepository represent the store opening hours' repository contract
+	StoreHoursRepository interface {
+		Save(ctx context.Context, hours *StoreHours) error
+		FindByStoreIDs(ctx context.Context, storeIDs []string) ([]*StoreHours, error)
+	}
+)
+
+// Check fails with ErrInvalidTimezone when the timezone is not an IANA one,
+// and with ErrInvalidHours when a time, a date or a weekday is malformed.
+// Closures without To are set to close on From only.
+func (r *StoreHoursRequest) Check() error {
+	if _, err := loadTimezone(r.Timezone); err != nil {
+		return ErrInvalidTimezone
+	}
+
+	for _, hours := range r.Weekly {
+		opens, err := parseClock(hours.Opens)
+		if err != nil || opens == 24*60 {
+			return ErrInvalidHours
+		}
+		closes, err := parseClock(hours.Closes)
+		if err != nil || closes == opens || hours.Weekday < 0 || hours.Weekday > 6 {
+			return ErrInvalidHours
+		}
+	}
+
+	for _, closure := range r.Closures {
+		if closure.To == "" {
+			closure.To = closure.From
+		}
+		from, err := time.Parse(dateLayout, closure.From)
+		if err != nil {
+			return ErrInvalidHours
+		}
+		to, err := time.Parse(dateLayout, closure.To)
+		if err != nil || to.Before(from) || to.Sub(from) >= maxClosureDays*24*time.Hour {
+			return ErrInvalidHours
+		}
+	}
+	return nil
+}
+
+// NewStoreHours creates the opening hours of param
+func NewStoreHours(param *StoreHoursRequest) (hours *StoreHours) {
+	hours = new(StoreHours)
+	hours.StoreID = param.ID
+	hours.Timezone = param.Timezone
+	hours.Weekly = make([]*OpeningHours, 0, len(param.Weekly))
+	for _, h := range param.Weekly {
+		hours.Weekly = append(hours.Weekly, &OpeningHours{StoreID: param.ID, Weekday: h.Weekday, Opens: h.Opens, Closes: h.Closes})
+	}
+	hours.Closures = make([]*StoreClosure, 0, len(param.Closures))
+	for _, c := range param.Closures {
+		hours.Closures = append(hours.Closures, &StoreClosure{StoreID: param.ID, From: c.From, To: c.To, Reason: c.Reason})
+	}
+	hours.UpdatedAt = time.Now()
+	return
+}
+
+// SetHours sets the opening hours of the store and whether it is open at now
+func (s *Store) SetHours(hours *StoreHours, now time.Time) {
+	s.Hours = hours
+	s.IsOpenNow = false
+	s.NextChangeAt = nil
+	if hours != nil {
+		s.IsOpenNow, s.NextChangeAt = hours.OpenAt(now)
+	}
+}
+
+// openPeriod is a span of time the store is open, from start to end
+type openPeriod struct {
+	start time.Time
+	end   time.Time
+}
+
+// OpenAt tells whether the store is open at t and when that changes next.
+// Overlapping and back to back intervals are one open period, so the next
+// change of a store that never closes, or never opens again, is nil.
+func (h *StoreHours) OpenAt(t time.Time) (open bool, next *time.Time) {
+	loc, err := loadTimezone(h.Timezone)
+	if err != nil {
+		return false, nil
+	}
+
+	weekly := make(map[time.Weekday][]*OpeningHours)
+	for _, hours := range h.Weekly {
+		weekday := time.Weekday(hours.Weekday)
+		weekly[weekday] = append(weekly[weekday], hours)
+	}
+	for _, hours := range weekly {
+		sort.Slice(hours, func(i, j int) bool { return hours[i].Opens < hours[j].Opens })
+	}
+
+	local := t.In(loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	horizon := today.AddDate(0, 0, hoursHorizonDays)
+
+	// the periods are built day by day, starting with the ones of yesterday
+	// still open after midnight, and merged until one holds or follows t
+	var current *openPeriod
+	for day := today.AddDate(0, 0, -1); day.Before(horizon); day = day.AddDate(0, 0, 1) {
+		if h.closedOn(day) {
+			continue
+		}
+
+		for _, hours := range weekly[day.Weekday()] {
+			period := hours.period(day, loc)
+			if current != nil && period.start.After(current.end) {
+				if t.Before(current.end) {
+					return current.at(t)
+				}
+				current = nil
+			}
+
+			if current == nil {
+				current = &period
+			} else if period.end.After(current.end) {
+				current.end = period.end
+			}
+		}
+	}
+
+	if current == nil || !t.Before(current.end) {
+		return false, nil
+	}
+	if t.Before(current.start) || current.end.Before(horizon) {
+		return current.at(t)
+	}
+	return true, nil
+}
+
+// at returns whether the period holds t, which is before its end, and when
+// that changes
+func (p *openPeriod) at(t time.Time) (bool, *time.Time) {
+	if t.Before(p.start) {
+		return false, &p.start
+	}
+	return true, &p.end
+}
+
+// period returns when the interval opens and closes on day
+func (h *OpeningHours) period(day time.Time, loc *time.Location) openPeriod {
+	opens, _ := parseClock(h.Opens)
+	closes, _ := parseClock(h.Closes)
+	if closes <= opens {
+		closes += 24 * 60
+	}
+	return openPeriod{
+		start: time.Date(day.Year(), day.Month(), day.Day(), 0, opens, 0, 0, loc),
+		end:   time.Date(day.Year(), day.Month(), day.Day(), 0, closes, 0, 0, loc),
+	}
+}
+
+// closedOn tells whether a closure covers day
+func (h *StoreHours) closedOn(day time.Time) bool {
+	date := day.Format(dateLayout)
+	for _, closure := range h.Closures {
+		to := closure.To
+		if to == "" {
+			to = closure.From
+		}
+		if closure.From <= date && date <= to {
+			return true
+		}
+	}
+	return false
+}
+
+// parseClock returns the minutes since midnight of a HH:MM clock time,
+// 24:00 included
+func parseClock(clock string) (int, error) {
+	if clock == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse(clockLayout, clock)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// loadTimezone loads an IANA timezone, refusing the empty and Local names
+// time.LoadLocation accepts
+func loadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+	return time.LoadLocation(name)
+}
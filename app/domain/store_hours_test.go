@@ -0,0 +1,223 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreHoursRequest_Check(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		change      func(r *domain.StoreHoursRequest)
+		expectedErr error
+	}{
+		{
+			name:   "valid",
+			change: func(r *domain.StoreHoursRequest) {},
+		},
+		{
+			name:        "unknown_timezone",
+			change:      func(r *domain.StoreHoursRequest) { r.Timezone = "Mars/Olympus" },
+			expectedErr: domain.ErrInvalidTimezone,
+		},
+		{
+			name:        "local_timezone",
+			change:      func(r *domain.StoreHoursRequest) { r.Timezone = "Local" },
+			expectedErr: domain.ErrInvalidTimezone,
+		},
+		{
+			name:        "malformed_time",
+			change:      func(r *domain.StoreHoursRequest) { r.Weekly[0].Closes = "25:00" },
+			expectedErr: domain.ErrInvalidHours,
+		},
+		{
+			name:        "opening_at_midnight_end",
+			change:      func(r *domain.StoreHoursRequest) { r.Weekly[0].Opens = "24:00" },
+			expectedErr: domain.ErrInvalidHours,
+		},
+		{
+			name:        "empty_interval",
+			change:      func(r *domain.StoreHoursRequest) { r.Weekly[0].Closes = r.Weekly[0].Opens },
+			expectedErr: domain.ErrInvalidHours,
+		},
+		{
+			name:        "unknown_weekday",
+			change:      func(r *domain.StoreHoursRequest) { r.Weekly[0].Weekday = 7 },
+			expectedErr: domain.ErrInvalidHours,
+		},
+		{
+			name:        "malformed_date",
+			change:      func(r *domain.StoreHoursRequest) { r.Closures[0].From = "25/12/2021" },
+			expectedErr: domain.ErrInvalidHours,
+		},
+		{
+			name:        "closure_ending_before_it_starts",
+			change:      func(r *domain.StoreHoursRequest) { r.Closures[0].To = "2021-12-24" },
+			expectedErr: domain.ErrInvalidHours,
+		},
+		{
+			name:        "closure_too_long",
+			change:      func(r *domain.StoreHoursRequest) { r.Closures[0].To = "2023-01-01" },
+			expectedErr: domain.ErrInvalidHours,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := sample.NewStoreHoursRequest()
+			tc.change(r)
+			err := r.Check()
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, r.Closures[0].From, r.Closures[0].To)
+		})
+	}
+}
+
+func TestNewStoreHours(t *testing.T) {
+	t.Parallel()
+	r := sample.NewStoreHoursRequest()
+
+	hours := domain.NewStoreHours(r)
+	assert.Equal(t, r.ID, hours.StoreID)
+	assert.Equal(t, r.Timezone, hours.Timezone)
+	assert.Len(t, hours.Weekly, len(r.Weekly))
+	assert.Equal(t, r.ID, hours.Weekly[0].StoreID)
+	assert.Len(t, hours.Closures, len(r.Closures))
+	assert.Equal(t, r.ID, hours.Closures[0].StoreID)
+	assert.False(t, hours.UpdatedAt.IsZero())
+}
+
+func TestStoreHours_OpenAt(t *testing.T) {
+	t.Parallel()
+	luanda, err := time.LoadLocation("Africa/Luanda")
+	assert.NoError(t, err)
+	at := func(day, hour, min int) time.Time {
+		return time.Date(2021, 12, day, hour, min, 0, 0, luanda)
+	}
+
+	// mondays 09:00-13:00 and 14:00-18:00, fridays 20:00-02:00, closed on
+	// friday 2021-12-24; 2021-12-20 is a monday
+	r := sample.NewStoreHoursRequest()
+	r.Closures[0].From = "2021-12-24"
+	assert.NoError(t, r.Check())
+	hours := domain.NewStoreHours(r)
+
+	lateNights := domain.NewStoreHours(&domain.StoreHoursRequest{
+		Timezone: "Africa/Luanda",
+		Weekly: []*domain.OpeningHours{
+			{Weekday: 2, Opens: "22:00", Closes: "24:00"},
+			{Weekday: 3, Opens: "00:00", Closes: "03:00"},
+		},
+	})
+
+	testCases := []struct {
+		name         string
+		hours        *domain.StoreHours
+		at           time.Time
+		expectedOpen bool
+		expectedNext *time.Time
+	}{
+		{
+			name:         "within_an_interval",
+			hours:        hours,
+			at:           at(20, 10, 0),
+			expectedOpen: true,
+			expectedNext: timePtr(at(20, 13, 0)),
+		},
+		{
+			name:         "between_intervals",
+			hours:        hours,
+			at:           at(20, 13, 30),
+			expectedNext: timePtr(at(20, 14, 0)),
+		},
+		{
+			name:         "given_in_another_timezone",
+			hours:        hours,
+			at:           at(20, 13, 30).UTC(),
+			expectedNext: timePtr(at(20, 14, 0)),
+		},
+		{
+			name:         "past_midnight_of_the_day_before",
+			hours:        hours,
+			at:           at(18, 1, 0),
+			expectedOpen: true,
+			expectedNext: timePtr(at(18, 2, 0)),
+		},
+		{
+			name:         "past_midnight_of_a_closure",
+			hours:        hours,
+			at:           at(25, 1, 0),
+			expectedNext: timePtr(at(27, 9, 0)),
+		},
+		{
+			name:         "skipping_a_closure",
+			hours:        hours,
+			at:           at(20, 19, 0),
+			expectedNext: timePtr(at(27, 9, 0)),
+		},
+		{
+			name:         "back_to_back_intervals",
+			hours:        lateNights,
+			at:           at(21, 23, 0),
+			expectedOpen: true,
+			expectedNext: timePtr(at(22, 3, 0)),
+		},
+		{
+			name:         "around_the_clock",
+			hours:        sample.NewStoreHours(),
+			at:           at(20, 3, 0),
+			expectedOpen: true,
+		},
+		{
+			name:  "without_intervals",
+			hours: &domain.StoreHours{Timezone: "Africa/Luanda"},
+			at:    at(20, 10, 0),
+		},
+		{
+			name:  "invalid_timezone",
+			hours: &domain.StoreHours{Timezone: "Mars/Olympus", Weekly: hours.Weekly},
+			at:    at(20, 10, 0),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			open, next := tc.hours.OpenAt(tc.at)
+			assert.Equal(t, tc.expectedOpen, open)
+			if tc.expectedNext == nil {
+				assert.Nil(t, next)
+				return
+			}
+			if assert.NotNil(t, next) {
+				assert.True(t, tc.expectedNext.Equal(*next), "next change at %s, expected %s", next, tc.expectedNext)
+			}
+		})
+	}
+}
+
+func TestStore_SetHours(t *testing.T) {
+	t.Parallel()
+	store := sample.NewStore()
+
+	store.SetHours(sample.NewStoreHours(), time.Now())
+	assert.NotNil(t, store.Hours)
+	assert.True(t, store.IsOpenNow)
+
+	store.SetHours(nil, time.Now())
+	assert.Nil(t, store.Hours)
+	assert.False(t, store.IsOpenNow)
+	assert.Nil(t, store.NextChangeAt)
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,127 @@
+package domain
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	// StoreImageOriginal variant, the uploaded image with its metadata stripped
+	StoreImageOriginal string = "original"
+	// StoreImageMedium variant, fits 600x600
+	StoreImageMedium string = "medium"
+	// StoreImageThumbnail variant, fits 150x150
+	StoreImageThumbnail string = "thumbnail"
+)
+
+// DefaultMaxImageSize is the size limit of an uploaded image, in bytes
+const DefaultMaxImageSize int64 = 5 << 20
+
+// ImageContentTypes are the content types of the images a store accepts,
+// along with the extension of their keys
+var ImageContentTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+}
+
+// An ImageVariant is a rendition of an uploaded image, scaled down to fit
+// Width x Height. A zero Width and Height keep the image size.
+type ImageVariant struct {
+	Name   string
+	Width  int
+	Height int
+}
+
+// StoreImageVariants are the renditions stored for each store image
+var StoreImageVariants = []ImageVariant{
+	{Name: StoreImageOriginal},
+	{Name: StoreImageMedium, Width: 600, Height: 600},
+	{Name: StoreImageThumbnail, Width: 150, Height: 150},
+}
+
+// An EncodedImage is an image variant ready to be stored
+type EncodedImage struct {
+	Variant     string
+	ContentType string
+	Width       int
+	Height      int
+	Data        []byte
+}
+
+// StoreImages maps each variant of the store image to its blob key
+type StoreImages map[string]string
+
+// StoreImageRequest uploads the image of a store. Size is the declared size
+// of Body, zero when unknown.
+type StoreImageRequest struct {
+	ID          string    `validate:"required,uuid4"`
+	ContentType string    `validate:"required"`
+	Size        int64     `validate:"gte=0"`
+	Body        io.Reader `validate:"required"`
+}
+
+type (
+	// ImageProcessor represent the contract of the decoding and encoding of
+	// the uploaded images
+	ImageProcessor interface {
+		Process(ctx context.Context, body io.Reader, contentType string, variants []ImageVariant) ([]*EncodedImage, error)
+	}
+)
+
+// CheckImage fails when contentType is not an accepted image, or size is
+// over max
+func CheckImage(contentType string, size, max int64) error {
+	if _, ok := ImageContentTypes[contentType]; !ok {
+		return ErrUnsupportedImage
+	}
+	if size > max {
+		return ErrImageTooLarge
+	}
+	return nil
+}
+
+// StoreImageKey returns the blob key of variant of the upload of a store
+func StoreImageKey(storeID, uploadID, variant, contentType string) string {
+	return fmt.Sprintf("stores/%s/%s/%s%s", storeID, uploadID, variant, ImageContentTypes[contentType])
+}
+
+// SetImages replaces the images of the store, its Image being the key of the
+// original variant
+func (s *Store) SetImages(images StoreImages) (changes StoreChanges) {
+	changes = StoreChanges{
+		"image":  FieldChange{From: s.Image, To: images[StoreImageOriginal]},
+		"images": FieldChange{From: s.Images, To: images},
+	}
+	s.Image = images[StoreImageOriginal]
+	s.Images = images
+	s.UpdatedAt = time.Now()
+	return
+}
+
+// Value returns the JSON encoding of the images to be stored in a jsonb column
+func (i StoreImages) Value() (driver.Value, error) {
+	if i == nil {
+		return nil, nil
+	}
+	return json.Marshal(i)
+}
+
+// Scan reads the images from a jsonb column
+func (i *StoreImages) Scan(src interface{}) error {
+	*i = nil
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return json.Unmarshal(v, i)
+	case string:
+		return json.Unmarshal([]byte(v), i)
+	}
+	return errors.New("unsupported store images value")
+}
@@ -0,0 +1,60 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreImages(t *testing.T) {
+	t.Parallel()
+	t.Run("check_image", func(t *testing.T) {
+		assert.NoError(t, domain.CheckImage("image/png", 10, 10))
+		assert.NoError(t, domain.CheckImage("image/jpeg", 0, 10))
+		assert.ErrorIs(t, domain.CheckImage("image/svg+xml", 10, 10), domain.ErrUnsupportedImage)
+		assert.ErrorIs(t, domain.CheckImage("image/gif", 11, 10), domain.ErrImageTooLarge)
+	})
+
+	t.Run("store_image_key", func(t *testing.T) {
+		key := domain.StoreImageKey("store", "upload", domain.StoreImageThumbnail, "image/png")
+		assert.Equal(t, "stores/store/upload/thumbnail.png", key)
+	})
+
+	t.Run("set_images", func(t *testing.T) {
+		store := sample.NewStore()
+		images := domain.StoreImages{
+			domain.StoreImageOriginal:  "stores/1/2/original.jpg",
+			domain.StoreImageThumbnail: "stores/1/2/thumbnail.jpg",
+		}
+
+		changes := store.SetImages(images)
+		assert.Equal(t, "stores/1/2/original.jpg", store.Image)
+		assert.Equal(t, images, store.Images)
+		assert.Equal(t, domain.FieldChange{From: "image.png", To: "stores/1/2/original.jpg"}, changes["image"])
+		assert.Contains(t, changes, "images")
+		assert.False(t, store.UpdatedAt.IsZero())
+	})
+
+	t.Run("value_and_scan", func(t *testing.T) {
+		images := domain.StoreImages{domain.StoreImageOriginal: "stores/1/2/original.jpg"}
+
+		value, err := images.Value()
+		assert.NoError(t, err)
+
+		var scanned domain.StoreImages
+		assert.NoError(t, scanned.Scan(value))
+		assert.Equal(t, images, scanned)
+		assert.NoError(t, scanned.Scan(`{"medium":"stores/1/2/medium.jpg"}`))
+		assert.Equal(t, domain.StoreImages{domain.StoreImageMedium: "stores/1/2/medium.jpg"}, scanned)
+
+		assert.NoError(t, scanned.Scan(nil))
+		assert.Nil(t, scanned)
+		assert.Error(t, scanned.Scan(42))
+
+		value, err = domain.StoreImages(nil).Value()
+		assert.NoError(t, err)
+		assert.Nil(t, value)
+	})
+}
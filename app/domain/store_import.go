@@ -0,0 +1,341 @@
+package domain
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// StoreFormatCSV is a CSV file with a header row
+	StoreFormatCSV = "csv"
+	// StoreFormatNDJSON is a file of one JSON object per line
+	StoreFormatNDJSON = "ndjson"
+)
+
+// StoreTagSeparator separates the tags of a store in a CSV cell
+const StoreTagSeparator = "|"
+
+// StoreRecordColumns are the CSV columns of a store record, in order. The
+// import reads name, description, category_id, tags, latitude and
+// longitude, and ignores the other columns.
+var StoreRecordColumns = []string{"id", "name", "description", "category_id", "tags", "latitude", "longitude", "status", "user_id", "created_at"}
+
+// storeImportColumns are the columns a CSV import requires
+var storeImportColumns = []string{"name", "description", "category_id"}
+
+// A StoreRecord is a store as a row of an export. Its fields are named after
+// those of CreateStoreRequest, so an export can be imported back.
+type StoreRecord struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CategoryID  string    `json:"category_id"`
+	Tags        []string  `json:"tags"`
+	Lat         float64   `json:"latitude"`
+	Lng         float64   `json:"longitude"`
+	Status      string    `json:"status"`
+	UserID      string    `json:"user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// NewStoreRecord returns the export record of store
+func NewStoreRecord(store *Store) *StoreRecord {
+	tags := []string(store.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+	return &StoreRecord{
+		ID:          store.ID,
+		Name:        store.Name,
+		Description: store.Description,
+		CategoryID:  store.CategoryID,
+		Tags:        tags,
+		Lat:         store.Position.Lat,
+		Lng:         store.Position.Lng,
+		Status:      store.Status,
+		UserID:      store.UserID,
+		CreatedAt:   store.CreatedAt,
+	}
+}
+
+func (r *StoreRecord) csvRow() []string {
+	return []string{
+		r.ID,
+		r.Name,
+		r.Description,
+		r.CategoryID,
+		strings.Join(r.Tags, StoreTagSeparator),
+		strconv.FormatFloat(r.Lat, 'f', -1, 64),
+		strconv.FormatFloat(r.Lng, 'f', -1, 64),
+		r.Status,
+		r.UserID,
+		r.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// StoreImportRequest holds a file of stores to create, owned by the caller.
+// With DryRun the rows are only checked, and nothing is created.
+type StoreImportRequest struct {
+	Format string
+	Body   io.Reader
+	DryRun bool
+}
+
+// A StoreRowError tells why a row of an import was rejected. Row is the
+// position of the row in the file, from 1 and without the CSV header. Field
+// is empty when the error is about the whole row.
+type StoreRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *StoreRowError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("row %d: %s", e.Row, e.Message)
+	}
+	return fmt.Sprintf("row %d: %s: %s", e.Row, e.Field, e.Message)
+}
+
+// StoreImportReport is the outcome of an import. Imported counts the rows
+// created, or that would be created on a dry run, and Errors lists the
+// reasons of each failed row.
+type StoreImportReport struct {
+	DryRun   bool             `json:"dry_run"`
+	Rows     int              `json:"rows"`
+	Imported int              `json:"imported"`
+	Failed   int              `json:"failed"`
+	Errors   []*StoreRowError `json:"errors"`
+}
+
+// NewStoreImportReport returns the empty report of an import
+func NewStoreImportReport(dryRun bool) *StoreImportReport {
+	return &StoreImportReport{DryRun: dryRun, Errors: []*StoreRowError{}}
+}
+
+// Fail records that row was rejected for errs
+func (r *StoreImportReport) Fail(row int, errs ...*StoreRowError) {
+	r.Failed++
+	for _, err := range errs {
+		err.Row = row
+		r.Errors = append(r.Errors, err)
+	}
+}
+
+// A StoreDecoder reads the rows of an import
+type StoreDecoder interface {
+	// Decode returns the next row, and io.EOF after the last one. A
+	// malformed row returns a *StoreRowError, and the next rows can still
+	// be decoded.
+	Decode() (*CreateStoreRequest, error)
+}
+
+// A StoreEncoder writes the records of an export
+type StoreEncoder interface {
+	Encode(store *Store) error
+	// Flush writes the buffered records
+	Flush() error
+}
+
+// NewStoreDecoder returns a decoder of the rows of r, in format. A CSV file
+// whose header misses a required column returns ErrInvalidImportHeader.
+func NewStoreDecoder(format string, r io.Reader) (StoreDecoder, error) {
+	switch format {
+	case StoreFormatCSV:
+		return newCSVStoreDecoder(r)
+	case StoreFormatNDJSON:
+		return &ndjsonStoreDecoder{reader: bufio.NewReaderSize(r, 64*1024)}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+// NewStoreEncoder returns an encoder writing the records to w, in format.
+// A CSV export starts with the StoreRecordColumns header.
+func NewStoreEncoder(format string, w io.Writer) (StoreEncoder, error) {
+	switch format {
+	case StoreFormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(StoreRecordColumns); err != nil {
+			return nil, err
+		}
+		return &csvStoreEncoder{writer: writer}, nil
+	case StoreFormatNDJSON:
+		buf := bufio.NewWriter(w)
+		return &ndjsonStoreEncoder{buf: buf, encoder: json.NewEncoder(buf)}, nil
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
+
+type csvStoreDecoder struct {
+	reader  *csv.Reader
+	columns map[string]int
+}
+
+func newCSVStoreDecoder(r io.Reader) (*csvStoreDecoder, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	var parseErr *csv.ParseError
+	if err == io.EOF || errors.As(err, &parseErr) {
+		return nil, ErrInvalidImportHeader
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		if _, ok := columns[name]; !ok {
+			columns[name] = i
+		}
+	}
+	for _, name := range storeImportColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, ErrInvalidImportHeader
+		}
+	}
+	return &csvStoreDecoder{reader: reader, columns: columns}, nil
+}
+
+func (d *csvStoreDecoder) Decode() (*CreateStoreRequest, error) {
+	row, err := d.reader.Read()
+	if err == io.EOF {
+		return nil, err
+	}
+	if err != nil {
+		return nil, &StoreRowError{Message: err.Error()}
+	}
+
+	cell := func(column string) string {
+		i, ok := d.columns[column]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return strings.TrimSpace(row[i])
+	}
+
+	req := &CreateStoreRequest{
+		Name:        cell("name"),
+		Description: cell("description"),
+		CategoryID:  cell("category_id"),
+	}
+	if tags := cell("tags"); tags != "" {
+		for _, tag := range strings.Split(tags, StoreTagSeparator) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				req.Tags = append(req.Tags, tag)
+			}
+		}
+	}
+	if req.Lat, err = parseCoordinate(cell("latitude")); err != nil {
+		return nil, &StoreRowError{Field: "latitude", Message: err.Error()}
+	}
+	if req.Lng, err = parseCoordinate(cell("longitude")); err != nil {
+		return nil, &StoreRowError{Field: "longitude", Message: err.Error()}
+	}
+	return req, nil
+}
+
+// parseCoordinate parses a latitude or longitude cell, an empty one being 0
+func parseCoordinate(value string) (float64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", value)
+	}
+	return v, nil
+}
+
+// maxNDJSONLine is the size of the longest row of an NDJSON import
+const maxNDJSONLine = 1024 * 1024
+
+type ndjsonStoreDecoder struct {
+	reader *bufio.Reader
+}
+
+func (d *ndjsonStoreDecoder) Decode() (*CreateStoreRequest, error) {
+	for {
+		line, err := d.readLine()
+		if err != nil {
+			return nil, err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		req := new(CreateStoreRequest)
+		if err := json.Unmarshal(line, req); err != nil {
+			return nil, &StoreRowError{Message: err.Error()}
+		}
+		return req, nil
+	}
+}
+
+// readLine returns the next line, and io.EOF after the last one. A line
+// longer than maxNDJSONLine is skipped, returning a *StoreRowError.
+func (d *ndjsonStoreDecoder) readLine() ([]byte, error) {
+	var line []byte
+	tooLong := false
+	for {
+		chunk, isPrefix, err := d.reader.ReadLine()
+		if err == io.EOF && (len(line) > 0 || tooLong) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !tooLong {
+			line = append(line, chunk...)
+			tooLong = len(line) > maxNDJSONLine
+		}
+		if !isPrefix {
+			break
+		}
+	}
+
+	if tooLong {
+		return nil, &StoreRowError{Message: fmt.Sprintf("row longer than %d bytes", maxNDJSONLine)}
+	}
+	return line, nil
+}
+
+type csvStoreEncoder struct {
+	writer *csv.Writer
+}
+
+func (e *csvStoreEncoder) Encode(store *Store) error {
+	return e.writer.Write(NewStoreRecord(store).csvRow())
+}
+
+func (e *csvStoreEncoder) Flush() error {
+	e.writer.Flush()
+	return e.writer.Error()
+}
+
+type ndjsonStoreEncoder struct {
+	buf     *bufio.Writer
+	encoder *json.Encoder
+}
+
+func (e *ndjsonStoreEncoder) Encode(store *Store) error {
+	return e.encoder.Encode(NewStoreRecord(store))
+}
+
+func (e *ndjsonStoreEncoder) Flush() error {
+	return e.buf.Flush()
+}
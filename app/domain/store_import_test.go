@@ -0,0 +1,166 @@
+package domain_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStoreDecoder(t *testing.T) {
+	t.Parallel()
+	categoryID := "3a9c8a2b-4f0c-4c8e-9a43-2b1e0d4c7f11"
+	testCases := []struct {
+		name        string
+		format      string
+		body        string
+		expectedErr error
+		rows        []*domain.CreateStoreRequest
+		rowErrors   []string
+	}{
+		{
+			name:        "unknown_format",
+			format:      "xml",
+			expectedErr: domain.ErrUnknownFormat,
+		},
+		{
+			name:        "csv_without_header",
+			format:      domain.StoreFormatCSV,
+			expectedErr: domain.ErrInvalidImportHeader,
+		},
+		{
+			name:        "csv_missing_required_column",
+			format:      domain.StoreFormatCSV,
+			body:        "name,category_id\nstore 001," + categoryID + "\n",
+			expectedErr: domain.ErrInvalidImportHeader,
+		},
+		{
+			name:   "csv",
+			format: domain.StoreFormatCSV,
+			body: "Name,description,category_id,tags,latitude,longitude\n" +
+				"store 001,first store," + categoryID + ",coffee| bakery ,-8.8383,13.2344\n" +
+				"store 002,second store," + categoryID + ",,north,13.2344\n" +
+				"store 003,\"third, store\"," + categoryID + "\n",
+			rows: []*domain.CreateStoreRequest{
+				{Name: "store 001", Description: "first store", CategoryID: categoryID, Tags: []string{"coffee", "bakery"}, Lat: -8.8383, Lng: 13.2344},
+				nil,
+				{Name: "store 003", Description: "third, store", CategoryID: categoryID},
+			},
+			rowErrors: []string{"", "latitude", ""},
+		},
+		{
+			name:   "ndjson",
+			format: domain.StoreFormatNDJSON,
+			body: `{"name":"store 001","description":"first store","category_id":"` + categoryID + `","tags":["coffee"],"latitude":-8.8383,"longitude":13.2344,"status":"active"}` + "\n" +
+				"\n" +
+				`{"name":` + "\n",
+			rows: []*domain.CreateStoreRequest{
+				{Name: "store 001", Description: "first store", CategoryID: categoryID, Tags: []string{"coffee"}, Lat: -8.8383, Lng: 13.2344},
+				nil,
+			},
+			rowErrors: []string{"", ""},
+		},
+		{
+			name:   "ndjson_line_too_long",
+			format: domain.StoreFormatNDJSON,
+			body: `{"name":"` + strings.Repeat("a", 2*1024*1024) + `"}` + "\n" +
+				`{"name":"store 002","category_id":"` + categoryID + `"}` + "\n",
+			rows: []*domain.CreateStoreRequest{
+				nil,
+				{Name: "store 002", CategoryID: categoryID},
+			},
+			rowErrors: []string{""},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			decoder, err := domain.NewStoreDecoder(tc.format, strings.NewReader(tc.body))
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+
+			for i, expected := range tc.rows {
+				row, err := decoder.Decode()
+				if expected == nil {
+					rowErr, ok := err.(*domain.StoreRowError)
+					assert.True(t, ok)
+					assert.Equal(t, tc.rowErrors[i], rowErr.Field)
+					continue
+				}
+				assert.NoError(t, err)
+				assert.Equal(t, expected, row)
+			}
+			_, err = decoder.Decode()
+			assert.Equal(t, io.EOF, err)
+		})
+	}
+}
+
+func TestNewStoreEncoder(t *testing.T) {
+	t.Parallel()
+	store := sample.NewStore()
+	store.Tags = []string{"coffee", "bakery"}
+
+	t.Run("csv_imported_back", func(t *testing.T) {
+		var buf bytes.Buffer
+		encoder, err := domain.NewStoreEncoder(domain.StoreFormatCSV, &buf)
+		assert.NoError(t, err)
+		assert.NoError(t, encoder.Encode(store))
+		assert.NoError(t, encoder.Flush())
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		assert.Len(t, lines, 2)
+		assert.Equal(t, strings.Join(domain.StoreRecordColumns, ","), lines[0])
+
+		decoder, err := domain.NewStoreDecoder(domain.StoreFormatCSV, &buf)
+		assert.NoError(t, err)
+		row, err := decoder.Decode()
+		assert.NoError(t, err)
+		assert.Equal(t, store.Name, row.Name)
+		assert.Equal(t, []string{"coffee", "bakery"}, row.Tags)
+		assert.Equal(t, store.Position.Lng, row.Lng)
+	})
+
+	t.Run("ndjson_imported_back", func(t *testing.T) {
+		var buf bytes.Buffer
+		encoder, err := domain.NewStoreEncoder(domain.StoreFormatNDJSON, &buf)
+		assert.NoError(t, err)
+		assert.NoError(t, encoder.Encode(store))
+		assert.NoError(t, encoder.Flush())
+
+		decoder, err := domain.NewStoreDecoder(domain.StoreFormatNDJSON, &buf)
+		assert.NoError(t, err)
+		row, err := decoder.Decode()
+		assert.NoError(t, err)
+		assert.Equal(t, store.Name, row.Name)
+		assert.Equal(t, store.CategoryID, row.CategoryID)
+		assert.Equal(t, []string(store.Tags), row.Tags)
+		assert.Equal(t, store.Position.Lat, row.Lat)
+	})
+
+	t.Run("unknown_format", func(t *testing.T) {
+		_, err := domain.NewStoreEncoder("xml", io.Discard)
+		assert.ErrorIs(t, err, domain.ErrUnknownFormat)
+	})
+}
+
+func TestStoreImportReport_Fail(t *testing.T) {
+	t.Parallel()
+	report := domain.NewStoreImportReport(true)
+	report.Fail(3, &domain.StoreRowError{Field: "Name", Message: "required"}, &domain.StoreRowError{Field: "CategoryID", Message: "uuid4"})
+
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Failed)
+	assert.Len(t, report.Errors, 2)
+	assert.Equal(t, 3, report.Errors[1].Row)
+	assert.Equal(t, "row 3: Name: required", report.Errors[0].Error())
+}
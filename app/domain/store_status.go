@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+)
+
+// StatusActorSystem is the actor of the status changes made by the service
+// itself, such as lifting an expired block
+const StatusActorSystem = "system"
+
+// StoreStatusHistory belong to the domain layer.
+type StoreStatusHistory []*StoreStatusChange
+
+// A StoreStatusChange records one status transition of a store, who made it
+// and why. Changes are never updated or deleted, but for the failed attempts
+// to release an expired one.
+type StoreStatusChange struct {
+	ID         string     `json:"id" gorm:"column:id;type:uuid;primary key"`
+	StoreID    string     `json:"store_id" gorm:"column:store_id;type:uuid;not null"`
+	FromStatus string     `json:"from_status" gorm:"column:from_status;type:varchar(20)"`
+	ToStatus   string     `json:"to_status" gorm:"column:to_status;type:varchar(20);not null"`
+	ActorID    string     `json:"actor_id" gorm:"column:actor_id;type:varchar(255);not null"`
+	Reason     string     `json:"reason" gorm:"column:reason;type:varchar(255)"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" gorm:"column:expires_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+
+	ReleaseAttempts int        `json:"-" gorm:"column:release_attempts;->"`
+	ReleaseRetryAt  *time.Time `json:"-" gorm:"column:release_retry_at;->"`
+}
+
+// StoreStatusRequest applies the lifecycle Action to a store. A change with
+// ExpiresAt is temporary, the store is moved back once it expires.
+type StoreStatusRequest struct {
+	ID        string     `json:"-" validate:"required,uuid4"`
+	Action    string     `json:"-" validate:"required"`
+	Reason    string     `json:"reason" validate:"max=255"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type (
+	// StoreStatusHistoryRepository represent the store status history's repository contract
+	StoreStatusHistoryRepository interface {
+		Store(ctx context.Context, change *StoreStatusChange) error
+		FindByStoreID(ctx context.Context, storeID string, limit, page int) (StoreStatusHistory, int64, error)
+		// FindExpired returns the latest changes of the stores expired at now,
+		// but those whose release is to be retried later
+		FindExpired(ctx context.Context, now time.Time, limit int) (StoreStatusHistory, error)
+		// UpdateRelease saves the release attempts of an expired change
+		UpdateRelease(ctx context.Context, change *StoreStatusChange) error
+	}
+)
+
+// CheckExpiry fails when the change is set to expire before now
+func (r *StoreStatusRequest) CheckExpiry(now time.Time) error {
+	if r.ExpiresAt != nil && !r.ExpiresAt.After(now) {
+		return ErrInvalidExpiry
+	}
+	return nil
+}
+
+// NewStoreStatusChange creates a *StoreStatusChange for the transition of
+// store from status from to its current status
+func NewStoreStatusChange(store *Store, from, actorID, reason string, expiresAt *time.Time) (change *StoreStatusChange) {
+	change = new(StoreStatusChange)
+	change.ID = uuid.NewV4().String()
+	change.StoreID = store.ID
+	change.FromStatus = from
+	change.ToStatus = store.Status
+	change.ActorID = actorID
+	change.Reason = reason
+	change.ExpiresAt = expiresAt
+	change.CreatedAt = time.Now()
+	return
+}
+
+// FailRelease records a failed attempt to release the expired change, the
+// next one being made after backoff
+func (c *StoreStatusChange) FailRelease(backoff time.Duration) {
+	c.ReleaseAttempts++
+	retryAt := time.Now().Add(backoff)
+	c.ReleaseRetryAt = &retryAt
+}
@@ -0,0 +1,45 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreStatusChange(t *testing.T) {
+	t.Parallel()
+	t.Run("new_store_status_change", func(t *testing.T) {
+		store := sample.NewStore()
+		store.Status = domain.StoreStatusBlock
+		expiresAt := time.Now().Add(time.Hour)
+		change := domain.NewStoreStatusChange(store, domain.StoreStatusActive, "admin", "spam", &expiresAt)
+		assert.NotEmpty(t, change.ID)
+		assert.Equal(t, store.ID, change.StoreID)
+		assert.Equal(t, domain.StoreStatusActive, change.FromStatus)
+		assert.Equal(t, domain.StoreStatusBlock, change.ToStatus)
+		assert.Equal(t, "admin", change.ActorID)
+		assert.Equal(t, "spam", change.Reason)
+		assert.Equal(t, &expiresAt, change.ExpiresAt)
+	})
+
+	t.Run("check_expiry", func(t *testing.T) {
+		now := time.Now()
+		past := now.Add(-time.Minute)
+		future := now.Add(time.Minute)
+		assert.NoError(t, (&domain.StoreStatusRequest{}).CheckExpiry(now))
+		assert.NoError(t, (&domain.StoreStatusRequest{ExpiresAt: &future}).CheckExpiry(now))
+		assert.ErrorIs(t, (&domain.StoreStatusRequest{ExpiresAt: &past}).CheckExpiry(now), domain.ErrInvalidExpiry)
+	})
+	t.Run("fail_release", func(t *testing.T) {
+		change := sample.NewStoreStatusChange()
+		before := time.Now()
+		change.FailRelease(time.Minute)
+		change.FailRelease(2 * time.Minute)
+		assert.Equal(t, 2, change.ReleaseAttempts)
+		assert.NotNil(t, change.ReleaseRetryAt)
+		assert.False(t, change.ReleaseRetryAt.Before(before.Add(2*time.Minute)))
+	})
+}
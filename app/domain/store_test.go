@@ -3,6 +3,7 @@ package domain_test
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
 	"github.com/EdlanioJ/kbu-store/app/utils/sample"
@@ -33,87 +34,65 @@ func Test_Store(t *testing.T) {
 	})
 
 	t.Run("from_update_request", func(t *testing.T) {
-		store := new(domain.Store)
-		store.FromUpdateRequest(ur)
+		store := domain.NewStore(cr)
+		store.Image = "image.png"
+		status := store.Status
+		changes := store.FromUpdateRequest(ur)
 
 		assert.NotNil(t, store)
-		assert.Equal(t, store.ID, ur.ID)
-		assert.Equal(t, store.CategoryID, ur.CategoryID)
-		assert.Equal(t, store.Description, ur.Description)
-		assert.Equal(t, store.Name, ur.Name)
-		assert.Equal(t, store.Lat, ur.Lat)
-		assert.Equal(t, store.Lng, ur.Lng)
-		assert.Equal(t, store.Image, ur.Image)
-		assert.Equal(t, store.Tags, pq.StringArray(ur.Tags))
+		assert.NotEqual(t, store.ID, ur.ID)
+		assert.Equal(t, cr.CategoryID, store.CategoryID)
+		assert.Equal(t, status, store.Status)
+		assert.Equal(t, *ur.Description, store.Description)
+		assert.Equal(t, *ur.Name, store.Name)
+		assert.Equal(t, *ur.Lat, store.Lat)
+		assert.Equal(t, *ur.Lng, store.Lng)
+		assert.Equal(t, "image.png", store.Image)
+		assert.Equal(t, pq.StringArray(*ur.Tags), store.Tags)
+		assert.False(t, store.UpdatedAt.IsZero())
+
+		assert.Len(t, changes, 3)
+		assert.Equal(t, domain.FieldChange{From: cr.Name, To: *ur.Name}, changes["name"])
+		assert.NotContains(t, changes, "image")
+		assert.NotContains(t, changes, "category_id")
+		assert.NotContains(t, changes, "location.lat")
 	})
 
-	t.Run("block", func(t *testing.T) {
-		t.Parallel()
-		t.Run("failure_already_blocked", func(t *testing.T) {
-			store := domain.NewStore(cr)
-			store.Status = domain.StoreStatusBlock
-			err := store.Block()
-
-			assert.Error(t, err)
-		})
+	t.Run("from_empty_update_request", func(t *testing.T) {
+		store := domain.NewStore(cr)
+		changes := store.FromUpdateRequest(&domain.UpdateStoreRequest{ID: store.ID})
 
-		t.Run("failure_still_pending", func(t *testing.T) {
-			store := domain.NewStore(cr)
-			err := store.Block()
+		assert.Empty(t, changes)
+		assert.True(t, store.UpdatedAt.IsZero())
+		assert.Equal(t, cr.Name, store.Name)
+		assert.Equal(t, pq.StringArray(cr.Tags), store.Tags)
+	})
 
-			assert.Error(t, err)
-		})
+	t.Run("mark_deleted_and_restore", func(t *testing.T) {
+		store := domain.NewStore(cr)
+		at := time.Now().Add(-time.Hour)
 
-		t.Run("success", func(t *testing.T) {
-			store := domain.NewStore(cr)
-			store.Status = domain.StoreStatusActive
-			err := store.Block()
+		store.MarkDeleted(at)
+		assert.Equal(t, &at, store.DeletedAt)
+		assert.Equal(t, at, store.UpdatedAt)
 
-			assert.Nil(t, err)
-		})
+		store.Restore()
+		assert.Nil(t, store.DeletedAt)
+		assert.True(t, store.UpdatedAt.After(at))
 	})
 
-	t.Run("activate", func(t *testing.T) {
-		t.Parallel()
-		t.Run("failure_already_actived", func(t *testing.T) {
-			store := domain.NewStore(cr)
-			store.Status = domain.StoreStatusActive
-			err := store.Activate()
-
-			assert.Error(t, err)
-		})
-
-		t.Run("success", func(t *testing.T) {
-			store := domain.NewStore(cr)
-			err := store.Activate()
+	t.Run("store_updated_event_to_json", func(t *testing.T) {
+		store := domain.NewStore(cr)
+		changes := store.FromUpdateRequest(ur)
 
-			assert.Nil(t, err)
-		})
+		data := domain.NewStoreUpdatedEvent(store, changes).ToJson()
+		event := make(map[string]interface{})
+		err := json.Unmarshal(data, &event)
+		assert.NoError(t, err)
+		assert.Equal(t, store.ID, event["id"])
+		assert.Contains(t, event["changes"], "name")
 	})
 
-	t.Run("disable", func(t *testing.T) {
-		t.Parallel()
-		t.Run("failure_already_disabled", func(t *testing.T) {
-			store := domain.NewStore(cr)
-			store.Status = domain.StoreStatusDisable
-			err := store.Disable()
-
-			assert.Error(t, err)
-		})
-		t.Run("failure_is_blocked", func(t *testing.T) {
-			store := domain.NewStore(cr)
-			store.Status = domain.StoreStatusBlock
-			err := store.Disable()
-
-			assert.Error(t, err)
-		})
-		t.Run("success", func(t *testing.T) {
-			store := domain.NewStore(cr)
-			err := store.Disable()
-
-			assert.Nil(t, err)
-		})
-	})
 	t.Run("to_json", func(t *testing.T) {
 		store := domain.NewStore(cr)
 		store.Name = "store 001"
@@ -0,0 +1,207 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// approve action, accepts a pending store
+	StoreActionApprove string = "approve"
+	// reject action, turns a pending store down
+	StoreActionReject string = "reject"
+	// activate action, enables a disabled store back
+	StoreActionActivate string = "activate"
+	// disable action, takes an active store down
+	StoreActionDisable string = "disable"
+	// block action, takes a store down for moderation
+	StoreActionBlock string = "block"
+	// unblock action, lifts the block of a store
+	StoreActionUnblock string = "unblock"
+	// suspend action, taken while the store category is disabled
+	StoreActionSuspend string = "suspend"
+	// resume action, taken when the store category is activated back
+	StoreActionResume string = "resume"
+)
+
+const (
+	// TransitionActorAdmin transitions are applied by admins
+	TransitionActorAdmin string = "admin"
+	// TransitionActorOwner transitions are applied by the store owner, or admins
+	TransitionActorOwner string = "owner"
+	// TransitionActorSystem transitions are only applied by the service itself
+	TransitionActorSystem string = "system"
+)
+
+// A StoreTransition moves a store in any of the From statuses to To. An
+// expirable transition may be given an expiry, once reached the store is
+// moved back by the Revert action. A restoring transition lifts the last
+// change of the store, moving it back to the status it had before, and only
+// to To when that status is not known.
+type StoreTransition struct {
+	Action         string   `json:"action"`
+	From           []string `json:"-"`
+	To             string   `json:"to"`
+	Actor          string   `json:"actor"`
+	ReasonRequired bool     `json:"reason_required"`
+	Expirable      bool     `json:"expirable"`
+	Revert         string   `json:"-"`
+	Restores       bool     `json:"-"`
+}
+
+// StoreTransitions belong to the domain layer.
+type StoreTransitions []*StoreTransition
+
+// StoreLifecycle is the transition table of the stores. A store is created
+// pending, and only moves through the transitions listed here.
+var StoreLifecycle = StoreTransitions{
+	{
+		Action: StoreActionApprove,
+		From:   []string{StoreStatusPending},
+		To:     StoreStatusActive,
+		Actor:  TransitionActorAdmin,
+	},
+	{
+		Action:         StoreActionReject,
+		From:           []string{StoreStatusPending},
+		To:             StoreStatusRejected,
+		Actor:          TransitionActorAdmin,
+		ReasonRequired: true,
+	},
+	{
+		Action: StoreActionActivate,
+		From:   []string{StoreStatusDisable},
+		To:     StoreStatusActive,
+		Actor:  TransitionActorOwner,
+	},
+	{
+		Action:    StoreActionDisable,
+		From:      []string{StoreStatusActive},
+		To:        StoreStatusDisable,
+		Actor:     TransitionActorOwner,
+		Expirable: true,
+		Revert:    StoreActionActivate,
+	},
+	{
+		Action:         StoreActionBlock,
+		From:           []string{StoreStatusActive, StoreStatusDisable, StoreStatusSuspended},
+		To:             StoreStatusBlock,
+		Actor:          TransitionActorAdmin,
+		ReasonRequired: true,
+		Expirable:      true,
+		Revert:         StoreActionUnblock,
+	},
+	{
+		Action:   StoreActionUnblock,
+		From:     []string{StoreStatusBlock},
+		To:       StoreStatusActive,
+		Actor:    TransitionActorAdmin,
+		Restores: true,
+	},
+	{
+		Action: StoreActionSuspend,
+		From:   []string{StoreStatusActive},
+		To:     StoreStatusSuspended,
+		Actor:  TransitionActorSystem,
+	},
+	{
+		Action: StoreActionResume,
+		From:   []string{StoreStatusSuspended},
+		To:     StoreStatusActive,
+		Actor:  TransitionActorSystem,
+	},
+}
+
+// A TransitionError reports an action the status of a store does not allow
+type TransitionError struct {
+	Action string
+	Status string
+}
+
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot %s a store in status %s", e.Action, e.Status)
+}
+
+// Is makes every TransitionError match ErrInvalidTransition
+func (e *TransitionError) Is(target error) bool {
+	return target == ErrInvalidTransition
+}
+
+// Find returns the transition of action
+func (t StoreTransitions) Find(action string) (*StoreTransition, error) {
+	for _, transition := range t {
+		if transition.Action == action {
+			return transition, nil
+		}
+	}
+	return nil, ErrUnknownAction
+}
+
+// From returns the transitions allowed to a store in status
+func (t StoreTransitions) From(status string) (res StoreTransitions) {
+	res = make(StoreTransitions, 0)
+	for _, transition := range t {
+		if transition.Allows(status) {
+			res = append(res, transition)
+		}
+	}
+	return
+}
+
+// RevertOf returns the transition moving back a store whose expirable change
+// to status expired
+func (t StoreTransitions) RevertOf(status string) (*StoreTransition, error) {
+	for _, transition := range t {
+		if transition.Expirable && transition.To == status {
+			return t.Find(transition.Revert)
+		}
+	}
+	return nil, ErrUnknownAction
+}
+
+// Allows reports whether a store in status may take the transition
+func (t *StoreTransition) Allows(status string) bool {
+	for _, from := range t.From {
+		if from == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Check fails when param misses the reason the transition requires, or
+// carries an expiry it does not take
+func (t *StoreTransition) Check(param *StoreStatusRequest, now time.Time) error {
+	if t.ReasonRequired && param.Reason == "" {
+		return ErrReasonRequired
+	}
+	if param.ExpiresAt != nil && !t.Expirable {
+		return ErrNotExpirable
+	}
+	return param.CheckExpiry(now)
+}
+
+// Apply moves the store through the transition of action
+func (s *Store) Apply(action string) error {
+	return s.Lift(action, "")
+}
+
+// Lift moves the store through the transition of action like Apply, but a
+// restoring transition moves it back to previous, the status it had before
+// the change being lifted, when given
+func (s *Store) Lift(action, previous string) error {
+	transition, err := StoreLifecycle.Find(action)
+	if err != nil {
+		return err
+	}
+
+	if !transition.Allows(s.Status) {
+		return &TransitionError{Action: action, Status: s.Status}
+	}
+
+	s.Status = transition.To
+	if transition.Restores && previous != "" {
+		s.Status = previous
+	}
+	return nil
+}
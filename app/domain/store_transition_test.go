@@ -0,0 +1,125 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StoreTransition(t *testing.T) {
+	t.Parallel()
+
+	t.Run("apply", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			status string
+			action string
+			to     string
+			err    error
+		}{
+			{name: "approve_pending", status: domain.StoreStatusPending, action: domain.StoreActionApprove, to: domain.StoreStatusActive},
+			{name: "reject_pending", status: domain.StoreStatusPending, action: domain.StoreActionReject, to: domain.StoreStatusRejected},
+			{name: "activate_disabled", status: domain.StoreStatusDisable, action: domain.StoreActionActivate, to: domain.StoreStatusActive},
+			{name: "disable_active", status: domain.StoreStatusActive, action: domain.StoreActionDisable, to: domain.StoreStatusDisable},
+			{name: "block_suspended", status: domain.StoreStatusSuspended, action: domain.StoreActionBlock, to: domain.StoreStatusBlock},
+			{name: "unblock_blocked", status: domain.StoreStatusBlock, action: domain.StoreActionUnblock, to: domain.StoreStatusActive},
+			{name: "suspend_active", status: domain.StoreStatusActive, action: domain.StoreActionSuspend, to: domain.StoreStatusSuspended},
+			{name: "resume_suspended", status: domain.StoreStatusSuspended, action: domain.StoreActionResume, to: domain.StoreStatusActive},
+			{name: "failure_activate_blocked", status: domain.StoreStatusBlock, action: domain.StoreActionActivate, err: domain.ErrInvalidTransition},
+			{name: "failure_block_pending", status: domain.StoreStatusPending, action: domain.StoreActionBlock, err: domain.ErrInvalidTransition},
+			{name: "failure_approve_rejected", status: domain.StoreStatusRejected, action: domain.StoreActionApprove, err: domain.ErrInvalidTransition},
+			{name: "failure_unknown_action", status: domain.StoreStatusActive, action: "close", err: domain.ErrUnknownAction},
+		}
+
+		for _, tc := range testCases {
+			store := sample.NewStore()
+			store.Status = tc.status
+			err := store.Apply(tc.action)
+
+			if tc.err != nil {
+				assert.True(t, errors.Is(err, tc.err), tc.name)
+				assert.Equal(t, tc.status, store.Status, tc.name)
+				continue
+			}
+			assert.NoError(t, err, tc.name)
+			assert.Equal(t, tc.to, store.Status, tc.name)
+		}
+	})
+
+	t.Run("lift", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			status   string
+			action   string
+			previous string
+			to       string
+		}{
+			{name: "unblock_to_previous", status: domain.StoreStatusBlock, action: domain.StoreActionUnblock, previous: domain.StoreStatusSuspended, to: domain.StoreStatusSuspended},
+			{name: "unblock_without_previous", status: domain.StoreStatusBlock, action: domain.StoreActionUnblock, to: domain.StoreStatusActive},
+			{name: "not_restoring", status: domain.StoreStatusDisable, action: domain.StoreActionActivate, previous: domain.StoreStatusBlock, to: domain.StoreStatusActive},
+		}
+
+		for _, tc := range testCases {
+			store := sample.NewStore()
+			store.Status = tc.status
+			err := store.Lift(tc.action, tc.previous)
+
+			assert.NoError(t, err, tc.name)
+			assert.Equal(t, tc.to, store.Status, tc.name)
+		}
+
+		store := sample.NewStore()
+		store.Status = domain.StoreStatusActive
+		err := store.Lift(domain.StoreActionUnblock, domain.StoreStatusDisable)
+		assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+		assert.Equal(t, domain.StoreStatusActive, store.Status)
+	})
+
+	t.Run("transition_error", func(t *testing.T) {
+		err := &domain.TransitionError{Action: domain.StoreActionBlock, Status: domain.StoreStatusPending}
+
+		assert.Equal(t, "cannot block a store in status pending", err.Error())
+		assert.True(t, errors.Is(err, domain.ErrInvalidTransition))
+	})
+
+	t.Run("from", func(t *testing.T) {
+		var actions []string
+		for _, transition := range domain.StoreLifecycle.From(domain.StoreStatusPending) {
+			actions = append(actions, transition.Action)
+		}
+
+		assert.Equal(t, []string{domain.StoreActionApprove, domain.StoreActionReject}, actions)
+		assert.Empty(t, domain.StoreLifecycle.From(domain.StoreStatusRejected))
+	})
+
+	t.Run("revert_of", func(t *testing.T) {
+		revert, err := domain.StoreLifecycle.RevertOf(domain.StoreStatusBlock)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StoreActionUnblock, revert.Action)
+
+		revert, err = domain.StoreLifecycle.RevertOf(domain.StoreStatusDisable)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StoreActionActivate, revert.Action)
+
+		_, err = domain.StoreLifecycle.RevertOf(domain.StoreStatusActive)
+		assert.ErrorIs(t, err, domain.ErrUnknownAction)
+	})
+
+	t.Run("check", func(t *testing.T) {
+		now := time.Now()
+		past := now.Add(-time.Hour)
+		future := now.Add(time.Hour)
+		block, _ := domain.StoreLifecycle.Find(domain.StoreActionBlock)
+		unblock, _ := domain.StoreLifecycle.Find(domain.StoreActionUnblock)
+
+		assert.ErrorIs(t, block.Check(&domain.StoreStatusRequest{}, now), domain.ErrReasonRequired)
+		assert.ErrorIs(t, block.Check(&domain.StoreStatusRequest{Reason: "spam", ExpiresAt: &past}, now), domain.ErrInvalidExpiry)
+		assert.NoError(t, block.Check(&domain.StoreStatusRequest{Reason: "spam", ExpiresAt: &future}, now))
+		assert.ErrorIs(t, unblock.Check(&domain.StoreStatusRequest{ExpiresAt: &future}, now), domain.ErrNotExpirable)
+		assert.NoError(t, unblock.Check(&domain.StoreStatusRequest{}, now))
+	})
+}
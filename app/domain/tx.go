@@ -0,0 +1,11 @@
+package domain
+
+import "context"
+
+// TxManager is the unit of work shared by the repositories. It runs fn inside
+// a database transaction, and repositories called with the context passed to
+// fn take part in that transaction. A nested call runs in a savepoint of the
+// outer transaction: when it fails, only its own changes are rolled back.
+type TxManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
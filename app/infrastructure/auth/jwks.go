@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// A KeySet holds the RSA signing keys of a JSON Web Key Set, read from a
+// local file or fetched from an http(s) URL. The keys of a URL are fetched
+// again when a token names an unknown key, at most once per MinRefresh.
+// Concurrent lookups share a single fetch, made without holding the keys, so
+// the known keys are still served meanwhile.
+type KeySet struct {
+	Source     string
+	Client     *http.Client
+	MinRefresh time.Duration
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+	loads     singleflight.Group
+}
+
+func NewKeySet(source string) *KeySet {
+	return &KeySet{
+		Source:     source,
+		Client:     &http.Client{Timeout: 10 * time.Second},
+		MinRefresh: 5 * time.Minute,
+	}
+}
+
+// Key returns the key with id kid. An empty kid matches the only key of a
+// set holding a single one.
+func (s *KeySet) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	key, ok, fresh := s.lookup(kid)
+	if ok {
+		return key, nil
+	}
+	if fresh {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	_, err, _ := s.loads.Do(s.Source, func() (interface{}, error) {
+		// a load may have completed since the lookup
+		if _, ok, fresh := s.lookup(kid); ok || fresh {
+			return nil, nil
+		}
+		return nil, s.load(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok, _ = s.lookup(kid)
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	return key, nil
+}
+
+// lookup finds the key with id kid, and tells whether the keys were loaded
+// less than MinRefresh ago
+func (s *KeySet) lookup(kid string) (key *rsa.PublicKey, ok, fresh bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok = s.find(kid)
+	fresh = s.keys != nil && time.Since(s.fetchedAt) < s.MinRefresh
+	return
+}
+
+func (s *KeySet) find(kid string) (*rsa.PublicKey, bool) {
+	if kid == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key, true
+		}
+	}
+
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// load reads the keys from Source, then replaces the previous ones
+func (s *KeySet) load(ctx context.Context) error {
+	var data []byte
+	var err error
+
+	if strings.HasPrefix(s.Source, "http://") || strings.HasPrefix(s.Source, "https://") {
+		data, err = s.fetch(ctx)
+	} else {
+		data, err = ioutil.ReadFile(s.Source)
+	}
+	if err != nil {
+		return err
+	}
+
+	keys, err := parseKeySet(data)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.keys = keys
+	s.fetchedAt = time.Now()
+	return nil
+}
+
+func (s *KeySet) fetch(ctx context.Context) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.Source, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetching key set: %s", res.Status)
+	}
+	return ioutil.ReadAll(res.Body)
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+}
+
+// parseKeySet returns the RSA signing keys of a JSON Web Key Set by key id.
+// Keys of another type or use are skipped.
+func parseKeySet(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	err := json.Unmarshal(data, &set)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.Kty != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", jwk.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %v", jwk.Kid, err)
+		}
+
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 {
+			return nil, fmt.Errorf("key %q: invalid exponent", jwk.Kid)
+		}
+
+		keys[jwk.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(exponent.Int64()),
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, errors.New("key set has no RSA signing key")
+	}
+	return keys, nil
+}
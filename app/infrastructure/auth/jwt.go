@@ -0,0 +1,113 @@
+// Package auth verifies the JWT bearer tokens of the callers of the service.
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/config"
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/golang-jwt/jwt"
+	uuid "github.com/satori/go.uuid"
+)
+
+// A JWTVerifier accepts HS256 tokens signed with Secret and RS256 tokens
+// signed by a key of KeySet. The subject of a token is the user ID, a UUID,
+// and its roles claim holds the roles of the user.
+type JWTVerifier struct {
+	Secret   []byte
+	KeySet   *KeySet
+	Issuer   string
+	Audience string
+}
+
+// NewJWTVerifier fails when neither a secret nor a key set is configured
+func NewJWTVerifier(cfg *config.Config) (*JWTVerifier, error) {
+	verifier := &JWTVerifier{
+		Issuer:   cfg.Auth.Issuer,
+		Audience: cfg.Auth.Audience,
+	}
+	if cfg.Auth.Secret != "" {
+		verifier.Secret = []byte(cfg.Auth.Secret)
+	}
+	if cfg.Auth.JWKS != "" {
+		verifier.KeySet = NewKeySet(cfg.Auth.JWKS)
+	}
+
+	if verifier.Secret == nil && verifier.KeySet == nil {
+		return nil, errors.New("no token secret or key set configured")
+	}
+	return verifier, nil
+}
+
+// Verify checks the signature, expiry, issuer and audience of token. Every
+// failure wraps domain.ErrUnauthorized.
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (*domain.Claims, error) {
+	var methods []string
+	if v.Secret != nil {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+	if v.KeySet != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	claims := jwt.MapClaims{}
+	parser := &jwt.Parser{ValidMethods: methods}
+	_, err := parser.ParseWithClaims(token, claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method == jwt.SigningMethodHS256 {
+			return v.Secret, nil
+		}
+
+		kid, _ := t.Header["kid"].(string)
+		return v.KeySet.Key(ctx, kid)
+	})
+	if err != nil {
+		return nil, unauthorized(err.Error())
+	}
+
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, unauthorized("token has no expiry")
+	}
+	if v.Issuer != "" && !claims.VerifyIssuer(v.Issuer, true) {
+		return nil, unauthorized("unexpected issuer")
+	}
+	if v.Audience != "" && !claims.VerifyAudience(v.Audience, true) {
+		return nil, unauthorized("unexpected audience")
+	}
+
+	sub, _ := claims["sub"].(string)
+	if sub == "" {
+		return nil, unauthorized("token has no subject")
+	}
+	if _, err := uuid.FromString(sub); err != nil {
+		return nil, unauthorized("invalid subject")
+	}
+
+	return &domain.Claims{
+		UserID: sub,
+		Roles:  roles(claims["roles"]),
+	}, nil
+}
+
+// roles reads the roles claim, either a list or a single role
+func roles(claim interface{}) []string {
+	switch v := claim.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		roles := make([]string, 0, len(v))
+		for _, role := range v {
+			if s, ok := role.(string); ok {
+				roles = append(roles, s)
+			}
+		}
+		return roles
+	}
+	return nil
+}
+
+func unauthorized(reason string) error {
+	return fmt.Errorf("%w: %s", domain.ErrUnauthorized, reason)
+}
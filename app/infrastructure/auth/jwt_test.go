@@ -0,0 +1,313 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/auth"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var secret = []byte("secret")
+
+const userID = "3bd43dd8-bccb-4939-af79-12ee25e475ad"
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   userID,
+		"iss":   "kbu",
+		"aud":   []string{"kbu-store"},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{domain.RoleAdmin},
+	}
+}
+
+func keySetJSON(t *testing.T, kid string, key *rsa.PublicKey) []byte {
+	data, err := json.Marshal(map[string]interface{}{
+		"keys": []map[string]string{
+			{"kty": "EC", "kid": "ec"},
+			{
+				"kty": "RSA",
+				"kid": kid,
+				"use": "sig",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			},
+		},
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func Test_JWTVerifier_Verify_HS256(t *testing.T) {
+	testCases := []struct {
+		name        string
+		token       func() string
+		expectedErr bool
+	}{
+		{
+			name:        "failure_malformed_token",
+			token:       func() string { return "not-a-token" },
+			expectedErr: true,
+		},
+		{
+			name: "failure_wrong_secret",
+			token: func() string {
+				return sign(t, jwt.SigningMethodHS256, []byte("other"), "", validClaims())
+			},
+			expectedErr: true,
+		},
+		{
+			name: "failure_disallowed_method",
+			token: func() string {
+				return sign(t, jwt.SigningMethodHS512, secret, "", validClaims())
+			},
+			expectedErr: true,
+		},
+		{
+			name: "failure_expired",
+			token: func() string {
+				claims := validClaims()
+				claims["exp"] = time.Now().Add(-time.Minute).Unix()
+				return sign(t, jwt.SigningMethodHS256, secret, "", claims)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "failure_without_expiry",
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "exp")
+				return sign(t, jwt.SigningMethodHS256, secret, "", claims)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "failure_wrong_issuer",
+			token: func() string {
+				claims := validClaims()
+				claims["iss"] = "other"
+				return sign(t, jwt.SigningMethodHS256, secret, "", claims)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "failure_wrong_audience",
+			token: func() string {
+				claims := validClaims()
+				claims["aud"] = "other"
+				return sign(t, jwt.SigningMethodHS256, secret, "", claims)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "failure_without_subject",
+			token: func() string {
+				claims := validClaims()
+				delete(claims, "sub")
+				return sign(t, jwt.SigningMethodHS256, secret, "", claims)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "failure_subject_not_uuid",
+			token: func() string {
+				claims := validClaims()
+				claims["sub"] = "user-id"
+				return sign(t, jwt.SigningMethodHS256, secret, "", claims)
+			},
+			expectedErr: true,
+		},
+		{
+			name: "success",
+			token: func() string {
+				return sign(t, jwt.SigningMethodHS256, secret, "", validClaims())
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			v := &auth.JWTVerifier{
+				Secret:   secret,
+				Issuer:   "kbu",
+				Audience: "kbu-store",
+			}
+
+			claims, err := v.Verify(context.TODO(), tc.token())
+			if tc.expectedErr {
+				assert.ErrorIs(t, err, domain.ErrUnauthorized)
+				assert.Nil(t, claims)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, userID, claims.UserID)
+				assert.True(t, claims.IsAdmin())
+			}
+		})
+	}
+}
+
+func Test_JWTVerifier_Verify_RS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, ioutil.WriteFile(file, keySetJSON(t, "key-1", &key.PublicKey), 0600))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(keySetJSON(t, "key-1", &key.PublicKey))
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		name        string
+		source      string
+		token       string
+		expectedErr bool
+	}{
+		{
+			name:        "failure_unknown_key",
+			source:      file,
+			token:       sign(t, jwt.SigningMethodRS256, key, "key-2", validClaims()),
+			expectedErr: true,
+		},
+		{
+			name:        "failure_wrong_key",
+			source:      file,
+			token:       sign(t, jwt.SigningMethodRS256, otherKey, "key-1", validClaims()),
+			expectedErr: true,
+		},
+		{
+			name:        "failure_hs256_without_secret",
+			source:      file,
+			token:       sign(t, jwt.SigningMethodHS256, secret, "", validClaims()),
+			expectedErr: true,
+		},
+		{
+			name:   "success_key_set_file",
+			source: file,
+			token:  sign(t, jwt.SigningMethodRS256, key, "key-1", validClaims()),
+		},
+		{
+			name:   "success_key_set_url",
+			source: server.URL,
+			token:  sign(t, jwt.SigningMethodRS256, key, "key-1", validClaims()),
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			v := &auth.JWTVerifier{KeySet: auth.NewKeySet(tc.source)}
+
+			claims, err := v.Verify(context.TODO(), tc.token)
+			if tc.expectedErr {
+				assert.ErrorIs(t, err, domain.ErrUnauthorized)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, userID, claims.UserID)
+			}
+		})
+	}
+}
+
+func Test_KeySet_Key(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	t.Run("concurrent_lookups_share_one_fetch", func(t *testing.T) {
+		var fetches int32
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&fetches, 1)
+			<-release
+			_, _ = w.Write(keySetJSON(t, "key-1", &key.PublicKey))
+		}))
+		t.Cleanup(server.Close)
+		keySet := auth.NewKeySet(server.URL)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := keySet.Key(context.TODO(), "key-1")
+				assert.NoError(t, err)
+			}()
+		}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+	})
+
+	t.Run("known_keys_are_served_during_a_fetch", func(t *testing.T) {
+		var fetches int32
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&fetches, 1) > 1 {
+				<-release
+			}
+			_, _ = w.Write(keySetJSON(t, "key-1", &key.PublicKey))
+		}))
+		t.Cleanup(server.Close)
+		keySet := auth.NewKeySet(server.URL)
+		keySet.MinRefresh = 0
+
+		_, err := keySet.Key(context.TODO(), "key-1")
+		require.NoError(t, err)
+
+		done := make(chan error)
+		go func() {
+			_, err := keySet.Key(context.TODO(), "key-2")
+			done <- err
+		}()
+		time.Sleep(50 * time.Millisecond)
+
+		served := make(chan *rsa.PublicKey, 1)
+		go func() {
+			found, _ := keySet.Key(context.TODO(), "key-1")
+			served <- found
+		}()
+		select {
+		case found := <-served:
+			assert.Equal(t, key.N, found.N)
+		case <-time.After(time.Second):
+			t.Error("the known key waits for the fetch")
+		}
+
+		close(release)
+		assert.Error(t, <-done)
+	})
+}
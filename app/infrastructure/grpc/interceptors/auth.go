@@ -0,0 +1,99 @@
+package interceptors
+
+import (
+	"context"
+	"strings"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	grpc_middleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type AuthInterceptor struct {
+	verifier  domain.TokenVerifier
+	protected map[string]bool
+}
+
+// NewAuthInterceptor requires a valid bearer token on the calls of the
+// methods, given by full name
+func NewAuthInterceptor(verifier domain.TokenVerifier, methods ...string) *AuthInterceptor {
+	protected := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		protected[method] = true
+	}
+
+	return &AuthInterceptor{
+		verifier:  verifier,
+		protected: protected,
+	}
+}
+
+// Unary rejects the calls of the protected methods without a valid token
+// with domain.ErrUnauthorized, and puts the claims of the token in the
+// context of the others
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !i.protected[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		claims, err := i.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(domain.ContextWithClaims(ctx, claims), req)
+	}
+}
+
+// Stream checks the streaming calls as Unary does, putting the claims in
+// the context of the stream
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !i.protected[info.FullMethod] {
+			return handler(srv, ss)
+		}
+
+		claims, err := i.authenticate(ss.Context())
+		if err != nil {
+			return err
+		}
+
+		wrapped := grpc_middleware.WrapServerStream(ss)
+		wrapped.WrappedContext = domain.ContextWithClaims(ss.Context(), claims)
+		return handler(srv, wrapped)
+	}
+}
+
+// authenticate returns the claims of the bearer token of the call
+func (i *AuthInterceptor) authenticate(ctx context.Context) (*domain.Claims, error) {
+	token := bearerToken(ctx)
+	if token == "" {
+		return nil, domain.ErrUnauthorized
+	}
+
+	claims, err := i.verifier.Verify(ctx, token)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("verifier.Verify: %v", err)
+		return nil, domain.ErrUnauthorized
+	}
+	return claims, nil
+}
+
+// bearerToken returns the token of the "authorization: Bearer <token>"
+// metadata of the call
+func bearerToken(ctx context.Context) string {
+	const prefix = "bearer "
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, header := range md.Get("authorization") {
+		if len(header) > len(prefix) && strings.EqualFold(header[:len(prefix)], prefix) {
+			return strings.TrimSpace(header[len(prefix):])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,151 @@
+package interceptors_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/grpc/interceptors"
+	"github.com/EdlanioJ/kbu-store/app/utils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const protectedMethod = "/edlanioj.kbu.store.StoreService/Block"
+
+func TestAuthInterceptor_Unary(t *testing.T) {
+	testCases := []struct {
+		name        string
+		method      string
+		md          metadata.MD
+		expectedErr error
+		prepare     func(verifier *mocks.TokenVerifier)
+	}{
+		{
+			name:   "public_method",
+			method: "/edlanioj.kbu.store.StoreService/Get",
+		},
+		{
+			name:        "failure_missing_token",
+			method:      protectedMethod,
+			expectedErr: domain.ErrUnauthorized,
+		},
+		{
+			name:        "failure_invalid_token",
+			method:      protectedMethod,
+			md:          metadata.Pairs("authorization", "Bearer invalid"),
+			expectedErr: domain.ErrUnauthorized,
+			prepare: func(verifier *mocks.TokenVerifier) {
+				verifier.On("Verify", mock.Anything, "invalid").Return(nil, domain.ErrUnauthorized).Once()
+			},
+		},
+		{
+			name:   "success",
+			method: protectedMethod,
+			md:     metadata.Pairs("authorization", "Bearer valid"),
+			prepare: func(verifier *mocks.TokenVerifier) {
+				verifier.On("Verify", mock.Anything, "valid").Return(&domain.Claims{UserID: "user-id"}, nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			verifier := new(mocks.TokenVerifier)
+			if tc.prepare != nil {
+				tc.prepare(verifier)
+			}
+			interceptor := interceptors.NewAuthInterceptor(verifier, protectedMethod)
+
+			ctx := metadata.NewIncomingContext(context.TODO(), tc.md)
+			info := &grpc.UnaryServerInfo{FullMethod: tc.method}
+			_, err := interceptor.Unary()(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+				_, ok := domain.ClaimsFromContext(ctx)
+				assert.Equal(t, tc.method == protectedMethod, ok)
+				return nil, nil
+			})
+
+			assert.Equal(t, tc.expectedErr, err)
+			verifier.AssertExpectations(t)
+		})
+	}
+}
+
+// serverStream is a stream of a call made with ctx
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestAuthInterceptor_Stream(t *testing.T) {
+	const protectedStream = "/edlanioj.kbu.store.StoreService/UploadImage"
+
+	testCases := []struct {
+		name        string
+		method      string
+		md          metadata.MD
+		expectedErr error
+		prepare     func(verifier *mocks.TokenVerifier)
+	}{
+		{
+			name:   "public_stream",
+			method: "/edlanioj.kbu.store.StoreService/Public",
+		},
+		{
+			name:        "failure_missing_token",
+			method:      protectedStream,
+			expectedErr: domain.ErrUnauthorized,
+		},
+		{
+			name:        "failure_invalid_token",
+			method:      protectedStream,
+			md:          metadata.Pairs("authorization", "Bearer invalid"),
+			expectedErr: domain.ErrUnauthorized,
+			prepare: func(verifier *mocks.TokenVerifier) {
+				verifier.On("Verify", mock.Anything, "invalid").Return(nil, domain.ErrUnauthorized).Once()
+			},
+		},
+		{
+			name:   "success",
+			method: protectedStream,
+			md:     metadata.Pairs("authorization", "Bearer valid"),
+			prepare: func(verifier *mocks.TokenVerifier) {
+				verifier.On("Verify", mock.Anything, "valid").Return(&domain.Claims{UserID: "user-id"}, nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			verifier := new(mocks.TokenVerifier)
+			if tc.prepare != nil {
+				tc.prepare(verifier)
+			}
+			interceptor := interceptors.NewAuthInterceptor(verifier, protectedStream)
+
+			ss := &serverStream{ctx: metadata.NewIncomingContext(context.TODO(), tc.md)}
+			info := &grpc.StreamServerInfo{FullMethod: tc.method}
+			err := interceptor.Stream()(nil, ss, info, func(srv interface{}, stream grpc.ServerStream) error {
+				claims, ok := domain.ClaimsFromContext(stream.Context())
+				assert.Equal(t, tc.method == protectedStream, ok)
+				if ok {
+					assert.Equal(t, "user-id", claims.UserID)
+				}
+				return nil
+			})
+
+			assert.Equal(t, tc.expectedErr, err)
+			verifier.AssertExpectations(t)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package interceptors
 
 import (
 	"context"
+	"errors"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
 	"github.com/asaskevich/govalidator"
@@ -29,20 +30,57 @@ func (i *ErrorInterceptor) Unary() grpc.UnaryServerInterceptor {
 	}
 }
 
+func (i *ErrorInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, ss)
+		if err != nil {
+			return appError(err)
+		}
+
+		return nil
+	}
+}
+
 func appError(err error) error {
 	logrus.Error(err)
 	if _, ok := err.(govalidator.Errors); ok {
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	var transitionErr *domain.TransitionError
+	if errors.As(err, &transitionErr) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+
 	switch err {
 	case domain.ErrNotFound,
 		gorm.ErrRecordNotFound:
 		return status.Error(codes.NotFound, domain.ErrNotFound.Error())
 	case domain.ErrActived,
-		domain.ErrBlocked,
 		domain.ErrInactived,
-		domain.ErrPending:
+		domain.ErrInUse:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case domain.ErrUnauthorized:
+		return status.Error(codes.Unauthenticated, err.Error())
+	case domain.ErrForbidden:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case domain.ErrConflict:
+		return status.Error(codes.Aborted, err.Error())
+	case domain.ErrBadRequest,
+		domain.ErrInvalidAmount,
+		domain.ErrInvalidSort,
+		domain.ErrInvalidCursor,
+		domain.ErrCategoryCycle,
+		domain.ErrUnknownAction,
+		domain.ErrReasonRequired,
+		domain.ErrInvalidExpiry,
+		domain.ErrNotExpirable,
+		domain.ErrUnsupportedImage,
+		domain.ErrImageTooLarge,
+		domain.ErrInvalidTimezone,
+		domain.ErrInvalidHours:
+		return status.Error(codes.InvalidArgument, err.Error())
+	case domain.ErrInsufficientBalance:
 		return status.Error(codes.FailedPrecondition, err.Error())
 	default:
 		return status.Error(codes.Internal, domain.ErrInternal.Error())
@@ -22,11 +22,24 @@ type StoreServiceClient interface {
 	Create(ctx context.Context, in *CreateStoreRequest, opts ...grpc.CallOption) (*empty.Empty, error)
 	Get(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*Store, error)
 	List(ctx context.Context, in *ListStoreRequest, opts ...grpc.CallOption) (*ListStoreResponse, error)
+	ListNearby(ctx context.Context, in *ListNearbyRequest, opts ...grpc.CallOption) (*ListNearbyResponse, error)
+	Search(ctx context.Context, in *SearchStoreRequest, opts ...grpc.CallOption) (*SearchStoreResponse, error)
 	Activate(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	Block(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*empty.Empty, error)
-	Disable(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Block(ctx context.Context, in *StoreStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Disable(ctx context.Context, in *StoreStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ChangeStatus(ctx context.Context, in *StoreStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	ListActions(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*ListActionsResponse, error)
+	GetStatusHistory(ctx context.Context, in *ListStatusHistoryRequest, opts ...grpc.CallOption) (*ListStatusHistoryResponse, error)
 	Update(ctx context.Context, in *UpdateStoreRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	UploadImage(ctx context.Context, opts ...grpc.CallOption) (StoreService_UploadImageClient, error)
+	SetHours(ctx context.Context, in *SetHoursRequest, opts ...grpc.CallOption) (*Store, error)
 	Delete(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Restore(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetAccount(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*Account, error)
+	Deposit(ctx context.Context, in *AccountOperationRequest, opts ...grpc.CallOption) (*Account, error)
+	Withdraw(ctx context.Context, in *AccountOperationRequest, opts ...grpc.CallOption) (*Account, error)
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	ReconcileAccount(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*Reconciliation, error)
 }
 
 type storeServiceClient struct {
@@ -64,6 +77,24 @@ func (c *storeServiceClient) List(ctx context.Context, in *ListStoreRequest, opt
 	return out, nil
 }
 
+func (c *storeServiceClient) ListNearby(ctx context.Context, in *ListNearbyRequest, opts ...grpc.CallOption) (*ListNearbyResponse, error) {
+	out := new(ListNearbyResponse)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/ListNearby", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) Search(ctx context.Context, in *SearchStoreRequest, opts ...grpc.CallOption) (*SearchStoreResponse, error) {
+	out := new(SearchStoreResponse)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/Search", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storeServiceClient) Activate(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/Activate", in, out, opts...)
@@ -73,7 +104,7 @@ func (c *storeServiceClient) Activate(ctx context.Context, in *StoreRequest, opt
 	return out, nil
 }
 
-func (c *storeServiceClient) Block(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (c *storeServiceClient) Block(ctx context.Context, in *StoreStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/Block", in, out, opts...)
 	if err != nil {
@@ -82,7 +113,7 @@ func (c *storeServiceClient) Block(ctx context.Context, in *StoreRequest, opts .
 	return out, nil
 }
 
-func (c *storeServiceClient) Disable(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+func (c *storeServiceClient) Disable(ctx context.Context, in *StoreStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/Disable", in, out, opts...)
 	if err != nil {
@@ -91,6 +122,33 @@ func (c *storeServiceClient) Disable(ctx context.Context, in *StoreRequest, opts
 	return out, nil
 }
 
+func (c *storeServiceClient) ChangeStatus(ctx context.Context, in *StoreStatusRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/ChangeStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) ListActions(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*ListActionsResponse, error) {
+	out := new(ListActionsResponse)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/ListActions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) GetStatusHistory(ctx context.Context, in *ListStatusHistoryRequest, opts ...grpc.CallOption) (*ListStatusHistoryResponse, error) {
+	out := new(ListStatusHistoryResponse)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/GetStatusHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storeServiceClient) Update(ctx context.Context, in *UpdateStoreRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/Update", in, out, opts...)
@@ -100,6 +158,49 @@ func (c *storeServiceClient) Update(ctx context.Context, in *UpdateStoreRequest,
 	return out, nil
 }
 
+func (c *storeServiceClient) UploadImage(ctx context.Context, opts ...grpc.CallOption) (StoreService_UploadImageClient, error) {
+	stream, err := c.cc.NewStream(ctx, &StoreService_ServiceDesc.Streams[0], "/edlanioj.kbu.store.StoreService/UploadImage", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &storeServiceUploadImageClient{stream}
+	return x, nil
+}
+
+type StoreService_UploadImageClient interface {
+	Send(*UploadImageRequest) error
+	CloseAndRecv() (*Store, error)
+	grpc.ClientStream
+}
+
+type storeServiceUploadImageClient struct {
+	grpc.ClientStream
+}
+
+func (x *storeServiceUploadImageClient) Send(m *UploadImageRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *storeServiceUploadImageClient) CloseAndRecv() (*Store, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(Store)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *storeServiceClient) SetHours(ctx context.Context, in *SetHoursRequest, opts ...grpc.CallOption) (*Store, error) {
+	out := new(Store)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/SetHours", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *storeServiceClient) Delete(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
 	out := new(empty.Empty)
 	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/Delete", in, out, opts...)
@@ -109,6 +210,60 @@ func (c *storeServiceClient) Delete(ctx context.Context, in *StoreRequest, opts
 	return out, nil
 }
 
+func (c *storeServiceClient) Restore(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/Restore", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) GetAccount(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/GetAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) Deposit(ctx context.Context, in *AccountOperationRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/Deposit", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) Withdraw(ctx context.Context, in *AccountOperationRequest, opts ...grpc.CallOption) (*Account, error) {
+	out := new(Account)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/Withdraw", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/ListTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storeServiceClient) ReconcileAccount(ctx context.Context, in *StoreRequest, opts ...grpc.CallOption) (*Reconciliation, error) {
+	out := new(Reconciliation)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.StoreService/ReconcileAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StoreServiceServer is the server API for StoreService service.
 // All implementations must embed UnimplementedStoreServiceServer
 // for forward compatibility
@@ -116,11 +271,24 @@ type StoreServiceServer interface {
 	Create(context.Context, *CreateStoreRequest) (*empty.Empty, error)
 	Get(context.Context, *StoreRequest) (*Store, error)
 	List(context.Context, *ListStoreRequest) (*ListStoreResponse, error)
+	ListNearby(context.Context, *ListNearbyRequest) (*ListNearbyResponse, error)
+	Search(context.Context, *SearchStoreRequest) (*SearchStoreResponse, error)
 	Activate(context.Context, *StoreRequest) (*empty.Empty, error)
-	Block(context.Context, *StoreRequest) (*empty.Empty, error)
-	Disable(context.Context, *StoreRequest) (*empty.Empty, error)
+	Block(context.Context, *StoreStatusRequest) (*empty.Empty, error)
+	Disable(context.Context, *StoreStatusRequest) (*empty.Empty, error)
+	ChangeStatus(context.Context, *StoreStatusRequest) (*empty.Empty, error)
+	ListActions(context.Context, *StoreRequest) (*ListActionsResponse, error)
+	GetStatusHistory(context.Context, *ListStatusHistoryRequest) (*ListStatusHistoryResponse, error)
 	Update(context.Context, *UpdateStoreRequest) (*empty.Empty, error)
+	UploadImage(StoreService_UploadImageServer) error
+	SetHours(context.Context, *SetHoursRequest) (*Store, error)
 	Delete(context.Context, *StoreRequest) (*empty.Empty, error)
+	Restore(context.Context, *StoreRequest) (*empty.Empty, error)
+	GetAccount(context.Context, *StoreRequest) (*Account, error)
+	Deposit(context.Context, *AccountOperationRequest) (*Account, error)
+	Withdraw(context.Context, *AccountOperationRequest) (*Account, error)
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	ReconcileAccount(context.Context, *StoreRequest) (*Reconciliation, error)
 	mustEmbedUnimplementedStoreServiceServer()
 }
 
@@ -137,21 +305,60 @@ func (UnimplementedStoreServiceServer) Get(context.Context, *StoreRequest) (*Sto
 func (UnimplementedStoreServiceServer) List(context.Context, *ListStoreRequest) (*ListStoreResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
 }
+func (UnimplementedStoreServiceServer) ListNearby(context.Context, *ListNearbyRequest) (*ListNearbyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListNearby not implemented")
+}
+func (UnimplementedStoreServiceServer) Search(context.Context, *SearchStoreRequest) (*SearchStoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Search not implemented")
+}
 func (UnimplementedStoreServiceServer) Activate(context.Context, *StoreRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Activate not implemented")
 }
-func (UnimplementedStoreServiceServer) Block(context.Context, *StoreRequest) (*empty.Empty, error) {
+func (UnimplementedStoreServiceServer) Block(context.Context, *StoreStatusRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Block not implemented")
 }
-func (UnimplementedStoreServiceServer) Disable(context.Context, *StoreRequest) (*empty.Empty, error) {
+func (UnimplementedStoreServiceServer) Disable(context.Context, *StoreStatusRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Disable not implemented")
 }
+func (UnimplementedStoreServiceServer) ChangeStatus(context.Context, *StoreStatusRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeStatus not implemented")
+}
+func (UnimplementedStoreServiceServer) ListActions(context.Context, *StoreRequest) (*ListActionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListActions not implemented")
+}
+func (UnimplementedStoreServiceServer) GetStatusHistory(context.Context, *ListStatusHistoryRequest) (*ListStatusHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStatusHistory not implemented")
+}
 func (UnimplementedStoreServiceServer) Update(context.Context, *UpdateStoreRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
 }
+func (UnimplementedStoreServiceServer) UploadImage(StoreService_UploadImageServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadImage not implemented")
+}
+func (UnimplementedStoreServiceServer) SetHours(context.Context, *SetHoursRequest) (*Store, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetHours not implemented")
+}
 func (UnimplementedStoreServiceServer) Delete(context.Context, *StoreRequest) (*empty.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
 }
+func (UnimplementedStoreServiceServer) Restore(context.Context, *StoreRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedStoreServiceServer) GetAccount(context.Context, *StoreRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccount not implemented")
+}
+func (UnimplementedStoreServiceServer) Deposit(context.Context, *AccountOperationRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deposit not implemented")
+}
+func (UnimplementedStoreServiceServer) Withdraw(context.Context, *AccountOperationRequest) (*Account, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Withdraw not implemented")
+}
+func (UnimplementedStoreServiceServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedStoreServiceServer) ReconcileAccount(context.Context, *StoreRequest) (*Reconciliation, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileAccount not implemented")
+}
 func (UnimplementedStoreServiceServer) mustEmbedUnimplementedStoreServiceServer() {}
 
 // UnsafeStoreServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -219,6 +426,42 @@ func _StoreService_List_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StoreService_ListNearby_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListNearbyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).ListNearby(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.StoreService/ListNearby",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).ListNearby(ctx, req.(*ListNearbyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_Search_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchStoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).Search(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.StoreService/Search",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).Search(ctx, req.(*SearchStoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StoreService_Activate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StoreRequest)
 	if err := dec(in); err != nil {
@@ -238,7 +481,7 @@ func _StoreService_Activate_Handler(srv interface{}, ctx context.Context, dec fu
 }
 
 func _StoreService_Block_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StoreRequest)
+	in := new(StoreStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -250,13 +493,13 @@ func _StoreService_Block_Handler(srv interface{}, ctx context.Context, dec func(
 		FullMethod: "/edlanioj.kbu.store.StoreService/Block",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StoreServiceServer).Block(ctx, req.(*StoreRequest))
+		return srv.(StoreServiceServer).Block(ctx, req.(*StoreStatusRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
 func _StoreService_Disable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StoreRequest)
+	in := new(StoreStatusRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -268,7 +511,61 @@ func _StoreService_Disable_Handler(srv interface{}, ctx context.Context, dec fun
 		FullMethod: "/edlanioj.kbu.store.StoreService/Disable",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(StoreServiceServer).Disable(ctx, req.(*StoreRequest))
+		return srv.(StoreServiceServer).Disable(ctx, req.(*StoreStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_ChangeStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).ChangeStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.StoreService/ChangeStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).ChangeStatus(ctx, req.(*StoreStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_ListActions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).ListActions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.StoreService/ListActions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).ListActions(ctx, req.(*StoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_GetStatusHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStatusHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).GetStatusHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.StoreService/GetStatusHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).GetStatusHistory(ctx, req.(*ListStatusHistoryRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -291,6 +588,50 @@ func _StoreService_Update_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StoreService_UploadImage_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(StoreServiceServer).UploadImage(&storeServiceUploadImageServer{stream})
+}
+
+type StoreService_UploadImageServer interface {
+	SendAndClose(*Store) error
+	Recv() (*UploadImageRequest, error)
+	grpc.ServerStream
+}
+
+type storeServiceUploadImageServer struct {
+	grpc.ServerStream
+}
+
+func (x *storeServiceUploadImageServer) SendAndClose(m *Store) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *storeServiceUploadImageServer) Recv() (*UploadImageRequest, error) {
+	m := new(UploadImageRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _StoreService_SetHours_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetHoursRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).SetHours(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.StoreService/SetHours",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).SetHours(ctx, req.(*SetHoursRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StoreService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(StoreRequest)
 	if err := dec(in); err != nil {
@@ -309,6 +650,114 @@ func _StoreService_Delete_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StoreService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.StoreService/Restore",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).Restore(ctx, req.(*StoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_GetAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).GetAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.StoreService/GetAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).GetAccount(ctx, req.(*StoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_Deposit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).Deposit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.StoreService/Deposit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).Deposit(ctx, req.(*AccountOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_Withdraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AccountOperationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).Withdraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.StoreService/Withdraw",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).Withdraw(ctx, req.(*AccountOperationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.StoreService/ListTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StoreService_ReconcileAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StoreServiceServer).ReconcileAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.StoreService/ReconcileAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StoreServiceServer).ReconcileAccount(ctx, req.(*StoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StoreService_ServiceDesc is the grpc.ServiceDesc for StoreService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -328,6 +777,14 @@ var StoreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "List",
 			Handler:    _StoreService_List_Handler,
 		},
+		{
+			MethodName: "ListNearby",
+			Handler:    _StoreService_ListNearby_Handler,
+		},
+		{
+			MethodName: "Search",
+			Handler:    _StoreService_Search_Handler,
+		},
 		{
 			MethodName: "Activate",
 			Handler:    _StoreService_Activate_Handler,
@@ -340,14 +797,326 @@ var StoreService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Disable",
 			Handler:    _StoreService_Disable_Handler,
 		},
+		{
+			MethodName: "ChangeStatus",
+			Handler:    _StoreService_ChangeStatus_Handler,
+		},
+		{
+			MethodName: "ListActions",
+			Handler:    _StoreService_ListActions_Handler,
+		},
+		{
+			MethodName: "GetStatusHistory",
+			Handler:    _StoreService_GetStatusHistory_Handler,
+		},
 		{
 			MethodName: "Update",
 			Handler:    _StoreService_Update_Handler,
 		},
+		{
+			MethodName: "SetHours",
+			Handler:    _StoreService_SetHours_Handler,
+		},
 		{
 			MethodName: "Delete",
 			Handler:    _StoreService_Delete_Handler,
 		},
+		{
+			MethodName: "Restore",
+			Handler:    _StoreService_Restore_Handler,
+		},
+		{
+			MethodName: "GetAccount",
+			Handler:    _StoreService_GetAccount_Handler,
+		},
+		{
+			MethodName: "Deposit",
+			Handler:    _StoreService_Deposit_Handler,
+		},
+		{
+			MethodName: "Withdraw",
+			Handler:    _StoreService_Withdraw_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _StoreService_ListTransactions_Handler,
+		},
+		{
+			MethodName: "ReconcileAccount",
+			Handler:    _StoreService_ReconcileAccount_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadImage",
+			Handler:       _StoreService_UploadImage_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "protofiles/store.proto",
+}
+
+// CategoryServiceClient is the client API for CategoryService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type CategoryServiceClient interface {
+	Get(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*Category, error)
+	List(ctx context.Context, in *ListCategoryRequest, opts ...grpc.CallOption) (*ListCategoryResponse, error)
+	Tree(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CategoryTreeResponse, error)
+	Activate(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Disable(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+	Delete(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*empty.Empty, error)
+}
+
+type categoryServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewCategoryServiceClient(cc grpc.ClientConnInterface) CategoryServiceClient {
+	return &categoryServiceClient{cc}
+}
+
+func (c *categoryServiceClient) Get(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*Category, error) {
+	out := new(Category)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.CategoryService/Get", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) List(ctx context.Context, in *ListCategoryRequest, opts ...grpc.CallOption) (*ListCategoryResponse, error) {
+	out := new(ListCategoryResponse)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.CategoryService/List", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) Tree(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CategoryTreeResponse, error) {
+	out := new(CategoryTreeResponse)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.CategoryService/Tree", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) Activate(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.CategoryService/Activate", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) Disable(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.CategoryService/Disable", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *categoryServiceClient) Delete(ctx context.Context, in *CategoryRequest, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/edlanioj.kbu.store.CategoryService/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// CategoryServiceServer is the server API for CategoryService service.
+// All implementations must embed UnimplementedCategoryServiceServer
+// for forward compatibility
+type CategoryServiceServer interface {
+	Get(context.Context, *CategoryRequest) (*Category, error)
+	List(context.Context, *ListCategoryRequest) (*ListCategoryResponse, error)
+	Tree(context.Context, *empty.Empty) (*CategoryTreeResponse, error)
+	Activate(context.Context, *CategoryRequest) (*empty.Empty, error)
+	Disable(context.Context, *CategoryRequest) (*empty.Empty, error)
+	Delete(context.Context, *CategoryRequest) (*empty.Empty, error)
+	mustEmbedUnimplementedCategoryServiceServer()
+}
+
+// UnimplementedCategoryServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedCategoryServiceServer struct {
+}
+
+func (UnimplementedCategoryServiceServer) Get(context.Context, *CategoryRequest) (*Category, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedCategoryServiceServer) List(context.Context, *ListCategoryRequest) (*ListCategoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method List not implemented")
+}
+func (UnimplementedCategoryServiceServer) Tree(context.Context, *empty.Empty) (*CategoryTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Tree not implemented")
+}
+func (UnimplementedCategoryServiceServer) Activate(context.Context, *CategoryRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Activate not implemented")
+}
+func (UnimplementedCategoryServiceServer) Disable(context.Context, *CategoryRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Disable not implemented")
+}
+func (UnimplementedCategoryServiceServer) Delete(context.Context, *CategoryRequest) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedCategoryServiceServer) mustEmbedUnimplementedCategoryServiceServer() {}
+
+// UnsafeCategoryServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to CategoryServiceServer will
+// result in compilation errors.
+type UnsafeCategoryServiceServer interface {
+	mustEmbedUnimplementedCategoryServiceServer()
+}
+
+func RegisterCategoryServiceServer(s grpc.ServiceRegistrar, srv CategoryServiceServer) {
+	s.RegisterService(&CategoryService_ServiceDesc, srv)
+}
+
+func _CategoryService_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.CategoryService/Get",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).Get(ctx, req.(*CategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_List_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).List(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.CategoryService/List",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).List(ctx, req.(*ListCategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_Tree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).Tree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.CategoryService/Tree",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).Tree(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_Activate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).Activate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.CategoryService/Activate",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).Activate(ctx, req.(*CategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_Disable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).Disable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.CategoryService/Disable",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).Disable(ctx, req.(*CategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CategoryService_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CategoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CategoryServiceServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/edlanioj.kbu.store.CategoryService/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CategoryServiceServer).Delete(ctx, req.(*CategoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// CategoryService_ServiceDesc is the grpc.ServiceDesc for CategoryService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var CategoryService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "edlanioj.kbu.store.CategoryService",
+	HandlerType: (*CategoryServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Get",
+			Handler:    _CategoryService_Get_Handler,
+		},
+		{
+			MethodName: "List",
+			Handler:    _CategoryService_List_Handler,
+		},
+		{
+			MethodName: "Tree",
+			Handler:    _CategoryService_Tree_Handler,
+		},
+		{
+			MethodName: "Activate",
+			Handler:    _CategoryService_Activate_Handler,
+		},
+		{
+			MethodName: "Disable",
+			Handler:    _CategoryService_Disable_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _CategoryService_Delete_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "protofiles/store.proto",
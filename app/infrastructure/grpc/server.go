@@ -1,9 +1,11 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"net/http"
+	"time"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
 	"github.com/EdlanioJ/kbu-store/app/infrastructure/grpc/interceptors"
@@ -16,6 +18,8 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -30,50 +34,176 @@ func init() {
 }
 
 type grpcServer struct {
-	Port         int
-	MetricPort   int
-	StoreUsecase domain.StoreUsecase
-	Validate     *validator.Validate
+	Port            int
+	MetricPort      int
+	ShutdownTimeout time.Duration
+	OnReady         func()
+	StoreUsecase    domain.StoreUsecase
+	CategoryUsecase domain.CategoryUsecase
+	HealthUsecase   domain.HealthUsecase
+	HealthInterval  time.Duration
+	TokenVerifier   domain.TokenVerifier
+	Validate        *validator.Validate
 }
 
 func NewGrpcServer() *grpcServer {
-	return &grpcServer{}
+	return &grpcServer{
+		ShutdownTimeout: 10 * time.Second,
+		HealthInterval:  5 * time.Second,
+	}
 }
 
-func (s *grpcServer) Serve() {
+// Serve listens until ctx is done, then waits up to ShutdownTimeout for the
+// in-flight calls to finish before closing the remaining connections. OnReady
+// is called once the port is bound.
+func (s *grpcServer) Serve(ctx context.Context) error {
 	errorInterceptor := interceptors.NewErrorInterceptor()
+	authInterceptor := interceptors.NewAuthInterceptor(s.TokenVerifier, protectedMethods()...)
 
-	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(
-		grpc_middleware.ChainUnaryServer(
-			errorInterceptor.Unary(),
-			grpcMetrics.UnaryServerInterceptor(),
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(
+			grpc_middleware.ChainUnaryServer(
+				errorInterceptor.Unary(),
+				grpcMetrics.UnaryServerInterceptor(),
+				authInterceptor.Unary(),
+			),
+		),
+		grpc.StreamInterceptor(
+			grpc_middleware.ChainStreamServer(
+				errorInterceptor.Stream(),
+				grpcMetrics.StreamServerInterceptor(),
+				authInterceptor.Stream(),
+			),
 		),
-	))
+	)
 	reflection.Register(grpcServer)
 
 	storeService := service.NewStoreServer(s.StoreUsecase, s.Validate)
+	categoryService := service.NewCategoryServer(s.CategoryUsecase, s.Validate)
 
 	pb.RegisterStoreServiceServer(grpcServer, storeService)
+	pb.RegisterCategoryServiceServer(grpcServer, categoryService)
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
 
 	address := fmt.Sprintf("0.0.0.0:%d", s.Port)
 	listener, err := net.Listen("tcp", address)
 	if err != nil {
-		log.Error(err)
+		return err
 	}
 
 	grpcMetrics.InitializeMetrics(grpcServer)
 	http.Handle("/metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{}))
+	metricServer := &http.Server{Addr: fmt.Sprintf("0.0.0.0:%d", s.MetricPort)}
 
 	go func() {
 		log.Infof("metric server started at port \u001b[92m%d\u001b[0m", s.MetricPort)
-		if err := http.ListenAndServe(fmt.Sprintf("0.0.0.0:%d", s.MetricPort), nil); err != nil {
-			log.Fatal("Unable to start a http server.")
+		if err := metricServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error("Unable to start a http server: ", err)
 		}
 	}()
 
-	log.Infof("gRPC server started at port \u001b[92m%d\u001b[0m", s.Port)
-	err = grpcServer.Serve(listener)
-	if err != nil {
-		log.Fatal(err)
+	if s.OnReady != nil {
+		s.OnReady()
+	}
+
+	healthCtx, stopHealth := context.WithCancel(ctx)
+	defer stopHealth()
+	go s.watchHealth(healthCtx, healthServer)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Infof("gRPC server started at port \u001b[92m%d\u001b[0m", s.Port)
+		serveErr <- grpcServer.Serve(listener)
+	}()
+
+	select {
+	case err = <-serveErr:
+	case <-ctx.Done():
+		log.Info("shutting down gRPC server...")
+		healthServer.Shutdown()
+		s.gracefulStop(grpcServer)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), s.ShutdownTimeout)
+	defer cancel()
+	_ = metricServer.Shutdown(shutdownCtx)
+	return err
+}
+
+// protectedMethods returns the full names of the StoreService and
+// CategoryService methods and streams that require an authenticated caller,
+// that is, all of them but the reads
+func protectedMethods() []string {
+	public := map[string]bool{
+		"Get":        true,
+		"List":       true,
+		"ListNearby": true,
+		"Search":     true,
+		"Tree":       true,
+	}
+
+	var methods []string
+	for _, desc := range []grpc.ServiceDesc{pb.StoreService_ServiceDesc, pb.CategoryService_ServiceDesc} {
+		for _, method := range desc.Methods {
+			if !public[method.MethodName] {
+				methods = append(methods, "/"+desc.ServiceName+"/"+method.MethodName)
+			}
+		}
+		for _, stream := range desc.Streams {
+			methods = append(methods, "/"+desc.ServiceName+"/"+stream.StreamName)
+		}
+	}
+	return methods
+}
+
+// gracefulStop waits for the in-flight calls up to ShutdownTimeout, then
+// stops the server
+func (s *grpcServer) gracefulStop(server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(s.ShutdownTimeout):
+		log.Warn("gRPC server shutdown timed out, closing connections")
+		server.Stop()
+	}
+}
+
+// watchHealth sets the serving status of the server and of each of its
+// services from the readiness of the dependencies, every HealthInterval until
+// ctx is done
+func (s *grpcServer) watchHealth(ctx context.Context, healthServer *health.Server) {
+	services := []string{
+		"",
+		pb.StoreService_ServiceDesc.ServiceName,
+		pb.CategoryService_ServiceDesc.ServiceName,
+	}
+
+	ticker := time.NewTicker(s.HealthInterval)
+	defer ticker.Stop()
+
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		if !s.HealthUsecase.Readiness(ctx).Up() {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		for _, service := range services {
+			healthServer.SetServingStatus(service, status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
 	}
 }
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/grpc/pb"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/opentracing/opentracing-go"
+	log "github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type categoryService struct {
+	categoryUsecase domain.CategoryUsecase
+	validate        *validator.Validate
+	pb.UnimplementedCategoryServiceServer
+}
+
+func NewCategoryServer(usecase domain.CategoryUsecase, validate *validator.Validate) pb.CategoryServiceServer {
+	return &categoryService{
+		categoryUsecase: usecase,
+		validate:        validate,
+	}
+}
+
+func (s *categoryService) newPBCategory(category *domain.Category) *pb.Category {
+	res := &pb.Category{
+		ID:        category.ID,
+		Name:      category.Name,
+		Status:    category.Status,
+		CreatedAt: timestamppb.New(category.CreatedAt),
+		UpdatedAt: timestamppb.New(category.UpdatedAt),
+	}
+	if category.ParentID != nil {
+		res.ParentID = *category.ParentID
+	}
+	return res
+}
+
+func (s *categoryService) newPBCategoryNodes(nodes []*domain.CategoryNode) []*pb.CategoryNode {
+	res := make([]*pb.CategoryNode, 0, len(nodes))
+	for _, node := range nodes {
+		res = append(res, &pb.CategoryNode{
+			Category: s.newPBCategory(node.Category),
+			Children: s.newPBCategoryNodes(node.Children),
+		})
+	}
+	return res
+}
+
+func (s *categoryService) Get(ctx context.Context, in *pb.CategoryRequest) (*pb.Category, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CategoryService.Get")
+	defer span.Finish()
+	categoryGetMessages.Inc()
+
+	if err := s.validate.VarCtx(ctx, in.GetId(), "uuid4"); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	res, err := s.categoryUsecase.Get(ctx, in.GetId())
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("categoryUsecase.Get: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	successMessages.Inc()
+	return s.newPBCategory(res), nil
+}
+
+func (s *categoryService) List(ctx context.Context, in *pb.ListCategoryRequest) (*pb.ListCategoryResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CategoryService.List")
+	defer span.Finish()
+	categoryListMessages.Inc()
+
+	if err := s.validate.VarCtx(ctx, in.GetStatus(), "omitempty,oneof=pending active disable"); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	res, total, err := s.categoryUsecase.List(ctx, in.GetStatus(), int(in.GetLimit()), int(in.GetPage()))
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("categoryUsecase.List: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	categories := make([]*pb.Category, 0, len(res))
+	for _, item := range res {
+		categories = append(categories, s.newPBCategory(item))
+	}
+
+	successMessages.Inc()
+	return &pb.ListCategoryResponse{
+		Categories: categories,
+		Total:      total,
+	}, nil
+}
+
+func (s *categoryService) Tree(ctx context.Context, _ *empty.Empty) (*pb.CategoryTreeResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CategoryService.Tree")
+	defer span.Finish()
+	categoryTreeMessages.Inc()
+
+	res, err := s.categoryUsecase.Tree(ctx)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("categoryUsecase.Tree: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	successMessages.Inc()
+	return &pb.CategoryTreeResponse{
+		Categories: s.newPBCategoryNodes(res),
+	}, nil
+}
+
+func (s *categoryService) Activate(ctx context.Context, in *pb.CategoryRequest) (*empty.Empty, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CategoryService.Activate")
+	defer span.Finish()
+	categoryActivateMessages.Inc()
+
+	if err := s.validate.VarCtx(ctx, in.GetId(), "uuid4"); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	err := s.categoryUsecase.Activate(ctx, in.GetId())
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("categoryUsecase.Activate: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	successMessages.Inc()
+	return &empty.Empty{}, nil
+}
+
+func (s *categoryService) Disable(ctx context.Context, in *pb.CategoryRequest) (*empty.Empty, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CategoryService.Disable")
+	defer span.Finish()
+	categoryDisableMessages.Inc()
+
+	if err := s.validate.VarCtx(ctx, in.GetId(), "uuid4"); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	err := s.categoryUsecase.Disable(ctx, in.GetId())
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("categoryUsecase.Disable: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	successMessages.Inc()
+	return &empty.Empty{}, nil
+}
+
+func (s *categoryService) Delete(ctx context.Context, in *pb.CategoryRequest) (*empty.Empty, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "CategoryService.Delete")
+	defer span.Finish()
+	categoryDeleteMessages.Inc()
+
+	if err := s.validate.VarCtx(ctx, in.GetId(), "uuid4"); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	err := s.categoryUsecase.Delete(ctx, in.GetId())
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("categoryUsecase.Delete: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	successMessages.Inc()
+	return &empty.Empty{}, nil
+}
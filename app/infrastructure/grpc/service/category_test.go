@@ -0,0 +1,392 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/grpc/pb"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/grpc/service"
+	"github.com/EdlanioJ/kbu-store/app/utils/mocks"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/protobuf/ptypes/empty"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_CategoryGrpcService_Tree(t *testing.T) {
+	t.Parallel()
+	parent := sample.NewCategory()
+	child := sample.NewCategory()
+	child.ParentID = &parent.ID
+	testCases := []struct {
+		name        string
+		prepare     func(categoryUsecase *mocks.CategoryUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_usecase_returns_error",
+			expectedErr: true,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.
+					On("Tree", mock.Anything).
+					Return(nil, errors.New("Unexpected Error"))
+			},
+		},
+		{
+			name:        "success",
+			expectedErr: false,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.
+					On("Tree", mock.Anything).
+					Return(domain.NewCategoryTree(domain.Categories{parent, child}), nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.CategoryUsecase)
+			tc.prepare(usecase)
+			validate := validator.New()
+			s := service.NewCategoryServer(usecase, validate)
+			res, err := s.Tree(context.TODO(), &empty.Empty{})
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res.GetCategories(), 1)
+				children := res.GetCategories()[0].GetChildren()
+				assert.Len(t, children, 1)
+				assert.Equal(t, parent.ID, children[0].GetCategory().GetParentID())
+			}
+			usecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryGrpcService_List(t *testing.T) {
+	t.Parallel()
+	arg := &pb.ListCategoryRequest{Page: 1, Limit: 10, Status: domain.CategoryStatusActive}
+	testCases := []struct {
+		name        string
+		arg         *pb.ListCategoryRequest
+		prepare     func(categoryUsecase *mocks.CategoryUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_invalid_status",
+			arg:         &pb.ListCategoryRequest{Status: "block"},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         arg,
+			expectedErr: true,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.
+					On("List", mock.Anything, arg.GetStatus(), 10, 1).
+					Return(nil, int64(0), errors.New("Unexpected Error"))
+			},
+		},
+		{
+			name:        "success",
+			arg:         arg,
+			expectedErr: false,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.
+					On("List", mock.Anything, arg.GetStatus(), 10, 1).
+					Return(domain.Categories{sample.NewCategory()}, int64(1), nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.CategoryUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewCategoryServer(usecase, validate)
+			res, err := s.List(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res.GetCategories(), 1)
+				assert.Equal(t, int64(1), res.GetTotal())
+			}
+			usecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryGrpcService_Get(t *testing.T) {
+	t.Parallel()
+	arg := &pb.CategoryRequest{Id: uuid.NewV4().String()}
+
+	testCases := []struct {
+		name        string
+		arg         *pb.CategoryRequest
+		prepare     func(categoryUsecase *mocks.CategoryUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_empty_id",
+			arg:         &pb.CategoryRequest{},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_invalid_id",
+			arg:         &pb.CategoryRequest{Id: "invalid_id"},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         arg,
+			expectedErr: true,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.
+					On("Get", mock.Anything, arg.GetId()).
+					Return(nil, domain.ErrNotFound)
+			},
+		},
+		{
+			name:        "success",
+			arg:         arg,
+			expectedErr: false,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.
+					On("Get", mock.Anything, arg.GetId()).
+					Return(sample.NewCategory(), nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.CategoryUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewCategoryServer(usecase, validate)
+			res, err := s.Get(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Nil(t, res)
+				assert.Error(t, err)
+			} else {
+				assert.NotNil(t, res)
+				assert.NoError(t, err)
+			}
+			usecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryGrpcService_Activate(t *testing.T) {
+	t.Parallel()
+	arg := &pb.CategoryRequest{Id: uuid.NewV4().String()}
+
+	testCases := []struct {
+		name        string
+		arg         *pb.CategoryRequest
+		prepare     func(categoryUsecase *mocks.CategoryUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_empty_id",
+			arg:         &pb.CategoryRequest{},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_invalid_id",
+			arg:         &pb.CategoryRequest{Id: "invalid_id"},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         arg,
+			expectedErr: true,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.
+					On("Activate", mock.Anything, arg.GetId()).
+					Return(errors.New("Unexpected Error"))
+			},
+		},
+		{
+			name:        "success",
+			arg:         arg,
+			expectedErr: false,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.
+					On("Activate", mock.Anything, arg.GetId()).
+					Return(nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.CategoryUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewCategoryServer(usecase, validate)
+			res, err := s.Activate(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Nil(t, res)
+				assert.Error(t, err)
+			} else {
+				assert.NotNil(t, res)
+				assert.NoError(t, err)
+			}
+			usecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryGrpcService_Disable(t *testing.T) {
+	t.Parallel()
+	arg := &pb.CategoryRequest{Id: uuid.NewV4().String()}
+
+	testCases := []struct {
+		name        string
+		arg         *pb.CategoryRequest
+		prepare     func(categoryUsecase *mocks.CategoryUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_empty_id",
+			arg:         &pb.CategoryRequest{},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_invalid_id",
+			arg:         &pb.CategoryRequest{Id: "invalid_id"},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         arg,
+			expectedErr: true,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.
+					On("Disable", mock.Anything, arg.GetId()).
+					Return(errors.New("Unexpected Error"))
+			},
+		},
+		{
+			name:        "success",
+			arg:         arg,
+			expectedErr: false,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.
+					On("Disable", mock.Anything, arg.GetId()).
+					Return(nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.CategoryUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewCategoryServer(usecase, validate)
+			res, err := s.Disable(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Nil(t, res)
+				assert.Error(t, err)
+			} else {
+				assert.NotNil(t, res)
+				assert.NoError(t, err)
+			}
+			usecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryGrpcService_Delete(t *testing.T) {
+	t.Parallel()
+	arg := &pb.CategoryRequest{Id: uuid.NewV4().String()}
+
+	testCases := []struct {
+		name        string
+		arg         *pb.CategoryRequest
+		prepare     func(categoryUsecase *mocks.CategoryUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_empty_id",
+			arg:         &pb.CategoryRequest{},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_invalid_id",
+			arg:         &pb.CategoryRequest{Id: "invalid_id"},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         arg,
+			expectedErr: true,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.
+					On("Delete", mock.Anything, arg.GetId()).
+					Return(domain.ErrInUse)
+			},
+		},
+		{
+			name:        "success",
+			arg:         arg,
+			expectedErr: false,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.
+					On("Delete", mock.Anything, arg.GetId()).
+					Return(nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.CategoryUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewCategoryServer(usecase, validate)
+			res, err := s.Delete(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Nil(t, res)
+				assert.Error(t, err)
+			} else {
+				assert.NotNil(t, res)
+				assert.NoError(t, err)
+			}
+			usecase.AssertExpectations(t)
+		})
+	}
+}
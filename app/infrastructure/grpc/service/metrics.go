@@ -26,24 +26,88 @@ var (
 		Name: "stores_list_incoming_grpc_requests_total",
 		Help: "The total number of incoming list stores gRPC messages",
 	})
+	nearbyMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stores_nearby_incoming_grpc_requests_total",
+		Help: "The total number of incoming nearby stores gRPC messages",
+	})
+	searchMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stores_search_incoming_grpc_requests_total",
+		Help: "The total number of incoming search stores gRPC messages",
+	})
 	updateMessages = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "stores_update_incoming_grpc_requests_total",
 		Help: "The total number of incoming update store gRPC messages",
 	})
+	imageMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stores_image_incoming_grpc_requests_total",
+		Help: "The total number of incoming upload store image gRPC messages",
+	})
+	hoursMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stores_hours_incoming_grpc_requests_total",
+		Help: "The total number of incoming set store opening hours gRPC messages",
+	})
 	deleteMessages = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "stores_delete_incoming_grpc_requests_total",
 		Help: "The total number of incoming delete store gRPC messages",
 	})
-	activateMessages = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "stores_activate_incoming_grpc_requests_total",
-		Help: "The total number of incoming activate store gRPC messages",
+	restoreMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stores_restore_incoming_grpc_requests_total",
+		Help: "The total number of incoming restore store gRPC messages",
+	})
+	statusMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "stores_status_incoming_grpc_requests_total",
+		Help: "The total number of incoming store status change gRPC messages, by action",
+	}, []string{"action"})
+	actionsMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stores_actions_incoming_grpc_requests_total",
+		Help: "The total number of incoming list store actions gRPC messages",
+	})
+	accountMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stores_account_incoming_grpc_requests_total",
+		Help: "The total number of incoming get store account gRPC messages",
+	})
+	depositMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stores_deposit_incoming_grpc_requests_total",
+		Help: "The total number of incoming deposit store gRPC messages",
+	})
+	withdrawMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stores_withdraw_incoming_grpc_requests_total",
+		Help: "The total number of incoming withdraw store gRPC messages",
+	})
+	historyMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stores_history_incoming_grpc_requests_total",
+		Help: "The total number of incoming list store status history gRPC messages",
+	})
+	transactionsMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stores_transactions_incoming_grpc_requests_total",
+		Help: "The total number of incoming list store transactions gRPC messages",
+	})
+	reconcileMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "stores_reconcile_incoming_grpc_requests_total",
+		Help: "The total number of incoming reconcile store account gRPC messages",
+	})
+	categoryGetMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "categories_get_incoming_grpc_requests_total",
+		Help: "The total number of incoming get by id category gRPC messages",
+	})
+	categoryListMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "categories_list_incoming_grpc_requests_total",
+		Help: "The total number of incoming list categories gRPC messages",
+	})
+	categoryTreeMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "categories_tree_incoming_grpc_requests_total",
+		Help: "The total number of incoming category tree gRPC messages",
+	})
+	categoryActivateMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "categories_activate_incoming_grpc_requests_total",
+		Help: "The total number of incoming activate category gRPC messages",
 	})
-	blockMessages = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "stores_block_incoming_grpc_requests_total",
-		Help: "The total number of incoming block store gRPC messages",
+	categoryDisableMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "categories_disable_incoming_grpc_requests_total",
+		Help: "The total number of incoming disable category gRPC messages",
 	})
-	disableMessages = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "stores_disable_incoming_grpc_requests_total",
-		Help: "The total number of incoming disable store gRPC messages",
+	categoryDeleteMessages = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "categories_delete_incoming_grpc_requests_total",
+		Help: "The total number of incoming delete category gRPC messages",
 	})
 )
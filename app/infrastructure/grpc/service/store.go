@@ -2,12 +2,16 @@ package service
 
 import (
 	"context"
+	"io"
+	"strings"
+	"time"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
 	"github.com/EdlanioJ/kbu-store/app/infrastructure/grpc/pb"
 	"github.com/go-playground/validator/v10"
 	"github.com/golang/protobuf/ptypes/empty"
 	"github.com/opentracing/opentracing-go"
+	"github.com/shopspring/decimal"
 	log "github.com/sirupsen/logrus"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -33,6 +37,8 @@ func (s *storeService) newPBStore(store *domain.Store) *pb.Store {
 		Status:      store.Status,
 		ExternalID:  store.UserID,
 		AccountID:   store.AccountID,
+		Image:       store.Image,
+		Images:      store.Images,
 		Tags:        store.Tags,
 		Location: &pb.Location{
 			Latitude:  store.Position.Lat,
@@ -40,10 +46,233 @@ func (s *storeService) newPBStore(store *domain.Store) *pb.Store {
 		},
 		Category:  store.CategoryID,
 		CreatedAt: timestamppb.New(store.CreatedAt),
+		Version:   int64(store.Version),
+		Hours:     s.newPBStoreHours(store.Hours),
+		IsOpenNow: store.IsOpenNow,
+	}
+	if store.NextChangeAt != nil {
+		t.NextChangeAt = timestamppb.New(*store.NextChangeAt)
+	}
+	if store.DeletedAt != nil {
+		t.DeletedAt = timestamppb.New(*store.DeletedAt)
 	}
 	return t
 }
 
+func (s *storeService) newPBStoreHours(hours *domain.StoreHours) *pb.StoreHours {
+	if hours == nil {
+		return nil
+	}
+
+	res := &pb.StoreHours{
+		Timezone:  hours.Timezone,
+		Weekly:    make([]*pb.OpeningHours, 0, len(hours.Weekly)),
+		Closures:  make([]*pb.StoreClosure, 0, len(hours.Closures)),
+		UpdatedAt: timestamppb.New(hours.UpdatedAt),
+	}
+	for _, o := range hours.Weekly {
+		res.Weekly = append(res.Weekly, &pb.OpeningHours{
+			Weekday: int32(o.Weekday),
+			Opens:   o.Opens,
+			Closes:  o.Closes,
+		})
+	}
+	for _, c := range hours.Closures {
+		res.Closures = append(res.Closures, &pb.StoreClosure{
+			From:   c.From,
+			To:     c.To,
+			Reason: c.Reason,
+		})
+	}
+	return res
+}
+
+func (s *storeService) newPBAccount(account *domain.Account) *pb.Account {
+	return &pb.Account{
+		ID:        account.ID,
+		Balance:   account.Balance.String(),
+		CreatedAt: timestamppb.New(account.CreatedAt),
+		UpdatedAt: timestamppb.New(account.UpdatedAt),
+	}
+}
+
+func (s *storeService) newPBLedgerEntry(entry *domain.LedgerEntry) *pb.LedgerEntry {
+	return &pb.LedgerEntry{
+		ID:          entry.ID,
+		AccountID:   entry.AccountID,
+		Amount:      entry.Amount.String(),
+		Direction:   entry.Direction,
+		Reason:      entry.Reason,
+		ReferenceID: entry.ReferenceID,
+		Balance:     entry.Balance.String(),
+		CreatedAt:   timestamppb.New(entry.CreatedAt),
+	}
+}
+
+func (s *storeService) newPBStatusChange(change *domain.StoreStatusChange) *pb.StatusChange {
+	res := &pb.StatusChange{
+		ID:         change.ID,
+		StoreID:    change.StoreID,
+		FromStatus: change.FromStatus,
+		ToStatus:   change.ToStatus,
+		ActorID:    change.ActorID,
+		Reason:     change.Reason,
+		CreatedAt:  timestamppb.New(change.CreatedAt),
+	}
+	if change.ExpiresAt != nil {
+		res.ExpiresAt = timestamppb.New(*change.ExpiresAt)
+	}
+	return res
+}
+
+// newStoreStatusRequest converts in, leaving the expiry unset when in has none
+func (s *storeService) newStoreStatusRequest(in *pb.StoreStatusRequest) *domain.StoreStatusRequest {
+	req := &domain.StoreStatusRequest{
+		ID:     in.GetId(),
+		Reason: in.GetReason(),
+	}
+	if in.GetExpiresAt() != nil {
+		expiresAt := in.GetExpiresAt().AsTime()
+		req.ExpiresAt = &expiresAt
+	}
+	return req
+}
+
+// newAccountOperationRequest parses the decimal amount of in
+func (s *storeService) newAccountOperationRequest(in *pb.AccountOperationRequest) (*domain.AccountOperationRequest, error) {
+	amount, err := decimal.NewFromString(in.GetAmount())
+	if err != nil {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	return &domain.AccountOperationRequest{
+		ID:          in.GetId(),
+		Amount:      amount,
+		Reason:      in.GetReason(),
+		ReferenceID: in.GetReferenceID(),
+	}, nil
+}
+
+// storeFilterRequest is implemented by the messages carrying a store filter
+type storeFilterRequest interface {
+	GetStatus() string
+	GetCategoryID() string
+	GetIncludeDescendants() bool
+	GetUserID() string
+	GetAnyTags() []string
+	GetAllTags() []string
+	GetCreatedFrom() *timestamppb.Timestamp
+	GetCreatedTo() *timestamppb.Timestamp
+	GetNamePrefix() string
+	GetOpenNow() bool
+}
+
+func (s *storeService) newStoreFilter(in storeFilterRequest) *domain.StoreFilter {
+	filter := &domain.StoreFilter{
+		Status:             in.GetStatus(),
+		CategoryID:         in.GetCategoryID(),
+		IncludeDescendants: in.GetIncludeDescendants(),
+		UserID:             in.GetUserID(),
+		AnyTags:            in.GetAnyTags(),
+		AllTags:            in.GetAllTags(),
+		NamePrefix:         in.GetNamePrefix(),
+	}
+	if in.GetCreatedFrom() != nil {
+		filter.CreatedFrom = in.GetCreatedFrom().AsTime()
+	}
+	if in.GetCreatedTo() != nil {
+		filter.CreatedTo = in.GetCreatedTo().AsTime()
+	}
+	if in.GetOpenNow() {
+		filter.OpenAt = time.Now()
+	}
+	return filter
+}
+
+func (s *storeService) newStoreHoursRequest(in *pb.SetHoursRequest) *domain.StoreHoursRequest {
+	req := &domain.StoreHoursRequest{
+		ID:       in.GetId(),
+		Timezone: in.GetTimezone(),
+		Weekly:   make([]*domain.OpeningHours, 0, len(in.GetWeekly())),
+		Closures: make([]*domain.StoreClosure, 0, len(in.GetClosures())),
+	}
+	for _, o := range in.GetWeekly() {
+		req.Weekly = append(req.Weekly, &domain.OpeningHours{
+			Weekday: int(o.GetWeekday()),
+			Opens:   o.GetOpens(),
+			Closes:  o.GetCloses(),
+		})
+	}
+	for _, c := range in.GetClosures() {
+		req.Closures = append(req.Closures, &domain.StoreClosure{
+			From:   c.GetFrom(),
+			To:     c.GetTo(),
+			Reason: c.GetReason(),
+		})
+	}
+	return req
+}
+
+// newUpdateStoreRequest keeps only the fields listed in the update mask. With
+// no mask, the fields holding a non-zero value are updated.
+func (s *storeService) newUpdateStoreRequest(in *pb.UpdateStoreRequest) (*domain.UpdateStoreRequest, error) {
+	ur := &domain.UpdateStoreRequest{
+		ID:      in.GetID(),
+		Version: int(in.GetVersion()),
+	}
+
+	paths := in.GetUpdateMask().GetPaths()
+	if len(paths) == 0 {
+		if in.GetName() != "" {
+			paths = append(paths, "name")
+		}
+		if in.GetDescription() != "" {
+			paths = append(paths, "description")
+		}
+		if in.GetCategoryID() != "" {
+			paths = append(paths, "categoryID")
+		}
+		if len(in.GetTags()) > 0 {
+			paths = append(paths, "tags")
+		}
+		if in.GetLatitude() != 0 {
+			paths = append(paths, "latitude")
+		}
+		if in.GetLongitude() != 0 {
+			paths = append(paths, "longitude")
+		}
+	}
+
+	for _, path := range paths {
+		switch path {
+		case "name":
+			name := in.GetName()
+			ur.Name = &name
+		case "description":
+			description := in.GetDescription()
+			ur.Description = &description
+		case "categoryID":
+			categoryID := in.GetCategoryID()
+			ur.CategoryID = &categoryID
+		case "image":
+			// ignored, the image is only set through UploadImage
+		case "tags":
+			tags := in.GetTags()
+			ur.Tags = &tags
+		case "latitude":
+			lat := in.GetLatitude()
+			ur.Lat = &lat
+		case "longitude":
+			lng := in.GetLongitude()
+			ur.Lng = &lng
+		default:
+			return nil, domain.ErrBadRequest
+		}
+	}
+
+	return ur, nil
+}
+
 func (s *storeService) Create(ctx context.Context, in *pb.CreateStoreRequest) (*empty.Empty, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.Create")
 	defer span.Finish()
@@ -53,7 +282,6 @@ func (s *storeService) Create(ctx context.Context, in *pb.CreateStoreRequest) (*
 	cr.Name = in.GetName()
 	cr.Description = in.GetDescription()
 	cr.CategoryID = in.GetCategoryID()
-	cr.UserID = in.GetExternalID()
 	cr.Tags = in.GetTags()
 	cr.Lat = in.GetLatitude()
 	cr.Lng = in.GetLongitude()
@@ -111,9 +339,18 @@ func (s *storeService) List(ctx context.Context, in *pb.ListStoreRequest) (*pb.L
 	defer span.Finish()
 	listMessages.Inc()
 
+	filter := s.newStoreFilter(in)
+	if err := s.validate.StructCtx(ctx, filter); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
 	var stores []*pb.Store
 
-	res, total, err := s.storeUsecase.Index(ctx, in.GetSort(), int(in.GetLimit()), int(in.GetPage()))
+	res, err := s.storeUsecase.Index(ctx, filter, in.GetSort(), in.GetPageToken(), int(in.GetLimit()), int(in.GetPage()))
 	if err != nil {
 		log.
 			WithContext(ctx).
@@ -121,35 +358,154 @@ func (s *storeService) List(ctx context.Context, in *pb.ListStoreRequest) (*pb.L
 		errorMessages.Inc()
 		return nil, err
 	}
-	for _, item := range res {
+	for _, item := range res.Stores {
 		stores = append(stores, s.newPBStore(item))
 	}
 
 	successMessages.Inc()
 	return &pb.ListStoreResponse{
-		Stores: stores,
-		Total:  total,
+		Stores:        stores,
+		Total:         res.Total,
+		NextPageToken: res.NextCursor,
+		PrevPageToken: res.PrevCursor,
+	}, nil
+}
+
+func (s *storeService) ListNearby(ctx context.Context, in *pb.ListNearbyRequest) (*pb.ListNearbyResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.ListNearby")
+	defer span.Finish()
+	nearbyMessages.Inc()
+
+	req := &domain.NearbyStoreRequest{
+		Lat:      in.GetLatitude(),
+		Lng:      in.GetLongitude(),
+		RadiusKm: in.GetRadiusKm(),
+		Limit:    int(in.GetLimit()),
+		Filter:   s.newStoreFilter(in),
+	}
+	if err := s.validate.StructCtx(ctx, req); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	res, err := s.storeUsecase.Nearby(ctx, req)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.Nearby: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	stores := make([]*pb.NearbyStore, 0, len(res))
+	for _, item := range res {
+		stores = append(stores, &pb.NearbyStore{
+			Store:      s.newPBStore(item.Store),
+			DistanceKm: item.Distance,
+		})
+	}
+
+	successMessages.Inc()
+	return &pb.ListNearbyResponse{Stores: stores}, nil
+}
+
+func (s *storeService) Search(ctx context.Context, in *pb.SearchStoreRequest) (*pb.SearchStoreResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.Search")
+	defer span.Finish()
+	searchMessages.Inc()
+
+	req := &domain.SearchStoreRequest{
+		Query:  strings.TrimSpace(in.GetQuery()),
+		Limit:  int(in.GetLimit()),
+		Page:   int(in.GetPage()),
+		Filter: s.newStoreFilter(in),
+	}
+	if err := s.validate.StructCtx(ctx, req); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	res, err := s.storeUsecase.Search(ctx, req)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.Search: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	results := make([]*pb.StoreSearchResult, 0, len(res.Results))
+	for _, item := range res.Results {
+		results = append(results, &pb.StoreSearchResult{
+			Store:   s.newPBStore(item.Store),
+			Rank:    item.Rank,
+			Snippet: item.Snippet,
+		})
+	}
+
+	successMessages.Inc()
+	return &pb.SearchStoreResponse{
+		Results: results,
+		Total:   res.Total,
 	}, nil
 }
 
+// Activate is a shorthand of ChangeStatus for the activate action
 func (s *storeService) Activate(ctx context.Context, in *pb.StoreRequest) (*empty.Empty, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.Activate")
 	defer span.Finish()
-	activateMessages.Inc()
 
-	if err := s.validate.VarCtx(ctx, in.GetId(), "uuid4"); err != nil {
+	return s.changeStatus(ctx, &pb.StoreStatusRequest{Id: in.GetId()}, domain.StoreActionActivate)
+}
+
+// Block is a shorthand of ChangeStatus for the block action
+func (s *storeService) Block(ctx context.Context, in *pb.StoreStatusRequest) (*empty.Empty, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.Block")
+	defer span.Finish()
+
+	return s.changeStatus(ctx, in, domain.StoreActionBlock)
+}
+
+// Disable is a shorthand of ChangeStatus for the disable action
+func (s *storeService) Disable(ctx context.Context, in *pb.StoreStatusRequest) (*empty.Empty, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.Disable")
+	defer span.Finish()
+
+	return s.changeStatus(ctx, in, domain.StoreActionDisable)
+}
+
+func (s *storeService) ChangeStatus(ctx context.Context, in *pb.StoreStatusRequest) (*empty.Empty, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.ChangeStatus")
+	defer span.Finish()
+
+	return s.changeStatus(ctx, in, in.GetAction())
+}
+
+// changeStatus applies action of the store lifecycle to the store of in
+func (s *storeService) changeStatus(ctx context.Context, in *pb.StoreStatusRequest, action string) (*empty.Empty, error) {
+	statusMessages.WithLabelValues(action).Inc()
+
+	req := s.newStoreStatusRequest(in)
+	req.Action = action
+	if err := s.validate.StructCtx(ctx, req); err != nil {
 		log.
 			WithContext(ctx).
-			Errorf("validate.VarCtx: %v", err)
+			Errorf("validate.StructCtx: %v", err)
 		errorMessages.Inc()
 		return nil, err
 	}
 
-	err := s.storeUsecase.Active(ctx, in.GetId())
+	err := s.storeUsecase.ChangeStatus(ctx, req)
 	if err != nil {
 		log.
 			WithContext(ctx).
-			Errorf("storeUsecase.Active: %v", err)
+			Errorf("storeUsecase.ChangeStatus: %v", err)
 		errorMessages.Inc()
 		return nil, err
 	}
@@ -158,10 +514,10 @@ func (s *storeService) Activate(ctx context.Context, in *pb.StoreRequest) (*empt
 	return &empty.Empty{}, nil
 }
 
-func (s *storeService) Block(ctx context.Context, in *pb.StoreRequest) (*empty.Empty, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.Block")
+func (s *storeService) ListActions(ctx context.Context, in *pb.StoreRequest) (*pb.ListActionsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.ListActions")
 	defer span.Finish()
-	blockMessages.Inc()
+	actionsMessages.Inc()
 
 	if err := s.validate.VarCtx(ctx, in.GetId(), "uuid4"); err != nil {
 		log.
@@ -171,23 +527,34 @@ func (s *storeService) Block(ctx context.Context, in *pb.StoreRequest) (*empty.E
 		return nil, err
 	}
 
-	err := s.storeUsecase.Block(ctx, in.GetId())
+	res, err := s.storeUsecase.Actions(ctx, in.GetId())
 	if err != nil {
 		log.
 			WithContext(ctx).
-			Errorf("storeUsecase.Block: %v", err)
+			Errorf("storeUsecase.Actions: %v", err)
 		errorMessages.Inc()
 		return nil, err
 	}
 
+	actions := make([]*pb.StoreAction, 0, len(res))
+	for _, transition := range res {
+		actions = append(actions, &pb.StoreAction{
+			Action:         transition.Action,
+			To:             transition.To,
+			Actor:          transition.Actor,
+			ReasonRequired: transition.ReasonRequired,
+			Expirable:      transition.Expirable,
+		})
+	}
+
 	successMessages.Inc()
-	return &empty.Empty{}, nil
+	return &pb.ListActionsResponse{Actions: actions}, nil
 }
 
-func (s *storeService) Disable(ctx context.Context, in *pb.StoreRequest) (*empty.Empty, error) {
-	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.Disable")
+func (s *storeService) GetStatusHistory(ctx context.Context, in *pb.ListStatusHistoryRequest) (*pb.ListStatusHistoryResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.GetStatusHistory")
 	defer span.Finish()
-	disableMessages.Inc()
+	historyMessages.Inc()
 
 	if err := s.validate.VarCtx(ctx, in.GetId(), "uuid4"); err != nil {
 		log.
@@ -197,17 +564,25 @@ func (s *storeService) Disable(ctx context.Context, in *pb.StoreRequest) (*empty
 		return nil, err
 	}
 
-	err := s.storeUsecase.Disable(ctx, in.GetId())
+	var changes []*pb.StatusChange
+
+	res, total, err := s.storeUsecase.StatusHistory(ctx, in.GetId(), int(in.GetLimit()), int(in.GetPage()))
 	if err != nil {
 		log.
 			WithContext(ctx).
-			Errorf("storeUsecase.Disable: %v", err)
+			Errorf("storeUsecase.StatusHistory: %v", err)
 		errorMessages.Inc()
 		return nil, err
 	}
+	for _, item := range res {
+		changes = append(changes, s.newPBStatusChange(item))
+	}
 
 	successMessages.Inc()
-	return &empty.Empty{}, nil
+	return &pb.ListStatusHistoryResponse{
+		Changes: changes,
+		Total:   total,
+	}, nil
 }
 
 func (s *storeService) Update(ctx context.Context, in *pb.UpdateStoreRequest) (*empty.Empty, error) {
@@ -215,16 +590,14 @@ func (s *storeService) Update(ctx context.Context, in *pb.UpdateStoreRequest) (*
 	defer span.Finish()
 	updateMessages.Inc()
 
-	ur := new(domain.UpdateStoreRequest)
-
-	ur.ID = in.GetID()
-	ur.Name = in.GetName()
-	ur.Description = in.GetDescription()
-	ur.Tags = in.GetTags()
-	ur.Lat = in.GetLatitude()
-	ur.Image = in.GetImage()
-	ur.Lng = in.GetLongitude()
-	ur.CategoryID = in.GetCategoryID()
+	ur, err := s.newUpdateStoreRequest(in)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("s.newUpdateStoreRequest: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
 
 	if err := s.validate.StructCtx(ctx, ur); err != nil {
 		log.
@@ -233,7 +606,7 @@ func (s *storeService) Update(ctx context.Context, in *pb.UpdateStoreRequest) (*
 		errorMessages.Inc()
 		return nil, err
 	}
-	err := s.storeUsecase.Update(ctx, ur)
+	err = s.storeUsecase.Update(ctx, ur)
 	if err != nil {
 		log.
 			WithContext(ctx).
@@ -246,6 +619,98 @@ func (s *storeService) Update(ctx context.Context, in *pb.UpdateStoreRequest) (*
 	return &empty.Empty{}, nil
 }
 
+// imageStream reads the chunks of an UploadImage call, starting with the one
+// sent along with the info of the image
+type imageStream struct {
+	stream pb.StoreService_UploadImageServer
+	chunk  []byte
+}
+
+func (r *imageStream) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		in, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.chunk = in.GetChunk()
+	}
+
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+func (s *storeService) UploadImage(stream pb.StoreService_UploadImageServer) error {
+	span, ctx := opentracing.StartSpanFromContext(stream.Context(), "StoreService.UploadImage")
+	defer span.Finish()
+	imageMessages.Inc()
+
+	in, err := stream.Recv()
+	if err == io.EOF || (err == nil && in.GetInfo() == nil) {
+		err = domain.ErrBadRequest
+	}
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("stream.Recv: %v", err)
+		errorMessages.Inc()
+		return err
+	}
+
+	info := in.GetInfo()
+	if err := s.validate.VarCtx(ctx, info.GetStoreID(), "uuid4"); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorMessages.Inc()
+		return err
+	}
+
+	res, err := s.storeUsecase.UploadImage(ctx, &domain.StoreImageRequest{
+		ID:          info.GetStoreID(),
+		ContentType: info.GetContentType(),
+		Size:        info.GetSize(),
+		Body:        &imageStream{stream: stream, chunk: in.GetChunk()},
+	})
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.UploadImage: %v", err)
+		errorMessages.Inc()
+		return err
+	}
+
+	successMessages.Inc()
+	return stream.SendAndClose(s.newPBStore(res))
+}
+
+func (s *storeService) SetHours(ctx context.Context, in *pb.SetHoursRequest) (*pb.Store, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.SetHours")
+	defer span.Finish()
+	hoursMessages.Inc()
+
+	req := s.newStoreHoursRequest(in)
+	if err := s.validate.StructCtx(ctx, req); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	res, err := s.storeUsecase.SetHours(ctx, req)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.SetHours: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	successMessages.Inc()
+	return s.newPBStore(res), nil
+}
+
 func (s *storeService) Delete(ctx context.Context, in *pb.StoreRequest) (*empty.Empty, error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.Delete")
 	defer span.Finish()
@@ -271,3 +736,190 @@ func (s *storeService) Delete(ctx context.Context, in *pb.StoreRequest) (*empty.
 	successMessages.Inc()
 	return &empty.Empty{}, nil
 }
+
+func (s *storeService) Restore(ctx context.Context, in *pb.StoreRequest) (*empty.Empty, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.Restore")
+	defer span.Finish()
+	restoreMessages.Inc()
+
+	if err := s.validate.VarCtx(ctx, in.GetId(), "uuid4"); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	err := s.storeUsecase.Restore(ctx, in.GetId())
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.Restore: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	successMessages.Inc()
+	return &empty.Empty{}, nil
+}
+
+func (s *storeService) GetAccount(ctx context.Context, in *pb.StoreRequest) (*pb.Account, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.GetAccount")
+	defer span.Finish()
+	accountMessages.Inc()
+
+	if err := s.validate.VarCtx(ctx, in.GetId(), "uuid4"); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	res, err := s.storeUsecase.GetAccount(ctx, in.GetId())
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.GetAccount: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	successMessages.Inc()
+	return s.newPBAccount(res), nil
+}
+
+func (s *storeService) Deposit(ctx context.Context, in *pb.AccountOperationRequest) (*pb.Account, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.Deposit")
+	defer span.Finish()
+	depositMessages.Inc()
+
+	ar, err := s.newAccountOperationRequest(in)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("newAccountOperationRequest: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	if err := s.validate.StructCtx(ctx, ar); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	res, err := s.storeUsecase.Deposit(ctx, ar)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.Deposit: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	successMessages.Inc()
+	return s.newPBAccount(res), nil
+}
+
+func (s *storeService) Withdraw(ctx context.Context, in *pb.AccountOperationRequest) (*pb.Account, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.Withdraw")
+	defer span.Finish()
+	withdrawMessages.Inc()
+
+	ar, err := s.newAccountOperationRequest(in)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("newAccountOperationRequest: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	if err := s.validate.StructCtx(ctx, ar); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	res, err := s.storeUsecase.Withdraw(ctx, ar)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.Withdraw: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	successMessages.Inc()
+	return s.newPBAccount(res), nil
+}
+
+func (s *storeService) ListTransactions(ctx context.Context, in *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.ListTransactions")
+	defer span.Finish()
+	transactionsMessages.Inc()
+
+	if err := s.validate.VarCtx(ctx, in.GetId(), "uuid4"); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	var transactions []*pb.LedgerEntry
+
+	res, total, err := s.storeUsecase.ListTransactions(ctx, in.GetId(), int(in.GetLimit()), int(in.GetPage()))
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.ListTransactions: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+	for _, item := range res {
+		transactions = append(transactions, s.newPBLedgerEntry(item))
+	}
+
+	successMessages.Inc()
+	return &pb.ListTransactionsResponse{
+		Transactions: transactions,
+		Total:        total,
+	}, nil
+}
+
+func (s *storeService) ReconcileAccount(ctx context.Context, in *pb.StoreRequest) (*pb.Reconciliation, error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreService.ReconcileAccount")
+	defer span.Finish()
+	reconcileMessages.Inc()
+
+	if err := s.validate.VarCtx(ctx, in.GetId(), "uuid4"); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	res, err := s.storeUsecase.ReconcileAccount(ctx, in.GetId())
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.ReconcileAccount: %v", err)
+		errorMessages.Inc()
+		return nil, err
+	}
+
+	successMessages.Inc()
+	return &pb.Reconciliation{
+		AccountID:     res.AccountID,
+		Balance:       res.Balance.String(),
+		LedgerBalance: res.LedgerBalance.String(),
+		Consistent:    res.Consistent,
+	}, nil
+}
@@ -3,7 +3,10 @@ package service_test
 import (
 	"context"
 	"errors"
+	"io"
+	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
 	"github.com/EdlanioJ/kbu-store/app/infrastructure/grpc/pb"
@@ -12,8 +15,13 @@ import (
 	"github.com/EdlanioJ/kbu-store/app/utils/sample"
 	"github.com/go-playground/validator/v10"
 	"github.com/golang/protobuf/ptypes/empty"
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func Test_StoreGrpcService_Create(t *testing.T) {
@@ -150,6 +158,16 @@ func Test_StoreGrpcService_List(t *testing.T) {
 	t.Parallel()
 	arg := sample.NewPBListStoreRequest()
 	store := sample.NewStore()
+	tokenArg := sample.NewPBListStoreRequest()
+	tokenArg.PageToken = "token"
+	invalidArg := sample.NewPBListStoreRequest()
+	invalidArg.Status = "unknown"
+	filteredArg := sample.NewPBListStoreRequest()
+	filteredArg.CategoryID = store.CategoryID
+	filteredArg.AllTags = []string{"tag001"}
+	filteredArg.CreatedTo = timestamppb.New(store.CreatedAt)
+	openNowArg := sample.NewPBListStoreRequest()
+	openNowArg.OpenNow = true
 	testCases := []struct {
 		name        string
 		arg         *pb.ListStoreRequest
@@ -162,8 +180,37 @@ func Test_StoreGrpcService_List(t *testing.T) {
 			expectedErr: true,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
 				storeUsecase.
-					On("Index", mock.Anything, arg.Sort, int(arg.Limit), int(arg.Page)).
-					Return(nil, int64(0), errors.New("Unexpected Error"))
+					On("Index", mock.Anything, mock.Anything, arg.Sort, "", int(arg.Limit), int(arg.Page)).
+					Return(nil, errors.New("Unexpected Error"))
+			},
+		},
+		{
+			name:        "failure_validate_filter_returns_error",
+			arg:         invalidArg,
+			expectedErr: true,
+		},
+		{
+			name:        "success_filtered",
+			arg:         filteredArg,
+			expectedErr: false,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.
+					On("Index", mock.Anything, mock.MatchedBy(func(f *domain.StoreFilter) bool {
+						return f.CategoryID == store.CategoryID && len(f.AllTags) == 1 && f.CreatedTo.Equal(store.CreatedAt) && f.CreatedFrom.IsZero()
+					}), arg.Sort, "", int(arg.Limit), int(arg.Page)).
+					Return(&domain.StorePage{Stores: domain.Stores{store}, Total: 1}, nil)
+			},
+		},
+		{
+			name:        "success_open_now",
+			arg:         openNowArg,
+			expectedErr: false,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.
+					On("Index", mock.Anything, mock.MatchedBy(func(f *domain.StoreFilter) bool {
+						return !f.OpenAt.IsZero()
+					}), arg.Sort, "", int(arg.Limit), int(arg.Page)).
+					Return(&domain.StorePage{Stores: domain.Stores{store}, Total: 1}, nil)
 			},
 		},
 		{
@@ -175,8 +222,18 @@ func Test_StoreGrpcService_List(t *testing.T) {
 
 				stores = append(stores, store)
 				storeUsecase.
-					On("Index", mock.Anything, arg.Sort, int(arg.Limit), int(arg.Page)).
-					Return(stores, int64(1), nil)
+					On("Index", mock.Anything, mock.Anything, arg.Sort, "", int(arg.Limit), int(arg.Page)).
+					Return(&domain.StorePage{Stores: stores, Total: 1}, nil)
+			},
+		},
+		{
+			name:        "success_with_page_token",
+			arg:         tokenArg,
+			expectedErr: false,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.
+					On("Index", mock.Anything, mock.Anything, arg.Sort, "token", int(arg.Limit), int(arg.Page)).
+					Return(&domain.StorePage{Stores: domain.Stores{store}, Total: 1, NextCursor: "next"}, nil)
 			},
 		},
 	}
@@ -198,8 +255,149 @@ func Test_StoreGrpcService_List(t *testing.T) {
 			} else {
 				assert.NotNil(t, res)
 				assert.Equal(t, res.Total, int64(1))
+				assert.Equal(t, tc.arg.PageToken != "", res.NextPageToken == "next")
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_StoreGrpcService_ListNearby(t *testing.T) {
+	t.Parallel()
+	store := sample.NewStore()
+	testCases := []struct {
+		name        string
+		arg         *pb.ListNearbyRequest
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_validate_returns_error",
+			arg:         &pb.ListNearbyRequest{Latitude: -8.8383, Longitude: 13.2344},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_invalid_filter",
+			arg:         &pb.ListNearbyRequest{Latitude: -8.8383, Longitude: 13.2344, RadiusKm: 5, Status: "closed"},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         &pb.ListNearbyRequest{Latitude: -8.8383, Longitude: 13.2344, RadiusKm: 5},
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Nearby", mock.Anything, mock.Anything).
+					Return(nil, errors.New("Unexpected Error")).
+					Once()
+			},
+		},
+		{
+			name: "success",
+			arg: &pb.ListNearbyRequest{
+				Latitude:    -8.8383,
+				Longitude:   13.2344,
+				RadiusKm:    5,
+				Limit:       10,
+				Status:      domain.StoreStatusActive,
+				CreatedFrom: timestamppb.New(store.CreatedAt),
+			},
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Nearby", mock.Anything, mock.MatchedBy(func(req *domain.NearbyStoreRequest) bool {
+					return req.RadiusKm == 5 && req.Limit == 10 && req.Filter.Status == domain.StoreStatusActive && req.Filter.CreatedFrom.Equal(store.CreatedAt)
+				})).
+					Return(domain.NearbyStores{{Store: store, Distance: 1.2}}, nil).
+					Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			res, err := s.ListNearby(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res.GetStores(), 1)
+				assert.Equal(t, store.ID, res.GetStores()[0].GetStore().GetID())
+				assert.Equal(t, 1.2, res.GetStores()[0].GetDistanceKm())
+			}
+			usecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreGrpcService_Search(t *testing.T) {
+	t.Parallel()
+	store := sample.NewStore()
+	testCases := []struct {
+		name        string
+		arg         *pb.SearchStoreRequest
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_validate_returns_error",
+			arg:         &pb.SearchStoreRequest{Query: " "},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         &pb.SearchStoreRequest{Query: "coffee"},
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Search", mock.Anything, mock.Anything).
+					Return(nil, errors.New("Unexpected Error")).
+					Once()
+			},
+		},
+		{
+			name: "success",
+			arg:  &pb.SearchStoreRequest{Query: "coffee", Page: 2, Limit: 5, Status: domain.StoreStatusActive},
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Search", mock.Anything, mock.MatchedBy(func(req *domain.SearchStoreRequest) bool {
+					return req.Query == "coffee" && req.Page == 2 && req.Limit == 5 && req.Filter.Status == domain.StoreStatusActive
+				})).
+					Return(&domain.StoreSearchPage{
+						Results: domain.StoreSearchResults{{Store: store, Rank: 0.6, Snippet: "<b>coffee</b>"}},
+						Total:   6,
+					}, nil).
+					Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			res, err := s.Search(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, int64(6), res.GetTotal())
+				assert.Len(t, res.GetResults(), 1)
+				assert.Equal(t, store.ID, res.GetResults()[0].GetStore().GetID())
+				assert.Equal(t, "<b>coffee</b>", res.GetResults()[0].GetSnippet())
 			}
+			usecase.AssertExpectations(t)
 		})
 	}
 }
@@ -234,7 +432,9 @@ func Test_StoreGrpcService_Activate(t *testing.T) {
 			expectedErr: true,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
 				storeUsecase.
-					On("Active", mock.Anything, arg.GetId()).
+					On("ChangeStatus", mock.Anything, mock.MatchedBy(func(r *domain.StoreStatusRequest) bool {
+						return r.ID == arg.GetId() && r.Action == domain.StoreActionActivate
+					})).
 					Return(errors.New("Unexpected Error"))
 			},
 		},
@@ -244,7 +444,9 @@ func Test_StoreGrpcService_Activate(t *testing.T) {
 			expectedErr: false,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
 				storeUsecase.
-					On("Active", mock.Anything, arg.GetId()).
+					On("ChangeStatus", mock.Anything, mock.MatchedBy(func(r *domain.StoreStatusRequest) bool {
+						return r.ID == arg.GetId() && r.Action == domain.StoreActionActivate
+					})).
 					Return(nil)
 			},
 		},
@@ -274,15 +476,13 @@ func Test_StoreGrpcService_Activate(t *testing.T) {
 
 func Test_StoreGrpcService_Block(t *testing.T) {
 	t.Parallel()
-	arg := sample.NewPBStoreRequest()
-	emptyId := sample.NewPBStoreRequest()
-	emptyId.Id = ""
-	invalidId := sample.NewPBStoreRequest()
-	invalidId.Id = "invalid_id"
+	arg := &pb.StoreStatusRequest{Id: uuid.NewV4().String(), Reason: "fraudulent listings"}
+	emptyId := &pb.StoreStatusRequest{}
+	invalidId := &pb.StoreStatusRequest{Id: "invalid_id"}
 
 	testCases := []struct {
 		name        string
-		arg         *pb.StoreRequest
+		arg         *pb.StoreStatusRequest
 		prepare     func(storeUsecase *mocks.StoreUsecase)
 		expectedErr bool
 	}{
@@ -302,7 +502,9 @@ func Test_StoreGrpcService_Block(t *testing.T) {
 			expectedErr: true,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
 				storeUsecase.
-					On("Block", mock.Anything, arg.GetId()).
+					On("ChangeStatus", mock.Anything, mock.MatchedBy(func(r *domain.StoreStatusRequest) bool {
+						return r.ID == arg.GetId() && r.Action == domain.StoreActionBlock && r.Reason == arg.GetReason()
+					})).
 					Return(errors.New("Unexpected Error"))
 			},
 		},
@@ -312,7 +514,9 @@ func Test_StoreGrpcService_Block(t *testing.T) {
 			expectedErr: false,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
 				storeUsecase.
-					On("Block", mock.Anything, arg.GetId()).
+					On("ChangeStatus", mock.Anything, mock.MatchedBy(func(r *domain.StoreStatusRequest) bool {
+						return r.ID == arg.GetId() && r.Action == domain.StoreActionBlock && r.Reason == arg.GetReason()
+					})).
 					Return(nil)
 			},
 		},
@@ -342,15 +546,13 @@ func Test_StoreGrpcService_Block(t *testing.T) {
 
 func Test_StoreGrpcService_Disable(t *testing.T) {
 	t.Parallel()
-	arg := sample.NewPBStoreRequest()
-	emptyId := sample.NewPBStoreRequest()
-	emptyId.Id = ""
-	invalidId := sample.NewPBStoreRequest()
-	invalidId.Id = "invalid_id"
+	arg := &pb.StoreStatusRequest{Id: uuid.NewV4().String(), Reason: "fraudulent listings"}
+	emptyId := &pb.StoreStatusRequest{}
+	invalidId := &pb.StoreStatusRequest{Id: "invalid_id"}
 
 	testCases := []struct {
 		name        string
-		arg         *pb.StoreRequest
+		arg         *pb.StoreStatusRequest
 		prepare     func(storeUsecase *mocks.StoreUsecase)
 		expectedErr bool
 	}{
@@ -370,7 +572,9 @@ func Test_StoreGrpcService_Disable(t *testing.T) {
 			expectedErr: true,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
 				storeUsecase.
-					On("Disable", mock.Anything, arg.GetId()).
+					On("ChangeStatus", mock.Anything, mock.MatchedBy(func(r *domain.StoreStatusRequest) bool {
+						return r.ID == arg.GetId() && r.Action == domain.StoreActionDisable && r.Reason == arg.GetReason()
+					})).
 					Return(errors.New("Unexpected Error"))
 			},
 		},
@@ -380,7 +584,9 @@ func Test_StoreGrpcService_Disable(t *testing.T) {
 			expectedErr: false,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
 				storeUsecase.
-					On("Disable", mock.Anything, arg.GetId()).
+					On("ChangeStatus", mock.Anything, mock.MatchedBy(func(r *domain.StoreStatusRequest) bool {
+						return r.ID == arg.GetId() && r.Action == domain.StoreActionDisable && r.Reason == arg.GetReason()
+					})).
 					Return(nil)
 			},
 		},
@@ -413,6 +619,12 @@ func Test_StoreGrpcService_Update(t *testing.T) {
 	arg := sample.NewPBUpdateStoreRequest()
 	invalidArg := sample.NewPBUpdateStoreRequest()
 	invalidArg.ID = "invalid_id"
+	versionedArg := sample.NewPBUpdateStoreRequest()
+	versionedArg.Version = 2
+	maskedArg := sample.NewPBUpdateStoreRequest()
+	maskedArg.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"name", "image"}}
+	invalidMaskArg := sample.NewPBUpdateStoreRequest()
+	invalidMaskArg.UpdateMask = &fieldmaskpb.FieldMask{Paths: []string{"status"}}
 
 	testCases := []struct {
 		name        string
@@ -435,7 +647,35 @@ func Test_StoreGrpcService_Update(t *testing.T) {
 					Return(errors.New("Unexpected Error"))
 			},
 		},
-
+		{
+			name:        "failure_unknown_update_mask_path",
+			arg:         invalidMaskArg,
+			expectedErr: true,
+		},
+		{
+			name:        "success_with_update_mask",
+			arg:         maskedArg,
+			expectedErr: false,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.
+					On("Update", mock.Anything, mock.MatchedBy(func(ur *domain.UpdateStoreRequest) bool {
+						return *ur.Name == maskedArg.Name && ur.Description == nil && ur.Tags == nil && ur.Lat == nil
+					})).
+					Return(nil)
+			},
+		},
+		{
+			name:        "failure_version_conflict",
+			arg:         versionedArg,
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.
+					On("Update", mock.Anything, mock.MatchedBy(func(ur *domain.UpdateStoreRequest) bool {
+						return ur.Version == 2
+					})).
+					Return(domain.ErrConflict)
+			},
+		},
 		{
 			name:        "success",
 			arg:         arg,
@@ -470,6 +710,178 @@ func Test_StoreGrpcService_Update(t *testing.T) {
 	}
 }
 
+// uploadImageStream is the server side of an UploadImage call sending in
+type uploadImageStream struct {
+	grpc.ServerStream
+	in  []*pb.UploadImageRequest
+	res *pb.Store
+}
+
+func (s *uploadImageStream) Context() context.Context {
+	return context.TODO()
+}
+
+func (s *uploadImageStream) Recv() (*pb.UploadImageRequest, error) {
+	if len(s.in) == 0 {
+		return nil, io.EOF
+	}
+	in := s.in[0]
+	s.in = s.in[1:]
+	return in, nil
+}
+
+func (s *uploadImageStream) SendAndClose(res *pb.Store) error {
+	s.res = res
+	return nil
+}
+
+func Test_StoreGrpcService_UploadImage(t *testing.T) {
+	t.Parallel()
+	info := &pb.ImageInfo{StoreID: uuid.NewV4().String(), ContentType: "image/jpeg", Size: 10}
+
+	testCases := []struct {
+		name        string
+		in          []*pb.UploadImageRequest
+		expectedErr bool
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:        "failure_empty_stream",
+			expectedErr: true,
+		},
+		{
+			name:        "failure_missing_info",
+			in:          []*pb.UploadImageRequest{{Chunk: []byte("image")}},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_invalid_id",
+			in:          []*pb.UploadImageRequest{{Info: &pb.ImageInfo{StoreID: "invalid_id"}}},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			in:          []*pb.UploadImageRequest{{Info: info}, {Chunk: []byte("image")}},
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("UploadImage", mock.Anything, mock.Anything).Return(nil, domain.ErrUnsupportedImage).Once()
+			},
+		},
+		{
+			name: "success",
+			in: []*pb.UploadImageRequest{
+				{Info: info, Chunk: []byte("ima")},
+				{Chunk: []byte("ge")},
+				{Chunk: []byte(" data")},
+			},
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				store := sample.NewStore()
+				store.Images = domain.StoreImages{domain.StoreImageOriginal: "stores/1/2/original.jpg"}
+				storeUsecase.On("UploadImage", mock.Anything, mock.MatchedBy(func(param *domain.StoreImageRequest) bool {
+					data, err := ioutil.ReadAll(param.Body)
+					return err == nil && string(data) == "image data" && param.ID == info.StoreID && param.ContentType == "image/jpeg" && param.Size == 10
+				})).Return(store, nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			stream := &uploadImageStream{in: tc.in}
+			err := s.UploadImage(stream)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, stream.res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "stores/1/2/original.jpg", stream.res.GetImages()[domain.StoreImageOriginal])
+			}
+			usecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreGrpcService_SetHours(t *testing.T) {
+	t.Parallel()
+	arg := &pb.SetHoursRequest{
+		Id:       uuid.NewV4().String(),
+		Timezone: "Africa/Luanda",
+		Weekly: []*pb.OpeningHours{
+			{Weekday: 1, Opens: "09:00", Closes: "18:00"},
+			{Weekday: 5, Opens: "20:00", Closes: "02:00"},
+		},
+		Closures: []*pb.StoreClosure{{From: "2021-12-25", Reason: "Christmas"}},
+	}
+	invalidId := &pb.SetHoursRequest{Id: "invalid_id", Timezone: arg.Timezone}
+	store := sample.NewStore()
+	store.SetHours(sample.NewStoreHours(), time.Now())
+
+	testCases := []struct {
+		name        string
+		arg         *pb.SetHoursRequest
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_invalid_id",
+			arg:         invalidId,
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         arg,
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.
+					On("SetHours", mock.Anything, mock.Anything).
+					Return(nil, domain.ErrInvalidTimezone)
+			},
+		},
+		{
+			name: "success",
+			arg:  arg,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.
+					On("SetHours", mock.Anything, mock.MatchedBy(func(req *domain.StoreHoursRequest) bool {
+						return req.ID == arg.Id && req.Timezone == arg.Timezone && len(req.Weekly) == 2 && req.Weekly[1].Closes == "02:00" && len(req.Closures) == 1 && req.Closures[0].Reason == "Christmas"
+					})).
+					Return(store, nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			res, err := s.SetHours(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, res.GetIsOpenNow())
+				assert.Equal(t, "Africa/Luanda", res.GetHours().GetTimezone())
+				assert.Len(t, res.GetHours().GetWeekly(), 7)
+			}
+		})
+	}
+}
+
 func Test_StoreGrpcService_Delete(t *testing.T) {
 	t.Parallel()
 	arg := sample.NewPBStoreRequest()
@@ -537,3 +949,508 @@ func Test_StoreGrpcService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func Test_StoreGrpcService_Restore(t *testing.T) {
+	t.Parallel()
+	arg := sample.NewPBStoreRequest()
+	emptyId := sample.NewPBStoreRequest()
+	emptyId.Id = ""
+	invalidId := sample.NewPBStoreRequest()
+	invalidId.Id = "invalid_id"
+	testCases := []struct {
+		name          string
+		arg           *pb.StoreRequest
+		prepare       func(storeUsecase *mocks.StoreUsecase)
+		expectedErr   bool
+		checkResponse func(t *testing.T, res *empty.Empty, err error)
+	}{
+		{
+			name:        "failure_empty_id",
+			arg:         emptyId,
+			expectedErr: true,
+		},
+		{
+			name:        "failure_invalid_id",
+			arg:         invalidId,
+			expectedErr: true,
+		},
+		{
+			name:        "failure_store_not_deleted",
+			arg:         arg,
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.
+					On("Restore", mock.Anything, arg.GetId()).
+					Return(domain.ErrNotFound)
+			},
+		},
+		{
+			name:        "success",
+			arg:         arg,
+			expectedErr: false,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.
+					On("Restore", mock.Anything, arg.GetId()).
+					Return(nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			res, err := s.Restore(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Nil(t, res)
+				assert.Error(t, err)
+			} else {
+				assert.NotNil(t, res)
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_StoreGrpcService_GetAccount(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		arg         *pb.StoreRequest
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_validate_returns_error",
+			arg:         &pb.StoreRequest{Id: "invalid_id"},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         sample.NewPBStoreRequest(),
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("GetAccount", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound)
+			},
+		},
+		{
+			name: "success",
+			arg:  sample.NewPBStoreRequest(),
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("GetAccount", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			res, err := s.GetAccount(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "1000", res.Balance)
+			}
+		})
+	}
+}
+
+func Test_StoreGrpcService_Deposit(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		arg         *pb.AccountOperationRequest
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+		expectedErr error
+	}{
+		{
+			name:        "failure_invalid_amount",
+			arg:         &pb.AccountOperationRequest{Id: uuid.NewV4().String(), Amount: "ten"},
+			expectedErr: domain.ErrInvalidAmount,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         &pb.AccountOperationRequest{Id: uuid.NewV4().String(), Amount: "10.5"},
+			expectedErr: domain.ErrNotFound,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Deposit", mock.Anything, mock.Anything).Return(nil, domain.ErrNotFound)
+			},
+		},
+		{
+			name: "success",
+			arg:  &pb.AccountOperationRequest{Id: uuid.NewV4().String(), Amount: "10.5"},
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Deposit", mock.Anything, mock.MatchedBy(func(ar *domain.AccountOperationRequest) bool {
+					return ar.Amount.Equal(decimal.RequireFromString("10.5"))
+				})).Return(sample.NewAccount(), nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			res, err := s.Deposit(context.TODO(), tc.arg)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, res)
+			}
+			usecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreGrpcService_Withdraw(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		arg         *pb.AccountOperationRequest
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_validate_returns_error",
+			arg:         &pb.AccountOperationRequest{Id: "invalid_id", Amount: "10.5"},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         &pb.AccountOperationRequest{Id: uuid.NewV4().String(), Amount: "10.5"},
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Withdraw", mock.Anything, mock.Anything).Return(nil, domain.ErrInsufficientBalance)
+			},
+		},
+		{
+			name: "success",
+			arg:  &pb.AccountOperationRequest{Id: uuid.NewV4().String(), Amount: "10.5"},
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Withdraw", mock.Anything, mock.Anything).Return(sample.NewAccount(), nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			res, err := s.Withdraw(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, res)
+			}
+			usecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreGrpcService_ListTransactions(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		arg         *pb.ListTransactionsRequest
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_validate_returns_error",
+			arg:         &pb.ListTransactionsRequest{Id: "invalid_id"},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         &pb.ListTransactionsRequest{Id: uuid.NewV4().String(), Page: 1, Limit: 10},
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("ListTransactions", mock.Anything, mock.AnythingOfType("string"), 10, 1).Return(nil, int64(0), domain.ErrNotFound)
+			},
+		},
+		{
+			name: "success",
+			arg:  &pb.ListTransactionsRequest{Id: uuid.NewV4().String(), Page: 1, Limit: 10},
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				entries := domain.LedgerEntries{sample.NewLedgerEntry()}
+				storeUsecase.On("ListTransactions", mock.Anything, mock.AnythingOfType("string"), 10, 1).Return(entries, int64(1), nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			res, err := s.ListTransactions(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res.Transactions, 1)
+				assert.Equal(t, int64(1), res.Total)
+			}
+		})
+	}
+}
+
+func Test_StoreGrpcService_ChangeStatus(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		arg         *pb.StoreStatusRequest
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_invalid_id",
+			arg:         &pb.StoreStatusRequest{Id: "invalid_id", Action: domain.StoreActionApprove},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_empty_action",
+			arg:         &pb.StoreStatusRequest{Id: uuid.NewV4().String()},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         &pb.StoreStatusRequest{Id: uuid.NewV4().String(), Action: domain.StoreActionUnblock},
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				err := &domain.TransitionError{Action: domain.StoreActionUnblock, Status: domain.StoreStatusActive}
+				storeUsecase.On("ChangeStatus", mock.Anything, mock.AnythingOfType("*domain.StoreStatusRequest")).Return(err)
+			},
+		},
+		{
+			name: "success",
+			arg:  &pb.StoreStatusRequest{Id: uuid.NewV4().String(), Action: domain.StoreActionReject, Reason: "incomplete"},
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("ChangeStatus", mock.Anything, mock.MatchedBy(func(r *domain.StoreStatusRequest) bool {
+					return r.Action == domain.StoreActionReject && r.Reason == "incomplete"
+				})).Return(nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			res, err := s.ChangeStatus(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Nil(t, res)
+				assert.Error(t, err)
+			} else {
+				assert.NotNil(t, res)
+				assert.NoError(t, err)
+			}
+			usecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreGrpcService_ListActions(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		arg         *pb.StoreRequest
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_validate_returns_error",
+			arg:         &pb.StoreRequest{Id: "invalid_id"},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         sample.NewPBStoreRequest(),
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Actions", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound)
+			},
+		},
+		{
+			name: "success",
+			arg:  sample.NewPBStoreRequest(),
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				actions := domain.StoreLifecycle.From(domain.StoreStatusPending)
+				storeUsecase.On("Actions", mock.Anything, mock.AnythingOfType("string")).Return(actions, nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			res, err := s.ListActions(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res.Actions, 2)
+				assert.Equal(t, domain.StoreActionApprove, res.Actions[0].Action)
+				assert.True(t, res.Actions[1].ReasonRequired)
+			}
+		})
+	}
+}
+
+func Test_StoreGrpcService_GetStatusHistory(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		arg         *pb.ListStatusHistoryRequest
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_validate_returns_error",
+			arg:         &pb.ListStatusHistoryRequest{Id: "invalid_id"},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         &pb.ListStatusHistoryRequest{Id: uuid.NewV4().String(), Page: 1, Limit: 10},
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("StatusHistory", mock.Anything, mock.AnythingOfType("string"), 10, 1).Return(nil, int64(0), domain.ErrNotFound)
+			},
+		},
+		{
+			name: "success",
+			arg:  &pb.ListStatusHistoryRequest{Id: uuid.NewV4().String(), Page: 1, Limit: 10},
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				history := domain.StoreStatusHistory{sample.NewStoreStatusChange()}
+				storeUsecase.On("StatusHistory", mock.Anything, mock.AnythingOfType("string"), 10, 1).Return(history, int64(1), nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			res, err := s.GetStatusHistory(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res.Changes, 1)
+				assert.Equal(t, int64(1), res.Total)
+			}
+		})
+	}
+}
+
+func Test_StoreGrpcService_ReconcileAccount(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		arg         *pb.StoreRequest
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+		expectedErr bool
+	}{
+		{
+			name:        "failure_validate_returns_error",
+			arg:         &pb.StoreRequest{Id: "invalid_id"},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_usecase_returns_error",
+			arg:         sample.NewPBStoreRequest(),
+			expectedErr: true,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("ReconcileAccount", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound)
+			},
+		},
+		{
+			name: "success",
+			arg:  sample.NewPBStoreRequest(),
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				account := sample.NewAccount()
+				storeUsecase.On("ReconcileAccount", mock.Anything, mock.AnythingOfType("string")).Return(domain.NewReconciliation(account, account.Balance), nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			usecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(usecase)
+			}
+			validate := validator.New()
+			s := service.NewStoreServer(usecase, validate)
+			res, err := s.ReconcileAccount(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, res.Consistent)
+			}
+		})
+	}
+}
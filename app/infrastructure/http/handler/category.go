@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	"github.com/opentracing/opentracing-go"
+	log "github.com/sirupsen/logrus"
+)
+
+type categoryHandler struct {
+	categoryUsecase domain.CategoryUsecase
+	validate        *validator.Validate
+}
+
+func NewCategoryHandler(usecase domain.CategoryUsecase, validate *validator.Validate) *categoryHandler {
+	return &categoryHandler{
+		categoryUsecase: usecase,
+		validate:        validate,
+	}
+}
+
+// @Summary Index categories
+// @Description Get list of categories
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param page query int false "Page" default(1)
+// @Param limit query int false "Limit" default(10)
+// @Param status query string false "Status" Enums(pending, active, disable)
+// @Success 200 {array} domain.Category
+// @Header 200 {integer} X-total "filtered total"
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Router /categories [get]
+func (h *categoryHandler) Index(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "CategoryHandler.Index")
+	defer span.Finish()
+	categoryIndexRequests.Inc()
+
+	status := c.Query("status")
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	err := h.validate.VarCtx(ctx, status, "omitempty,oneof=pending active disable")
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	list, total, err := h.categoryUsecase.List(ctx, status, limit, page)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("categoryUsecase.List: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	c.Response().Header.Add("X-total", fmt.Sprint(total))
+	successRequests.Inc()
+	return c.JSON(list)
+}
+
+// @Summary Category tree
+// @Description Get every category, arranged under its parent
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Success 200 {array} domain.CategoryNode
+// @Failure 500 {object} ErrorResponse
+// @Router /categories/tree [get]
+func (h *categoryHandler) Tree(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "CategoryHandler.Tree")
+	defer span.Finish()
+	categoryTreeRequests.Inc()
+
+	res, err := h.categoryUsecase.Tree(ctx)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("categoryUsecase.Tree: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.JSON(res)
+}
+
+// @Summary Get category
+// @Description Get a category by id
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "category ID"
+// @Success 200 {object} domain.Category
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Router /categories/{id} [get]
+func (h *categoryHandler) Get(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "CategoryHandler.Get")
+	defer span.Finish()
+	categoryGetRequests.Inc()
+
+	id := c.Params("id")
+
+	err := h.validate.VarCtx(ctx, id, "uuid4")
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	res, err := h.categoryUsecase.Get(ctx, id)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("categoryUsecase.Get: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.JSON(res)
+}
+
+// @Summary Activate category
+// @Description Activate a category, resuming the stores suspended with it when configured to
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "category ID"
+// @Success 204
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /categories/{id}/activate [patch]
+func (h *categoryHandler) Activate(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "CategoryHandler.Activate")
+	defer span.Finish()
+	categoryActivateRequests.Inc()
+
+	id := c.Params("id")
+
+	err := h.validate.VarCtx(ctx, id, "uuid4")
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	err = h.categoryUsecase.Activate(ctx, id)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("categoryUsecase.Activate: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Disable category
+// @Description Disable a category, suspending its active stores when configured to
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "category ID"
+// @Success 204
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /categories/{id}/disable [patch]
+func (h *categoryHandler) Disable(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "CategoryHandler.Disable")
+	defer span.Finish()
+	categoryDisableRequests.Inc()
+
+	id := c.Params("id")
+
+	err := h.validate.VarCtx(ctx, id, "uuid4")
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	err = h.categoryUsecase.Disable(ctx, id)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("categoryUsecase.Disable: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// @Summary Delete category
+// @Description Delete a category no store belongs to
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "category ID"
+// @Success 204
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /categories/{id} [delete]
+func (h *categoryHandler) Delete(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "CategoryHandler.Delete")
+	defer span.Finish()
+	categoryDeleteRequests.Inc()
+
+	id := c.Params("id")
+
+	err := h.validate.VarCtx(ctx, id, "uuid4")
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	err = h.categoryUsecase.Delete(ctx, id)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("categoryUsecase.Delete: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.SendStatus(fiber.StatusNoContent)
+}
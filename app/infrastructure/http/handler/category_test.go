@@ -0,0 +1,333 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/http/handler"
+	"github.com/EdlanioJ/kbu-store/app/utils/mocks"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	uuid "github.com/satori/go.uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_CategoryHandler_Index(t *testing.T) {
+	testCases := []struct {
+		name       string
+		query      string
+		statusCode int
+		prepare    func(categoryUsecase *mocks.CategoryUsecase)
+	}{
+		{
+			name:       "failure_invalid_status",
+			query:      "status=block",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			statusCode: fiber.StatusInternalServerError,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("List", mock.Anything, "", 0, 0).Return(nil, int64(0), errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:       "success",
+			query:      "status=active&page=2&limit=5",
+			statusCode: fiber.StatusOK,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("List", mock.Anything, domain.CategoryStatusActive, 5, 2).Return(domain.Categories{sample.NewCategory()}, int64(6), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryUsecase := new(mocks.CategoryUsecase)
+			if tc.prepare != nil {
+				tc.prepare(categoryUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewCategoryHandler(categoryUsecase, validator)
+			app.Get("/", handler.Index)
+			req := httptest.NewRequest(fiber.MethodGet, "/?"+tc.query, nil)
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+			if res.StatusCode == fiber.StatusOK {
+				assert.Equal(t, "6", res.Header.Get("X-total"))
+			}
+			categoryUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryHandler_Tree(t *testing.T) {
+	testCases := []struct {
+		name       string
+		statusCode int
+		prepare    func(categoryUsecase *mocks.CategoryUsecase)
+	}{
+		{
+			name:       "failure_usecase_returns_error",
+			statusCode: fiber.StatusInternalServerError,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Tree", mock.Anything).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:       "success",
+			statusCode: fiber.StatusOK,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				parent := sample.NewCategory()
+				child := sample.NewCategory()
+				child.ParentID = &parent.ID
+				tree := domain.NewCategoryTree(domain.Categories{parent, child})
+				categoryUsecase.On("Tree", mock.Anything).Return(tree, nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryUsecase := new(mocks.CategoryUsecase)
+			tc.prepare(categoryUsecase)
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewCategoryHandler(categoryUsecase, validator)
+			app.Get("/tree", handler.Tree)
+			req := httptest.NewRequest(fiber.MethodGet, "/tree", nil)
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+			if res.StatusCode == fiber.StatusOK {
+				var tree []map[string]interface{}
+				assert.NoError(t, json.NewDecoder(res.Body).Decode(&tree))
+				assert.Len(t, tree, 1)
+				assert.Len(t, tree[0]["children"], 1)
+			}
+			categoryUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryHandler_Get(t *testing.T) {
+	testCases := []struct {
+		name       string
+		arg        string
+		statusCode int
+		prepare    func(categoryUsecase *mocks.CategoryUsecase)
+	}{
+		{
+			name:       "failure_invalid_id",
+			arg:        "invalid_id",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusNotFound,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:       "success",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusOK,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewCategory(), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryUsecase := new(mocks.CategoryUsecase)
+			if tc.prepare != nil {
+				tc.prepare(categoryUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewCategoryHandler(categoryUsecase, validator)
+			app.Get("/:id", handler.Get)
+			req := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/%s", tc.arg), nil)
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+			categoryUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryHandler_Activate(t *testing.T) {
+	testCases := []struct {
+		name       string
+		arg        string
+		statusCode int
+		prepare    func(categoryUsecase *mocks.CategoryUsecase)
+	}{
+		{
+			name:       "failure_invalid_id",
+			arg:        "invalid_id",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusNotFound,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Activate", mock.Anything, mock.AnythingOfType("string")).Return(domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:       "success",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusNoContent,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Activate", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryUsecase := new(mocks.CategoryUsecase)
+			if tc.prepare != nil {
+				tc.prepare(categoryUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewCategoryHandler(categoryUsecase, validator)
+			app.Patch("/:id/activate", handler.Activate)
+			req := httptest.NewRequest(fiber.MethodPatch, fmt.Sprintf("/%s/activate", tc.arg), nil)
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+			categoryUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryHandler_Disable(t *testing.T) {
+	testCases := []struct {
+		name       string
+		arg        string
+		statusCode int
+		prepare    func(categoryUsecase *mocks.CategoryUsecase)
+	}{
+		{
+			name:       "failure_invalid_id",
+			arg:        "invalid_id",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusInternalServerError,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Disable", mock.Anything, mock.AnythingOfType("string")).Return(errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:       "success",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusNoContent,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Disable", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryUsecase := new(mocks.CategoryUsecase)
+			if tc.prepare != nil {
+				tc.prepare(categoryUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewCategoryHandler(categoryUsecase, validator)
+			app.Patch("/:id/disable", handler.Disable)
+			req := httptest.NewRequest(fiber.MethodPatch, fmt.Sprintf("/%s/disable", tc.arg), nil)
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+			categoryUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryHandler_Delete(t *testing.T) {
+	testCases := []struct {
+		name       string
+		arg        string
+		statusCode int
+		prepare    func(categoryUsecase *mocks.CategoryUsecase)
+	}{
+		{
+			name:       "failure_invalid_id",
+			arg:        "invalid_id",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusConflict,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(domain.ErrInUse).Once()
+			},
+		},
+		{
+			name:       "success",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusNoContent,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryUsecase := new(mocks.CategoryUsecase)
+			if tc.prepare != nil {
+				tc.prepare(categoryUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewCategoryHandler(categoryUsecase, validator)
+			app.Delete("/:id", handler.Delete)
+			req := httptest.NewRequest(fiber.MethodDelete, fmt.Sprintf("/%s", tc.arg), nil)
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+			categoryUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -34,14 +34,101 @@ func getHttpError(err error) HttpError {
 			Status: fiber.StatusNotFound,
 			Error:  ErrorResponse{Message: domain.ErrNotFound.Error()},
 		}
+	case errors.Is(err, domain.ErrUnauthorized):
+		return HttpError{
+			Status: fiber.StatusUnauthorized,
+			Error:  ErrorResponse{Message: domain.ErrUnauthorized.Error()},
+		}
+	case errors.Is(err, domain.ErrForbidden):
+		return HttpError{
+			Status: fiber.StatusForbidden,
+			Error:  ErrorResponse{Message: err.Error()},
+		}
 	case errors.Is(err, domain.ErrActived),
-		errors.Is(err, domain.ErrBlocked),
 		errors.Is(err, domain.ErrInactived),
-		errors.Is(err, domain.ErrPending):
+		errors.Is(err, domain.ErrInvalidTransition),
+		errors.Is(err, domain.ErrConflict),
+		errors.Is(err, domain.ErrInUse):
 		return HttpError{
 			Status: fiber.StatusConflict,
 			Error:  ErrorResponse{Message: err.Error()},
 		}
+	case errors.Is(err, domain.ErrUnsupportedImage):
+		return HttpError{
+			Status: fiber.StatusUnsupportedMediaType,
+			Error:  ErrorResponse{Message: err.Error(), Field: "image"},
+		}
+	case errors.Is(err, domain.ErrImageTooLarge):
+		return HttpError{
+			Status: fiber.StatusRequestEntityTooLarge,
+			Error:  ErrorResponse{Message: err.Error(), Field: "image"},
+		}
+	case errors.Is(err, domain.ErrInvalidAmount):
+		return HttpError{
+			Status: fiber.StatusBadRequest,
+			Error:  ErrorResponse{Message: err.Error(), Field: "amount"},
+		}
+	case errors.Is(err, domain.ErrInvalidSort):
+		return HttpError{
+			Status: fiber.StatusBadRequest,
+			Error:  ErrorResponse{Message: err.Error(), Field: "sort"},
+		}
+	case errors.Is(err, domain.ErrCategoryCycle):
+		return HttpError{
+			Status: fiber.StatusBadRequest,
+			Error:  ErrorResponse{Message: err.Error(), Field: "parent_id"},
+		}
+	case errors.Is(err, domain.ErrReasonRequired):
+		return HttpError{
+			Status: fiber.StatusBadRequest,
+			Error:  ErrorResponse{Message: err.Error(), Field: "reason"},
+		}
+	case errors.Is(err, domain.ErrUnknownAction):
+		return HttpError{
+			Status: fiber.StatusBadRequest,
+			Error:  ErrorResponse{Message: err.Error(), Field: "action"},
+		}
+	case errors.Is(err, domain.ErrInvalidExpiry),
+		errors.Is(err, domain.ErrNotExpirable):
+		return HttpError{
+			Status: fiber.StatusBadRequest,
+			Error:  ErrorResponse{Message: err.Error(), Field: "expires_at"},
+		}
+	case errors.Is(err, domain.ErrInvalidTimezone):
+		return HttpError{
+			Status: fiber.StatusBadRequest,
+			Error:  ErrorResponse{Message: err.Error(), Field: "timezone"},
+		}
+	case errors.Is(err, domain.ErrInvalidHours):
+		return HttpError{
+			Status: fiber.StatusBadRequest,
+			Error:  ErrorResponse{Message: err.Error(), Field: "hours"},
+		}
+	case errors.Is(err, domain.ErrInvalidCursor):
+		return HttpError{
+			Status: fiber.StatusBadRequest,
+			Error:  ErrorResponse{Message: err.Error(), Field: "cursor"},
+		}
+	case errors.Is(err, domain.ErrUnknownFormat):
+		return HttpError{
+			Status: fiber.StatusBadRequest,
+			Error:  ErrorResponse{Message: err.Error(), Field: "format"},
+		}
+	case errors.Is(err, domain.ErrInvalidImportHeader):
+		return HttpError{
+			Status: fiber.StatusBadRequest,
+			Error:  ErrorResponse{Message: err.Error()},
+		}
+	case errors.Is(err, domain.ErrInsufficientBalance):
+		return HttpError{
+			Status: fiber.StatusUnprocessableEntity,
+			Error:  ErrorResponse{Message: err.Error()},
+		}
+	case errors.Is(err, domain.ErrBadRequest):
+		return HttpError{
+			Status: fiber.StatusBadRequest,
+			Error:  ErrorResponse{Message: err.Error()},
+		}
 	case strings.Contains(strings.ToLower(err.Error()), "json"):
 		return HttpError{
 			Status: fiber.StatusBadRequest,
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// etag returns the entity tag of an entity version
+func etag(version int) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
+
+// parseETag returns the entity version held by an entity tag
+func parseETag(tag string) (int, error) {
+	tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+	return strconv.Atoi(strings.Trim(tag, `"`))
+}
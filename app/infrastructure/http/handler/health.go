@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/gofiber/fiber/v2"
+)
+
+type healthHandler struct {
+	healthUsecase domain.HealthUsecase
+}
+
+func NewHealthHandler(usecase domain.HealthUsecase) *healthHandler {
+	return &healthHandler{
+		healthUsecase: usecase,
+	}
+}
+
+// Liveness reports the process is up, without checking its dependencies
+func (h *healthHandler) Liveness(c *fiber.Ctx) error {
+	return c.JSON(fiber.Map{"status": domain.HealthStatusUp})
+}
+
+// Readiness reports the health of each dependency, with 503 Service
+// Unavailable when one of them is down
+func (h *healthHandler) Readiness(c *fiber.Ctx) error {
+	report := h.healthUsecase.Readiness(c.Context())
+	if !report.Up() {
+		return c.Status(fiber.StatusServiceUnavailable).JSON(report)
+	}
+	return c.JSON(report)
+}
@@ -0,0 +1,79 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/http/handler"
+	"github.com/EdlanioJ/kbu-store/app/utils/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_HealthHandler_Liveness(t *testing.T) {
+	healthUsecase := new(mocks.HealthUsecase)
+	app := fiber.New()
+	handler := handler.NewHealthHandler(healthUsecase)
+	app.Get("/healthz", handler.Liveness)
+	req := httptest.NewRequest(fiber.MethodGet, "/healthz", nil)
+	res, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, fiber.StatusOK, res.StatusCode)
+	healthUsecase.AssertExpectations(t)
+}
+
+func Test_HealthHandler_Readiness(t *testing.T) {
+	testCases := []struct {
+		name       string
+		report     *domain.HealthReport
+		statusCode int
+	}{
+		{
+			name: "dependency_down",
+			report: &domain.HealthReport{
+				Status: domain.HealthStatusDown,
+				Dependencies: map[string]domain.DependencyHealth{
+					"postgres": {Status: domain.HealthStatusUp},
+					"kafka":    {Status: domain.HealthStatusDown, Error: "connection refused"},
+				},
+			},
+			statusCode: fiber.StatusServiceUnavailable,
+		},
+		{
+			name: "success",
+			report: &domain.HealthReport{
+				Status: domain.HealthStatusUp,
+				Dependencies: map[string]domain.DependencyHealth{
+					"postgres": {Status: domain.HealthStatusUp},
+					"kafka":    {Status: domain.HealthStatusUp},
+				},
+			},
+			statusCode: fiber.StatusOK,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			healthUsecase := new(mocks.HealthUsecase)
+			healthUsecase.On("Readiness", mock.Anything).Return(tc.report).Once()
+			app := fiber.New()
+			handler := handler.NewHealthHandler(healthUsecase)
+			app.Get("/readyz", handler.Readiness)
+			req := httptest.NewRequest(fiber.MethodGet, "/readyz", nil)
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+
+			var report domain.HealthReport
+			assert.NoError(t, json.NewDecoder(res.Body).Decode(&report))
+			assert.Equal(t, tc.report.Status, report.Status)
+			assert.Len(t, report.Dependencies, len(tc.report.Dependencies))
+			healthUsecase.AssertExpectations(t)
+		})
+	}
+}
@@ -26,24 +26,100 @@ var (
 		Name: "http_stores_index_incoming_requests_total",
 		Help: "The total number of incoming index store HTTP requests",
 	})
-	ativateRequests = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "http_stores_activate_incoming_requests_total",
-		Help: "The total number of incoming activate store HTTP requests",
+	nearbyRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_nearby_incoming_requests_total",
+		Help: "The total number of incoming nearby store HTTP requests",
 	})
-	blockRequests = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "http_stores_block_incoming_requests_total",
-		Help: "The total number of incoming block store HTTP requests",
+	searchRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_search_incoming_requests_total",
+		Help: "The total number of incoming search store HTTP requests",
 	})
-	disableRequests = promauto.NewCounter(prometheus.CounterOpts{
-		Name: "http_stores_disable_incoming_requests_total",
-		Help: "The total number of incoming disable store HTTP requests",
+	statusRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_stores_status_incoming_requests_total",
+		Help: "The total number of incoming store status change HTTP requests, by action",
+	}, []string{"action"})
+	actionsRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_actions_incoming_requests_total",
+		Help: "The total number of incoming store actions HTTP requests",
+	})
+	historyRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_history_incoming_requests_total",
+		Help: "The total number of incoming store status history HTTP requests",
 	})
 	deleteRequests = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "http_stores_delete_incoming_requests_total",
 		Help: "The total number of incoming delete store HTTP requests",
 	})
+	restoreRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_restore_incoming_requests_total",
+		Help: "The total number of incoming restore store HTTP requests",
+	})
+	importRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_import_incoming_requests_total",
+		Help: "The total number of incoming import stores HTTP requests",
+	})
+	exportRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_export_incoming_requests_total",
+		Help: "The total number of incoming export stores HTTP requests",
+	})
 	updateRequests = promauto.NewCounter(prometheus.CounterOpts{
 		Name: "http_stores_update_incoming_requests_total",
 		Help: "The total number of incoming update store HTTP requests",
 	})
+	imageRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_image_incoming_requests_total",
+		Help: "The total number of incoming upload store image HTTP requests",
+	})
+	hoursRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_hours_incoming_requests_total",
+		Help: "The total number of incoming set store opening hours HTTP requests",
+	})
+	accountRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_account_incoming_requests_total",
+		Help: "The total number of incoming get store account HTTP requests",
+	})
+	depositRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_deposit_incoming_requests_total",
+		Help: "The total number of incoming deposit store HTTP requests",
+	})
+	withdrawRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_withdraw_incoming_requests_total",
+		Help: "The total number of incoming withdraw store HTTP requests",
+	})
+	transactionsRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_transactions_incoming_requests_total",
+		Help: "The total number of incoming list store transactions HTTP requests",
+	})
+	reconcileRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_stores_reconcile_incoming_requests_total",
+		Help: "The total number of incoming reconcile store account HTTP requests",
+	})
+	categoryIndexRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_categories_index_incoming_requests_total",
+		Help: "The total number of incoming index category HTTP requests",
+	})
+	categoryTreeRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_categories_tree_incoming_requests_total",
+		Help: "The total number of incoming category tree HTTP requests",
+	})
+	categoryStoresRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_categories_stores_incoming_requests_total",
+		Help: "The total number of incoming index category stores HTTP requests",
+	})
+	categoryGetRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_categories_get_incoming_requests_total",
+		Help: "The total number of incoming get by id category HTTP requests",
+	})
+	categoryActivateRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_categories_activate_incoming_requests_total",
+		Help: "The total number of incoming activate category HTTP requests",
+	})
+	categoryDisableRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_categories_disable_incoming_requests_total",
+		Help: "The total number of incoming disable category HTTP requests",
+	})
+	categoryDeleteRequests = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "http_categories_delete_incoming_requests_total",
+		Help: "The total number of incoming delete category HTTP requests",
+	})
 )
@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/gofiber/fiber/v2"
+)
+
+// newStoreFilter reads the store listing filter from the query string
+func newStoreFilter(c *fiber.Ctx) (filter *domain.StoreFilter, err error) {
+	filter = &domain.StoreFilter{
+		Status:     c.Query("status"),
+		CategoryID: c.Query("category_id"),
+		UserID:     c.Query("user_id"),
+		AnyTags:    splitQuery(c.Query("tags")),
+		AllTags:    splitQuery(c.Query("all_tags")),
+		NamePrefix: c.Query("name"),
+	}
+
+	if filter.IncludeDescendants, err = parseBoolQuery(c, "include_descendants"); err != nil {
+		return nil, err
+	}
+
+	openNow, err := parseBoolQuery(c, "open_now")
+	if err != nil {
+		return nil, err
+	}
+	if openNow {
+		filter.OpenAt = time.Now()
+	}
+
+	if filter.CreatedFrom, err = parseTimeQuery(c, "created_from"); err != nil {
+		return nil, err
+	}
+	if filter.CreatedTo, err = parseTimeQuery(c, "created_to"); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// newNearbyStoreRequest reads the nearby search, and its filter, from the
+// query string
+func newNearbyStoreRequest(c *fiber.Ctx) (req *domain.NearbyStoreRequest, err error) {
+	req = &domain.NearbyStoreRequest{}
+	if req.Lat, err = parseFloatQuery(c, "lat"); err != nil {
+		return nil, err
+	}
+	if req.Lng, err = parseFloatQuery(c, "lng"); err != nil {
+		return nil, err
+	}
+	if req.RadiusKm, err = parseFloatQuery(c, "radius_km"); err != nil {
+		return nil, err
+	}
+	req.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	if req.Filter, err = newStoreFilter(c); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// splitQuery splits a comma separated query value, dropping empty items
+func splitQuery(value string) (res []string) {
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			res = append(res, item)
+		}
+	}
+	return
+}
+
+// parseTimeQuery parses an RFC 3339 query value, returning the zero time when
+// it is missing
+func parseTimeQuery(c *fiber.Ctx, key string) (t time.Time, err error) {
+	value := c.Query(key)
+	if value == "" {
+		return
+	}
+
+	t, err = time.Parse(time.RFC3339, value)
+	if err != nil {
+		err = fmt.Errorf("%w: %s must be an RFC 3339 date", domain.ErrBadRequest, key)
+	}
+	return
+}
+
+// parseBoolQuery parses a boolean query value, returning false when it is
+// missing
+func parseBoolQuery(c *fiber.Ctx, key string) (b bool, err error) {
+	value := c.Query(key)
+	if value == "" {
+		return
+	}
+
+	b, err = strconv.ParseBool(value)
+	if err != nil {
+		err = fmt.Errorf("%w: %s must be a boolean", domain.ErrBadRequest, key)
+	}
+	return
+}
+
+// parseFloatQuery parses a required numeric query value
+func parseFloatQuery(c *fiber.Ctx, key string) (f float64, err error) {
+	f, err = strconv.ParseFloat(c.Query(key), 64)
+	if err != nil {
+		err = fmt.Errorf("%w: %s must be a number", domain.ErrBadRequest, key)
+	}
+	return
+}
+
+// pageLinks returns the Link header value pointing at the next and previous
+// pages of the current listing
+func pageLinks(c *fiber.Ctx, nextCursor, prevCursor string) string {
+	var links []string
+	for _, link := range []struct{ rel, cursor string }{{"next", nextCursor}, {"prev", prevCursor}} {
+		if link.cursor == "" {
+			continue
+		}
+
+		query, _ := url.ParseQuery(string(c.Request().URI().QueryString()))
+		query.Del("page")
+		query.Set("cursor", link.cursor)
+		links = append(links, fmt.Sprintf(`<%s%s?%s>; rel="%s"`, c.BaseURL(), c.Path(), query.Encode(), link.rel))
+	}
+	return strings.Join(links, ", ")
+}
+
+// storeFormatContentTypes are the content types of the import and export
+// formats
+var storeFormatContentTypes = map[string]string{
+	domain.StoreFormatCSV:    "text/csv",
+	domain.StoreFormatNDJSON: "application/x-ndjson",
+}
+
+// storeFormat reads the import or export format from the query string, or
+// from the media type of header when not given
+func storeFormat(c *fiber.Ctx, header string) string {
+	if format := c.Query("format"); format != "" {
+		return format
+	}
+
+	for format, contentType := range storeFormatContentTypes {
+		if strings.Contains(header, contentType) {
+			return format
+		}
+	}
+	return ""
+}
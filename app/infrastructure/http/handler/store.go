@@ -1,8 +1,14 @@
 package handler
 
 import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"strconv"
+	"strings"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
 	"github.com/go-playground/validator/v10"
@@ -34,9 +40,10 @@ func NewStoreHandler(usecase domain.StoreUsecase, validate *validator.Validate)
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Failure 422 {object} ErrorResponse
+// @Security BearerAuth
 // @Router /stores [post]
 func (h *storeHandler) Store(c *fiber.Ctx) error {
-	span, ctx := opentracing.StartSpanFromContext(c.Context(), "StoreHandler.Store")
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Store")
 	defer span.Finish()
 	createRequests.Inc()
 
@@ -78,20 +85,101 @@ func (h *storeHandler) Store(c *fiber.Ctx) error {
 // @Produce json
 // @Param page query int false "Page" default(1)
 // @Param limit query int false "Limit" default(10)
-// @Param sort query string false "Sort" default(created_at DESC)
-// @Success 200 {array} domain.Store
+// @Param cursor query string false "Cursor of the page to continue from, ignores page"
+// @Param sort query string false "Comma separated columns (name, status, created_at, updated_at), prefixed with - for descending order" default(-created_at)
+// @Param status query string false "Status" Enums(pending, active, disable, block, suspended, rejected)
+// @Param category_id query string false "Category ID"
+// @Param include_descendants query bool false "Include the stores of the sub-categories of category_id"
+// @Param user_id query string false "Owner ID"
+// @Param tags query string false "Comma separated tags, any of them"
+// @Param all_tags query string false "Comma separated tags, all of them"
+// @Param created_from query string false "Created at or after (RFC 3339)"
+// @Param created_to query string false "Created before (RFC 3339)"
+// @Param name query string false "Name prefix"
+// @Param open_now query bool false "Only the stores open now, in their timezone"
+// @Success 200 {object} domain.StorePage
+// @Header 200 {integer} X-total "filtered total"
+// @Header 200 {string} Link "next and prev page links"
 // @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 400 {object} ErrorResponse
 // @Router /stores [get]
 func (h *storeHandler) Index(c *fiber.Ctx) error {
-	span, ctx := opentracing.StartSpanFromContext(c.Context(), "StoreHandler.Index")
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Index")
 	defer span.Finish()
 	indexRequests.Inc()
 
+	filter, err := newStoreFilter(c)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("newStoreFilter: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	return h.index(ctx, c, filter)
+}
+
+// @Summary Index category stores
+// @Description Get list of the stores of a category
+// @Tags categories
+// @Accept json
+// @Produce json
+// @Param id path string true "category ID"
+// @Param include_descendants query bool false "Include the stores of its sub-categories"
+// @Param page query int false "Page" default(1)
+// @Param limit query int false "Limit" default(10)
+// @Param cursor query string false "Cursor of the page to continue from, ignores page"
+// @Param sort query string false "Comma separated columns (name, status, created_at, updated_at), prefixed with - for descending order" default(-created_at)
+// @Param status query string false "Status" Enums(pending, active, disable, block, suspended, rejected)
+// @Param user_id query string false "Owner ID"
+// @Param tags query string false "Comma separated tags, any of them"
+// @Param all_tags query string false "Comma separated tags, all of them"
+// @Param created_from query string false "Created at or after (RFC 3339)"
+// @Param created_to query string false "Created before (RFC 3339)"
+// @Param name query string false "Name prefix"
+// @Param open_now query bool false "Only the stores open now, in their timezone"
+// @Success 200 {object} domain.StorePage
+// @Header 200 {integer} X-total "filtered total"
+// @Header 200 {string} Link "next and prev page links"
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /categories/{id}/stores [get]
+func (h *storeHandler) IndexByCategory(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.IndexByCategory")
+	defer span.Finish()
+	categoryStoresRequests.Inc()
+
+	filter, err := newStoreFilter(c)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("newStoreFilter: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+	filter.CategoryID = c.Params("id")
+
+	return h.index(ctx, c, filter)
+}
+
+// index responds with the page of the stores matching filter
+func (h *storeHandler) index(ctx context.Context, c *fiber.Ctx, filter *domain.StoreFilter) error {
 	sort := c.Query("sort")
 	page, _ := strconv.Atoi(c.Query("page"))
 	limit, _ := strconv.Atoi(c.Query("limit"))
 
-	list, total, err := h.storeUsecase.Index(ctx, sort, limit, page)
+	if err := h.validate.StructCtx(ctx, filter); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	res, err := h.storeUsecase.Index(ctx, filter, sort, c.Query("cursor"), limit, page)
 	if err != nil {
 		log.
 			WithContext(ctx).
@@ -100,9 +188,138 @@ func (h *storeHandler) Index(c *fiber.Ctx) error {
 		return errorHandler(c, err)
 	}
 
-	c.Response().Header.Add("X-total", fmt.Sprint(total))
+	c.Response().Header.Add("X-total", fmt.Sprint(res.Total))
+	if links := pageLinks(c, res.NextCursor, res.PrevCursor); links != "" {
+		c.Set(fiber.HeaderLink, links)
+	}
 	successRequests.Inc()
-	return c.JSON(list)
+	return c.JSON(res)
+}
+
+// @Summary Nearby stores
+// @Description Get the stores around a position, nearest first
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Param lat query number true "Latitude"
+// @Param lng query number true "Longitude"
+// @Param radius_km query number true "Radius in km" maximum(500)
+// @Param limit query int false "Limit" default(20)
+// @Param status query string false "Status" Enums(pending, active, disable, block, suspended, rejected)
+// @Param category_id query string false "Category ID"
+// @Param include_descendants query bool false "Include the stores of the sub-categories of category_id"
+// @Param user_id query string false "Owner ID"
+// @Param tags query string false "Comma separated tags, any of them"
+// @Param all_tags query string false "Comma separated tags, all of them"
+// @Param created_from query string false "Created at or after (RFC 3339)"
+// @Param created_to query string false "Created before (RFC 3339)"
+// @Param name query string false "Name prefix"
+// @Param open_now query bool false "Only the stores open now, in their timezone"
+// @Success 200 {array} domain.NearbyStore
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /stores/nearby [get]
+func (h *storeHandler) Nearby(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Nearby")
+	defer span.Finish()
+	nearbyRequests.Inc()
+
+	req, err := newNearbyStoreRequest(c)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("newNearbyStoreRequest: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	if err := h.validate.StructCtx(ctx, req); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	res, err := h.storeUsecase.Nearby(ctx, req)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.Nearby: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.JSON(res)
+}
+
+// @Summary Search stores
+// @Description Search stores by name, description and tags, most relevant first
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Param q query string true "Search terms"
+// @Param page query int false "Page" default(1)
+// @Param limit query int false "Limit" default(10)
+// @Param status query string false "Status" Enums(pending, active, disable, block, suspended, rejected)
+// @Param category_id query string false "Category ID"
+// @Param include_descendants query bool false "Include the stores of the sub-categories of category_id"
+// @Param user_id query string false "Owner ID"
+// @Param tags query string false "Comma separated tags, any of them"
+// @Param all_tags query string false "Comma separated tags, all of them"
+// @Param created_from query string false "Created at or after (RFC 3339)"
+// @Param created_to query string false "Created before (RFC 3339)"
+// @Param name query string false "Name prefix"
+// @Param open_now query bool false "Only the stores open now, in their timezone"
+// @Success 200 {object} domain.StoreSearchPage
+// @Header 200 {integer} X-total "total of matches"
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Router /stores/search [get]
+func (h *storeHandler) Search(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Search")
+	defer span.Finish()
+	searchRequests.Inc()
+
+	filter, err := newStoreFilter(c)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("newStoreFilter: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	req := &domain.SearchStoreRequest{
+		Query:  strings.TrimSpace(c.Query("q")),
+		Filter: filter,
+	}
+	req.Page, _ = strconv.Atoi(c.Query("page"))
+	req.Limit, _ = strconv.Atoi(c.Query("limit"))
+
+	if err := h.validate.StructCtx(ctx, req); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	res, err := h.storeUsecase.Search(ctx, req)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.Search: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	c.Response().Header.Add("X-total", fmt.Sprint(res.Total))
+	successRequests.Inc()
+	return c.JSON(res)
 }
 
 // @Summary Get stores
@@ -111,13 +328,16 @@ func (h *storeHandler) Index(c *fiber.Ctx) error {
 // @Accept json
 // @Produce json
 // @Param id path string true "store ID"
+// @Param If-None-Match header string false "ETag of a cached version"
 // @Success 200 {object} domain.Store
+// @Header 200 {string} ETag "store version"
+// @Success 304
 // @Failure 500 {object} ErrorResponse
 // @Failure 400 {array} ErrorResponse
 // @Failure 404 {object} ErrorResponse
 // @Router /stores/{id} [get]
 func (h *storeHandler) Get(c *fiber.Ctx) error {
-	span, ctx := opentracing.StartSpanFromContext(c.Context(), "StoreHandler.Get")
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Get")
 	defer span.Finish()
 	getRequests.Inc()
 
@@ -141,25 +361,96 @@ func (h *storeHandler) Get(c *fiber.Ctx) error {
 		return errorHandler(c, err)
 	}
 
+	c.Set(fiber.HeaderETag, etag(res.Version))
 	successRequests.Inc()
+	if c.Get(fiber.HeaderIfNoneMatch) == etag(res.Version) {
+		return c.SendStatus(fiber.StatusNotModified)
+	}
 	return c.JSON(res)
 }
 
-// @Summary Activate stores
-// @Description Activate a stores
+// @Summary Change store status
+// @Description Move a store through a lifecycle action. block and reject require a reason, block and disable may expire at expires_at
 // @Tags stores
 // @Accept json
 // @Produce json
 // @Param id path string true "store ID"
+// @Param action path string true "Action" Enums(approve, reject, activate, disable, block, unblock)
+// @Param status body domain.StoreStatusRequest false "Reason and expiry"
 // @Success 204
 // @Failure 500 {object} ErrorResponse
 // @Failure 400 {array} ErrorResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
-// @Router /stores/{id}/activate [patch]
-func (h *storeHandler) Activate(c *fiber.Ctx) error {
-	span, ctx := opentracing.StartSpanFromContext(c.Context(), "StoreHandler.Activate")
+// @Failure 409 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /stores/{id}/{action} [patch]
+func (h *storeHandler) ChangeStatus(action string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.ChangeStatus")
+		defer span.Finish()
+		statusRequests.WithLabelValues(action).Inc()
+
+		sr, err := h.statusRequest(ctx, c, action)
+		if err != nil {
+			errorRequests.Inc()
+			return errorHandler(c, err)
+		}
+
+		err = h.storeUsecase.ChangeStatus(ctx, sr)
+		if err != nil {
+			log.
+				WithContext(ctx).
+				Errorf("storeUsecase.ChangeStatus: %v", err)
+			errorRequests.Inc()
+			return errorHandler(c, err)
+		}
+
+		successRequests.Inc()
+		return c.SendStatus(fiber.StatusNoContent)
+	}
+}
+
+// statusRequest reads the action on the store in the path. The body is
+// optional, without one the change has no reason and does not expire.
+func (h *storeHandler) statusRequest(ctx context.Context, c *fiber.Ctx, action string) (*domain.StoreStatusRequest, error) {
+	sr := new(domain.StoreStatusRequest)
+	if len(c.Body()) > 0 {
+		if err := c.BodyParser(sr); err != nil {
+			log.
+				WithContext(ctx).
+				Errorf("c.BodyParser: %v", err)
+			return nil, err
+		}
+	}
+
+	sr.ID = c.Params("id")
+	sr.Action = action
+	if err := h.validate.StructCtx(ctx, sr); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		return nil, err
+	}
+	return sr, nil
+}
+
+// @Summary List store actions
+// @Description Get the lifecycle actions the caller may take on a store in its current status
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Param id path string true "store ID"
+// @Success 200 {array} domain.StoreTransition
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /stores/{id}/actions [get]
+func (h *storeHandler) Actions(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Actions")
 	defer span.Finish()
-	ativateRequests.Inc()
+	actionsRequests.Inc()
 
 	id := c.Params("id")
 
@@ -172,36 +463,41 @@ func (h *storeHandler) Activate(c *fiber.Ctx) error {
 		return errorHandler(c, err)
 	}
 
-	err = h.storeUsecase.Active(ctx, id)
+	res, err := h.storeUsecase.Actions(ctx, id)
 	if err != nil {
 		log.
 			WithContext(ctx).
-			Errorf("storeUsecase.Active: %v", err)
+			Errorf("storeUsecase.Actions: %v", err)
 		errorRequests.Inc()
 		return errorHandler(c, err)
 	}
 
 	successRequests.Inc()
-	return c.SendStatus(fiber.StatusNoContent)
+	return c.JSON(res)
 }
 
-// @Summary Block stores
-// @Description Block a stores
+// @Summary List store status history
+// @Description Get the status changes of a store, newest first
 // @Tags stores
 // @Accept json
 // @Produce json
 // @Param id path string true "store ID"
-// @Success 204
+// @Param page query int false "Page" default(1)
+// @Param limit query int false "Limit" default(10)
+// @Success 200 {array} domain.StoreStatusChange
 // @Failure 500 {object} ErrorResponse
 // @Failure 400 {array} ErrorResponse
 // @Failure 404 {object} ErrorResponse
-// @Router /stores/{id}/block [patch]
-func (h *storeHandler) Block(c *fiber.Ctx) error {
-	span, ctx := opentracing.StartSpanFromContext(c.Context(), "StoreHandler.Block")
+// @Security BearerAuth
+// @Router /stores/{id}/history [get]
+func (h *storeHandler) History(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.History")
 	defer span.Finish()
-	blockRequests.Inc()
+	historyRequests.Inc()
 
 	id := c.Params("id")
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
 
 	err := h.validate.VarCtx(ctx, id, "uuid4")
 	if err != nil {
@@ -212,34 +508,44 @@ func (h *storeHandler) Block(c *fiber.Ctx) error {
 		return errorHandler(c, err)
 	}
 
-	err = h.storeUsecase.Block(ctx, id)
+	list, total, err := h.storeUsecase.StatusHistory(ctx, id, limit, page)
 	if err != nil {
 		log.
 			WithContext(ctx).
-			Errorf("storeUsecase.Block: %v", err)
+			Errorf("storeUsecase.StatusHistory: %v", err)
 		errorRequests.Inc()
 		return errorHandler(c, err)
 	}
 
+	c.Response().Header.Add("X-total", fmt.Sprint(total))
 	successRequests.Inc()
-	return c.SendStatus(fiber.StatusNoContent)
+	return c.JSON(list)
 }
 
-// @Summary Disable stores
-// @Description Disable a stores
+// @Summary Upload store image
+// @Description Upload the image of a store, stored without its EXIF metadata along with resized variants. It replaces the previous image.
 // @Tags stores
-// @Accept json
+// @Accept mpfd
 // @Produce json
 // @Param id path string true "store ID"
-// @Success 204
+// @Param image formData file true "jpeg, png or gif image"
+// @Success 200 {object} domain.Store
+// @Header 200 {string} ETag "store version"
 // @Failure 500 {object} ErrorResponse
 // @Failure 400 {array} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
-// @Router /stores/{id}/disable [patch]
-func (h *storeHandler) Disable(c *fiber.Ctx) error {
-	span, ctx := opentracing.StartSpanFromContext(c.Context(), "StoreHandler.Disable")
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 415 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /stores/{id}/image [post]
+func (h *storeHandler) UploadImage(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.UploadImage")
 	defer span.Finish()
-	disableRequests.Inc()
+	imageRequests.Inc()
 
 	id := c.Params("id")
 
@@ -252,21 +558,98 @@ func (h *storeHandler) Disable(c *fiber.Ctx) error {
 		return errorHandler(c, err)
 	}
 
-	err = h.storeUsecase.Disable(ctx, id)
+	file, err := c.FormFile("image")
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("c.FormFile: %v", err)
+		errorRequests.Inc()
+		return c.Status(fiber.StatusBadRequest).JSON(ErrorResponse{Message: domain.ErrBadRequest.Error(), Field: "image"})
+	}
+
+	body, err := file.Open()
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("file.Open: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+	defer body.Close()
+
+	res, err := h.storeUsecase.UploadImage(ctx, &domain.StoreImageRequest{
+		ID:          id,
+		ContentType: file.Header.Get(fiber.HeaderContentType),
+		Size:        file.Size,
+		Body:        body,
+	})
 	if err != nil {
 		log.
 			WithContext(ctx).
-			Errorf("storeUsecase.Disable: %v", err)
+			Errorf("storeUsecase.UploadImage: %v", err)
 		errorRequests.Inc()
 		return errorHandler(c, err)
 	}
 
+	c.Set(fiber.HeaderETag, etag(res.Version))
 	successRequests.Inc()
-	return c.SendStatus(fiber.StatusNoContent)
+	return c.JSON(res)
+}
+
+// @Summary Set store opening hours
+// @Description Replace the weekly schedule, closures and timezone of a store. Weekday 0 is sunday, an interval closing at or before its opening time ends the next day.
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Param id path string true "store ID"
+// @Param hours body domain.StoreHoursRequest true "Opening hours"
+// @Success 200 {object} domain.Store
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /stores/{id}/hours [put]
+func (h *storeHandler) SetHours(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.SetHours")
+	defer span.Finish()
+	hoursRequests.Inc()
+
+	hr := new(domain.StoreHoursRequest)
+	if err := c.BodyParser(hr); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("c.BodyParser: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	hr.ID = c.Params("id")
+	if err := h.validate.StructCtx(ctx, hr); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	res, err := h.storeUsecase.SetHours(ctx, hr)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.SetHours: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.JSON(res)
 }
 
 // @Summary Delete stores
-// @Description Delete one stores
+// @Description Delete one stores. The store can be restored until it is purged, after the retention.
 // @Tags stores
 // @Accept json
 // @Produce json
@@ -275,9 +658,10 @@ func (h *storeHandler) Disable(c *fiber.Ctx) error {
 // @Failure 500 {object} ErrorResponse
 // @Failure 400 {array} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
 // @Router /stores/{id} [delete]
 func (h *storeHandler) Delete(c *fiber.Ctx) error {
-	span, ctx := opentracing.StartSpanFromContext(c.Context(), "StoreHandler.Delete")
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Delete")
 	defer span.Finish()
 	deleteRequests.Inc()
 
@@ -305,12 +689,54 @@ func (h *storeHandler) Delete(c *fiber.Ctx) error {
 	return c.SendStatus(fiber.StatusNoContent)
 }
 
+// @Summary Restore store
+// @Description Restore a deleted store, before it is purged
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Param id path string true "store ID"
+// @Success 204
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /stores/{id}/restore [post]
+func (h *storeHandler) Restore(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Restore")
+	defer span.Finish()
+	restoreRequests.Inc()
+
+	id := c.Params("id")
+
+	err := h.validate.VarCtx(ctx, id, "uuid4")
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	err = h.storeUsecase.Restore(ctx, id)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.Restore: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
 // @Summary Update store
 // @Description Uptate a stores
 // @Tags stores
 // @Accept json
 // @Produce json
 // @Param id path string true "store ID"
+// @Param If-Match header string false "ETag of the version being updated"
 // @Param category body domain.UpdateStoreRequest true "Create store"
 // @Success 204
 // @Failure 500 {object} ErrorResponse
@@ -318,9 +744,12 @@ func (h *storeHandler) Delete(c *fiber.Ctx) error {
 // @Failure 400 {object} ErrorResponse
 // @Failure 422 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Security BearerAuth
 // @Router /stores/{id} [patch]
 func (h *storeHandler) Update(c *fiber.Ctx) error {
-	span, ctx := opentracing.StartSpanFromContext(c.Context(), "StoreHandler.Update")
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Update")
 	defer span.Finish()
 	updateRequests.Inc()
 
@@ -343,15 +772,358 @@ func (h *storeHandler) Update(c *fiber.Ctx) error {
 		errorRequests.Inc()
 		return errorHandler(c, err)
 	}
+
+	if match := c.Get(fiber.HeaderIfMatch); match != "" && match != "*" {
+		version, err := parseETag(match)
+		if err != nil {
+			log.
+				WithContext(ctx).
+				Errorf("parseETag: %v", err)
+			errorRequests.Inc()
+			return c.Status(fiber.StatusPreconditionFailed).JSON(ErrorResponse{Message: domain.ErrConflict.Error()})
+		}
+		ur.Version = version
+	}
+
 	err := h.storeUsecase.Update(ctx, ur)
 	if err != nil {
 		log.
 			WithContext(ctx).
 			Errorf("storeUsecase.Update: %v", err)
 		errorRequests.Inc()
+		if errors.Is(err, domain.ErrConflict) && ur.Version != 0 {
+			return c.Status(fiber.StatusPreconditionFailed).JSON(ErrorResponse{Message: err.Error()})
+		}
 		return errorHandler(c, err)
 	}
 
 	successRequests.Inc()
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// @Summary Get store account
+// @Description Get the account and balance of a store
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Param id path string true "store ID"
+// @Success 200 {object} domain.Account
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /stores/{id}/account [get]
+func (h *storeHandler) Account(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Account")
+	defer span.Finish()
+	accountRequests.Inc()
+
+	id := c.Params("id")
+
+	err := h.validate.VarCtx(ctx, id, "uuid4")
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	res, err := h.storeUsecase.GetAccount(ctx, id)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.GetAccount: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.JSON(res)
+}
+
+// @Summary Deposit store balance
+// @Description Add funds to a store account
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Param id path string true "store ID"
+// @Param amount body domain.AccountOperationRequest true "Deposit amount"
+// @Success 200 {object} domain.Account
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /stores/{id}/account/deposit [post]
+func (h *storeHandler) Deposit(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Deposit")
+	defer span.Finish()
+	depositRequests.Inc()
+
+	ar := new(domain.AccountOperationRequest)
+	if err := c.BodyParser(ar); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("c.BodyParser: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	ar.ID = c.Params("id")
+	if err := h.validate.StructCtx(ctx, ar); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	res, err := h.storeUsecase.Deposit(ctx, ar)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.Deposit: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.JSON(res)
+}
+
+// @Summary Withdraw store balance
+// @Description Withdraw funds from a store account
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Param id path string true "store ID"
+// @Param amount body domain.AccountOperationRequest true "Withdraw amount"
+// @Success 200 {object} domain.Account
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 422 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /stores/{id}/account/withdraw [post]
+func (h *storeHandler) Withdraw(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Withdraw")
+	defer span.Finish()
+	withdrawRequests.Inc()
+
+	ar := new(domain.AccountOperationRequest)
+	if err := c.BodyParser(ar); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("c.BodyParser: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	ar.ID = c.Params("id")
+	if err := h.validate.StructCtx(ctx, ar); err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.StructCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	res, err := h.storeUsecase.Withdraw(ctx, ar)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.Withdraw: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.JSON(res)
+}
+
+// @Summary List store account transactions
+// @Description Get the ledger entries of a store account, newest first
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Param id path string true "store ID"
+// @Param page query int false "Page" default(1)
+// @Param limit query int false "Limit" default(10)
+// @Success 200 {array} domain.LedgerEntry
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /stores/{id}/account/transactions [get]
+func (h *storeHandler) Transactions(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Transactions")
+	defer span.Finish()
+	transactionsRequests.Inc()
+
+	id := c.Params("id")
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	err := h.validate.VarCtx(ctx, id, "uuid4")
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	list, total, err := h.storeUsecase.ListTransactions(ctx, id, limit, page)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.ListTransactions: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	c.Response().Header.Add("X-total", fmt.Sprint(total))
+	successRequests.Inc()
+	return c.JSON(list)
+}
+
+// @Summary Reconcile store account
+// @Description Recompute a store balance from its ledger and compare it with the account balance
+// @Tags stores
+// @Accept json
+// @Produce json
+// @Param id path string true "store ID"
+// @Success 200 {object} domain.Reconciliation
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {array} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /stores/{id}/account/reconciliation [get]
+func (h *storeHandler) Reconcile(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Reconcile")
+	defer span.Finish()
+	reconcileRequests.Inc()
+
+	id := c.Params("id")
+
+	err := h.validate.VarCtx(ctx, id, "uuid4")
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("validate.VarCtx: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	res, err := h.storeUsecase.ReconcileAccount(ctx, id)
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.ReconcileAccount: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.JSON(res)
+}
+
+// @Summary Import stores
+// @Description Create the stores of a CSV file, with a name, description, category_id, tags, latitude and longitude header, or of an NDJSON one, of CreateStoreRequest objects. Every row is checked as in Create store; the rows failing are left out and reported. The format is taken from the Content-Type (text/csv or application/x-ndjson) when not given.
+// @Tags stores
+// @Accept text/csv,application/x-ndjson
+// @Produce json
+// @Param format query string false "Format of the body" Enums(csv, ndjson)
+// @Param dry_run query bool false "Only check the rows, without creating the stores"
+// @Param stores body string true "CSV or NDJSON stores"
+// @Success 200 {object} domain.StoreImportReport
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /stores:import [post]
+func (h *storeHandler) Import(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Import")
+	defer span.Finish()
+	importRequests.Inc()
+
+	dryRun, err := parseBoolQuery(c, "dry_run")
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("parseBoolQuery: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	res, err := h.storeUsecase.Import(ctx, &domain.StoreImportRequest{
+		Format: storeFormat(c, c.Get(fiber.HeaderContentType)),
+		Body:   bytes.NewReader(c.Body()),
+		DryRun: dryRun,
+	})
+	if err != nil {
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.Import: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	successRequests.Inc()
+	return c.JSON(res)
+}
+
+// @Summary Export stores
+// @Description Stream every store, but the deleted ones, as CSV or NDJSON. The format is taken from the Accept header (text/csv or application/x-ndjson) when not given, and is NDJSON by default. An export can be imported back.
+// @Tags stores
+// @Accept json
+// @Produce text/csv,application/x-ndjson
+// @Param format query string false "Format of the export" Enums(csv, ndjson)
+// @Success 200 {string} string "CSV or NDJSON stores"
+// @Failure 500 {object} ErrorResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 403 {object} ErrorResponse
+// @Security BearerAuth
+// @Router /stores:export [get]
+func (h *storeHandler) Export(c *fiber.Ctx) error {
+	span, ctx := opentracing.StartSpanFromContext(c.UserContext(), "StoreHandler.Export")
+	exportRequests.Inc()
+
+	format := storeFormat(c, c.Get(fiber.HeaderAccept))
+	if format == "" {
+		format = domain.StoreFormatNDJSON
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		defer span.Finish()
+		pw.CloseWithError(h.storeUsecase.Export(ctx, format, pw))
+	}()
+
+	// the export writes nothing before it is authorized, so its first bytes
+	// tell whether it is streamed or failed
+	body := &exportBody{Reader: bufio.NewReader(pr), Closer: pr}
+	if _, err := body.Peek(1); err != nil && err != io.EOF {
+		pr.Close()
+		log.
+			WithContext(ctx).
+			Errorf("storeUsecase.Export: %v", err)
+		errorRequests.Inc()
+		return errorHandler(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, storeFormatContentTypes[format])
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="stores.%s"`, format))
+	successRequests.Inc()
+	return c.SendStream(body)
+}
+
+// exportBody is the streamed body of an export. Closing it, once sent or
+// when the client is gone, stops the export.
+type exportBody struct {
+	*bufio.Reader
+	io.Closer
+}
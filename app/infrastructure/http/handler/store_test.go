@@ -1,15 +1,22 @@
 package handler_test
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
 	"net/http/httptest"
+	"net/textproto"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
 	"github.com/EdlanioJ/kbu-store/app/infrastructure/http/handler"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/http/middleware"
 	"github.com/EdlanioJ/kbu-store/app/utils/mocks"
 	"github.com/EdlanioJ/kbu-store/app/utils/sample"
 	"github.com/go-playground/validator/v10"
@@ -87,10 +94,13 @@ func Test_StoreHandler_Store(t *testing.T) {
 
 func Test_StoreHandler_Index(t *testing.T) {
 	args := sample.NewHttpListReq()
+	userID := uuid.NewV4().String()
 	testCases := []struct {
 		name       string
 		args       sample.HttpListRequest
+		query      string
 		statusCode int
+		link       string
 		prepare    func(storeUsecase *mocks.StoreUsecase)
 	}{
 		{
@@ -98,7 +108,62 @@ func Test_StoreHandler_Index(t *testing.T) {
 			args:       args,
 			statusCode: fiber.StatusInternalServerError,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Index", mock.Anything, args.Sort, args.Limit, args.Page).Return(nil, int64(0), errors.New("Unexpected Error")).Once()
+				storeUsecase.On("Index", mock.Anything, mock.Anything, args.Sort, "", args.Limit, args.Page).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:       "failure_invalid_sort",
+			args:       sample.HttpListRequest{Sort: "balance", Page: 1, Limit: 5},
+			statusCode: fiber.StatusBadRequest,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Index", mock.Anything, mock.Anything, "balance", "", 5, 1).Return(nil, domain.ErrInvalidSort).Once()
+			},
+		},
+		{
+			name:       "failure_invalid_created_from",
+			args:       args,
+			query:      "&created_from=yesterday",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_invalid_status",
+			args:       args,
+			query:      "&status=unknown",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "success_filtered",
+			args:       args,
+			query:      fmt.Sprintf("&status=active&user_id=%s&tags=tag001,tag002&name=sto&created_from=2021-01-01T00:00:00Z", userID),
+			statusCode: fiber.StatusOK,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				stores := domain.Stores{sample.NewStore()}
+				storeUsecase.On("Index", mock.Anything, mock.MatchedBy(func(f *domain.StoreFilter) bool {
+					return f.Status == domain.StoreStatusActive &&
+						f.UserID == userID &&
+						len(f.AnyTags) == 2 &&
+						f.NamePrefix == "sto" &&
+						f.CreatedFrom.Equal(time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)) &&
+						f.CreatedTo.IsZero()
+				}), args.Sort, "", args.Limit, args.Page).Return(&domain.StorePage{Stores: stores, Total: 1}, nil).Once()
+			},
+		},
+		{
+			name:       "failure_invalid_open_now",
+			args:       args,
+			query:      "&open_now=sometimes",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "success_open_now",
+			args:       args,
+			query:      "&open_now=true",
+			statusCode: fiber.StatusOK,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				stores := domain.Stores{sample.NewStore()}
+				storeUsecase.On("Index", mock.Anything, mock.MatchedBy(func(f *domain.StoreFilter) bool {
+					return !f.OpenAt.IsZero() && time.Since(f.OpenAt) < time.Minute
+				}), args.Sort, "", args.Limit, args.Page).Return(&domain.StorePage{Stores: stores, Total: 1}, nil).Once()
 			},
 		},
 		{
@@ -109,7 +174,27 @@ func Test_StoreHandler_Index(t *testing.T) {
 				store := sample.NewStore()
 				stores := make(domain.Stores, 0)
 				stores = append(stores, store)
-				storeUsecase.On("Index", mock.Anything, args.Sort, args.Limit, args.Page).Return(stores, int64(1), nil).Once()
+				storeUsecase.On("Index", mock.Anything, mock.Anything, args.Sort, "", args.Limit, args.Page).Return(&domain.StorePage{Stores: stores, Total: 1}, nil).Once()
+			},
+		},
+		{
+			name:       "failure_invalid_cursor",
+			args:       args,
+			query:      "&cursor=abc",
+			statusCode: fiber.StatusBadRequest,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Index", mock.Anything, mock.Anything, args.Sort, "abc", args.Limit, args.Page).Return(nil, domain.ErrInvalidCursor).Once()
+			},
+		},
+		{
+			name:       "success_with_cursors",
+			args:       args,
+			query:      "&cursor=abc&status=active",
+			statusCode: fiber.StatusOK,
+			link:       fmt.Sprintf(`<http://example.com/?cursor=next&limit=%d&sort=%s&status=active>; rel="next", <http://example.com/?cursor=prev&limit=%d&sort=%s&status=active>; rel="prev"`, args.Limit, args.Sort, args.Limit, args.Sort),
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				page := &domain.StorePage{Stores: domain.Stores{sample.NewStore()}, Total: 1, NextCursor: "next", PrevCursor: "prev"}
+				storeUsecase.On("Index", mock.Anything, mock.Anything, args.Sort, "abc", args.Limit, args.Page).Return(page, nil).Once()
 			},
 		},
 	}
@@ -126,7 +211,7 @@ func Test_StoreHandler_Index(t *testing.T) {
 			validator := validator.New()
 			handler := handler.NewStoreHandler(storeUsecase, validator)
 			app.Get("/", handler.Index)
-			req := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/?sort=%s&page=%d&limit=%d", tc.args.Sort, tc.args.Page, tc.args.Limit), nil)
+			req := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/?sort=%s&page=%d&limit=%d%s", tc.args.Sort, tc.args.Page, tc.args.Limit, tc.query), nil)
 			req.Header.Set("Content-Type", "application/json")
 			res, err := app.Test(req)
 			assert.NoError(t, err)
@@ -134,16 +219,22 @@ func Test_StoreHandler_Index(t *testing.T) {
 			if res.StatusCode == fiber.StatusOK {
 				total := res.Header.Get("X-total")
 				assert.Equal(t, total, "1")
+				assert.Equal(t, tc.link, res.Header.Get("Link"))
+				page := new(domain.StorePage)
+				assert.NoError(t, json.NewDecoder(res.Body).Decode(page))
+				assert.Len(t, page.Stores, 1)
 			}
 			storeUsecase.AssertExpectations(t)
 		})
 	}
 }
 
-func Test_StoreHandler_Get(t *testing.T) {
+func Test_StoreHandler_IndexByCategory(t *testing.T) {
+	categoryID := uuid.NewV4().String()
 	testCases := []struct {
 		name       string
 		arg        string
+		query      string
 		statusCode int
 		prepare    func(storeUsecase *mocks.StoreUsecase)
 	}{
@@ -152,20 +243,29 @@ func Test_StoreHandler_Get(t *testing.T) {
 			arg:        "invalid_id",
 			statusCode: fiber.StatusBadRequest,
 		},
+		{
+			name:       "failure_invalid_include_descendants",
+			arg:        categoryID,
+			query:      "include_descendants=maybe",
+			statusCode: fiber.StatusBadRequest,
+		},
 		{
 			name:       "failure_usecase_returns_error",
-			arg:        uuid.NewV4().String(),
-			statusCode: fiber.StatusNotFound,
+			arg:        categoryID,
+			statusCode: fiber.StatusInternalServerError,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+				storeUsecase.On("Index", mock.Anything, mock.Anything, "", "", 0, 0).Return(nil, errors.New("Unexpected Error")).Once()
 			},
 		},
 		{
-			name:       "should succeed",
-			arg:        uuid.NewV4().String(),
+			name:       "success_including_descendants",
+			arg:        categoryID,
+			query:      "include_descendants=true&category_id=" + uuid.NewV4().String(),
 			statusCode: fiber.StatusOK,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				storeUsecase.On("Index", mock.Anything, mock.MatchedBy(func(f *domain.StoreFilter) bool {
+					return f.CategoryID == categoryID && f.IncludeDescendants
+				}), "", "", 0, 0).Return(&domain.StorePage{Stores: domain.Stores{sample.NewStore()}, Total: 1}, nil).Once()
 			},
 		},
 	}
@@ -181,20 +281,163 @@ func Test_StoreHandler_Get(t *testing.T) {
 			app := fiber.New()
 			validator := validator.New()
 			handler := handler.NewStoreHandler(storeUsecase, validator)
-			app.Get("/:id", handler.Get)
-			req := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/%s", tc.arg), nil)
+			app.Get("/:id/stores", handler.IndexByCategory)
+			req := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/%s/stores?%s", tc.arg, tc.query), nil)
 			req.Header.Set("Content-Type", "application/json")
 			res, err := app.Test(req)
 			assert.NoError(t, err)
-			assert.Equal(t, res.StatusCode, tc.statusCode)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+			if res.StatusCode == fiber.StatusOK {
+				assert.Equal(t, "1", res.Header.Get("X-total"))
+			}
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_Nearby(t *testing.T) {
+	testCases := []struct {
+		name       string
+		query      string
+		statusCode int
+		prepare    func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_missing_radius",
+			query:      "lat=-8.8383&lng=13.2344",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_invalid_latitude",
+			query:      "lat=-91&lng=13.2344&radius_km=5",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_radius_too_large",
+			query:      "lat=-8.8383&lng=13.2344&radius_km=1000",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_invalid_filter",
+			query:      "lat=-8.8383&lng=13.2344&radius_km=5&created_from=yesterday",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			query:      "lat=-8.8383&lng=13.2344&radius_km=5",
+			statusCode: fiber.StatusInternalServerError,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Nearby", mock.Anything, mock.Anything).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:       "success",
+			query:      "lat=-8.8383&lng=13.2344&radius_km=2.5&limit=5&status=active",
+			statusCode: fiber.StatusOK,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Nearby", mock.Anything, mock.MatchedBy(func(req *domain.NearbyStoreRequest) bool {
+					return req.Lat == -8.8383 && req.Lng == 13.2344 && req.RadiusKm == 2.5 && req.Limit == 5 && req.Filter.Status == domain.StoreStatusActive
+				})).
+					Return(domain.NearbyStores{{Store: sample.NewStore(), Distance: 1.2}}, nil).
+					Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Get("/nearby", handler.Nearby)
+			req := httptest.NewRequest(fiber.MethodGet, "/nearby?"+tc.query, nil)
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_Search(t *testing.T) {
+	testCases := []struct {
+		name       string
+		query      string
+		statusCode int
+		prepare    func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_missing_query",
+			query:      "q=%20%20",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_invalid_filter",
+			query:      "q=coffee&status=closed",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			query:      "q=coffee",
+			statusCode: fiber.StatusInternalServerError,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Search", mock.Anything, mock.Anything).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:       "success",
+			query:      "q=coffee+shop&page=2&limit=5&category_id=" + uuid.NewV4().String(),
+			statusCode: fiber.StatusOK,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Search", mock.Anything, mock.MatchedBy(func(req *domain.SearchStoreRequest) bool {
+					return req.Query == "coffee shop" && req.Page == 2 && req.Limit == 5 && req.Filter.CategoryID != ""
+				})).
+					Return(&domain.StoreSearchPage{
+						Results: domain.StoreSearchResults{{Store: sample.NewStore(), Rank: 0.6, Snippet: "<b>coffee</b>"}},
+						Total:   6,
+					}, nil).
+					Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Get("/search", handler.Search)
+			req := httptest.NewRequest(fiber.MethodGet, "/search?"+tc.query, nil)
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+			if res.StatusCode == fiber.StatusOK {
+				assert.Equal(t, "6", res.Header.Get("X-total"))
+			}
+			storeUsecase.AssertExpectations(t)
 		})
 	}
 }
 
-func Test_StoreHandler_Activate(t *testing.T) {
+func Test_StoreHandler_Get(t *testing.T) {
 	testCases := []struct {
 		name       string
 		arg        string
+		noneMatch  string
 		statusCode int
 		prepare    func(storeUsecase *mocks.StoreUsecase)
 	}{
@@ -204,19 +447,28 @@ func Test_StoreHandler_Activate(t *testing.T) {
 			statusCode: fiber.StatusBadRequest,
 		},
 		{
-			name:       "failure_usercase_returns_error",
+			name:       "failure_usecase_returns_error",
 			arg:        uuid.NewV4().String(),
-			statusCode: fiber.StatusConflict,
+			statusCode: fiber.StatusNotFound,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Active", mock.Anything, mock.AnythingOfType("string")).Return(domain.ErrActived).Once()
+				storeUsecase.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
 			},
 		},
 		{
-			name:       "success",
+			name:       "success_not_modified",
 			arg:        uuid.NewV4().String(),
-			statusCode: fiber.StatusNoContent,
+			noneMatch:  `"1"`,
+			statusCode: fiber.StatusNotModified,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+			},
+		},
+		{
+			name:       "should succeed",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusOK,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Active", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+				storeUsecase.On("Get", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
 			},
 		},
 	}
@@ -232,43 +484,75 @@ func Test_StoreHandler_Activate(t *testing.T) {
 			app := fiber.New()
 			validator := validator.New()
 			handler := handler.NewStoreHandler(storeUsecase, validator)
-			app.Patch("/:id/activate", handler.Activate)
-			req := httptest.NewRequest(fiber.MethodPatch, fmt.Sprintf("/%s/activate", tc.arg), nil)
+			app.Get("/:id", handler.Get)
+			req := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/%s", tc.arg), nil)
 			req.Header.Set("Content-Type", "application/json")
+			if tc.noneMatch != "" {
+				req.Header.Set("If-None-Match", tc.noneMatch)
+			}
 			res, err := app.Test(req)
 			assert.NoError(t, err)
 			assert.Equal(t, res.StatusCode, tc.statusCode)
-			storeUsecase.AssertExpectations(t)
+			if res.StatusCode == fiber.StatusOK || res.StatusCode == fiber.StatusNotModified {
+				assert.Equal(t, `"1"`, res.Header.Get("ETag"))
+			}
 		})
 	}
 }
 
-func Test_StoreHandler_Block(t *testing.T) {
+func Test_StoreHandler_ChangeStatus(t *testing.T) {
 	testCases := []struct {
 		name       string
 		arg        string
+		action     string
+		body       string
 		statusCode int
 		prepare    func(storeUsecase *mocks.StoreUsecase)
 	}{
 		{
-			name:       "failure_usecase_returns_error",
+			name:       "failure_invalid_id",
 			arg:        "invalid_id",
+			action:     domain.StoreActionApprove,
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_invalid_body",
+			arg:        uuid.NewV4().String(),
+			action:     domain.StoreActionBlock,
+			body:       `{"reason":`,
 			statusCode: fiber.StatusBadRequest,
 		},
 		{
-			name:       "should fail if usecase returns an error",
+			name:       "failure_usecase_returns_error",
 			arg:        uuid.NewV4().String(),
+			action:     domain.StoreActionActivate,
 			statusCode: fiber.StatusConflict,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Block", mock.Anything, mock.AnythingOfType("string")).Return(domain.ErrBlocked).Once()
+				err := &domain.TransitionError{Action: domain.StoreActionActivate, Status: domain.StoreStatusBlock}
+				storeUsecase.On("ChangeStatus", mock.Anything, mock.AnythingOfType("*domain.StoreStatusRequest")).Return(err).Once()
 			},
 		},
 		{
 			name:       "success",
 			arg:        uuid.NewV4().String(),
+			action:     domain.StoreActionBlock,
+			body:       `{"reason":"fraudulent listings"}`,
+			statusCode: fiber.StatusNoContent,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("ChangeStatus", mock.Anything, mock.MatchedBy(func(sr *domain.StoreStatusRequest) bool {
+					return sr.Action == domain.StoreActionBlock && sr.Reason == "fraudulent listings"
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:       "success_without_body",
+			arg:        uuid.NewV4().String(),
+			action:     domain.StoreActionApprove,
 			statusCode: fiber.StatusNoContent,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Block", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+				storeUsecase.On("ChangeStatus", mock.Anything, mock.MatchedBy(func(sr *domain.StoreStatusRequest) bool {
+					return sr.Action == domain.StoreActionApprove && sr.Reason == ""
+				})).Return(nil).Once()
 			},
 		},
 	}
@@ -284,8 +568,8 @@ func Test_StoreHandler_Block(t *testing.T) {
 			app := fiber.New()
 			validator := validator.New()
 			handler := handler.NewStoreHandler(storeUsecase, validator)
-			app.Patch("/:id/block", handler.Block)
-			req := httptest.NewRequest(fiber.MethodPatch, fmt.Sprintf("/%s/block", tc.arg), nil)
+			app.Patch("/:id/"+tc.action, handler.ChangeStatus(tc.action))
+			req := httptest.NewRequest(fiber.MethodPatch, fmt.Sprintf("/%s/%s", tc.arg, tc.action), strings.NewReader(tc.body))
 			req.Header.Set("Content-Type", "application/json")
 			res, err := app.Test(req)
 			assert.NoError(t, err)
@@ -295,7 +579,7 @@ func Test_StoreHandler_Block(t *testing.T) {
 	}
 }
 
-func Test_StoreHandler_Disable(t *testing.T) {
+func Test_StoreHandler_Actions(t *testing.T) {
 	testCases := []struct {
 		name       string
 		arg        string
@@ -310,17 +594,18 @@ func Test_StoreHandler_Disable(t *testing.T) {
 		{
 			name:       "failure_usecase_returns_error",
 			arg:        uuid.NewV4().String(),
-			statusCode: fiber.StatusConflict,
+			statusCode: fiber.StatusForbidden,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Disable", mock.Anything, mock.AnythingOfType("string")).Return(domain.ErrBlocked).Once()
+				storeUsecase.On("Actions", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrForbidden).Once()
 			},
 		},
 		{
 			name:       "success",
 			arg:        uuid.NewV4().String(),
-			statusCode: fiber.StatusNoContent,
+			statusCode: fiber.StatusOK,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Disable", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+				actions := domain.StoreLifecycle.From(domain.StoreStatusPending)
+				storeUsecase.On("Actions", mock.Anything, mock.AnythingOfType("string")).Return(actions, nil).Once()
 			},
 		},
 	}
@@ -336,9 +621,8 @@ func Test_StoreHandler_Disable(t *testing.T) {
 			app := fiber.New()
 			validator := validator.New()
 			handler := handler.NewStoreHandler(storeUsecase, validator)
-			app.Patch("/:id/disable", handler.Disable)
-			req := httptest.NewRequest(fiber.MethodPatch, fmt.Sprintf("/%s/disable", tc.arg), nil)
-			req.Header.Set("Content-Type", "application/json")
+			app.Get("/:id/actions", handler.Actions)
+			req := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/%s/actions", tc.arg), nil)
 			res, err := app.Test(req)
 			assert.NoError(t, err)
 			assert.Equal(t, res.StatusCode, tc.statusCode)
@@ -347,32 +631,67 @@ func Test_StoreHandler_Disable(t *testing.T) {
 	}
 }
 
-func Test_StoreHandler_Delete(t *testing.T) {
+func Test_StoreHandler_UploadImage(t *testing.T) {
+	// multipartBody returns a form holding data as the file of field
+	multipartBody := func(field, contentType string, data []byte) (*bytes.Buffer, string) {
+		body := new(bytes.Buffer)
+		w := multipart.NewWriter(body)
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="%s"; filename="image"`, field))
+		header.Set("Content-Type", contentType)
+		part, _ := w.CreatePart(header)
+		_, _ = part.Write(data)
+		_ = w.Close()
+		return body, w.FormDataContentType()
+	}
+
 	testCases := []struct {
 		name       string
 		arg        string
+		field      string
 		statusCode int
 		prepare    func(storeUsecase *mocks.StoreUsecase)
 	}{
 		{
 			name:       "failure_invalid_id",
 			arg:        "invalid_id",
+			field:      "image",
 			statusCode: fiber.StatusBadRequest,
 		},
 		{
-			name:       "failure_usecase_returns_error",
+			name:       "failure_missing_image",
 			arg:        uuid.NewV4().String(),
-			statusCode: fiber.StatusInternalServerError,
+			field:      "file",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_unsupported_image",
+			arg:        uuid.NewV4().String(),
+			field:      "image",
+			statusCode: fiber.StatusUnsupportedMediaType,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(errors.New("Unextpected Error")).Once()
+				storeUsecase.On("UploadImage", mock.Anything, mock.Anything).Return(nil, domain.ErrUnsupportedImage).Once()
+			},
+		},
+		{
+			name:       "failure_image_too_large",
+			arg:        uuid.NewV4().String(),
+			field:      "image",
+			statusCode: fiber.StatusRequestEntityTooLarge,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("UploadImage", mock.Anything, mock.Anything).Return(nil, domain.ErrImageTooLarge).Once()
 			},
 		},
 		{
 			name:       "success",
 			arg:        uuid.NewV4().String(),
-			statusCode: fiber.StatusNoContent,
+			field:      "image",
+			statusCode: fiber.StatusOK,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+				storeUsecase.On("UploadImage", mock.Anything, mock.MatchedBy(func(param *domain.StoreImageRequest) bool {
+					data, err := ioutil.ReadAll(param.Body)
+					return err == nil && string(data) == "image" && param.ContentType == "image/jpeg" && param.Size == 5
+				})).Return(sample.NewStore(), nil).Once()
 			},
 		},
 	}
@@ -388,9 +707,10 @@ func Test_StoreHandler_Delete(t *testing.T) {
 			app := fiber.New()
 			validator := validator.New()
 			handler := handler.NewStoreHandler(storeUsecase, validator)
-			app.Delete("/:id", handler.Delete)
-			req := httptest.NewRequest(fiber.MethodDelete, fmt.Sprintf("/%s", tc.arg), nil)
-			req.Header.Set("Content-Type", "application/json")
+			app.Post("/:id/image", handler.UploadImage)
+			body, contentType := multipartBody(tc.field, "image/jpeg", []byte("image"))
+			req := httptest.NewRequest(fiber.MethodPost, fmt.Sprintf("/%s/image", tc.arg), body)
+			req.Header.Set(fiber.HeaderContentType, contentType)
 			res, err := app.Test(req)
 			assert.NoError(t, err)
 			assert.Equal(t, res.StatusCode, tc.statusCode)
@@ -399,51 +719,64 @@ func Test_StoreHandler_Delete(t *testing.T) {
 	}
 }
 
-func Test_StoreHandler_Update(t *testing.T) {
-	ur := sample.NewUpdateStoreRequest()
+func Test_StoreHandler_SetHours(t *testing.T) {
+	hr := sample.NewStoreHoursRequest()
+	body, _ := json.Marshal(hr)
 
-	c, _ := json.Marshal(ur)
 	testCases := []struct {
 		name       string
-		id         string
-		request    string
+		arg        string
+		body       []byte
 		statusCode int
 		prepare    func(storeUsecase *mocks.StoreUsecase)
 	}{
 		{
-			name:       "failure_invalid_od",
-			id:         "invalid_id",
-			request:    "",
+			name:       "failure_body_parser",
+			arg:        uuid.NewV4().String(),
+			body:       []byte("{"),
 			statusCode: fiber.StatusBadRequest,
 		},
 		{
-			name:       "failure_parser_body",
-			id:         uuid.NewV4().String(),
-			request:    "{error: this is wrong}",
+			name:       "failure_invalid_id",
+			arg:        "invalid_id",
+			body:       body,
 			statusCode: fiber.StatusBadRequest,
 		},
 		{
-			name:       "failure_validate_returns_error",
-			id:         "invalid_id",
-			request:    string(c),
+			name:       "failure_missing_timezone",
+			arg:        uuid.NewV4().String(),
+			body:       []byte(`{"weekly":[]}`),
 			statusCode: fiber.StatusBadRequest,
 		},
 		{
-			name:       "failure_usecase_returns_error",
-			id:         uuid.NewV4().String(),
-			request:    string(c),
-			statusCode: fiber.StatusInternalServerError,
+			name:       "failure_invalid_timezone",
+			arg:        uuid.NewV4().String(),
+			body:       body,
+			statusCode: fiber.StatusBadRequest,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Update", mock.Anything, mock.Anything).Return(errors.New("Unextpected Error"))
+				storeUsecase.On("SetHours", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidTimezone).Once()
+			},
+		},
+		{
+			name:       "failure_forbidden",
+			arg:        uuid.NewV4().String(),
+			body:       body,
+			statusCode: fiber.StatusForbidden,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("SetHours", mock.Anything, mock.Anything).Return(nil, domain.ErrForbidden).Once()
 			},
 		},
 		{
 			name:       "success",
-			id:         uuid.NewV4().String(),
-			request:    string(c),
-			statusCode: fiber.StatusNoContent,
+			arg:        uuid.NewV4().String(),
+			body:       body,
+			statusCode: fiber.StatusOK,
 			prepare: func(storeUsecase *mocks.StoreUsecase) {
-				storeUsecase.On("Update", mock.Anything, mock.Anything).Return(nil)
+				store := sample.NewStore()
+				store.SetHours(sample.NewStoreHours(), time.Now())
+				storeUsecase.On("SetHours", mock.Anything, mock.MatchedBy(func(param *domain.StoreHoursRequest) bool {
+					return param.Timezone == hr.Timezone && len(param.Weekly) == len(hr.Weekly) && param.Closures[0].From == "2021-12-25"
+				})).Return(store, nil).Once()
 			},
 		},
 	}
@@ -459,12 +792,699 @@ func Test_StoreHandler_Update(t *testing.T) {
 			app := fiber.New()
 			validator := validator.New()
 			handler := handler.NewStoreHandler(storeUsecase, validator)
-			app.Patch("/:id", handler.Update)
-			req := httptest.NewRequest(fiber.MethodPatch, fmt.Sprintf("/%s", tc.id), strings.NewReader(tc.request))
+			app.Put("/:id/hours", handler.SetHours)
+			req := httptest.NewRequest(fiber.MethodPut, fmt.Sprintf("/%s/hours", tc.arg), bytes.NewReader(tc.body))
 			req.Header.Set("Content-Type", "application/json")
 			res, err := app.Test(req)
 			assert.NoError(t, err)
 			assert.Equal(t, res.StatusCode, tc.statusCode)
+			if res.StatusCode == fiber.StatusOK {
+				store := make(map[string]interface{})
+				assert.NoError(t, json.NewDecoder(res.Body).Decode(&store))
+				assert.Equal(t, true, store["is_open_now"])
+				assert.Contains(t, store, "hours")
+			}
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_Delete(t *testing.T) {
+	testCases := []struct {
+		name       string
+		arg        string
+		statusCode int
+		prepare    func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_invalid_id",
+			arg:        "invalid_id",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusInternalServerError,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(errors.New("Unextpected Error")).Once()
+			},
+		},
+		{
+			name:       "success",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusNoContent,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Delete("/:id", handler.Delete)
+			req := httptest.NewRequest(fiber.MethodDelete, fmt.Sprintf("/%s", tc.arg), nil)
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, res.StatusCode, tc.statusCode)
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_Restore(t *testing.T) {
+	testCases := []struct {
+		name       string
+		arg        string
+		statusCode int
+		prepare    func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_invalid_id",
+			arg:        "invalid_id",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_store_not_deleted",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusNotFound,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Restore", mock.Anything, mock.AnythingOfType("string")).Return(domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:       "success",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusNoContent,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Restore", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Post("/:id/restore", handler.Restore)
+			req := httptest.NewRequest(fiber.MethodPost, fmt.Sprintf("/%s/restore", tc.arg), nil)
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, res.StatusCode, tc.statusCode)
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_Update(t *testing.T) {
+	ur := sample.NewUpdateStoreRequest()
+
+	c, _ := json.Marshal(ur)
+	testCases := []struct {
+		name       string
+		id         string
+		request    string
+		ifMatch    string
+		statusCode int
+		prepare    func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_invalid_od",
+			id:         "invalid_id",
+			request:    "",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_parser_body",
+			id:         uuid.NewV4().String(),
+			request:    "{error: this is wrong}",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_validate_returns_error",
+			id:         "invalid_id",
+			request:    string(c),
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			id:         uuid.NewV4().String(),
+			request:    string(c),
+			statusCode: fiber.StatusInternalServerError,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Update", mock.Anything, mock.Anything).Return(errors.New("Unextpected Error"))
+			},
+		},
+		{
+			name:       "failure_invalid_if_match",
+			id:         uuid.NewV4().String(),
+			request:    string(c),
+			ifMatch:    `"abc"`,
+			statusCode: fiber.StatusPreconditionFailed,
+		},
+		{
+			name:       "failure_if_match_version_conflict",
+			id:         uuid.NewV4().String(),
+			request:    string(c),
+			ifMatch:    `"2"`,
+			statusCode: fiber.StatusPreconditionFailed,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Update", mock.Anything, mock.MatchedBy(func(ur *domain.UpdateStoreRequest) bool {
+					return ur.Version == 2
+				})).Return(domain.ErrConflict)
+			},
+		},
+		{
+			name:       "failure_concurrent_update_conflict",
+			id:         uuid.NewV4().String(),
+			request:    string(c),
+			statusCode: fiber.StatusConflict,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Update", mock.Anything, mock.Anything).Return(domain.ErrConflict)
+			},
+		},
+		{
+			name:       "success_with_if_match",
+			id:         uuid.NewV4().String(),
+			request:    string(c),
+			ifMatch:    `W/"3"`,
+			statusCode: fiber.StatusNoContent,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Update", mock.Anything, mock.MatchedBy(func(ur *domain.UpdateStoreRequest) bool {
+					return ur.Version == 3
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "success",
+			id:         uuid.NewV4().String(),
+			request:    string(c),
+			statusCode: fiber.StatusNoContent,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Update", mock.Anything, mock.Anything).Return(nil)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Patch("/:id", handler.Update)
+			req := httptest.NewRequest(fiber.MethodPatch, fmt.Sprintf("/%s", tc.id), strings.NewReader(tc.request))
+			req.Header.Set("Content-Type", "application/json")
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, res.StatusCode, tc.statusCode)
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_Account(t *testing.T) {
+	testCases := []struct {
+		name       string
+		arg        string
+		statusCode int
+		prepare    func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_invalid_id",
+			arg:        "invalid_id",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusNotFound,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("GetAccount", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:       "success",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusOK,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("GetAccount", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Get("/:id/account", handler.Account)
+			req := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/%s/account", tc.arg), nil)
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, res.StatusCode, tc.statusCode)
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_Deposit(t *testing.T) {
+	testCases := []struct {
+		name       string
+		id         string
+		arg        string
+		statusCode int
+		prepare    func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_parser_body",
+			id:         uuid.NewV4().String(),
+			arg:        `{error: this is wrong}`,
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_invalid_id",
+			id:         "invalid_id",
+			arg:        `{"amount": 10.5}`,
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_invalid_amount",
+			id:         uuid.NewV4().String(),
+			arg:        `{"amount": -10.5}`,
+			statusCode: fiber.StatusBadRequest,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Deposit", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidAmount).Once()
+			},
+		},
+		{
+			name:       "success",
+			id:         uuid.NewV4().String(),
+			arg:        `{"amount": "10.50"}`,
+			statusCode: fiber.StatusOK,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Deposit", mock.Anything, mock.MatchedBy(func(ar *domain.AccountOperationRequest) bool {
+					return ar.Amount.String() == "10.5"
+				})).Return(sample.NewAccount(), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Post("/:id/account/deposit", handler.Deposit)
+			req := httptest.NewRequest(fiber.MethodPost, fmt.Sprintf("/%s/account/deposit", tc.id), strings.NewReader(tc.arg))
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, res.StatusCode, tc.statusCode)
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_Withdraw(t *testing.T) {
+	testCases := []struct {
+		name       string
+		id         string
+		arg        string
+		statusCode int
+		prepare    func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_invalid_id",
+			id:         "invalid_id",
+			arg:        `{"amount": 10.5}`,
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_insufficient_balance",
+			id:         uuid.NewV4().String(),
+			arg:        `{"amount": 10.5}`,
+			statusCode: fiber.StatusUnprocessableEntity,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Withdraw", mock.Anything, mock.Anything).Return(nil, domain.ErrInsufficientBalance).Once()
+			},
+		},
+		{
+			name:       "success",
+			id:         uuid.NewV4().String(),
+			arg:        `{"amount": 10.5}`,
+			statusCode: fiber.StatusOK,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Withdraw", mock.Anything, mock.Anything).Return(sample.NewAccount(), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Post("/:id/account/withdraw", handler.Withdraw)
+			req := httptest.NewRequest(fiber.MethodPost, fmt.Sprintf("/%s/account/withdraw", tc.id), strings.NewReader(tc.arg))
+			req.Header.Set("Content-Type", "application/json")
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, res.StatusCode, tc.statusCode)
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_Transactions(t *testing.T) {
+	testCases := []struct {
+		name       string
+		arg        string
+		statusCode int
+		prepare    func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_invalid_id",
+			arg:        "invalid_id",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusNotFound,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("ListTransactions", mock.Anything, mock.AnythingOfType("string"), 10, 2).Return(nil, int64(0), domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:       "success",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusOK,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				entries := domain.LedgerEntries{sample.NewLedgerEntry()}
+				storeUsecase.On("ListTransactions", mock.Anything, mock.AnythingOfType("string"), 10, 2).Return(entries, int64(11), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Get("/:id/account/transactions", handler.Transactions)
+			req := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/%s/account/transactions?page=2&limit=10", tc.arg), nil)
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, res.StatusCode, tc.statusCode)
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_History(t *testing.T) {
+	testCases := []struct {
+		name       string
+		arg        string
+		statusCode int
+		prepare    func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_invalid_id",
+			arg:        "invalid_id",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusNotFound,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("StatusHistory", mock.Anything, mock.AnythingOfType("string"), 10, 2).Return(nil, int64(0), domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:       "success",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusOK,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				history := domain.StoreStatusHistory{sample.NewStoreStatusChange()}
+				storeUsecase.On("StatusHistory", mock.Anything, mock.AnythingOfType("string"), 10, 2).Return(history, int64(11), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Get("/:id/history", handler.History)
+			req := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/%s/history?page=2&limit=10", tc.arg), nil)
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, res.StatusCode, tc.statusCode)
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_Reconcile(t *testing.T) {
+	testCases := []struct {
+		name       string
+		arg        string
+		statusCode int
+		prepare    func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_invalid_id",
+			arg:        "invalid_id",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_usecase_returns_error",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusNotFound,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("ReconcileAccount", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:       "success",
+			arg:        uuid.NewV4().String(),
+			statusCode: fiber.StatusOK,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("ReconcileAccount", mock.Anything, mock.AnythingOfType("string")).Return(&domain.Reconciliation{Consistent: true}, nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Get("/:id/account/reconciliation", handler.Reconcile)
+			req := httptest.NewRequest(fiber.MethodGet, fmt.Sprintf("/%s/account/reconciliation", tc.arg), nil)
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, res.StatusCode, tc.statusCode)
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_Import(t *testing.T) {
+	body := "name,description,category_id\nstore 001,first store," + uuid.NewV4().String() + "\n"
+	testCases := []struct {
+		name        string
+		query       string
+		contentType string
+		statusCode  int
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_invalid_dry_run",
+			query:      "?dry_run=maybe",
+			statusCode: fiber.StatusBadRequest,
+		},
+		{
+			name:       "failure_unknown_format",
+			query:      "?format=xml",
+			statusCode: fiber.StatusBadRequest,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Import", mock.Anything, mock.MatchedBy(func(r *domain.StoreImportRequest) bool {
+					return r.Format == "xml"
+				})).Return(nil, domain.ErrUnknownFormat).Once()
+			},
+		},
+		{
+			name:        "failure_invalid_header",
+			contentType: "text/csv",
+			statusCode:  fiber.StatusBadRequest,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Import", mock.Anything, mock.Anything).Return(nil, domain.ErrInvalidImportHeader).Once()
+			},
+		},
+		{
+			name:        "success_format_from_content_type",
+			query:       "?dry_run=true",
+			contentType: "text/csv; charset=utf-8",
+			statusCode:  fiber.StatusOK,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Import", mock.Anything, mock.MatchedBy(func(r *domain.StoreImportRequest) bool {
+					return r.Format == domain.StoreFormatCSV && r.DryRun
+				})).Return(&domain.StoreImportReport{DryRun: true, Rows: 1, Imported: 1}, nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Post("/stores\\::action", middleware.CustomMethod("import"), handler.Import)
+			req := httptest.NewRequest(fiber.MethodPost, "/stores:import"+tc.query, strings.NewReader(body))
+			if tc.contentType != "" {
+				req.Header.Set(fiber.HeaderContentType, tc.contentType)
+			}
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, res.StatusCode, tc.statusCode)
+			storeUsecase.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreHandler_Export(t *testing.T) {
+	testCases := []struct {
+		name        string
+		query       string
+		statusCode  int
+		contentType string
+		body        string
+		prepare     func(storeUsecase *mocks.StoreUsecase)
+	}{
+		{
+			name:       "failure_forbidden",
+			statusCode: fiber.StatusForbidden,
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Export", mock.Anything, domain.StoreFormatNDJSON, mock.Anything).Return(domain.ErrForbidden).Once()
+			},
+		},
+		{
+			name:        "success_empty",
+			query:       "?format=ndjson",
+			statusCode:  fiber.StatusOK,
+			contentType: "application/x-ndjson",
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Export", mock.Anything, domain.StoreFormatNDJSON, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:        "success_csv",
+			query:       "?format=csv",
+			statusCode:  fiber.StatusOK,
+			contentType: "text/csv",
+			body:        "id,name\n",
+			prepare: func(storeUsecase *mocks.StoreUsecase) {
+				storeUsecase.On("Export", mock.Anything, domain.StoreFormatCSV, mock.Anything).
+					Run(func(args mock.Arguments) {
+						args.Get(2).(io.Writer).Write([]byte("id,name\n"))
+					}).
+					Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeUsecase := new(mocks.StoreUsecase)
+			if tc.prepare != nil {
+				tc.prepare(storeUsecase)
+			}
+			app := fiber.New()
+			validator := validator.New()
+			handler := handler.NewStoreHandler(storeUsecase, validator)
+			app.Get("/stores\\::action", middleware.CustomMethod("export"), handler.Export)
+			req := httptest.NewRequest(fiber.MethodGet, "/stores:export"+tc.query, nil)
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, res.StatusCode, tc.statusCode)
+			if tc.contentType != "" {
+				assert.Equal(t, tc.contentType, res.Header.Get(fiber.HeaderContentType))
+				body, err := ioutil.ReadAll(res.Body)
+				assert.NoError(t, err)
+				assert.Equal(t, tc.body, string(body))
+			}
 			storeUsecase.AssertExpectations(t)
 		})
 	}
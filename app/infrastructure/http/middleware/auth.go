@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+// Authenticate rejects the requests without a valid bearer token, and puts
+// the claims of the token in the user context of the others
+func Authenticate(verifier domain.TokenVerifier) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token := bearerToken(c.Get(fiber.HeaderAuthorization))
+		if token == "" {
+			return unauthorized(c)
+		}
+
+		claims, err := verifier.Verify(c.UserContext(), token)
+		if err != nil {
+			log.
+				WithContext(c.UserContext()).
+				Errorf("verifier.Verify: %v", err)
+			return unauthorized(c)
+		}
+
+		c.SetUserContext(domain.ContextWithClaims(c.UserContext(), claims))
+		return c.Next()
+	}
+}
+
+// bearerToken returns the token of a "Bearer <token>" authorization header
+func bearerToken(header string) string {
+	const prefix = "bearer "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ""
+	}
+	return strings.TrimSpace(header[len(prefix):])
+}
+
+func unauthorized(c *fiber.Ctx) error {
+	c.Set(fiber.HeaderWWWAuthenticate, "Bearer")
+	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+		"message": domain.ErrUnauthorized.Error(),
+	})
+}
@@ -0,0 +1,73 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/http/middleware"
+	"github.com/EdlanioJ/kbu-store/app/utils/mocks"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthenticate(t *testing.T) {
+	testCases := []struct {
+		name          string
+		authorization string
+		statusCode    int
+		prepare       func(verifier *mocks.TokenVerifier)
+	}{
+		{
+			name:       "failure_missing_token",
+			statusCode: fiber.StatusUnauthorized,
+		},
+		{
+			name:          "failure_not_bearer",
+			authorization: "Basic dXNlcjpwYXNz",
+			statusCode:    fiber.StatusUnauthorized,
+		},
+		{
+			name:          "failure_invalid_token",
+			authorization: "Bearer invalid",
+			statusCode:    fiber.StatusUnauthorized,
+			prepare: func(verifier *mocks.TokenVerifier) {
+				verifier.On("Verify", mock.Anything, "invalid").Return(nil, domain.ErrUnauthorized).Once()
+			},
+		},
+		{
+			name:          "success",
+			authorization: "bearer valid",
+			statusCode:    fiber.StatusOK,
+			prepare: func(verifier *mocks.TokenVerifier) {
+				verifier.On("Verify", mock.Anything, "valid").Return(&domain.Claims{UserID: "user-id"}, nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			verifier := new(mocks.TokenVerifier)
+			if tc.prepare != nil {
+				tc.prepare(verifier)
+			}
+			app := fiber.New()
+			app.Get("/", middleware.Authenticate(verifier), func(c *fiber.Ctx) error {
+				claims, ok := domain.ClaimsFromContext(c.UserContext())
+				assert.True(t, ok)
+				return c.SendString(claims.UserID)
+			})
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tc.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tc.authorization)
+			}
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+			verifier.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package middleware
+
+import "github.com/gofiber/fiber/v2"
+
+// CustomMethod serves a route with an :action param, like
+// "/stores\\::action", only for the custom method name, and answers not found
+// for the other actions. Fiber only unescapes the colon of the routes with
+// params, so a custom method can not be a static route.
+func CustomMethod(name string) fiber.Handler {
+	notFound := NotFound()
+
+	return func(c *fiber.Ctx) error {
+		if c.Params("action") != name {
+			return notFound(c)
+		}
+		return c.Next()
+	}
+}
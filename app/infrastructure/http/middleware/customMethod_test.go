@@ -0,0 +1,49 @@
+package middleware_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/http/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustomMethod(t *testing.T) {
+	testCases := []struct {
+		name       string
+		path       string
+		statusCode int
+	}{
+		{
+			name:       "failure_other_action",
+			path:       "/stores:export",
+			statusCode: fiber.StatusNotFound,
+		},
+		{
+			name:       "failure_without_colon",
+			path:       "/storesimport",
+			statusCode: fiber.StatusNotFound,
+		},
+		{
+			name:       "success",
+			path:       "/stores:import",
+			statusCode: fiber.StatusNoContent,
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			app := fiber.New()
+			app.Post("/stores\\::action", middleware.CustomMethod("import"), func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+			req := httptest.NewRequest(fiber.MethodPost, tc.path, nil)
+			res, err := app.Test(req)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.statusCode, res.StatusCode)
+		})
+	}
+}
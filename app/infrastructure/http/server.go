@@ -1,8 +1,11 @@
 package http
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
+	"net"
+	"time"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
 	_ "github.com/EdlanioJ/kbu-store/app/infrastructure/http/docs"
@@ -15,16 +18,26 @@ import (
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/gofiber/helmet/v2"
+	log "github.com/sirupsen/logrus"
 )
 
 type httpServer struct {
-	Port         int
-	StoreUsecase domain.StoreUsecase
-	Validate     *validator.Validate
+	Port            int
+	ShutdownTimeout time.Duration
+	BodyLimit       int
+	OnReady         func()
+	StoreUsecase    domain.StoreUsecase
+	CategoryUsecase domain.CategoryUsecase
+	HealthUsecase   domain.HealthUsecase
+	TokenVerifier   domain.TokenVerifier
+	Validate        *validator.Validate
 }
 
 func NewHttpServer() *httpServer {
-	return &httpServer{}
+	return &httpServer{
+		ShutdownTimeout: 10 * time.Second,
+		BodyLimit:       fiber.DefaultBodyLimit,
+	}
 }
 
 // @title KBU Store API
@@ -36,8 +49,15 @@ func NewHttpServer() *httpServer {
 // @license.name Apache 2.0
 // @license.url http://www.apache.org/licenses/LICENSE-2.0.html
 // @BasePath /api/v1
-func (s *httpServer) Serve() {
-	app := fiber.New()
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// Serve listens until ctx is done, then waits up to ShutdownTimeout for the
+// in-flight requests to finish. OnReady is called once the port is bound.
+func (s *httpServer) Serve(ctx context.Context) error {
+	app := fiber.New(fiber.Config{
+		BodyLimit: s.BodyLimit,
+	})
 
 	prometheus := fiberprometheus.New("kbu-store")
 	prometheus.RegisterAt(app, "/metrics")
@@ -47,6 +67,10 @@ func (s *httpServer) Serve() {
 	app.Use(helmet.New())
 	app.Use(requestid.New())
 
+	healthHandler := handler.NewHealthHandler(s.HealthUsecase)
+	app.Get("/healthz", healthHandler.Liveness)
+	app.Get("/readyz", healthHandler.Readiness)
+
 	v1 := app.Group("/api/v1")
 
 	v1.Get("/docs/*", swagger.Handler)
@@ -54,19 +78,79 @@ func (s *httpServer) Serve() {
 	s.routes(v1)
 	app.Use(middleware.NotFound())
 
-	log.Fatal(app.Listen(fmt.Sprintf(":%d", s.Port)))
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", s.Port))
+	if err != nil {
+		return err
+	}
+	if s.OnReady != nil {
+		s.OnReady()
+	}
+
+	listenErr := make(chan error, 1)
+	go func() {
+		listenErr <- app.Listener(listener)
+	}()
+
+	select {
+	case err := <-listenErr:
+		return err
+	case <-ctx.Done():
+	}
+
+	log.Info("shutting down http server...")
+	shutdownErr := make(chan error, 1)
+	go func() {
+		shutdownErr <- app.Shutdown()
+	}()
+
+	select {
+	case err := <-shutdownErr:
+		return err
+	case <-time.After(s.ShutdownTimeout):
+		return errors.New("http server shutdown timed out")
+	}
 }
 
 func (s *httpServer) routes(route fiber.Router) {
 	storeHandler := handler.NewStoreHandler(s.StoreUsecase, s.Validate)
 	storeRoutes := route.Group("/stores")
 
-	storeRoutes.Post("/", storeHandler.Store)
 	storeRoutes.Get("/", storeHandler.Index)
+	storeRoutes.Get("/nearby", storeHandler.Nearby)
+	storeRoutes.Get("/search", storeHandler.Search)
 	storeRoutes.Get("/:id", storeHandler.Get)
-	storeRoutes.Patch("/:id", storeHandler.Update)
-	storeRoutes.Patch("/:id/activate", storeHandler.Activate)
-	storeRoutes.Patch("/:id/block", storeHandler.Block)
-	storeRoutes.Patch("/:id/disable", storeHandler.Disable)
-	storeRoutes.Delete("/:id", storeHandler.Delete)
+
+	authenticate := middleware.Authenticate(s.TokenVerifier)
+	storeRoutes.Post("/", authenticate, storeHandler.Store)
+	route.Post("/stores\\::action", middleware.CustomMethod("import"), authenticate, storeHandler.Import)
+	route.Get("/stores\\::action", middleware.CustomMethod("export"), authenticate, storeHandler.Export)
+	storeRoutes.Patch("/:id", authenticate, storeHandler.Update)
+	storeRoutes.Post("/:id/image", authenticate, storeHandler.UploadImage)
+	storeRoutes.Put("/:id/hours", authenticate, storeHandler.SetHours)
+	for _, transition := range domain.StoreLifecycle {
+		if transition.Actor == domain.TransitionActorSystem {
+			continue
+		}
+		storeRoutes.Patch("/:id/"+transition.Action, authenticate, storeHandler.ChangeStatus(transition.Action))
+	}
+	storeRoutes.Get("/:id/actions", authenticate, storeHandler.Actions)
+	storeRoutes.Get("/:id/history", authenticate, storeHandler.History)
+	storeRoutes.Delete("/:id", authenticate, storeHandler.Delete)
+	storeRoutes.Post("/:id/restore", authenticate, storeHandler.Restore)
+	storeRoutes.Get("/:id/account", authenticate, storeHandler.Account)
+	storeRoutes.Post("/:id/account/deposit", authenticate, storeHandler.Deposit)
+	storeRoutes.Post("/:id/account/withdraw", authenticate, storeHandler.Withdraw)
+	storeRoutes.Get("/:id/account/transactions", authenticate, storeHandler.Transactions)
+	storeRoutes.Get("/:id/account/reconciliation", authenticate, storeHandler.Reconcile)
+
+	categoryHandler := handler.NewCategoryHandler(s.CategoryUsecase, s.Validate)
+	categoryRoutes := route.Group("/categories")
+
+	categoryRoutes.Get("/", categoryHandler.Index)
+	categoryRoutes.Get("/tree", categoryHandler.Tree)
+	categoryRoutes.Get("/:id", categoryHandler.Get)
+	categoryRoutes.Get("/:id/stores", storeHandler.IndexByCategory)
+	categoryRoutes.Patch("/:id/activate", authenticate, categoryHandler.Activate)
+	categoryRoutes.Patch("/:id/disable", authenticate, categoryHandler.Disable)
+	categoryRoutes.Delete("/:id", authenticate, categoryHandler.Delete)
 }
@@ -0,0 +1,120 @@
+package imaging
+
+import (
+	"encoding/binary"
+	"image"
+)
+
+// exifOrientationTag is the EXIF tag telling how the camera was held
+const exifOrientationTag = 0x0112
+
+// jpegOrientation returns the EXIF orientation of the jpeg in data, from 1
+// (upright) to 8, and 1 when it has none
+func jpegOrientation(data []byte) int {
+	if len(data) < 2 || data[0] != 0xff || data[1] != 0xd8 {
+		return 1
+	}
+
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xff {
+			return 1
+		}
+		marker := data[i+1]
+		if marker == 0xff {
+			// fill byte before the marker
+			i++
+			continue
+		}
+		// the metadata segments all come before the start of scan
+		if marker == 0xda || marker == 0xd9 {
+			return 1
+		}
+
+		size := int(binary.BigEndian.Uint16(data[i+2:]))
+		if size < 2 || i+2+size > len(data) {
+			return 1
+		}
+		if marker == 0xe1 {
+			if o := exifOrientation(data[i+4 : i+2+size]); o != 0 {
+				return o
+			}
+		}
+		i += 2 + size
+	}
+	return 1
+}
+
+// exifOrientation reads the orientation tag of the first IFD of an APP1
+// EXIF segment, 0 when it is missing or invalid
+func exifOrientation(segment []byte) int {
+	if len(segment) < 14 || string(segment[:6]) != "Exif\x00\x00" {
+		return 0
+	}
+
+	tiff := segment[6:]
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 0
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 0
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for e := ifd + 2; entries > 0 && e+12 <= len(tiff); e, entries = e+12, entries-1 {
+		if order.Uint16(tiff[e:]) != exifOrientationTag {
+			continue
+		}
+		o := int(order.Uint16(tiff[e+8:]))
+		if o < 1 || o > 8 {
+			return 0
+		}
+		return o
+	}
+	return 0
+}
+
+// orient turns src upright, as told by an EXIF orientation. Orientations 5
+// to 8 swap the width and the height.
+func orient(src image.Image, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if orientation >= 5 {
+		w, h = h, w
+	}
+
+	dst := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2: // flip horizontally
+				sx, sy = b.Dx()-1-x, y
+			case 3: // rotate 180°
+				sx, sy = b.Dx()-1-x, b.Dy()-1-y
+			case 4: // flip vertically
+				sx, sy = x, b.Dy()-1-y
+			case 5: // transpose
+				sx, sy = y, x
+			case 6: // rotate 90° clockwise
+				sx, sy = y, b.Dy()-1-x
+			case 7: // transverse
+				sx, sy = b.Dx()-1-y, b.Dy()-1-x
+			case 8: // rotate 90° counterclockwise
+				sx, sy = b.Dx()-1-y, x
+			}
+			dst.Set(x, y, src.At(b.Min.X+sx, b.Min.Y+sy))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,163 @@
+package imaging
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"math"
+	"net/http"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/opentracing/opentracing-go"
+)
+
+// DefaultMaxPixels bounds the decoded size of an image, 40 megapixels
+const DefaultMaxPixels = 40_000_000
+
+// Processor decodes the uploaded images and encodes their variants with the
+// standard library codecs. Only the pixels are encoded back, so the EXIF and
+// every other metadata of the upload is dropped, once the EXIF orientation of
+// a jpeg has turned its pixels upright.
+type Processor struct {
+	JPEGQuality int
+	MaxPixels   int
+}
+
+func NewProcessor() *Processor {
+	return &Processor{
+		JPEGQuality: 85,
+		MaxPixels:   DefaultMaxPixels,
+	}
+}
+
+// Process encodes each of variants of the image in body, in its content type.
+// It fails with ErrUnsupportedImage when the content of body is not a
+// contentType image.
+func (p *Processor) Process(ctx context.Context, body io.Reader, contentType string, variants []domain.ImageVariant) (res []*domain.EncodedImage, err error) {
+	span, _ := opentracing.StartSpanFromContext(ctx, "Processor.Process")
+	defer span.Finish()
+
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return
+	}
+
+	if http.DetectContentType(data) != contentType {
+		return nil, domain.ErrUnsupportedImage
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrUnsupportedImage
+	}
+	if cfg.Width*cfg.Height > p.MaxPixels {
+		return nil, domain.ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, domain.ErrUnsupportedImage
+	}
+	if contentType == "image/jpeg" {
+		src = orient(src, jpegOrientation(data))
+	}
+
+	res = make([]*domain.EncodedImage, 0, len(variants))
+	for _, variant := range variants {
+		img := fit(src, variant.Width, variant.Height)
+
+		var buf bytes.Buffer
+		err = p.encode(&buf, img, contentType)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, &domain.EncodedImage{
+			Variant:     variant.Name,
+			ContentType: contentType,
+			Width:       img.Bounds().Dx(),
+			Height:      img.Bounds().Dy(),
+			Data:        buf.Bytes(),
+		})
+	}
+	return
+}
+
+func (p *Processor) encode(w io.Writer, img image.Image, contentType string) error {
+	switch contentType {
+	case "image/jpeg":
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: p.JPEGQuality})
+	case "image/png":
+		return png.Encode(w, img)
+	case "image/gif":
+		return gif.Encode(w, img, nil)
+	}
+	return domain.ErrUnsupportedImage
+}
+
+// fit scales src down to fit width x height, keeping its aspect ratio. A
+// zero width and height, or an image that already fits, are left as is.
+func fit(src image.Image, width, height int) image.Image {
+	b := src.Bounds()
+	if (width == 0 && height == 0) || (b.Dx() <= width && b.Dy() <= height) {
+		return src
+	}
+
+	scale := math.Min(float64(width)/float64(b.Dx()), float64(height)/float64(b.Dy()))
+	w := int(math.Max(1, math.Round(float64(b.Dx())*scale)))
+	h := int(math.Max(1, math.Round(float64(b.Dy())*scale)))
+	return resize(src, w, h)
+}
+
+// resize scales src down to width x height, each pixel being the average of
+// the source pixels it covers
+func resize(src image.Image, width, height int) *image.NRGBA {
+	b := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := b.Min.Y + y*b.Dy()/height
+		y1 := b.Min.Y + (y+1)*b.Dy()/height
+		if y1 == y0 {
+			y1++
+		}
+
+		for x := 0; x < width; x++ {
+			x0 := b.Min.X + x*b.Dx()/width
+			x1 := b.Min.X + (x+1)*b.Dx()/width
+			if x1 == x0 {
+				x1++
+			}
+
+			var r, g, bl, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r += uint64(pr)
+					g += uint64(pg)
+					bl += uint64(pb)
+					a += uint64(pa)
+					n++
+				}
+			}
+			if a == 0 {
+				continue
+			}
+
+			// RGBA values are alpha premultiplied, the NRGBA ones are not
+			dst.SetNRGBA(x, y, color.NRGBA{
+				R: uint8(r * 0xffff / a >> 8),
+				G: uint8(g * 0xffff / a >> 8),
+				B: uint8(bl * 0xffff / a >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
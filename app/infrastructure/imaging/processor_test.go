@@ -0,0 +1,161 @@
+package imaging_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+func newImage(width, height int) image.Image {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.SetNRGBA(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 200, A: 255})
+		}
+	}
+	return img
+}
+
+// withExif inserts an APP1 EXIF segment right after the SOI marker of a jpeg
+func withExif(data []byte) []byte {
+	payload := append([]byte("Exif\x00\x00"), []byte("GPS 38.8977,-77.0365")...)
+	size := len(payload) + 2
+	segment := append([]byte{0xff, 0xe1, byte(size >> 8), byte(size)}, payload...)
+
+	res := append([]byte{}, data[:2]...)
+	res = append(res, segment...)
+	return append(res, data[2:]...)
+}
+
+// withOrientation inserts an APP1 EXIF segment holding only the orientation
+// tag right after the SOI marker of a jpeg
+func withOrientation(data []byte, orientation byte) []byte {
+	tiff := []byte{
+		'M', 'M', 0x00, 0x2a, 0x00, 0x00, 0x00, 0x08,
+		0x00, 0x01,
+		0x01, 0x12, 0x00, 0x03, 0x00, 0x00, 0x00, 0x01, 0x00, orientation, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00,
+	}
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	size := len(payload) + 2
+	segment := append([]byte{0xff, 0xe1, byte(size >> 8), byte(size)}, payload...)
+
+	res := append([]byte{}, data[:2]...)
+	res = append(res, segment...)
+	return append(res, data[2:]...)
+}
+
+func Test_Processor_Process(t *testing.T) {
+	t.Parallel()
+
+	var jpegData bytes.Buffer
+	_ = jpeg.Encode(&jpegData, newImage(1200, 800), nil)
+	exifData := withExif(jpegData.Bytes())
+
+	var pngData bytes.Buffer
+	_ = png.Encode(&pngData, newImage(100, 300))
+
+	t.Run("jpeg_variants_without_exif", func(t *testing.T) {
+		p := imaging.NewProcessor()
+		assert.True(t, bytes.Contains(exifData, []byte("Exif")))
+
+		res, err := p.Process(context.TODO(), bytes.NewReader(exifData), "image/jpeg", domain.StoreImageVariants)
+		assert.NoError(t, err)
+		assert.Len(t, res, 3)
+
+		sizes := map[string][2]int{
+			domain.StoreImageOriginal:  {1200, 800},
+			domain.StoreImageMedium:    {600, 400},
+			domain.StoreImageThumbnail: {150, 100},
+		}
+		for _, img := range res {
+			assert.Equal(t, "image/jpeg", img.ContentType)
+			assert.Equal(t, sizes[img.Variant], [2]int{img.Width, img.Height})
+			assert.False(t, bytes.Contains(img.Data, []byte("Exif")))
+
+			decoded, err := jpeg.Decode(bytes.NewReader(img.Data))
+			assert.NoError(t, err)
+			assert.Equal(t, img.Width, decoded.Bounds().Dx())
+		}
+	})
+
+	t.Run("jpeg_turned_upright", func(t *testing.T) {
+		// red on the left, blue on the right
+		src := image.NewNRGBA(image.Rect(0, 0, 64, 32))
+		for y := 0; y < 32; y++ {
+			for x := 0; x < 64; x++ {
+				c := color.NRGBA{R: 255, A: 255}
+				if x >= 32 {
+					c = color.NRGBA{B: 255, A: 255}
+				}
+				src.SetNRGBA(x, y, c)
+			}
+		}
+		var data bytes.Buffer
+		_ = jpeg.Encode(&data, src, &jpeg.Options{Quality: 100})
+
+		p := imaging.NewProcessor()
+		res, err := p.Process(context.TODO(), bytes.NewReader(withOrientation(data.Bytes(), 6)), "image/jpeg", []domain.ImageVariant{
+			{Name: domain.StoreImageOriginal},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, 32, res[0].Width)
+		assert.Equal(t, 64, res[0].Height)
+		assert.False(t, bytes.Contains(res[0].Data, []byte("Exif")))
+
+		// turned clockwise, the left of the image is now its top
+		decoded, err := jpeg.Decode(bytes.NewReader(res[0].Data))
+		assert.NoError(t, err)
+		r, _, b, _ := decoded.At(16, 8).RGBA()
+		assert.Greater(t, r, b)
+		r, _, b, _ = decoded.At(16, 56).RGBA()
+		assert.Greater(t, b, r)
+	})
+
+	t.Run("png_keeps_aspect_ratio", func(t *testing.T) {
+		p := imaging.NewProcessor()
+
+		res, err := p.Process(context.TODO(), bytes.NewReader(pngData.Bytes()), "image/png", []domain.ImageVariant{
+			{Name: domain.StoreImageThumbnail, Width: 150, Height: 150},
+		})
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, 50, res[0].Width)
+		assert.Equal(t, 150, res[0].Height)
+
+		decoded, err := png.Decode(bytes.NewReader(res[0].Data))
+		assert.NoError(t, err)
+		assert.Equal(t, 50, decoded.Bounds().Dx())
+	})
+
+	t.Run("content_type_mismatch", func(t *testing.T) {
+		p := imaging.NewProcessor()
+
+		_, err := p.Process(context.TODO(), bytes.NewReader(pngData.Bytes()), "image/jpeg", domain.StoreImageVariants)
+		assert.ErrorIs(t, err, domain.ErrUnsupportedImage)
+	})
+
+	t.Run("not_an_image", func(t *testing.T) {
+		p := imaging.NewProcessor()
+
+		_, err := p.Process(context.TODO(), bytes.NewReader([]byte("<html></html>")), "image/png", domain.StoreImageVariants)
+		assert.ErrorIs(t, err, domain.ErrUnsupportedImage)
+	})
+
+	t.Run("too_many_pixels", func(t *testing.T) {
+		p := imaging.NewProcessor()
+		p.MaxPixels = 1000
+
+		_, err := p.Process(context.TODO(), bytes.NewReader(pngData.Bytes()), "image/png", domain.StoreImageVariants)
+		assert.ErrorIs(t, err, domain.ErrImageTooLarge)
+	})
+}
@@ -2,6 +2,10 @@ package kafka
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
 
 	"github.com/EdlanioJ/kbu-store/app/config"
 	"github.com/EdlanioJ/kbu-store/app/domain"
@@ -9,11 +13,39 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
+// Headers added to the messages sent to the dead-letter topic
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderError             = "x-error"
+)
+
+// MessageReader fetches messages of a consumer group and commits their offsets
+type MessageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// MessageWriter writes messages to the topic set on each of them
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
 type KafkaConsumer struct {
-	Reader              *kafka.Reader
-	createCategoryTopic string
-	updateCategoryTopic string
+	Reader              MessageReader
+	DeadLetterWriter    MessageWriter
+	CreateCategoryTopic string
+	UpdateCategoryTopic string
 	CategoryUsecase     domain.CategoryUsecase
+	InboxUsecase        domain.InboxUsecase
+	DeadLetterTopic     string
+	MaxRetries          int
+	Backoff             time.Duration
+	MaxBackoff          time.Duration
+	OnReady             func()
 }
 
 func NewKafkaConsumer(cfg *config.Config) *KafkaConsumer {
@@ -27,66 +59,229 @@ func NewKafkaConsumer(cfg *config.Config) *KafkaConsumer {
 		MinBytes:    10e3,
 		MaxBytes:    10e6,
 	})
+	writer := kafka.NewWriter(kafka.WriterConfig{
+		Brokers:     cfg.Kafka.Brokers,
+		Balancer:    &kafka.LeastBytes{},
+		Logger:      kafka.LoggerFunc(log.Debugf),
+		ErrorLogger: kafka.LoggerFunc(log.Errorf),
+	})
 	return &KafkaConsumer{
 		Reader:              reader,
-		createCategoryTopic: createCategoryTopic,
-		updateCategoryTopic: updateCategoryTopic,
+		DeadLetterWriter:    writer,
+		CreateCategoryTopic: createCategoryTopic,
+		UpdateCategoryTopic: updateCategoryTopic,
+		DeadLetterTopic:     cfg.Kafka.DeadLetterTopic,
+		MaxRetries:          cfg.Kafka.MaxRetries,
+		Backoff:             time.Duration(cfg.Kafka.RetryBackoff) * time.Second,
+		MaxBackoff:          time.Duration(cfg.Kafka.MaxRetryBackoff) * time.Second,
 	}
 }
 
-func (k *KafkaConsumer) Consume() {
+// Consume processes messages until ctx is done. The offset of a message is
+// only committed once it was processed, or sent to the dead-letter topic
+// after failing MaxRetries times, so a crash redelivers it. OnReady is called
+// once consuming starts.
+func (k *KafkaConsumer) Consume(ctx context.Context) error {
 	defer k.Reader.Close()
+	defer k.DeadLetterWriter.Close()
+
 	log.Info("\u001b[92mStart Consuming...\u001b[0m")
-	for {
-		m, err := k.Reader.ReadMessage(context.Background())
+	if k.OnReady != nil {
+		k.OnReady()
+	}
+	for attempt := 0; ; {
+		m, err := k.Reader.FetchMessage(ctx)
 		if err != nil {
-			log.Fatalln(err)
+			if ctx.Err() != nil {
+				return nil
+			}
+
+			log.Errorf("Reader.FetchMessage: %v", err)
+			if !k.sleep(ctx, attempt) {
+				return nil
+			}
+			attempt++
+			continue
 		}
+		attempt = 0
 
-		k.processMessage(m)
+		err = k.handleMessage(ctx, m)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
 	}
-
 }
 
-func (k *KafkaConsumer) processMessage(msg kafka.Message) {
-	ctx := context.Background()
+// handleMessage processes msg, retrying failures, and commits its offset
+func (k *KafkaConsumer) handleMessage(ctx context.Context, msg kafka.Message) (err error) {
+	err = k.processWithRetry(ctx, msg)
+	if err != nil {
+		// left uncommitted, to be processed again after a restart
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		log.WithFields(log.Fields{
+			"topic":  msg.Topic,
+			"offset": msg.Offset,
+			"msg":    string(msg.Value),
+		}).Error(err)
 
-	switch topic := msg.Topic; topic {
-	case k.createCategoryTopic:
-		err := k.createCategory(ctx, msg.Value)
+		err = k.deadLetter(ctx, msg, err)
 		if err != nil {
-			log.WithFields(log.Fields{
-				"topic": topic,
-				"msg":   string(msg.Value),
-			}).Error(err)
+			return
 		}
-	case k.updateCategoryTopic:
-		err := k.updateCategory(ctx, msg.Value)
-		if err != nil {
-			log.WithFields(log.Fields{
-				"topic": topic,
-				"msg":   string(msg.Value),
-			}).Error(err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = k.Reader.CommitMessages(ctx, msg)
+		if err == nil {
+			return
+		}
+
+		log.Errorf("Reader.CommitMessages: %v", err)
+		if !k.sleep(ctx, attempt) {
+			return ctx.Err()
+		}
+	}
+}
+
+// processWithRetry processes msg up to MaxRetries times, waiting longer after
+// each failure. Messages that can never succeed are not retried.
+func (k *KafkaConsumer) processWithRetry(ctx context.Context, msg kafka.Message) (err error) {
+	for attempt := 0; ; attempt++ {
+		err = k.processMessage(ctx, msg)
+		if err == nil || isPermanent(err) || attempt+1 >= k.MaxRetries {
+			return
+		}
+
+		log.WithFields(log.Fields{
+			"topic":   msg.Topic,
+			"offset":  msg.Offset,
+			"attempt": attempt + 1,
+		}).Warn(err)
+		if !k.sleep(ctx, attempt) {
+			return ctx.Err()
 		}
+	}
+}
+
+func (k *KafkaConsumer) processMessage(ctx context.Context, msg kafka.Message) error {
+	var handle func(ctx context.Context, category *domain.Category) error
+
+	switch topic := msg.Topic; topic {
+	case k.CreateCategoryTopic:
+		handle = k.CategoryUsecase.Create
+	case k.UpdateCategoryTopic:
+		handle = k.CategoryUsecase.Update
 	default:
 		log.WithField("topic", topic).Warn("Invalid msg: ", string(msg.Value))
+		return nil
 	}
-}
 
-func (k *KafkaConsumer) createCategory(ctx context.Context, data []byte) error {
 	category := new(domain.Category)
-	err := category.ParseJson(data)
+	err := category.ParseJson(msg.Value)
 	if err != nil {
 		return err
 	}
-	return k.CategoryUsecase.Create(ctx, category)
+
+	key := k.idempotencyKey(msg, category)
+	if key == "" {
+		return handle(ctx, category)
+	}
+
+	processed, err := k.InboxUsecase.Process(ctx, key, msg.Topic, func(ctx context.Context) error {
+		return handle(ctx, category)
+	})
+	if err == nil && !processed {
+		log.WithFields(log.Fields{
+			"topic": msg.Topic,
+			"key":   key,
+		}).Info("skipping duplicate message")
+	}
+	return err
 }
 
-func (k *KafkaConsumer) updateCategory(ctx context.Context, data []byte) error {
-	category := new(domain.Category)
-	err := category.ParseJson(data)
-	if err != nil {
-		return err
+// idempotencyKey returns the key a message is deduplicated by: its message
+// key, or the category ID for creations. Updates without a key are not
+// deduplicated, as applying one twice leaves the same category.
+func (k *KafkaConsumer) idempotencyKey(msg kafka.Message, category *domain.Category) string {
+	if len(msg.Key) > 0 {
+		return msg.Topic + ":" + string(msg.Key)
+	}
+	if msg.Topic == k.CreateCategoryTopic && category.ID != "" {
+		return msg.Topic + ":" + category.ID
+	}
+	return ""
+}
+
+// deadLetter sends msg to DeadLetterTopic along with where it came from and
+// why it failed. Without a dead-letter topic, msg is dropped.
+func (k *KafkaConsumer) deadLetter(ctx context.Context, msg kafka.Message, cause error) (err error) {
+	if k.DeadLetterTopic == "" {
+		log.WithField("topic", msg.Topic).Warn("dropping message, no dead-letter topic configured")
+		return
+	}
+
+	headers := append([]kafka.Header{}, msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: HeaderOriginalTopic, Value: []byte(msg.Topic)},
+		kafka.Header{Key: HeaderOriginalPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+		kafka.Header{Key: HeaderOriginalOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		kafka.Header{Key: HeaderError, Value: []byte(cause.Error())},
+	)
+	dead := kafka.Message{
+		Topic:   k.DeadLetterTopic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = k.DeadLetterWriter.WriteMessages(ctx, dead)
+		if err == nil {
+			return
+		}
+
+		log.Errorf("DeadLetterWriter.WriteMessages: %v", err)
+		if !k.sleep(ctx, attempt) {
+			return ctx.Err()
+		}
+	}
+}
+
+// sleep waits the backoff of attempt and reports whether ctx is still alive
+func (k *KafkaConsumer) sleep(ctx context.Context, attempt int) bool {
+	t := time.NewTimer(k.backoff(attempt))
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
+
+// backoff doubles the retry delay on every failed attempt, up to MaxBackoff
+func (k *KafkaConsumer) backoff(attempt int) time.Duration {
+	d := k.Backoff << uint(attempt)
+	if d <= 0 || d > k.MaxBackoff {
+		return k.MaxBackoff
 	}
-	return k.CategoryUsecase.Update(ctx, category)
+	return d
+}
+
+// isPermanent reports whether err would occur again on every retry
+func isPermanent(err error) bool {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	return errors.As(err, &syntaxErr) ||
+		errors.As(err, &typeErr) ||
+		errors.Is(err, domain.ErrCategoryCycle) ||
+		errors.Is(err, domain.ErrBadRequest)
 }
@@ -0,0 +1,192 @@
+package kafka_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/kafka"
+	"github.com/EdlanioJ/kbu-store/app/utils/mocks"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	createTopic     = "store.catetory.create"
+	updateTopic     = "store.catetory.update"
+	deadLetterTopic = "store.catetory.dead-letter"
+)
+
+// fakeReader hands out msgs, then cancels the consumer
+type fakeReader struct {
+	msgs      []kafkago.Message
+	committed []kafkago.Message
+	cancel    context.CancelFunc
+}
+
+func (r *fakeReader) FetchMessage(ctx context.Context) (kafkago.Message, error) {
+	if len(r.msgs) == 0 {
+		r.cancel()
+		return kafkago.Message{}, ctx.Err()
+	}
+	m := r.msgs[0]
+	r.msgs = r.msgs[1:]
+	return m, nil
+}
+
+func (r *fakeReader) CommitMessages(ctx context.Context, msgs ...kafkago.Message) error {
+	r.committed = append(r.committed, msgs...)
+	return nil
+}
+
+func (r *fakeReader) Close() error { return nil }
+
+type fakeWriter struct {
+	written []kafkago.Message
+}
+
+func (w *fakeWriter) WriteMessages(ctx context.Context, msgs ...kafkago.Message) error {
+	w.written = append(w.written, msgs...)
+	return nil
+}
+
+func (w *fakeWriter) Close() error { return nil }
+
+// fakeInbox processes each key once
+type fakeInbox struct {
+	seen map[string]bool
+}
+
+func (i *fakeInbox) Process(ctx context.Context, key, topic string, handle func(ctx context.Context) error) (bool, error) {
+	if i.seen[key] {
+		return false, nil
+	}
+	err := handle(ctx)
+	if err != nil {
+		return false, err
+	}
+	i.seen[key] = true
+	return true, nil
+}
+
+func header(m kafkago.Message, key string) string {
+	for _, h := range m.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func Test_KafkaConsumer_Consume(t *testing.T) {
+	categoryID := "4d6e8a3c-5a1e-4b6a-9c2d-2f1b0e7c9a11"
+	create := kafkago.Message{Topic: createTopic, Offset: 7, Value: []byte(`{"id":"` + categoryID + `","name":"Food"}`)}
+	update := kafkago.Message{Topic: updateTopic, Offset: 8, Key: []byte("evt-1"), Value: []byte(`{"id":"` + categoryID + `","name":"Foods"}`)}
+	poison := kafkago.Message{Topic: updateTopic, Offset: 9, Value: []byte(`{"id":`)}
+
+	testCases := []struct {
+		name       string
+		msgs       []kafkago.Message
+		committed  int
+		deadLetter int
+		prepare    func(categoryUsecase *mocks.CategoryUsecase)
+	}{
+		{
+			name:      "success",
+			msgs:      []kafkago.Message{create, update},
+			committed: 2,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				categoryUsecase.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:      "success_skips_duplicates",
+			msgs:      []kafkago.Message{create, create, update, update},
+			committed: 4,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				categoryUsecase.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:      "success_after_retry",
+			msgs:      []kafkago.Message{create},
+			committed: 1,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Create", mock.Anything, mock.Anything).Return(errors.New("Unexpected Error")).Twice()
+				categoryUsecase.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:       "failure_retries_exhausted_dead_letters",
+			msgs:       []kafkago.Message{create},
+			committed:  1,
+			deadLetter: 1,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Create", mock.Anything, mock.Anything).Return(errors.New("Unexpected Error")).Times(3)
+			},
+		},
+		{
+			name:       "failure_poison_message_dead_letters_without_retry",
+			msgs:       []kafkago.Message{poison},
+			committed:  1,
+			deadLetter: 1,
+			prepare:    func(categoryUsecase *mocks.CategoryUsecase) {},
+		},
+		{
+			name:       "failure_cycle_dead_letters_without_retry",
+			msgs:       []kafkago.Message{update},
+			committed:  1,
+			deadLetter: 1,
+			prepare: func(categoryUsecase *mocks.CategoryUsecase) {
+				categoryUsecase.On("Update", mock.Anything, mock.Anything).Return(domain.ErrCategoryCycle).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			reader := &fakeReader{msgs: tc.msgs, cancel: cancel}
+			writer := new(fakeWriter)
+			categoryUsecase := new(mocks.CategoryUsecase)
+			tc.prepare(categoryUsecase)
+
+			k := &kafka.KafkaConsumer{
+				Reader:              reader,
+				DeadLetterWriter:    writer,
+				CreateCategoryTopic: createTopic,
+				UpdateCategoryTopic: updateTopic,
+				CategoryUsecase:     categoryUsecase,
+				InboxUsecase:        &fakeInbox{seen: make(map[string]bool)},
+				DeadLetterTopic:     deadLetterTopic,
+				MaxRetries:          3,
+				Backoff:             time.Millisecond,
+				MaxBackoff:          time.Millisecond,
+			}
+
+			err := k.Consume(ctx)
+			assert.NoError(t, err)
+			assert.Len(t, reader.committed, tc.committed)
+			assert.Len(t, writer.written, tc.deadLetter)
+			for _, m := range writer.written {
+				original := tc.msgs[0]
+				assert.Equal(t, deadLetterTopic, m.Topic)
+				assert.Equal(t, original.Value, m.Value)
+				assert.Equal(t, original.Topic, header(m, kafka.HeaderOriginalTopic))
+				assert.Equal(t, "0", header(m, kafka.HeaderOriginalPartition))
+				assert.NotEmpty(t, header(m, kafka.HeaderOriginalOffset))
+				assert.NotEmpty(t, header(m, kafka.HeaderError))
+			}
+			categoryUsecase.AssertExpectations(t)
+		})
+	}
+}
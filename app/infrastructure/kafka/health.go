@@ -0,0 +1,78 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EdlanioJ/kbu-store/app/config"
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// GroupStateStable is the state of a consumer group whose partitions are
+// assigned to its members
+const GroupStateStable = "Stable"
+
+// AdminClient fetches the cluster metadata and describes consumer groups
+type AdminClient interface {
+	Metadata(ctx context.Context, req *kafka.MetadataRequest) (*kafka.MetadataResponse, error)
+	DescribeGroups(ctx context.Context, req *kafka.DescribeGroupsRequest) (*kafka.DescribeGroupsResponse, error)
+}
+
+// A HealthChecker checks the brokers and the consumer group of the service
+type HealthChecker struct {
+	Client  AdminClient
+	Brokers []string
+	GroupID string
+}
+
+func NewHealthChecker(cfg *config.Config) *HealthChecker {
+	return &HealthChecker{
+		Client:  &kafka.Client{},
+		Brokers: cfg.Kafka.Brokers,
+		GroupID: cfg.Kafka.GroupID,
+	}
+}
+
+// CheckBrokers fetches the cluster metadata from the first broker that
+// answers
+func (h *HealthChecker) CheckBrokers(ctx context.Context) error {
+	if len(h.Brokers) == 0 {
+		return errors.New("no kafka brokers configured")
+	}
+
+	var err error
+	for _, broker := range h.Brokers {
+		_, err = h.Client.Metadata(ctx, &kafka.MetadataRequest{Addr: kafka.TCP(broker)})
+		if err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// CheckConsumerGroup checks the consumer group is stable, that is, it is not
+// rebalancing and its partitions are assigned
+func (h *HealthChecker) CheckConsumerGroup(ctx context.Context) error {
+	res, err := h.Client.DescribeGroups(ctx, &kafka.DescribeGroupsRequest{
+		Addr:     kafka.TCP(h.Brokers...),
+		GroupIDs: []string{h.GroupID},
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, group := range res.Groups {
+		if group.GroupID != h.GroupID {
+			continue
+		}
+		if group.Error != nil {
+			return group.Error
+		}
+		if group.GroupState != GroupStateStable {
+			return fmt.Errorf("consumer group %s is %s", h.GroupID, group.GroupState)
+		}
+		return nil
+	}
+	return fmt.Errorf("consumer group %s not found", h.GroupID)
+}
@@ -0,0 +1,127 @@
+package kafka_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/kafka"
+	kafkago "github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAdmin answers for the brokers in up, with the groups set
+type fakeAdmin struct {
+	up     map[string]bool
+	groups []kafkago.DescribeGroupsResponseGroup
+	err    error
+}
+
+func (a *fakeAdmin) Metadata(ctx context.Context, req *kafkago.MetadataRequest) (*kafkago.MetadataResponse, error) {
+	if !a.up[req.Addr.String()] {
+		return nil, errors.New("connection refused")
+	}
+	return &kafkago.MetadataResponse{}, nil
+}
+
+func (a *fakeAdmin) DescribeGroups(ctx context.Context, req *kafkago.DescribeGroupsRequest) (*kafkago.DescribeGroupsResponse, error) {
+	if a.err != nil {
+		return nil, a.err
+	}
+	return &kafkago.DescribeGroupsResponse{Groups: a.groups}, nil
+}
+
+func Test_HealthChecker_CheckBrokers(t *testing.T) {
+	testCases := []struct {
+		name        string
+		brokers     []string
+		up          map[string]bool
+		expectedErr bool
+	}{
+		{
+			name:        "failure_no_brokers",
+			expectedErr: true,
+		},
+		{
+			name:        "failure_every_broker_down",
+			brokers:     []string{"kafka-1:9092", "kafka-2:9092"},
+			expectedErr: true,
+		},
+		{
+			name:    "success_one_broker_up",
+			brokers: []string{"kafka-1:9092", "kafka-2:9092"},
+			up:      map[string]bool{"kafka-2:9092": true},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := &kafka.HealthChecker{
+				Client:  &fakeAdmin{up: tc.up},
+				Brokers: tc.brokers,
+			}
+
+			err := h.CheckBrokers(context.TODO())
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_HealthChecker_CheckConsumerGroup(t *testing.T) {
+	const groupID = "kbu-store"
+
+	testCases := []struct {
+		name        string
+		admin       *fakeAdmin
+		expectedErr bool
+	}{
+		{
+			name:        "failure_describe_groups_returns_error",
+			admin:       &fakeAdmin{err: errors.New("Unexpected Error")},
+			expectedErr: true,
+		},
+		{
+			name:        "failure_group_not_found",
+			admin:       &fakeAdmin{},
+			expectedErr: true,
+		},
+		{
+			name: "failure_group_rebalancing",
+			admin: &fakeAdmin{groups: []kafkago.DescribeGroupsResponseGroup{
+				{GroupID: groupID, GroupState: "PreparingRebalance"},
+			}},
+			expectedErr: true,
+		},
+		{
+			name: "success",
+			admin: &fakeAdmin{groups: []kafkago.DescribeGroupsResponseGroup{
+				{GroupID: groupID, GroupState: kafka.GroupStateStable},
+			}},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			h := &kafka.HealthChecker{
+				Client:  tc.admin,
+				Brokers: []string{"kafka-1:9092"},
+				GroupID: groupID,
+			}
+
+			err := h.CheckConsumerGroup(context.TODO())
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -1,9 +1,11 @@
 package repository
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/EdlanioJ/kbu-store/app/config"
+	"github.com/EdlanioJ/kbu-store/app/domain"
 	"gorm.io/driver/postgres"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -36,3 +38,14 @@ func GORMConnection(cfg *config.Config) *gorm.DB {
 
 	return db
 }
+
+// GORMHealthCheck pings the database behind db
+func GORMHealthCheck(db *gorm.DB) domain.HealthCheck {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/EdlanioJ/kbu-store/app/domain"
 	"github.com/opentracing/opentracing-go"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type accountRepository struct {
@@ -22,7 +23,7 @@ func (r *accountRepository) Store(ctx context.Context, account *domain.Account)
 	span, ctx := opentracing.StartSpanFromContext(ctx, "accountRepository.Create")
 	defer span.Finish()
 
-	err = r.db.WithContext(ctx).
+	err = conn(ctx, r.db).WithContext(ctx).
 		Table("accounts").
 		Create(account).
 		Error
@@ -35,7 +36,7 @@ func (r *accountRepository) FindByID(ctx context.Context, id string) (res *domai
 	span, ctx := opentracing.StartSpanFromContext(ctx, "accountRepository.FindByID")
 	defer span.Finish()
 
-	err = r.db.WithContext(ctx).
+	err = conn(ctx, r.db).WithContext(ctx).
 		Table("accounts").
 		First(account, "id = ?", id).
 		Error
@@ -44,11 +45,27 @@ func (r *accountRepository) FindByID(ctx context.Context, id string) (res *domai
 	return
 }
 
+func (r *accountRepository) FindForUpdate(ctx context.Context, id string) (res *domain.Account, err error) {
+	account := &domain.Account{}
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "accountRepository.FindForUpdate")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("accounts").
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(account, "id = ?", id).
+		Error
+	res = account
+
+	return
+}
+
 func (r *accountRepository) Update(ctx context.Context, account *domain.Account) (err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "accountRepository.Update")
 	defer span.Finish()
 
-	err = r.db.WithContext(ctx).
+	err = conn(ctx, r.db).WithContext(ctx).
 		Table("accounts").
 		Save(account).
 		Error
@@ -59,7 +76,7 @@ func (r *accountRepository) Delete(ctx context.Context, id string) (err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "accountRepository.Delete")
 	defer span.Finish()
 
-	err = r.db.WithContext(ctx).
+	err = conn(ctx, r.db).WithContext(ctx).
 		Table("accounts").
 		Delete(&domain.Account{}, "id = ?", id).
 		Error
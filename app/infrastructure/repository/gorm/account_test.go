@@ -46,6 +46,22 @@ func TestAccountRepository(t *testing.T) {
 		assert.Equal(t, res.ID, account.ID)
 	})
 
+	t.Run("FindForUpdate", func(t *testing.T) {
+		account := sample.NewAccount()
+		row := sqlmock.
+			NewRows([]string{"id", "balance", "created_at", "updated_at"}).
+			AddRow(account.ID, account.Balance, account.CreatedAt, account.UpdatedAt)
+		query := `SELECT * FROM "accounts" WHERE id = $1 ORDER BY "accounts"."id" LIMIT 1 FOR UPDATE`
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(account.ID).
+			WillReturnRows(row)
+
+		res, err := repo.FindForUpdate(context.TODO(), account.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, res.ID, account.ID)
+	})
+
 	t.Run("Update", func(t *testing.T) {
 		account := sample.NewAccount()
 		query := `UPDATE "accounts" SET "created_at"=$1,"updated_at"=$2,"balance"=$3 WHERE "id" = $4`
@@ -2,6 +2,7 @@ package gorm
 
 import (
 	"context"
+	"errors"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
 	"github.com/opentracing/opentracing-go"
@@ -22,7 +23,7 @@ func (r *categoryRepository) Store(ctx context.Context, category *domain.Categor
 	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryRepository.Create")
 	defer span.Finish()
 
-	err = r.db.WithContext(ctx).
+	err = conn(ctx, r.db).WithContext(ctx).
 		Table("categories").
 		Create(category).
 		Error
@@ -35,23 +36,102 @@ func (r *categoryRepository) FindByID(ctx context.Context, id string) (res *doma
 	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryRepository.FindByID")
 	defer span.Finish()
 
-	err = r.db.
+	err = conn(ctx, r.db).
 		WithContext(ctx).
 		Table("categories").
 		First(category, "id = ?", id).
 		Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrNotFound
+	}
 
 	res = category
 	return
 }
 
+// FindAll returns a page of the categories ordered by name, all of them or
+// only those in status
+func (r *categoryRepository) FindAll(ctx context.Context, status string, limit, page int) (res domain.Categories, total int64, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryRepository.FindAll")
+	defer span.Finish()
+
+	query := conn(ctx, r.db).WithContext(ctx).
+		Table("categories")
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var categories []*domain.Category
+	err = query.Session(&gorm.Session{}).
+		Order("name").
+		Order("id").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&categories).Error
+	if err != nil {
+		return
+	}
+
+	err = query.Session(&gorm.Session{}).
+		Count(&total).Error
+	if err != nil {
+		return
+	}
+
+	res = categories
+	return
+}
+
+// FindTree returns every category ordered by name, to be arranged under
+// their parents
+func (r *categoryRepository) FindTree(ctx context.Context) (res domain.Categories, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryRepository.FindTree")
+	defer span.Finish()
+
+	var categories []*domain.Category
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("categories").
+		Order("name").
+		Order("id").
+		Find(&categories).Error
+	if err != nil {
+		return
+	}
+
+	res = categories
+	return
+}
+
+// CountChildren returns how many categories are directly under id
+func (r *categoryRepository) CountChildren(ctx context.Context, id string) (total int64, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryRepository.CountChildren")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("categories").
+		Where("parent_id = ?", id).
+		Count(&total).Error
+	return
+}
+
 func (r *categoryRepository) Update(ctx context.Context, category *domain.Category) (err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryRepository.Update")
 	defer span.Finish()
 
-	err = r.db.WithContext(ctx).
+	err = conn(ctx, r.db).WithContext(ctx).
 		Table("categories").
 		Save(category).
 		Error
 	return
 }
+
+func (r *categoryRepository) Delete(ctx context.Context, id string) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryRepository.Delete")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("categories").
+		Delete(&domain.Category{}, "id = ?", id).
+		Error
+	return
+}
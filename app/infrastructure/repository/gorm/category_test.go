@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/domain"
 	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/gorm"
 	"github.com/EdlanioJ/kbu-store/app/utils/sample"
 	"github.com/stretchr/testify/assert"
@@ -18,11 +19,11 @@ func TestCategoryRepository(t *testing.T) {
 
 	t.Run("Create", func(t *testing.T) {
 		category := sample.NewCategory()
-		query := `INSERT INTO "categories" ("id","created_at","updated_at","name","status") VALUES ($1,$2,$3,$4,$5)`
+		query := `INSERT INTO "categories" ("id","created_at","updated_at","name","status","parent_id") VALUES ($1,$2,$3,$4,$5,$6)`
 
 		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(query)).
-			WithArgs(category.ID, category.CreatedAt, sqlmock.AnyArg(), category.Name, category.Status).
+			WithArgs(category.ID, category.CreatedAt, sqlmock.AnyArg(), category.Name, category.Status, category.ParentID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -45,17 +46,97 @@ func TestCategoryRepository(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 	})
+	t.Run("FindByID_NotFound", func(t *testing.T) {
+		id := sample.NewCategory().ID
+		query := `SELECT * FROM "categories" WHERE id = $1 ORDER BY "categories"."id" LIMIT 1`
+		row := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "status"})
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(id).
+			WillReturnRows(row)
+
+		res, err := repo.FindByID(context.TODO(), id)
+		assert.ErrorIs(t, err, domain.ErrNotFound)
+		assert.Nil(t, res)
+	})
 	t.Run("Update", func(t *testing.T) {
 		category := sample.NewCategory()
-		query := `UPDATE "categories" SET "created_at"=$1,"updated_at"=$2,"name"=$3,"status"=$4 WHERE "id" = $5`
+		query := `UPDATE "categories" SET "created_at"=$1,"updated_at"=$2,"name"=$3,"status"=$4,"parent_id"=$5 WHERE "id" = $6`
 
 		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(query)).
-			WithArgs(category.CreatedAt, sqlmock.AnyArg(), category.Name, category.Status, category.ID).
+			WithArgs(category.CreatedAt, sqlmock.AnyArg(), category.Name, category.Status, category.ParentID, category.ID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
 		err := repo.Update(context.TODO(), category)
 		assert.NoError(t, err)
 	})
+	t.Run("FindAll", func(t *testing.T) {
+		category := sample.NewCategory()
+		query := `SELECT * FROM "categories" WHERE status = $1 ORDER BY name,id LIMIT 10 OFFSET 10`
+		queryCount := `SELECT count(*) FROM "categories" WHERE status = $1`
+
+		row := sqlmock.
+			NewRows([]string{"id", "created_at", "updated_at", "name", "status"}).
+			AddRow(category.ID, category.CreatedAt, category.UpdatedAt, category.Name, domain.CategoryStatusActive)
+		countRow := sqlmock.NewRows([]string{"count"}).AddRow(11)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(domain.CategoryStatusActive).
+			WillReturnRows(row)
+		mock.ExpectQuery(regexp.QuoteMeta(queryCount)).
+			WithArgs(domain.CategoryStatusActive).
+			WillReturnRows(countRow)
+
+		list, total, err := repo.FindAll(context.TODO(), domain.CategoryStatusActive, 10, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(11), total)
+		assert.Len(t, list, 1)
+	})
+	t.Run("FindTree", func(t *testing.T) {
+		parent := sample.NewCategory()
+		child := sample.NewCategory()
+		child.ParentID = &parent.ID
+		query := `SELECT * FROM "categories" ORDER BY name,id`
+
+		row := sqlmock.
+			NewRows([]string{"id", "created_at", "updated_at", "name", "status", "parent_id"}).
+			AddRow(child.ID, child.CreatedAt, child.UpdatedAt, child.Name, child.Status, parent.ID).
+			AddRow(parent.ID, parent.CreatedAt, parent.UpdatedAt, parent.Name, parent.Status, nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WillReturnRows(row)
+
+		list, err := repo.FindTree(context.TODO())
+		assert.NoError(t, err)
+		assert.Len(t, list, 2)
+		assert.Equal(t, parent.ID, *list[0].ParentID)
+		assert.Nil(t, list[1].ParentID)
+	})
+	t.Run("CountChildren", func(t *testing.T) {
+		category := sample.NewCategory()
+		query := `SELECT count(*) FROM "categories" WHERE parent_id = $1`
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(category.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		total, err := repo.CountChildren(context.TODO(), category.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total)
+	})
+	t.Run("Delete", func(t *testing.T) {
+		category := sample.NewCategory()
+		query := `DELETE FROM "categories" WHERE id = $1`
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(category.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Delete(context.TODO(), category.ID)
+		assert.NoError(t, err)
+	})
 }
@@ -0,0 +1,45 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/opentracing/opentracing-go"
+	"gorm.io/gorm"
+)
+
+type inboxRepository struct {
+	db *gorm.DB
+}
+
+func NewInboxRepository(db *gorm.DB) *inboxRepository {
+	return &inboxRepository{
+		db: db,
+	}
+}
+
+func (r *inboxRepository) Exists(ctx context.Context, id string) (exists bool, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "inboxRepository.Exists")
+	defer span.Finish()
+
+	var total int64
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("inbox").
+		Where("id = ?", id).
+		Count(&total).
+		Error
+
+	exists = total > 0
+	return
+}
+
+func (r *inboxRepository) Store(ctx context.Context, msg *domain.InboxMessage) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "inboxRepository.Store")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("inbox").
+		Create(msg).
+		Error
+	return
+}
@@ -0,0 +1,45 @@
+package gorm_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/gorm"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInboxRepository(t *testing.T) {
+	t.Parallel()
+	db, mock := dbMock()
+	repo := gorm.NewInboxRepository(db)
+
+	t.Run("Exists", func(t *testing.T) {
+		msg := sample.NewInboxMessage()
+		query := `SELECT count(*) FROM "inbox" WHERE id = $1`
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(msg.ID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		exists, err := repo.Exists(context.TODO(), msg.ID)
+		assert.NoError(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("Store", func(t *testing.T) {
+		msg := sample.NewInboxMessage()
+		query := `INSERT INTO "inbox" ("id","topic","created_at") VALUES ($1,$2,$3)`
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(msg.ID, msg.Topic, msg.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Store(context.TODO(), msg)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,68 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/opentracing/opentracing-go"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type ledgerRepository struct {
+	db *gorm.DB
+}
+
+func NewLedgerRepository(db *gorm.DB) *ledgerRepository {
+	return &ledgerRepository{
+		db: db,
+	}
+}
+
+func (r *ledgerRepository) Store(ctx context.Context, entry *domain.LedgerEntry) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerRepository.Store")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("account_transactions").
+		Create(entry).
+		Error
+	return
+}
+
+func (r *ledgerRepository) FindByAccountID(ctx context.Context, accountID string, limit, page int) (res domain.LedgerEntries, total int64, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerRepository.FindByAccountID")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("account_transactions").
+		Where("account_id = ?", accountID).
+		Count(&total).
+		Error
+	if err != nil {
+		return
+	}
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("account_transactions").
+		Where("account_id = ?", accountID).
+		Order("created_at DESC, id").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&res).
+		Error
+	return
+}
+
+func (r *ledgerRepository) SumByAccountID(ctx context.Context, accountID string) (res decimal.Decimal, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "ledgerRepository.SumByAccountID")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("account_transactions").
+		Select("COALESCE(SUM(CASE WHEN direction = ? THEN amount ELSE -amount END), 0)", domain.LedgerDirectionCredit).
+		Where("account_id = ?", accountID).
+		Row().
+		Scan(&res)
+	return
+}
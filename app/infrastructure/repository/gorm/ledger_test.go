@@ -0,0 +1,68 @@
+package gorm_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/gorm"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedgerRepository(t *testing.T) {
+	t.Parallel()
+	db, mock := dbMock()
+	repo := gorm.NewLedgerRepository(db)
+
+	t.Run("Store", func(t *testing.T) {
+		entry := sample.NewLedgerEntry()
+		query := `INSERT INTO "account_transactions" ("id","created_at","updated_at","account_id","amount","direction","reason","reference_id","balance") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(entry.ID, entry.CreatedAt, sqlmock.AnyArg(), entry.AccountID, entry.Amount, entry.Direction, entry.Reason, entry.ReferenceID, entry.Balance).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Store(context.TODO(), entry)
+		assert.NoError(t, err)
+	})
+
+	t.Run("FindByAccountID", func(t *testing.T) {
+		entry := sample.NewLedgerEntry()
+		countQuery := `SELECT count(*) FROM "account_transactions" WHERE account_id = $1`
+		query := `SELECT * FROM "account_transactions" WHERE account_id = $1 ORDER BY created_at DESC, id LIMIT 10 OFFSET 10`
+		row := sqlmock.
+			NewRows([]string{"id", "created_at", "updated_at", "account_id", "amount", "direction", "reason", "reference_id", "balance"}).
+			AddRow(entry.ID, entry.CreatedAt, entry.UpdatedAt, entry.AccountID, entry.Amount, entry.Direction, entry.Reason, entry.ReferenceID, entry.Balance)
+
+		mock.ExpectQuery(regexp.QuoteMeta(countQuery)).
+			WithArgs(entry.AccountID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(entry.AccountID).
+			WillReturnRows(row)
+
+		res, total, err := repo.FindByAccountID(context.TODO(), entry.AccountID, 10, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(11), total)
+		assert.Len(t, res, 1)
+		assert.True(t, res[0].Balance.Equal(entry.Balance))
+	})
+
+	t.Run("SumByAccountID", func(t *testing.T) {
+		accountID := sample.NewAccount().ID
+		query := `SELECT COALESCE(SUM(CASE WHEN direction = $1 THEN amount ELSE -amount END), 0) FROM "account_transactions" WHERE account_id = $2`
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs("credit", accountID).
+			WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow("990.5"))
+
+		res, err := repo.SumByAccountID(context.TODO(), accountID)
+		assert.NoError(t, err)
+		assert.True(t, res.Equal(decimal.RequireFromString("990.5")))
+	})
+}
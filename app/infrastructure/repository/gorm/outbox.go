@@ -0,0 +1,58 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/opentracing/opentracing-go"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type outboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *outboxRepository {
+	return &outboxRepository{
+		db: db,
+	}
+}
+
+func (r *outboxRepository) Store(ctx context.Context, msg *domain.OutboxMessage) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "outboxRepository.Store")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("outbox").
+		Create(msg).
+		Error
+	return
+}
+
+func (r *outboxRepository) FindPending(ctx context.Context, limit int) (res []*domain.OutboxMessage, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "outboxRepository.FindPending")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("outbox").
+		Where("delivered_at IS NULL").
+		Order("created_at").
+		Order("id").
+		Limit(limit).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Find(&res).
+		Error
+	return
+}
+
+func (r *outboxRepository) Update(ctx context.Context, msg *domain.OutboxMessage) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "outboxRepository.Update")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("outbox").
+		Save(msg).
+		Error
+	return
+}
@@ -0,0 +1,63 @@
+package gorm_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/gorm"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxRepository(t *testing.T) {
+	t.Parallel()
+	db, mock := dbMock()
+	repo := gorm.NewOutboxRepository(db)
+
+	t.Run("Store", func(t *testing.T) {
+		msg := sample.NewOutboxMessage()
+		query := `INSERT INTO "outbox" ("id","created_at","updated_at","topic","payload","attempts","last_error","next_attempt_at","delivered_at","locked_until") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)`
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(msg.ID, msg.CreatedAt, sqlmock.AnyArg(), msg.Topic, msg.Payload, msg.Attempts, msg.LastError, msg.NextAttemptAt, msg.DeliveredAt, msg.LockedUntil).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Store(context.TODO(), msg)
+		assert.NoError(t, err)
+	})
+
+	t.Run("FindPending", func(t *testing.T) {
+		msg := sample.NewOutboxMessage()
+		query := `SELECT * FROM "outbox" WHERE delivered_at IS NULL ORDER BY created_at,id LIMIT 10 FOR UPDATE`
+		row := sqlmock.
+			NewRows([]string{"id", "created_at", "updated_at", "topic", "payload", "attempts", "last_error", "next_attempt_at", "delivered_at"}).
+			AddRow(msg.ID, msg.CreatedAt, msg.UpdatedAt, msg.Topic, msg.Payload, msg.Attempts, msg.LastError, msg.NextAttemptAt, nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WillReturnRows(row)
+
+		res, err := repo.FindPending(context.TODO(), 10)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, msg.ID, res[0].ID)
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		msg := sample.NewOutboxMessage()
+		msg.Deliver()
+		query := `UPDATE "outbox" SET "created_at"=$1,"updated_at"=$2,"topic"=$3,"payload"=$4,"attempts"=$5,"last_error"=$6,"next_attempt_at"=$7,"delivered_at"=$8,"locked_until"=$9 WHERE "id" = $10`
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(msg.CreatedAt, sqlmock.AnyArg(), msg.Topic, msg.Payload, msg.Attempts, msg.LastError, msg.NextAttemptAt, msg.DeliveredAt, msg.LockedUntil, msg.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Update(context.TODO(), msg)
+		assert.NoError(t, err)
+	})
+}
@@ -3,6 +3,7 @@ package gorm_test
 import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
 )
 
@@ -28,3 +29,21 @@ func dbMock() (*gorm.DB, sqlmock.Sqlmock) {
 
 	return gormDB, mock
 }
+
+func sqliteMock() (*gorm.DB, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+
+	if err != nil {
+		panic(err)
+	}
+
+	gormDB, err := gorm.Open(sqlite.Dialector{
+		Conn: db,
+	}, &gorm.Config{})
+
+	if err != nil {
+		panic(err)
+	}
+
+	return gormDB, mock
+}
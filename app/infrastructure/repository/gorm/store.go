@@ -2,10 +2,16 @@ package gorm
 
 import (
 	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/lib/pq"
 	"github.com/opentracing/opentracing-go"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type storeRepository struct {
@@ -22,7 +28,7 @@ func (r *storeRepository) Create(ctx context.Context, store *domain.Store) (err
 	span, ctx := opentracing.StartSpanFromContext(ctx, "storeRepository.Create")
 	defer span.Finish()
 
-	err = r.db.WithContext(ctx).
+	err = conn(ctx, r.db).WithContext(ctx).
 		Table("stores").
 		Create(store).
 		Error
@@ -34,56 +40,402 @@ func (r *storeRepository) FindByID(ctx context.Context, id string) (res *domain.
 	span, ctx := opentracing.StartSpanFromContext(ctx, "storeRepository.FindByID")
 	defer span.Finish()
 
-	err = r.db.WithContext(ctx).
+	err = conn(ctx, r.db).WithContext(ctx).
 		Table("stores").
 		Where("id = ?", id).
+		Where("deleted_at IS NULL").
 		First(res).
 		Error
 
 	return
 }
 
+// FindDeletedByID returns a store only while it is deleted
+func (r *storeRepository) FindDeletedByID(ctx context.Context, id string) (res *domain.Store, err error) {
+	res = &domain.Store{}
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storeRepository.FindDeletedByID")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("stores").
+		Where("id = ?", id).
+		Where("deleted_at IS NOT NULL").
+		First(res).
+		Error
+
+	return
+}
+
+// FindDeleted returns up to limit of the stores deleted before a time,
+// oldest deletion first
+func (r *storeRepository) FindDeleted(ctx context.Context, before time.Time, limit int) (res domain.Stores, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storeRepository.FindDeleted")
+	defer span.Finish()
+
+	res = make(domain.Stores, 0)
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("stores").
+		Where("deleted_at < ?", before).
+		Order("deleted_at").
+		Order("id").
+		Limit(limit).
+		Find(&res).
+		Error
+
+	return
+}
+
 func (r *storeRepository) FindByName(ctx context.Context, name string) (res *domain.Store, err error) {
 	res = &domain.Store{}
 	span, ctx := opentracing.StartSpanFromContext(ctx, "storeRepository.FindByName")
 	defer span.Finish()
 
-	err = r.db.WithContext(ctx).
+	err = conn(ctx, r.db).WithContext(ctx).
 		Table("stores").
 		Where("name = ?", name).
+		Where("deleted_at IS NULL").
 		First(res).
 		Error
 
 	return
 }
 
-func (r *storeRepository) FindAll(ctx context.Context, sort string, limit, page int) (res domain.Stores, total int64, err error) {
+// categoryDescendantsCondition matches the stores of a category and of all
+// the categories below it
+const categoryDescendantsCondition = `category_id IN (` +
+	`WITH RECURSIVE descendants AS (` +
+	`SELECT id FROM categories WHERE id = ? ` +
+	`UNION SELECT c.id FROM categories c JOIN descendants d ON c.parent_id = d.id` +
+	`) SELECT id FROM descendants)`
+
+// openAtCondition matches the stores with an interval of their opening
+// hours holding a time, in their timezone. The intervals opening the day
+// before are checked too, for the ones running past midnight, and the ones
+// opening on a closure are skipped.
+const openAtCondition = `EXISTS (` +
+	`SELECT 1 FROM store_hours sh ` +
+	`CROSS JOIN LATERAL (SELECT ?::timestamptz AT TIME ZONE sh.timezone AS local_at) l ` +
+	`JOIN store_opening_hours oh ON oh.store_id = sh.store_id ` +
+	`CROSS JOIN LATERAL (SELECT l.local_at::date - k AS day FROM generate_series(0, 1) AS k) d ` +
+	`WHERE sh.store_id = stores.id ` +
+	`AND oh.weekday = EXTRACT(DOW FROM d.day) ` +
+	`AND l.local_at >= d.day + oh.opens ` +
+	`AND l.local_at < d.day + oh.opens + CASE WHEN oh.closes > oh.opens THEN oh.closes - oh.opens ELSE oh.closes - oh.opens + interval '1 day' END ` +
+	`AND NOT EXISTS (SELECT 1 FROM store_closures sc WHERE sc.store_id = sh.store_id AND d.day BETWEEN sc.from_date AND sc.to_date))`
+
+// filterStores scopes a store query to the stores matching filter
+func filterStores(filter *domain.StoreFilter) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if filter == nil || !filter.IncludeDeleted {
+			db = db.Where("deleted_at IS NULL")
+		}
+		if filter == nil {
+			return db
+		}
+
+		if filter.Status != "" {
+			db = db.Where("status = ?", filter.Status)
+		}
+		if filter.CategoryID != "" && filter.IncludeDescendants {
+			db = db.Where(categoryDescendantsCondition, filter.CategoryID)
+		} else if filter.CategoryID != "" {
+			db = db.Where("category_id = ?", filter.CategoryID)
+		}
+		if filter.UserID != "" {
+			db = db.Where("user_id = ?", filter.UserID)
+		}
+		if len(filter.AnyTags) > 0 {
+			db = db.Where("tags && ?", pq.StringArray(filter.AnyTags))
+		}
+		if len(filter.AllTags) > 0 {
+			db = db.Where("tags @> ?", pq.StringArray(filter.AllTags))
+		}
+		if !filter.CreatedFrom.IsZero() {
+			db = db.Where("created_at >= ?", filter.CreatedFrom)
+		}
+		if !filter.CreatedTo.IsZero() {
+			db = db.Where("created_at < ?", filter.CreatedTo)
+		}
+		if filter.NamePrefix != "" {
+			db = db.Where(`name ILIKE ? ESCAPE '\'`, likePrefix(filter.NamePrefix))
+		}
+		if !filter.OpenAt.IsZero() {
+			db = db.Where(openAtCondition, filter.OpenAt)
+		}
+		return db
+	}
+}
+
+// likeEscaper escapes the LIKE wildcards of a pattern, using \ as escape character
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// likePrefix escapes the LIKE wildcards of prefix and matches anything after it
+func likePrefix(prefix string) string {
+	return likeEscaper.Replace(prefix) + "%"
+}
+
+// likeContains escapes the LIKE wildcards of s and matches it anywhere
+func likeContains(s string) string {
+	return "%" + likeEscaper.Replace(s) + "%"
+}
+
+// orderBy scopes a query to the order of sort, ending with the id to keep the
+// order stable. Backward reverses every direction.
+func orderBy(sort domain.SortSpec, backward bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		fields := append(append(domain.SortSpec{}, sort...), domain.SortField{Column: "id"})
+		for _, field := range fields {
+			db = db.Order(clause.OrderByColumn{Column: clause.Column{Name: field.Column}, Desc: field.Desc != backward})
+		}
+		return db
+	}
+}
+
+// keyset scopes a query to the rows after cursor in the order of sort
+func keyset(sort domain.SortSpec, cursor *domain.Cursor) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		fields := append(append(domain.SortSpec{}, sort...), domain.SortField{Column: "id"})
+		values := append(append([]interface{}{}, cursor.Values...), cursor.ID)
+
+		terms := make([]string, len(fields))
+		var args []interface{}
+		for i, field := range fields {
+			operator := ">"
+			if field.Desc != cursor.Backward {
+				operator = "<"
+			}
+
+			conditions := make([]string, 0, i+1)
+			for j := 0; j < i; j++ {
+				conditions = append(conditions, db.Statement.Quote(fields[j].Column)+" = ?")
+				args = append(args, values[j])
+			}
+			conditions = append(conditions, db.Statement.Quote(field.Column)+" "+operator+" ?")
+			args = append(args, values[i])
+			terms[i] = "(" + strings.Join(conditions, " AND ") + ")"
+		}
+
+		return db.Where(strings.Join(terms, " OR "), args...)
+	}
+}
+
+// FindAll returns a page of the stores matching filter, in the order of sort.
+// With a cursor the page starts right after it and page is ignored.
+func (r *storeRepository) FindAll(ctx context.Context, filter *domain.StoreFilter, sort domain.SortSpec, cursor *domain.Cursor, limit, page int) (res domain.Stores, total int64, err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "storeRepository.FindAll")
 
 	defer span.Finish()
 
 	var stores []*domain.Store
 
-	err = r.db.WithContext(ctx).
+	query := conn(ctx, r.db).WithContext(ctx).
 		Table("stores").
-		Offset((page - 1) * limit).
-		Limit(limit).
-		Order(sort).
-		Find(&stores).
+		Scopes(filterStores(filter))
+
+	if cursor != nil {
+		err = query.Session(&gorm.Session{}).
+			Scopes(keyset(sort, cursor), orderBy(sort, cursor.Backward)).
+			Limit(limit).
+			Find(&stores).Error
+	} else {
+		err = query.Session(&gorm.Session{}).
+			Scopes(orderBy(sort, false)).
+			Offset((page - 1) * limit).
+			Limit(limit).
+			Find(&stores).Error
+	}
+	if err != nil {
+		return
+	}
+
+	if cursor != nil && cursor.Backward {
+		for i, j := 0, len(stores)-1; i < j; i, j = i+1, j-1 {
+			stores[i], stores[j] = stores[j], stores[i]
+		}
+	}
+
+	err = query.Session(&gorm.Session{}).
 		Count(&total).Error
+	if err != nil {
+		return
+	}
 
 	res = stores
 	return
 }
 
+// nearbyStore is a store row along with its distance in km
+type nearbyStore struct {
+	domain.Store
+	Distance float64 `gorm:"column:distance"`
+}
+
+// FindNearby returns the stores matching filter within radiusKm of position,
+// nearest first. Postgres narrows the stores with the GiST index on
+// ll_to_earth(lat, lng); other databases only narrow them to a bounding box
+// and the distance is computed here.
+func (r *storeRepository) FindNearby(ctx context.Context, position domain.Position, radiusKm float64, filter *domain.StoreFilter, limit int) (res domain.NearbyStores, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storeRepository.FindNearby")
+	defer span.Finish()
+
+	query := conn(ctx, r.db).WithContext(ctx).
+		Table("stores").
+		Scopes(filterStores(filter))
+
+	if query.Dialector.Name() == "postgres" {
+		var rows []*nearbyStore
+		origin := clause.Expr{SQL: "ll_to_earth(?, ?)", Vars: []interface{}{position.Lat, position.Lng}}
+		err = query.
+			Select("*, earth_distance(?, ll_to_earth(lat::float8, lng::float8)) / 1000 AS distance", origin).
+			Where("earth_box(?, ?) @> ll_to_earth(lat::float8, lng::float8)", origin, radiusKm*1000).
+			Where("earth_distance(?, ll_to_earth(lat::float8, lng::float8)) <= ?", origin, radiusKm*1000).
+			Order("distance").
+			Order("id").
+			Limit(limit).
+			Find(&rows).Error
+		if err != nil {
+			return
+		}
+
+		res = make(domain.NearbyStores, 0, len(rows))
+		for _, row := range rows {
+			store := row.Store
+			res = append(res, &domain.NearbyStore{Store: &store, Distance: row.Distance})
+		}
+		return
+	}
+
+	min, max := position.BoundingBox(radiusKm)
+	var stores []*domain.Store
+	err = query.
+		Where("lat BETWEEN ? AND ?", min.Lat, max.Lat).
+		Where("lng BETWEEN ? AND ?", min.Lng, max.Lng).
+		Find(&stores).Error
+	if err != nil {
+		return
+	}
+
+	res = make(domain.NearbyStores, 0, len(stores))
+	for _, store := range stores {
+		distance := position.DistanceTo(store.Position)
+		if distance <= radiusKm {
+			res = append(res, &domain.NearbyStore{Store: store, Distance: distance})
+		}
+	}
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Distance == res[j].Distance {
+			return res[i].ID < res[j].ID
+		}
+		return res[i].Distance < res[j].Distance
+	})
+	if len(res) > limit {
+		res = res[:limit]
+	}
+	return
+}
+
+// searchStore is a store row along with its search rank and snippet
+type searchStore struct {
+	domain.Store
+	Rank    float64 `gorm:"column:rank"`
+	Snippet string  `gorm:"column:snippet"`
+}
+
+// snippetOptions configures the ts_headline excerpts of the search results,
+// turned into HTML by domain.NewSnippet
+const snippetOptions = "StartSel=" + domain.SnippetStartSel + ", StopSel=" + domain.SnippetStopSel + ", MaxFragments=2, MaxWords=20, MinWords=5"
+
+// Search returns a page of the stores matching filter whose name, tags or
+// description match query. Postgres ranks them with the weighted
+// search_vector; other databases fall back to a LIKE match on the name and
+// description, ordered by name.
+func (r *storeRepository) Search(ctx context.Context, query string, filter *domain.StoreFilter, limit, page int) (res domain.StoreSearchResults, total int64, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storeRepository.Search")
+	defer span.Finish()
+
+	db := conn(ctx, r.db).WithContext(ctx).
+		Table("stores").
+		Scopes(filterStores(filter))
+
+	res = make(domain.StoreSearchResults, 0)
+	if db.Dialector.Name() == "postgres" {
+		tsquery := clause.Expr{SQL: "websearch_to_tsquery('simple', ?)", Vars: []interface{}{query}}
+		db = db.Where("search_vector @@ ?", tsquery)
+
+		var rows []*searchStore
+		err = db.Session(&gorm.Session{}).
+			Select("*, ts_rank(search_vector, ?) AS rank, ts_headline('simple', name || ' ' || coalesce(description, ''), ?, ?) AS snippet", tsquery, tsquery, snippetOptions).
+			Order("rank DESC").
+			Order("id").
+			Offset((page - 1) * limit).
+			Limit(limit).
+			Find(&rows).Error
+		if err != nil {
+			return
+		}
+
+		for _, row := range rows {
+			store := row.Store
+			res = append(res, &domain.StoreSearchResult{Store: &store, Rank: row.Rank, Snippet: domain.NewSnippet(row.Snippet)})
+		}
+	} else {
+		pattern := likeContains(query)
+		db = db.Where(`(name LIKE ? ESCAPE '\' OR description LIKE ? ESCAPE '\')`, pattern, pattern)
+
+		var stores []*domain.Store
+		err = db.Session(&gorm.Session{}).
+			Order("name").
+			Order("id").
+			Offset((page - 1) * limit).
+			Limit(limit).
+			Find(&stores).Error
+		if err != nil {
+			return
+		}
+
+		for _, store := range stores {
+			res = append(res, &domain.StoreSearchResult{Store: store, Snippet: highlight(store.Name+" "+store.Description, query)})
+		}
+	}
+
+	err = db.Session(&gorm.Session{}).
+		Count(&total).Error
+	if err != nil {
+		res = make(domain.StoreSearchResults, 0)
+		return
+	}
+	return
+}
+
+// highlight marks every case-insensitive occurrence of query in text as
+// ts_headline does, and returns it as HTML
+func highlight(text, query string) string {
+	re := regexp.MustCompile("(?i)" + regexp.QuoteMeta(query))
+	return domain.NewSnippet(re.ReplaceAllString(text, domain.SnippetStartSel+"$0"+domain.SnippetStopSel))
+}
+
+// Update saves store only if its version was not changed since it was read,
+// and bumps the version on success
 func (r *storeRepository) Update(ctx context.Context, store *domain.Store) (err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "storeRepository.Update")
 	defer span.Finish()
 
-	err = r.db.WithContext(ctx).
+	version := store.Version
+	store.Version++
+
+	res := conn(ctx, r.db).WithContext(ctx).
 		Table("stores").
-		Save(store).
-		Error
+		Where("version = ?", version).
+		Select("*").
+		Updates(store)
+
+	err = res.Error
+	if err == nil && res.RowsAffected == 0 {
+		err = domain.ErrConflict
+	}
+	if err != nil {
+		store.Version = version
+	}
 
 	return
 }
@@ -92,7 +444,7 @@ func (r *storeRepository) Delete(ctx context.Context, id string) (err error) {
 	span, ctx := opentracing.StartSpanFromContext(ctx, "storeRepository.Delete")
 	defer span.Finish()
 
-	err = r.db.WithContext(ctx).
+	err = conn(ctx, r.db).WithContext(ctx).
 		Table("stores").
 		Delete(&domain.Store{}, "id = ?", id).
 		Error
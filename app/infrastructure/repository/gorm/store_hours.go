@@ -0,0 +1,127 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/opentracing/opentracing-go"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type storeHoursRepository struct {
+	db *gorm.DB
+}
+
+func NewStoreHoursRepository(db *gorm.DB) *storeHoursRepository {
+	return &storeHoursRepository{
+		db: db,
+	}
+}
+
+// Save replaces the opening hours of a store. It runs several statements, so
+// it is meant to be called within a transaction.
+func (r *storeHoursRepository) Save(ctx context.Context, hours *domain.StoreHours) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storeHoursRepository.Save")
+	defer span.Finish()
+
+	db := conn(ctx, r.db).WithContext(ctx)
+	err = db.Table("store_hours").
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "store_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"timezone", "updated_at"}),
+		}).
+		Create(hours).
+		Error
+	if err != nil {
+		return
+	}
+
+	err = db.Table("store_opening_hours").
+		Where("store_id = ?", hours.StoreID).
+		Delete(&domain.OpeningHours{}).
+		Error
+	if err != nil {
+		return
+	}
+	if len(hours.Weekly) > 0 {
+		err = db.Table("store_opening_hours").Create(hours.Weekly).Error
+		if err != nil {
+			return
+		}
+	}
+
+	err = db.Table("store_closures").
+		Where("store_id = ?", hours.StoreID).
+		Delete(&domain.StoreClosure{}).
+		Error
+	if err != nil {
+		return
+	}
+	if len(hours.Closures) > 0 {
+		err = db.Table("store_closures").Create(hours.Closures).Error
+	}
+	return
+}
+
+// FindByStoreIDs returns the opening hours of the given stores, skipping the
+// ones without any.
+func (r *storeHoursRepository) FindByStoreIDs(ctx context.Context, storeIDs []string) (res []*domain.StoreHours, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storeHoursRepository.FindByStoreIDs")
+	defer span.Finish()
+
+	res = make([]*domain.StoreHours, 0)
+	if len(storeIDs) == 0 {
+		return
+	}
+
+	db := conn(ctx, r.db).WithContext(ctx)
+	err = db.Table("store_hours").
+		Where("store_id IN ?", storeIDs).
+		Order("store_id").
+		Find(&res).
+		Error
+	if err != nil {
+		return
+	}
+
+	var weekly []*domain.OpeningHours
+	err = db.Table("store_opening_hours").
+		Select("store_id, weekday, to_char(opens, 'HH24:MI') AS opens, to_char(closes, 'HH24:MI') AS closes").
+		Where("store_id IN ?", storeIDs).
+		Order("store_id, weekday, opens").
+		Find(&weekly).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	var closures []*domain.StoreClosure
+	err = db.Table("store_closures").
+		Select("store_id, to_char(from_date, 'YYYY-MM-DD') AS from_date, to_char(to_date, 'YYYY-MM-DD') AS to_date, COALESCE(reason, '') AS reason").
+		Where("store_id IN ?", storeIDs).
+		Order("store_id, from_date").
+		Find(&closures).
+		Error
+	if err != nil {
+		return nil, err
+	}
+
+	hours := make(map[string]*domain.StoreHours, len(res))
+	for _, h := range res {
+		h.Weekly = make([]*domain.OpeningHours, 0)
+		h.Closures = make([]*domain.StoreClosure, 0)
+		hours[h.StoreID] = h
+	}
+	for _, o := range weekly {
+		if h, ok := hours[o.StoreID]; ok {
+			h.Weekly = append(h.Weekly, o)
+		}
+	}
+	for _, c := range closures {
+		if h, ok := hours[c.StoreID]; ok {
+			h.Closures = append(h.Closures, c)
+		}
+	}
+	return
+}
@@ -0,0 +1,87 @@
+package gorm_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/gorm"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreHoursRepository(t *testing.T) {
+	t.Parallel()
+	db, mock := dbMock()
+	repo := gorm.NewStoreHoursRepository(db)
+
+	t.Run("Save", func(t *testing.T) {
+		h := domain.NewStoreHours(sample.NewStoreHoursRequest())
+		h.Closures[0].To = h.Closures[0].From
+		upsertQuery := `INSERT INTO "store_hours" ("store_id","timezone","updated_at") VALUES ($1,$2,$3) ON CONFLICT ("store_id") DO UPDATE SET "timezone"="excluded"."timezone","updated_at"="excluded"."updated_at"`
+		weeklyQuery := `INSERT INTO "store_opening_hours" ("store_id","weekday","opens","closes") VALUES ($1,$2,$3,$4),($5,$6,$7,$8),($9,$10,$11,$12)`
+		closuresQuery := `INSERT INTO "store_closures" ("store_id","from_date","to_date","reason") VALUES ($1,$2,$3,$4)`
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(upsertQuery)).
+			WithArgs(h.StoreID, h.Timezone, h.UpdatedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "store_opening_hours" WHERE store_id = $1`)).
+			WithArgs(h.StoreID).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(weeklyQuery)).
+			WithArgs(h.StoreID, 1, "09:00", "13:00", h.StoreID, 1, "14:00", "18:00", h.StoreID, 5, "20:00", "02:00").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM "store_closures" WHERE store_id = $1`)).
+			WithArgs(h.StoreID).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(closuresQuery)).
+			WithArgs(h.StoreID, "2021-12-25", "2021-12-25", "Christmas").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.Save(context.TODO(), h)
+		assert.NoError(t, err)
+	})
+
+	t.Run("FindByStoreIDs", func(t *testing.T) {
+		h := sample.NewStoreHours()
+		hoursQuery := `SELECT * FROM "store_hours" WHERE store_id IN ($1) ORDER BY store_id`
+		weeklyQuery := `SELECT store_id, weekday, to_char(opens, 'HH24:MI') AS opens, to_char(closes, 'HH24:MI') AS closes FROM "store_opening_hours" WHERE store_id IN ($1) ORDER BY store_id, weekday, opens`
+		closuresQuery := `SELECT store_id, to_char(from_date, 'YYYY-MM-DD') AS from_date, to_char(to_date, 'YYYY-MM-DD') AS to_date, COALESCE(reason, '') AS reason FROM "store_closures" WHERE store_id IN ($1) ORDER BY store_id, from_date`
+
+		mock.ExpectQuery(regexp.QuoteMeta(hoursQuery)).
+			WithArgs(h.StoreID).
+			WillReturnRows(sqlmock.NewRows([]string{"store_id", "timezone", "updated_at"}).AddRow(h.StoreID, h.Timezone, h.UpdatedAt))
+		mock.ExpectQuery(regexp.QuoteMeta(weeklyQuery)).
+			WithArgs(h.StoreID).
+			WillReturnRows(sqlmock.NewRows([]string{"store_id", "weekday", "opens", "closes"}).AddRow(h.StoreID, 1, "09:00", "24:00"))
+		mock.ExpectQuery(regexp.QuoteMeta(closuresQuery)).
+			WithArgs(h.StoreID).
+			WillReturnRows(sqlmock.NewRows([]string{"store_id", "from_date", "to_date", "reason"}).AddRow(h.StoreID, "2021-12-25", "2021-12-25", ""))
+
+		res, err := repo.FindByStoreIDs(context.TODO(), []string{h.StoreID})
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, h.Timezone, res[0].Timezone)
+		assert.Len(t, res[0].Weekly, 1)
+		assert.Equal(t, "24:00", res[0].Weekly[0].Closes)
+		assert.Len(t, res[0].Closures, 1)
+	})
+
+	t.Run("FindByStoreIDs_without_ids", func(t *testing.T) {
+		res, err := repo.FindByStoreIDs(context.TODO(), nil)
+		assert.NoError(t, err)
+		assert.Empty(t, res)
+	})
+}
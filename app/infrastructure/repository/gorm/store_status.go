@@ -0,0 +1,98 @@
+package gorm
+
+import (
+	"context"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/opentracing/opentracing-go"
+	"gorm.io/gorm"
+)
+
+type storeStatusHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewStoreStatusHistoryRepository(db *gorm.DB) *storeStatusHistoryRepository {
+	return &storeStatusHistoryRepository{
+		db: db,
+	}
+}
+
+func (r *storeStatusHistoryRepository) Store(ctx context.Context, change *domain.StoreStatusChange) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storeStatusHistoryRepository.Store")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("store_status_history").
+		Create(change).
+		Error
+	return
+}
+
+func (r *storeStatusHistoryRepository) FindByStoreID(ctx context.Context, storeID string, limit, page int) (res domain.StoreStatusHistory, total int64, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storeStatusHistoryRepository.FindByStoreID")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("store_status_history").
+		Where("store_id = ?", storeID).
+		Count(&total).
+		Error
+	if err != nil {
+		return
+	}
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("store_status_history").
+		Where("store_id = ?", storeID).
+		Order("created_at DESC, id").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&res).
+		Error
+	return
+}
+
+// FindExpired returns the latest change of the stores, but the deleted ones,
+// still in a status that expired at now, oldest expiry first. The expired
+// changes are found through the expires_at index, then checked to be the
+// latest of their store; those whose release failed wait for their retry.
+func (r *storeStatusHistoryRepository) FindExpired(ctx context.Context, now time.Time, limit int) (res domain.StoreStatusHistory, err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storeStatusHistoryRepository.FindExpired")
+	defer span.Finish()
+
+	later := conn(ctx, r.db).
+		Table("store_status_history AS l").
+		Select("1").
+		Where("l.store_id = h.store_id AND (l.created_at > h.created_at OR (l.created_at = h.created_at AND l.id < h.id))")
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("store_status_history AS h").
+		Select("h.*").
+		Joins("JOIN stores s ON s.id = h.store_id AND s.status = h.to_status AND s.deleted_at IS NULL").
+		Where("h.expires_at <= ?", now).
+		Where("h.release_retry_at IS NULL OR h.release_retry_at <= ?", now).
+		Where("NOT EXISTS (?)", later).
+		Order("h.expires_at").
+		Limit(limit).
+		Find(&res).
+		Error
+	return
+}
+
+// UpdateRelease saves the release attempts of an expired change
+func (r *storeStatusHistoryRepository) UpdateRelease(ctx context.Context, change *domain.StoreStatusChange) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "storeStatusHistoryRepository.UpdateRelease")
+	defer span.Finish()
+
+	err = conn(ctx, r.db).WithContext(ctx).
+		Table("store_status_history").
+		Where("id = ?", change.ID).
+		Updates(map[string]interface{}{
+			"release_attempts": change.ReleaseAttempts,
+			"release_retry_at": change.ReleaseRetryAt,
+		}).
+		Error
+	return
+}
@@ -0,0 +1,88 @@
+package gorm_test
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/gorm"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStoreStatusHistoryRepository(t *testing.T) {
+	t.Parallel()
+	db, mock := dbMock()
+	repo := gorm.NewStoreStatusHistoryRepository(db)
+	columns := []string{"id", "store_id", "from_status", "to_status", "actor_id", "reason", "expires_at", "created_at"}
+
+	t.Run("Store", func(t *testing.T) {
+		change := sample.NewStoreStatusChange()
+		query := `INSERT INTO "store_status_history" ("id","store_id","from_status","to_status","actor_id","reason","expires_at","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(change.ID, change.StoreID, change.FromStatus, change.ToStatus, change.ActorID, change.Reason, change.ExpiresAt, change.CreatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.Store(context.TODO(), change)
+		assert.NoError(t, err)
+	})
+
+	t.Run("FindByStoreID", func(t *testing.T) {
+		change := sample.NewStoreStatusChange()
+		countQuery := `SELECT count(*) FROM "store_status_history" WHERE store_id = $1`
+		query := `SELECT * FROM "store_status_history" WHERE store_id = $1 ORDER BY created_at DESC, id LIMIT 10 OFFSET 10`
+		row := sqlmock.
+			NewRows(columns).
+			AddRow(change.ID, change.StoreID, change.FromStatus, change.ToStatus, change.ActorID, change.Reason, *change.ExpiresAt, change.CreatedAt)
+
+		mock.ExpectQuery(regexp.QuoteMeta(countQuery)).
+			WithArgs(change.StoreID).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(change.StoreID).
+			WillReturnRows(row)
+
+		res, total, err := repo.FindByStoreID(context.TODO(), change.StoreID, 10, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(11), total)
+		assert.Len(t, res, 1)
+		assert.Equal(t, change.Reason, res[0].Reason)
+	})
+
+	t.Run("FindExpired", func(t *testing.T) {
+		change := sample.NewStoreStatusChange()
+		now := time.Now()
+		query := `SELECT h.* FROM store_status_history AS h JOIN stores s ON s.id = h.store_id AND s.status = h.to_status AND s.deleted_at IS NULL WHERE h.expires_at <= $1 AND (h.release_retry_at IS NULL OR h.release_retry_at <= $2) AND NOT EXISTS (SELECT 1 FROM store_status_history AS l WHERE l.store_id = h.store_id AND (l.created_at > h.created_at OR (l.created_at = h.created_at AND l.id < h.id))) ORDER BY h.expires_at LIMIT 100`
+		row := sqlmock.
+			NewRows(columns).
+			AddRow(change.ID, change.StoreID, change.FromStatus, change.ToStatus, change.ActorID, change.Reason, *change.ExpiresAt, change.CreatedAt)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(now, now).
+			WillReturnRows(row)
+
+		res, err := repo.FindExpired(context.TODO(), now, 100)
+		assert.NoError(t, err)
+		assert.Len(t, res, 1)
+		assert.Equal(t, change.StoreID, res[0].StoreID)
+	})
+	t.Run("UpdateRelease", func(t *testing.T) {
+		change := sample.NewStoreStatusChange()
+		change.FailRelease(time.Minute)
+		query := `UPDATE "store_status_history" SET "release_attempts"=$1,"release_retry_at"=$2 WHERE id = $3`
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WithArgs(1, change.ReleaseRetryAt, change.ID).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := repo.UpdateRelease(context.TODO(), change)
+		assert.NoError(t, err)
+	})
+}
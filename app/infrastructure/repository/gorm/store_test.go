@@ -2,11 +2,14 @@ package gorm_test
 
 import (
 	"context"
+	"database/sql/driver"
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/domain"
 	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/gorm"
 	"github.com/EdlanioJ/kbu-store/app/utils/sample"
 	"github.com/lib/pq"
@@ -20,11 +23,11 @@ func TestStoreRepository(t *testing.T) {
 
 	t.Run("Create", func(t *testing.T) {
 		store := sample.NewStore()
-		query := `INSERT INTO "stores" ("id","created_at","updated_at","name","description","status","user_id","account_id","category_id","image","tags","lat","lng") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`
+		query := `INSERT INTO "stores" ("id","created_at","updated_at","name","description","status","user_id","account_id","category_id","image","images","tags","lat","lng","version","deleted_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16)`
 
 		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(query)).
-			WithArgs(store.ID, store.CreatedAt, sqlmock.AnyArg(), store.Name, store.Description, store.Status, store.UserID, store.AccountID, store.CategoryID, store.Image, store.Tags, store.Position.Lat, store.Position.Lng).
+			WithArgs(store.ID, store.CreatedAt, sqlmock.AnyArg(), store.Name, store.Description, store.Status, store.UserID, store.AccountID, store.CategoryID, store.Image, store.Images, store.Tags, store.Position.Lat, store.Position.Lng, store.Version, store.DeletedAt).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
@@ -33,7 +36,7 @@ func TestStoreRepository(t *testing.T) {
 	})
 	t.Run("FindByID", func(t *testing.T) {
 		store := sample.NewStore()
-		query := `SELECT * FROM "stores" WHERE id = $1 ORDER BY "stores"."id"`
+		query := `SELECT * FROM "stores" WHERE id = $1 AND deleted_at IS NULL ORDER BY "stores"."id"`
 
 		row := sqlmock.
 			NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "external_id", "lat", "lng", "image"}).
@@ -47,9 +50,43 @@ func TestStoreRepository(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, res)
 	})
+	t.Run("FindDeletedByID", func(t *testing.T) {
+		store := sample.NewStore()
+		deletedAt := time.Now()
+		query := `SELECT * FROM "stores" WHERE id = $1 AND deleted_at IS NOT NULL ORDER BY "stores"."id"`
+
+		row := sqlmock.
+			NewRows([]string{"id", "name", "status", "deleted_at"}).
+			AddRow(store.ID, store.Name, store.Status, deletedAt)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(store.ID).
+			WillReturnRows(row)
+
+		res, err := repo.FindDeletedByID(context.TODO(), store.ID)
+		assert.NoError(t, err)
+		assert.NotNil(t, res.DeletedAt)
+	})
+	t.Run("FindDeleted", func(t *testing.T) {
+		before := time.Now().AddDate(0, 0, -30)
+		query := `SELECT * FROM "stores" WHERE deleted_at < $1 ORDER BY deleted_at,id LIMIT 100`
+
+		row := sqlmock.
+			NewRows([]string{"id", "deleted_at"}).
+			AddRow("1", before.Add(-time.Hour)).
+			AddRow("2", before.Add(-time.Minute))
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(before).
+			WillReturnRows(row)
+
+		list, err := repo.FindDeleted(context.TODO(), before, 100)
+		assert.NoError(t, err)
+		assert.Len(t, list, 2)
+	})
 	t.Run("FindByName", func(t *testing.T) {
 		store := sample.NewStore()
-		query := `SELECT * FROM "stores" WHERE name = $1 ORDER BY "stores"."id"`
+		query := `SELECT * FROM "stores" WHERE name = $1 AND deleted_at IS NULL ORDER BY "stores"."id"`
 
 		row := sqlmock.
 			NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "external_id", "lat", "lng"}).
@@ -67,9 +104,9 @@ func TestStoreRepository(t *testing.T) {
 		store := sample.NewStore()
 		page := 2
 		limit := 10
-		sort := "created_at DESC"
-		query := fmt.Sprintf(`SELECT * FROM "stores" ORDER BY %s LIMIT %d`, sort, limit)
-		queryCount := `SELECT count(*) FROM "stores"`
+		sort := domain.SortSpec{{Column: "name"}, {Column: "created_at", Desc: true}}
+		query := fmt.Sprintf(`SELECT * FROM "stores" WHERE deleted_at IS NULL ORDER BY "name","created_at" DESC,"id" LIMIT %d OFFSET %d`, limit, (page-1)*limit)
+		queryCount := `SELECT count(*) FROM "stores" WHERE deleted_at IS NULL`
 
 		countRow := sqlmock.NewRows([]string{"count"}).AddRow(1)
 		row := sqlmock.
@@ -81,23 +118,266 @@ func TestStoreRepository(t *testing.T) {
 		mock.ExpectQuery(regexp.QuoteMeta(queryCount)).
 			WillReturnRows(countRow)
 
-		list, total, err := repo.FindAll(context.TODO(), sort, limit, page)
+		list, total, err := repo.FindAll(context.TODO(), nil, sort, nil, limit, page)
+		assert.NoError(t, err)
+		assert.Equal(t, total, int64(1))
+		assert.Len(t, list, 1)
+	})
+	t.Run("FindAll_Filtered", func(t *testing.T) {
+		store := sample.NewStore()
+		page := 1
+		limit := 10
+		sort := domain.SortSpec{{Column: "created_at", Desc: true}}
+		createdFrom := time.Now().Add(-time.Hour)
+		filter := &domain.StoreFilter{
+			Status:      domain.StoreStatusActive,
+			CategoryID:  store.CategoryID,
+			UserID:      store.UserID,
+			AnyTags:     []string{"tag001"},
+			AllTags:     []string{"tag001", "tag002"},
+			CreatedFrom: createdFrom,
+			NamePrefix:  "store_",
+		}
+		where := `WHERE deleted_at IS NULL AND status = $1 AND (category_id = $2) AND user_id = $3 AND tags && $4 AND tags @> $5 AND created_at >= $6 AND name ILIKE $7 ESCAPE '\'`
+		query := fmt.Sprintf(`SELECT * FROM "stores" %s ORDER BY "created_at" DESC,"id" LIMIT %d`, where, limit)
+		queryCount := `SELECT count(*) FROM "stores" ` + where
+		args := []driver.Value{domain.StoreStatusActive, store.CategoryID, store.UserID, pq.StringArray{"tag001"}, pq.StringArray{"tag001", "tag002"}, createdFrom, `store\_%`}
+
+		countRow := sqlmock.NewRows([]string{"count"}).AddRow(1)
+		row := sqlmock.
+			NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "lat", "lng"}).
+			AddRow(store.ID, store.CreatedAt, store.UpdatedAt, store.Name, domain.StoreStatusActive, store.Description, store.AccountID, store.CategoryID, store.Position.Lat, store.Position.Lng)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(args...).
+			WillReturnRows(row)
+		mock.ExpectQuery(regexp.QuoteMeta(queryCount)).
+			WithArgs(args...).
+			WillReturnRows(countRow)
+
+		list, total, err := repo.FindAll(context.TODO(), filter, sort, nil, limit, page)
+		assert.NoError(t, err)
+		assert.Equal(t, total, int64(1))
+		assert.Len(t, list, 1)
+	})
+	t.Run("FindAll_Descendants", func(t *testing.T) {
+		store := sample.NewStore()
+		limit := 10
+		sort := domain.SortSpec{{Column: "created_at", Desc: true}}
+		filter := &domain.StoreFilter{
+			Status:             domain.StoreStatusActive,
+			CategoryID:         store.CategoryID,
+			IncludeDescendants: true,
+		}
+		where := `WHERE deleted_at IS NULL AND status = $1 AND (category_id IN (` +
+			`WITH RECURSIVE descendants AS (` +
+			`SELECT id FROM categories WHERE id = $2 ` +
+			`UNION SELECT c.id FROM categories c JOIN descendants d ON c.parent_id = d.id` +
+			`) SELECT id FROM descendants))`
+		query := fmt.Sprintf(`SELECT * FROM "stores" %s ORDER BY "created_at" DESC,"id" LIMIT %d`, where, limit)
+		queryCount := `SELECT count(*) FROM "stores" ` + where
+
+		countRow := sqlmock.NewRows([]string{"count"}).AddRow(1)
+		row := sqlmock.
+			NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "lat", "lng"}).
+			AddRow(store.ID, store.CreatedAt, store.UpdatedAt, store.Name, domain.StoreStatusActive, store.Description, store.AccountID, store.CategoryID, store.Position.Lat, store.Position.Lng)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(domain.StoreStatusActive, store.CategoryID).
+			WillReturnRows(row)
+		mock.ExpectQuery(regexp.QuoteMeta(queryCount)).
+			WithArgs(domain.StoreStatusActive, store.CategoryID).
+			WillReturnRows(countRow)
+
+		list, total, err := repo.FindAll(context.TODO(), filter, sort, nil, limit, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, total, int64(1))
+		assert.Len(t, list, 1)
+	})
+	t.Run("FindAll_OpenAt", func(t *testing.T) {
+		store := sample.NewStore()
+		limit := 10
+		now := time.Now()
+		sort := domain.SortSpec{{Column: "created_at", Desc: true}}
+		filter := &domain.StoreFilter{OpenAt: now}
+		where := `WHERE deleted_at IS NULL AND (EXISTS (SELECT 1 FROM store_hours sh ` +
+			`CROSS JOIN LATERAL (SELECT $1::timestamptz AT TIME ZONE sh.timezone AS local_at) l `
+		query := fmt.Sprintf(`SELECT * FROM "stores" %s`, where)
+		queryCount := `SELECT count(*) FROM "stores" ` + where
+
+		countRow := sqlmock.NewRows([]string{"count"}).AddRow(1)
+		row := sqlmock.
+			NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "lat", "lng"}).
+			AddRow(store.ID, store.CreatedAt, store.UpdatedAt, store.Name, domain.StoreStatusActive, store.Description, store.AccountID, store.CategoryID, store.Position.Lat, store.Position.Lng)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(now).
+			WillReturnRows(row)
+		mock.ExpectQuery(regexp.QuoteMeta(queryCount)).
+			WithArgs(now).
+			WillReturnRows(countRow)
+
+		list, total, err := repo.FindAll(context.TODO(), filter, sort, nil, limit, 1)
 		assert.NoError(t, err)
 		assert.Equal(t, total, int64(1))
 		assert.Len(t, list, 1)
 	})
+
+	t.Run("FindAll_Cursor", func(t *testing.T) {
+		store := sample.NewStore()
+		limit := 10
+		sort := domain.SortSpec{{Column: "name"}, {Column: "created_at", Desc: true}}
+		filter := &domain.StoreFilter{Status: domain.StoreStatusActive}
+		cursor := domain.NewStoreCursor(store, sort, true)
+		keyset := `("name" < $2) OR ("name" = $3 AND "created_at" > $4) OR ("name" = $5 AND "created_at" = $6 AND "id" < $7)`
+		query := fmt.Sprintf(`SELECT * FROM "stores" WHERE deleted_at IS NULL AND status = $1 AND (%s) ORDER BY "name" DESC,"created_at","id" DESC LIMIT %d`, keyset, limit)
+		queryCount := `SELECT count(*) FROM "stores" WHERE deleted_at IS NULL AND status = $1`
+
+		countRow := sqlmock.NewRows([]string{"count"}).AddRow(2)
+		row := sqlmock.
+			NewRows([]string{"id", "name"}).
+			AddRow("2", "store 002").
+			AddRow("1", "store 001")
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(domain.StoreStatusActive, store.Name, store.Name, store.CreatedAt, store.Name, store.CreatedAt, store.ID).
+			WillReturnRows(row)
+		mock.ExpectQuery(regexp.QuoteMeta(queryCount)).
+			WithArgs(domain.StoreStatusActive).
+			WillReturnRows(countRow)
+
+		list, total, err := repo.FindAll(context.TODO(), filter, sort, cursor, limit, 3)
+		assert.NoError(t, err)
+		assert.Equal(t, total, int64(2))
+		assert.Len(t, list, 2)
+		assert.Equal(t, "1", list[0].ID)
+	})
+	t.Run("FindNearby", func(t *testing.T) {
+		store := sample.NewStore()
+		position := domain.Position{Lat: -8.8383, Lng: 13.2344}
+		filter := &domain.StoreFilter{Status: domain.StoreStatusActive}
+		origin := `ll_to_earth($1, $2)`
+		query := `SELECT *, earth_distance(` + origin + `, ll_to_earth(lat::float8, lng::float8)) / 1000 AS distance FROM "stores" WHERE earth_box(ll_to_earth($3, $4), $5) @> ll_to_earth(lat::float8, lng::float8) AND earth_distance(ll_to_earth($6, $7), ll_to_earth(lat::float8, lng::float8)) <= $8 AND deleted_at IS NULL AND status = $9 ORDER BY distance,id LIMIT 20`
+
+		row := sqlmock.
+			NewRows([]string{"id", "name", "status", "lat", "lng", "distance"}).
+			AddRow(store.ID, store.Name, domain.StoreStatusActive, store.Position.Lat, store.Position.Lng, 1.5)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(position.Lat, position.Lng, position.Lat, position.Lng, 5000.0, position.Lat, position.Lng, 5000.0, domain.StoreStatusActive).
+			WillReturnRows(row)
+
+		list, err := repo.FindNearby(context.TODO(), position, 5, filter, 20)
+		assert.NoError(t, err)
+		assert.Len(t, list, 1)
+		assert.Equal(t, store.ID, list[0].ID)
+		assert.Equal(t, 1.5, list[0].Distance)
+	})
+	t.Run("FindNearby_WithoutEarthDistance", func(t *testing.T) {
+		db, mock := sqliteMock()
+		repo := gorm.NewStoreRepository(db)
+		position := domain.Position{Lat: -8.8383, Lng: 13.2344}
+		min, max := position.BoundingBox(5)
+		query := "SELECT * FROM `stores` WHERE (lat BETWEEN ? AND ?) AND (lng BETWEEN ? AND ?) AND deleted_at IS NULL"
+
+		row := sqlmock.
+			NewRows([]string{"id", "name", "lat", "lng"}).
+			AddRow("far", "store 001", min.Lat+0.001, min.Lng+0.001).
+			AddRow("near", "store 002", position.Lat+0.01, position.Lng).
+			AddRow("nearest", "store 003", position.Lat, position.Lng+0.005).
+			AddRow("second", "store 004", position.Lat-0.02, position.Lng)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(min.Lat, max.Lat, min.Lng, max.Lng).
+			WillReturnRows(row)
+
+		list, err := repo.FindNearby(context.TODO(), position, 5, nil, 2)
+		assert.NoError(t, err)
+		assert.Len(t, list, 2)
+		assert.Equal(t, "nearest", list[0].ID)
+		assert.Equal(t, "near", list[1].ID)
+		assert.InDelta(t, 0.55, list[0].Distance, 0.01)
+		assert.InDelta(t, 1.11, list[1].Distance, 0.01)
+	})
+	t.Run("Search", func(t *testing.T) {
+		store := sample.NewStore()
+		filter := &domain.StoreFilter{Status: domain.StoreStatusActive}
+		tsquery := `websearch_to_tsquery('simple', $%d)`
+		where := fmt.Sprintf(`WHERE (search_vector @@ `+tsquery+`) AND deleted_at IS NULL AND status = $%d`, 4, 5)
+		query := fmt.Sprintf(`SELECT *, ts_rank(search_vector, `+tsquery+`) AS rank, ts_headline('simple', name || ' ' || coalesce(description, ''), `+tsquery+`, $3) AS snippet FROM "stores" %s ORDER BY rank DESC,id LIMIT 10 OFFSET 10`, 1, 2, where)
+		queryCount := fmt.Sprintf(`SELECT count(*) FROM "stores" WHERE (search_vector @@ `+tsquery+`) AND deleted_at IS NULL AND status = $2`, 1)
+
+		row := sqlmock.
+			NewRows([]string{"id", "name", "status", "rank", "snippet"}).
+			AddRow(store.ID, store.Name, domain.StoreStatusActive, 0.6, "\x02coffee\x03 & <tea>")
+		countRow := sqlmock.NewRows([]string{"count"}).AddRow(11)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs("coffee", "coffee", "StartSel=\x02, StopSel=\x03, MaxFragments=2, MaxWords=20, MinWords=5", "coffee", domain.StoreStatusActive).
+			WillReturnRows(row)
+		mock.ExpectQuery(regexp.QuoteMeta(queryCount)).
+			WithArgs("coffee", domain.StoreStatusActive).
+			WillReturnRows(countRow)
+
+		list, total, err := repo.Search(context.TODO(), "coffee", filter, 10, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(11), total)
+		assert.Len(t, list, 1)
+		assert.Equal(t, store.ID, list[0].ID)
+		assert.Equal(t, 0.6, list[0].Rank)
+		assert.Equal(t, "<b>coffee</b> &amp; &lt;tea&gt;", list[0].Snippet)
+	})
+	t.Run("Search_WithoutFullText", func(t *testing.T) {
+		db, mock := sqliteMock()
+		repo := gorm.NewStoreRepository(db)
+		where := "WHERE ((name LIKE ? ESCAPE '\\' OR description LIKE ? ESCAPE '\\')) AND deleted_at IS NULL"
+		query := "SELECT * FROM `stores` " + where + " ORDER BY name,id LIMIT 10"
+		queryCount := "SELECT count(*) FROM `stores` " + where
+
+		row := sqlmock.
+			NewRows([]string{"id", "name", "description"}).
+			AddRow("1", "Coffee 100% shop", "Best <i>coffee</i> in town")
+		countRow := sqlmock.NewRows([]string{"count"}).AddRow(1)
+
+		mock.ExpectQuery(regexp.QuoteMeta(query)).
+			WithArgs(`%100\%%`, `%100\%%`).
+			WillReturnRows(row)
+		mock.ExpectQuery(regexp.QuoteMeta(queryCount)).
+			WithArgs(`%100\%%`, `%100\%%`).
+			WillReturnRows(countRow)
+
+		list, total, err := repo.Search(context.TODO(), "100%", nil, 10, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), total)
+		assert.Len(t, list, 1)
+		assert.Equal(t, "Coffee <b>100%</b> shop Best &lt;i&gt;coffee&lt;/i&gt; in town", list[0].Snippet)
+	})
 	t.Run("Update", func(t *testing.T) {
 		store := sample.NewStore()
-		query := `UPDATE "stores" SET "created_at"=$1,"updated_at"=$2,"name"=$3,"description"=$4,"status"=$5,"user_id"=$6,"account_id"=$7,"category_id"=$8,"image"=$9,"tags"=$10,"lat"=$11,"lng"=$12 WHERE "id" = $13`
+		query := `UPDATE "stores" SET "created_at"=$1,"updated_at"=$2,"name"=$3,"description"=$4,"status"=$5,"user_id"=$6,"account_id"=$7,"category_id"=$8,"image"=$9,"images"=$10,"tags"=$11,"lat"=$12,"lng"=$13,"version"=$14,"deleted_at"=$15 WHERE version = $16 AND "id" = $17`
 
 		mock.ExpectBegin()
 		mock.ExpectExec(regexp.QuoteMeta(query)).
-			WithArgs(store.CreatedAt, sqlmock.AnyArg(), store.Name, store.Description, store.Status, store.UserID, store.AccountID, store.CategoryID, store.Image, pq.StringArray(store.Tags), store.Position.Lat, store.Position.Lng, store.ID).
+			WithArgs(store.CreatedAt, sqlmock.AnyArg(), store.Name, store.Description, store.Status, store.UserID, store.AccountID, store.CategoryID, store.Image, store.Images, pq.StringArray(store.Tags), store.Position.Lat, store.Position.Lng, 2, store.DeletedAt, 1, store.ID).
 			WillReturnResult(sqlmock.NewResult(1, 1))
 		mock.ExpectCommit()
 
 		err := repo.Update(context.TODO(), store)
 		assert.NoError(t, err)
+		assert.Equal(t, 2, store.Version)
+	})
+	t.Run("Update_Conflict", func(t *testing.T) {
+		store := sample.NewStore()
+		query := `UPDATE "stores" SET "created_at"=$1,"updated_at"=$2,"name"=$3,"description"=$4,"status"=$5,"user_id"=$6,"account_id"=$7,"category_id"=$8,"image"=$9,"images"=$10,"tags"=$11,"lat"=$12,"lng"=$13,"version"=$14,"deleted_at"=$15 WHERE version = $16 AND "id" = $17`
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(query)).
+			WillReturnResult(sqlmock.NewResult(1, 0))
+		mock.ExpectCommit()
+
+		err := repo.Update(context.TODO(), store)
+		assert.ErrorIs(t, err, domain.ErrConflict)
+		assert.Equal(t, 1, store.Version)
 	})
 	t.Run("Delete", func(t *testing.T) {
 		store := sample.NewStore()
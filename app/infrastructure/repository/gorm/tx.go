@@ -0,0 +1,40 @@
+package gorm
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+type txManager struct {
+	db *gorm.DB
+}
+
+func NewTxManager(db *gorm.DB) *txManager {
+	return &txManager{
+		db: db,
+	}
+}
+
+// WithinTransaction runs fn inside a transaction. Nested calls run in a
+// savepoint of the outer transaction.
+func (m *txManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "txManager.WithinTransaction")
+	defer span.Finish()
+
+	err = conn(ctx, m.db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+	return
+}
+
+// conn returns the transaction bound to ctx, or db when there is none
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+	return db
+}
@@ -0,0 +1,95 @@
+package gorm_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/gorm"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTxManager(t *testing.T) {
+	t.Parallel()
+	db, mock := dbMock()
+	txManager := gorm.NewTxManager(db)
+	storeRepo := gorm.NewStoreRepository(db)
+	outboxRepo := gorm.NewOutboxRepository(db)
+
+	t.Run("commit", func(t *testing.T) {
+		store := sample.NewStore()
+		msg := sample.NewOutboxMessage()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stores"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "outbox"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectCommit()
+
+		err := txManager.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+			if err := storeRepo.Create(ctx, store); err != nil {
+				return err
+			}
+			return outboxRepo.Store(ctx, msg)
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		store := sample.NewStore()
+		msg := sample.NewOutboxMessage()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stores"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "outbox"`)).
+			WillReturnError(errors.New("unexpected error"))
+		mock.ExpectRollback()
+
+		err := txManager.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+			if err := storeRepo.Create(ctx, store); err != nil {
+				return err
+			}
+			return outboxRepo.Store(ctx, msg)
+		})
+		assert.Error(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+	t.Run("nested_rollback", func(t *testing.T) {
+		store := sample.NewStore()
+		msg := sample.NewOutboxMessage()
+
+		mock.ExpectBegin()
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "stores"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`SAVEPOINT sp\w+`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO "outbox"`)).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+		mock.ExpectExec(`ROLLBACK TO SAVEPOINT sp\w+`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		var nestedErr error
+		err := txManager.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+			if err := storeRepo.Create(ctx, store); err != nil {
+				return err
+			}
+			nestedErr = txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+				if err := outboxRepo.Store(ctx, msg); err != nil {
+					return err
+				}
+				return errors.New("unexpected error")
+			})
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Error(t, nestedErr)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -20,7 +20,7 @@ func NewAccountRepository(db *sql.DB) *accountRepository {
 
 func (r *accountRepository) Store(ctx context.Context, a *domain.Account) (err error) {
 	query := `INSERT INTO accounts (id,created_at,updated_at,balance) VALUES ($1,$2,$3,$4)`
-	res, err := r.db.ExecContext(ctx, query, a.ID, a.CreatedAt, a.UpdatedAt, a.Balance)
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, a.ID, a.CreatedAt, a.UpdatedAt, a.Balance)
 	if err != nil {
 		return
 	}
@@ -39,7 +39,26 @@ func (r *accountRepository) Store(ctx context.Context, a *domain.Account) (err e
 
 func (r *accountRepository) FindByID(ctx context.Context, id string) (res *domain.Account, err error) {
 	query := `SELECT * FROM accounts WHERE id = $1`
-	row := r.db.QueryRowContext(ctx, query, id)
+	row := conn(ctx, r.db).QueryRowContext(ctx, query, id)
+
+	a := new(domain.Account)
+	err = row.Scan(
+		&a.ID,
+		&a.CreatedAt,
+		&a.UpdatedAt,
+		&a.Balance,
+	)
+	if err != nil {
+		return
+	}
+
+	res = a
+	return
+}
+
+func (r *accountRepository) FindForUpdate(ctx context.Context, id string) (res *domain.Account, err error) {
+	query := `SELECT * FROM accounts WHERE id = $1 FOR UPDATE`
+	row := conn(ctx, r.db).QueryRowContext(ctx, query, id)
 
 	a := new(domain.Account)
 	err = row.Scan(
@@ -58,7 +77,7 @@ func (r *accountRepository) FindByID(ctx context.Context, id string) (res *domai
 
 func (r *accountRepository) Update(ctx context.Context, a *domain.Account) (err error) {
 	query := `UPDATE accounts SET created_at=$1,updated_at=$2,balance=$3 WHERE id = $4`
-	res, err := r.db.ExecContext(ctx, query, a.CreatedAt, a.UpdatedAt, a.Balance, a.ID)
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, a.CreatedAt, a.UpdatedAt, a.Balance, a.ID)
 	if err != nil {
 		return
 	}
@@ -78,7 +97,7 @@ func (r *accountRepository) Update(ctx context.Context, a *domain.Account) (err
 
 func (r *accountRepository) Delete(ctx context.Context, id string) (err error) {
 	query := `DELETE FROM accounts WHERE id = $1`
-	res, err := r.db.ExecContext(ctx, query, id)
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return
 	}
@@ -128,6 +128,44 @@ func Test_AccountRepo_FindByID(t *testing.T) {
 	}
 }
 
+func Test_AccountRepo_FindForUpdate(t *testing.T) {
+	a := sample.NewAccount()
+	query := `SELECT * FROM accounts WHERE id = $1 FOR UPDATE`
+
+	t.Run("failure_exec_query_returns_error", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		repo := pg.NewAccountRepository(db)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(a.ID).WillReturnError(errors.New("unexpected error"))
+
+		res, err := repo.FindForUpdate(context.TODO(), a.ID)
+		assert.Nil(t, res)
+		assert.Error(t, err)
+	})
+
+	t.Run("success_locks_within_transaction", func(t *testing.T) {
+		t.Parallel()
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		repo := pg.NewAccountRepository(db)
+		row := sqlmock.
+			NewRows([]string{"id", "created_at", "updated_at", "balance"}).
+			AddRow(a.ID, a.CreatedAt, a.UpdatedAt, a.Balance)
+		mock.ExpectBegin()
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(a.ID).WillReturnRows(row)
+		mock.ExpectCommit()
+
+		err = pg.NewTxManager(db).WithinTransaction(context.TODO(), func(ctx context.Context) error {
+			res, err := repo.FindForUpdate(ctx, a.ID)
+			assert.Equal(t, a.ID, res.ID)
+			return err
+		})
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func Test_AccountRepo_Update(t *testing.T) {
 	a := sample.NewAccount()
 	testCases := []struct {
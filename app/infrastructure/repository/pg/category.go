@@ -8,6 +8,8 @@ import (
 	"github.com/EdlanioJ/kbu-store/app/domain"
 )
 
+const categoryColumns = `id,created_at,updated_at,name,status,parent_id`
+
 type categoryRepository struct {
 	db *sql.DB
 }
@@ -19,8 +21,8 @@ func NewCategoryRepository(db *sql.DB) *categoryRepository {
 }
 
 func (r *categoryRepository) Store(ctx context.Context, c *domain.Category) (err error) {
-	query := `INSERT INTO categories (id,created_at,updated_at,name,status) VALUES ($1,$2,$3,$4,$5)`
-	res, err := r.db.ExecContext(ctx, query, c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status)
+	query := `INSERT INTO categories (id,created_at,updated_at,name,status,parent_id) VALUES ($1,$2,$3,$4,$5,$6)`
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status, c.ParentID)
 	if err != nil {
 		return
 	}
@@ -38,27 +40,99 @@ func (r *categoryRepository) Store(ctx context.Context, c *domain.Category) (err
 }
 
 func (r *categoryRepository) FindByID(ctx context.Context, id string) (res *domain.Category, err error) {
-	query := `SELECT * FROM categories WHERE id = $1 ORDER BY id LIMIT 1`
-	row := r.db.QueryRowContext(ctx, query, id)
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1 ORDER BY id LIMIT 1`
+	row := conn(ctx, r.db).QueryRowContext(ctx, query, id)
+
+	res, err = scanCategory(row)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	res = &domain.Category{}
+	return
+}
+
+// scanCategory reads a row selected with categoryColumns
+func scanCategory(row interface{ Scan(...interface{}) error }) (c *domain.Category, err error) {
+	c = &domain.Category{}
 	err = row.Scan(
-		&res.ID,
-		&res.CreatedAt,
-		&res.UpdatedAt,
-		&res.Name,
-		&res.Status,
+		&c.ID,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+		&c.Name,
+		&c.Status,
+		&c.ParentID,
 	)
+	return
+}
+
+// FindAll returns a page of the categories ordered by name, all of them or
+// only those in status
+func (r *categoryRepository) FindAll(ctx context.Context, status string, limit, page int) (res domain.Categories, total int64, err error) {
+	var where string
+	var args []interface{}
+	if status != "" {
+		where = " WHERE status = $1"
+		args = append(args, status)
+	}
+
+	offset := (page - 1) * limit
+	query := fmt.Sprintf(`SELECT `+categoryColumns+` FROM categories%s ORDER BY name, id OFFSET %d LIMIT %d`, where, offset, limit)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
-		return nil, err
+		return
+	}
+	defer rows.Close()
+
+	res = make(domain.Categories, 0)
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		res = append(res, c)
+	}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, `SELECT count(1) FROM categories`+where, args...).Scan(&total)
+	if err != nil {
+		res = make(domain.Categories, 0)
+		return
+	}
+	return
+}
+
+// FindTree returns every category ordered by name, to be arranged under
+// their parents
+func (r *categoryRepository) FindTree(ctx context.Context) (res domain.Categories, err error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories ORDER BY name, id`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	res = make(domain.Categories, 0)
+	for rows.Next() {
+		c, err := scanCategory(rows)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, c)
 	}
+	return res, rows.Err()
+}
 
+// CountChildren returns how many categories are directly under id
+func (r *categoryRepository) CountChildren(ctx context.Context, id string) (total int64, err error) {
+	err = conn(ctx, r.db).QueryRowContext(ctx, `SELECT count(1) FROM categories WHERE parent_id = $1`, id).Scan(&total)
 	return
 }
 
 func (r *categoryRepository) Update(ctx context.Context, c *domain.Category) (err error) {
-	query := `UPDATE categories SET created_at=$1, updated_at=$2, name=$3, status=$4 WHERE id = $5`
-	res, err := r.db.ExecContext(ctx, query, c.CreatedAt, c.UpdatedAt, c.Name, c.Status, c.ID)
+	query := `UPDATE categories SET created_at=$1, updated_at=$2, name=$3, status=$4, parent_id=$5 WHERE id = $6`
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, c.CreatedAt, c.UpdatedAt, c.Name, c.Status, c.ParentID, c.ID)
 	if err != nil {
 		return
 	}
@@ -73,3 +147,22 @@ func (r *categoryRepository) Update(ctx context.Context, c *domain.Category) (er
 	}
 	return
 }
+
+func (r *categoryRepository) Delete(ctx context.Context, id string) (err error) {
+	query := `DELETE FROM categories WHERE id = $1`
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, id)
+	if err != nil {
+		return
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if affect != 1 {
+		err = fmt.Errorf("Weird Behavior. Total Affected: %d", affect)
+		return
+	}
+	return
+}
@@ -27,8 +27,8 @@ func Test_CategoryRepo_Store(t *testing.T) {
 			arg:         c,
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `INSERT INTO categories (id,created_at,updated_at,name,status) VALUES ($1,$2,$3,$4,$5)`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status).WillReturnError(errors.New("unexpected error"))
+				query := `INSERT INTO categories (id,created_at,updated_at,name,status,parent_id) VALUES ($1,$2,$3,$4,$5,$6)`
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status, nil).WillReturnError(errors.New("unexpected error"))
 			},
 		},
 		{
@@ -36,8 +36,8 @@ func Test_CategoryRepo_Store(t *testing.T) {
 			arg:         c,
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `INSERT INTO categories (id,created_at,updated_at,name,status) VALUES ($1,$2,$3,$4,$5)`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status).WillReturnResult(sqlmock.NewErrorResult(errors.New("unexpected error")))
+				query := `INSERT INTO categories (id,created_at,updated_at,name,status,parent_id) VALUES ($1,$2,$3,$4,$5,$6)`
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status, nil).WillReturnResult(sqlmock.NewErrorResult(errors.New("unexpected error")))
 			},
 		},
 		{
@@ -45,16 +45,16 @@ func Test_CategoryRepo_Store(t *testing.T) {
 			arg:         c,
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `INSERT INTO categories (id,created_at,updated_at,name,status) VALUES ($1,$2,$3,$4,$5)`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status).WillReturnResult(sqlmock.NewResult(1, 2))
+				query := `INSERT INTO categories (id,created_at,updated_at,name,status,parent_id) VALUES ($1,$2,$3,$4,$5,$6)`
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status, nil).WillReturnResult(sqlmock.NewResult(1, 2))
 			},
 		},
 		{
 			name: "success",
 			arg:  c,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `INSERT INTO categories (id,created_at,updated_at,name,status) VALUES ($1,$2,$3,$4,$5)`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status).WillReturnResult(sqlmock.NewResult(1, 1))
+				query := `INSERT INTO categories (id,created_at,updated_at,name,status,parent_id) VALUES ($1,$2,$3,$4,$5,$6)`
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status, nil).WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
 	}
@@ -91,18 +91,28 @@ func Test_CategoryRepo_FindByID(t *testing.T) {
 			arg:         id,
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `SELECT * FROM categories WHERE id = $1 ORDER BY id LIMIT 1`
+				query := `SELECT id,created_at,updated_at,name,status,parent_id FROM categories WHERE id = $1 ORDER BY id LIMIT 1`
 				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(id).WillReturnError(errors.New("unexpected error"))
 			},
 		},
+		{
+			name:        "failure_not_found",
+			arg:         id,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				row := sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "status", "parent_id"})
+				query := `SELECT id,created_at,updated_at,name,status,parent_id FROM categories WHERE id = $1 ORDER BY id LIMIT 1`
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(id).WillReturnRows(row)
+			},
+		},
 		{
 			name: "success",
 			arg:  id,
 			prepare: func(mock sqlmock.Sqlmock) {
 				row := sqlmock.
-					NewRows([]string{"id", "created_at", "updated_at", "name", "status"}).
-					AddRow(c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status)
-				query := `SELECT * FROM categories WHERE id = $1 ORDER BY id LIMIT 1`
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "parent_id"}).
+					AddRow(c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status, nil)
+				query := `SELECT id,created_at,updated_at,name,status,parent_id FROM categories WHERE id = $1 ORDER BY id LIMIT 1`
 				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(id).WillReturnRows(row)
 			},
 		},
@@ -142,8 +152,8 @@ func Test_CategoryRepo_Update(t *testing.T) {
 			arg:         c,
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `UPDATE categories SET created_at=$1, updated_at=$2, name=$3, status=$4 WHERE id = $5`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.CreatedAt, c.UpdatedAt, c.Name, c.Status, c.ID).WillReturnError(errors.New("unexpected error"))
+				query := `UPDATE categories SET created_at=$1, updated_at=$2, name=$3, status=$4, parent_id=$5 WHERE id = $6`
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.CreatedAt, c.UpdatedAt, c.Name, c.Status, nil, c.ID).WillReturnError(errors.New("unexpected error"))
 			},
 		},
 		{
@@ -151,8 +161,8 @@ func Test_CategoryRepo_Update(t *testing.T) {
 			arg:         c,
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `UPDATE categories SET created_at=$1, updated_at=$2, name=$3, status=$4 WHERE id = $5`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.CreatedAt, c.UpdatedAt, c.Name, c.Status, c.ID).WillReturnResult(sqlmock.NewErrorResult(errors.New("unexpected error")))
+				query := `UPDATE categories SET created_at=$1, updated_at=$2, name=$3, status=$4, parent_id=$5 WHERE id = $6`
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.CreatedAt, c.UpdatedAt, c.Name, c.Status, nil, c.ID).WillReturnResult(sqlmock.NewErrorResult(errors.New("unexpected error")))
 			},
 		},
 		{
@@ -160,16 +170,16 @@ func Test_CategoryRepo_Update(t *testing.T) {
 			arg:         c,
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `UPDATE categories SET created_at=$1, updated_at=$2, name=$3, status=$4 WHERE id = $5`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.CreatedAt, c.UpdatedAt, c.Name, c.Status, c.ID).WillReturnResult(sqlmock.NewResult(1, 2))
+				query := `UPDATE categories SET created_at=$1, updated_at=$2, name=$3, status=$4, parent_id=$5 WHERE id = $6`
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.CreatedAt, c.UpdatedAt, c.Name, c.Status, nil, c.ID).WillReturnResult(sqlmock.NewResult(1, 2))
 			},
 		},
 		{
 			name: "success",
 			arg:  c,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `UPDATE categories SET created_at=$1, updated_at=$2, name=$3, status=$4 WHERE id = $5`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.CreatedAt, c.UpdatedAt, c.Name, c.Status, c.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+				query := `UPDATE categories SET created_at=$1, updated_at=$2, name=$3, status=$4, parent_id=$5 WHERE id = $6`
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.CreatedAt, c.UpdatedAt, c.Name, c.Status, nil, c.ID).WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
 	}
@@ -191,3 +201,183 @@ func Test_CategoryRepo_Update(t *testing.T) {
 		})
 	}
 }
+
+func Test_CategoryRepo_FindAll(t *testing.T) {
+	c := sample.NewCategory()
+	testCases := []struct {
+		name        string
+		status      string
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_query_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				query := `SELECT id,created_at,updated_at,name,status,parent_id FROM categories ORDER BY name, id OFFSET 10 LIMIT 10`
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_count_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				query := `SELECT id,created_at,updated_at,name,status,parent_id FROM categories ORDER BY name, id OFFSET 10 LIMIT 10`
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "parent_id"}).
+					AddRow(c.ID, c.CreatedAt, c.UpdatedAt, c.Name, c.Status, nil)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(row)
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(1) FROM categories`)).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:   "success_with_status",
+			status: domain.CategoryStatusActive,
+			prepare: func(mock sqlmock.Sqlmock) {
+				query := `SELECT id,created_at,updated_at,name,status,parent_id FROM categories WHERE status = $1 ORDER BY name, id OFFSET 10 LIMIT 10`
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "parent_id"}).
+					AddRow(c.ID, c.CreatedAt, c.UpdatedAt, c.Name, domain.CategoryStatusActive, nil)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(domain.CategoryStatusActive).WillReturnRows(row)
+				countRow := sqlmock.NewRows([]string{"count"}).AddRow(11)
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(1) FROM categories WHERE status = $1`)).WithArgs(domain.CategoryStatusActive).WillReturnRows(countRow)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewCategoryRepository(db)
+			tc.prepare(mock)
+			res, total, err := repo.FindAll(context.TODO(), tc.status, 10, 2)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Len(t, res, 0)
+				assert.Equal(t, int64(0), total)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.Equal(t, int64(11), total)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_CategoryRepo_FindTree(t *testing.T) {
+	parent := sample.NewCategory()
+	child := sample.NewCategory()
+	child.ParentID = &parent.ID
+	query := `SELECT id,created_at,updated_at,name,status,parent_id FROM categories ORDER BY name, id`
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_query_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "parent_id"}).
+					AddRow(child.ID, child.CreatedAt, child.UpdatedAt, child.Name, child.Status, parent.ID).
+					AddRow(parent.ID, parent.CreatedAt, parent.UpdatedAt, parent.Name, parent.Status, nil)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(row)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewCategoryRepository(db)
+			tc.prepare(mock)
+			res, err := repo.FindTree(context.TODO())
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Len(t, res, 0)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, domain.Categories{child, parent}, res)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_CategoryRepo_CountChildren(t *testing.T) {
+	id := uuid.NewV4().String()
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	repo := pg.NewCategoryRepository(db)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT count(1) FROM categories WHERE parent_id = $1`)).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	total, err := repo.CountChildren(context.TODO(), id)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_CategoryRepo_Delete(t *testing.T) {
+	id := uuid.NewV4().String()
+	query := `DELETE FROM categories WHERE id = $1`
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(id).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_returns_invalid_number_of_affected_row",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(id).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(id).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewCategoryRepository(db)
+			tc.prepare(mock)
+			err = repo.Delete(context.TODO(), id)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
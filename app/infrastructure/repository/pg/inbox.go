@@ -0,0 +1,44 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+)
+
+type inboxRepository struct {
+	db *sql.DB
+}
+
+func NewInboxRepository(db *sql.DB) *inboxRepository {
+	return &inboxRepository{
+		db: db,
+	}
+}
+
+func (r *inboxRepository) Exists(ctx context.Context, id string) (exists bool, err error) {
+	query := `SELECT EXISTS (SELECT 1 FROM inbox WHERE id = $1)`
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, id).Scan(&exists)
+	return
+}
+
+func (r *inboxRepository) Store(ctx context.Context, m *domain.InboxMessage) (err error) {
+	query := `INSERT INTO inbox (id,topic,created_at) VALUES ($1,$2,$3)`
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, m.ID, m.Topic, m.CreatedAt)
+	if err != nil {
+		return
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if affect != 1 {
+		err = fmt.Errorf("Weird Behavior. Total Affected: %d", affect)
+		return
+	}
+	return
+}
@@ -0,0 +1,116 @@
+package pg_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/pg"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_InboxRepo_Exists(t *testing.T) {
+	m := sample.NewInboxMessage()
+	query := `SELECT EXISTS (SELECT 1 FROM inbox WHERE id = $1)`
+	testCases := []struct {
+		name        string
+		exists      bool
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_query_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(m.ID).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name: "success_not_found",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(m.ID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+			},
+		},
+		{
+			name:   "success",
+			exists: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(m.ID).WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewInboxRepository(db)
+			tc.prepare(mock)
+			exists, err := repo.Exists(context.TODO(), m.ID)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.exists, exists)
+		})
+	}
+}
+
+func Test_InboxRepo_Store(t *testing.T) {
+	m := sample.NewInboxMessage()
+	query := `INSERT INTO inbox (id,topic,created_at) VALUES ($1,$2,$3)`
+	testCases := []struct {
+		name        string
+		arg         *domain.InboxMessage
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			arg:         m,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(m.ID, m.Topic, m.CreatedAt).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_returns_invalid_number_of_affected_row",
+			arg:         m,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(m.ID, m.Topic, m.CreatedAt).WillReturnResult(sqlmock.NewResult(1, 2))
+			},
+		},
+		{
+			name: "success",
+			arg:  m,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(m.ID, m.Topic, m.CreatedAt).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewInboxRepository(db)
+			tc.prepare(mock)
+			err = repo.Store(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/shopspring/decimal"
+)
+
+type ledgerRepository struct {
+	db *sql.DB
+}
+
+func NewLedgerRepository(db *sql.DB) *ledgerRepository {
+	return &ledgerRepository{
+		db: db,
+	}
+}
+
+func (r *ledgerRepository) Store(ctx context.Context, e *domain.LedgerEntry) (err error) {
+	query := `INSERT INTO account_transactions (id,created_at,updated_at,account_id,amount,direction,reason,reference_id,balance) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, e.ID, e.CreatedAt, e.UpdatedAt, e.AccountID, e.Amount, e.Direction, e.Reason, e.ReferenceID, e.Balance)
+	if err != nil {
+		return
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if affect != 1 {
+		err = fmt.Errorf("Weird Behavior. Total Affected: %d", affect)
+		return
+	}
+	return
+}
+
+func (r *ledgerRepository) FindByAccountID(ctx context.Context, accountID string, limit, page int) (res domain.LedgerEntries, total int64, err error) {
+	query := `SELECT id,created_at,updated_at,account_id,amount,direction,COALESCE(reason,''),COALESCE(reference_id,''),balance FROM account_transactions WHERE account_id = $1 ORDER BY created_at DESC, id OFFSET $2 LIMIT $3`
+	countQuery := `SELECT count(1) FROM account_transactions WHERE account_id = $1`
+
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, accountID, (page-1)*limit, limit)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	res = make(domain.LedgerEntries, 0)
+	for rows.Next() {
+		e := new(domain.LedgerEntry)
+		err = rows.Scan(
+			&e.ID,
+			&e.CreatedAt,
+			&e.UpdatedAt,
+			&e.AccountID,
+			&e.Amount,
+			&e.Direction,
+			&e.Reason,
+			&e.ReferenceID,
+			&e.Balance,
+		)
+		if err != nil {
+			return nil, 0, err
+		}
+		res = append(res, e)
+	}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, countQuery, accountID).Scan(&total)
+	if err != nil {
+		res = make(domain.LedgerEntries, 0)
+		return
+	}
+	return
+}
+
+func (r *ledgerRepository) SumByAccountID(ctx context.Context, accountID string) (res decimal.Decimal, err error) {
+	query := `SELECT COALESCE(SUM(CASE WHEN direction = $1 THEN amount ELSE -amount END), 0) FROM account_transactions WHERE account_id = $2`
+	err = conn(ctx, r.db).QueryRowContext(ctx, query, domain.LedgerDirectionCredit, accountID).Scan(&res)
+	return
+}
@@ -0,0 +1,166 @@
+package pg_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/pg"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LedgerRepo_Store(t *testing.T) {
+	e := sample.NewLedgerEntry()
+	query := `INSERT INTO account_transactions (id,created_at,updated_at,account_id,amount,direction,reason,reference_id,balance) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+	testCases := []struct {
+		name        string
+		arg         *domain.LedgerEntry
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			arg:         e,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(e.ID, e.CreatedAt, e.UpdatedAt, e.AccountID, e.Amount, e.Direction, e.Reason, e.ReferenceID, e.Balance).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_returns_invalid_number_of_affected_row",
+			arg:         e,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(e.ID, e.CreatedAt, e.UpdatedAt, e.AccountID, e.Amount, e.Direction, e.Reason, e.ReferenceID, e.Balance).WillReturnResult(sqlmock.NewResult(1, 2))
+			},
+		},
+		{
+			name: "success",
+			arg:  e,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(e.ID, e.CreatedAt, e.UpdatedAt, e.AccountID, e.Amount, e.Direction, e.Reason, e.ReferenceID, e.Balance).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewLedgerRepository(db)
+			tc.prepare(mock)
+			err = repo.Store(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_LedgerRepo_FindByAccountID(t *testing.T) {
+	e := sample.NewLedgerEntry()
+	query := `SELECT id,created_at,updated_at,account_id,amount,direction,COALESCE(reason,''),COALESCE(reference_id,''),balance FROM account_transactions WHERE account_id = $1 ORDER BY created_at DESC, id OFFSET $2 LIMIT $3`
+	countQuery := `SELECT count(1) FROM account_transactions WHERE account_id = $1`
+	columns := []string{"id", "created_at", "updated_at", "account_id", "amount", "direction", "reason", "reference_id", "balance"}
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(e.AccountID, 10, 10).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_count_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				row := sqlmock.NewRows(columns).AddRow(e.ID, e.CreatedAt, e.UpdatedAt, e.AccountID, e.Amount, e.Direction, e.Reason, e.ReferenceID, e.Balance)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(e.AccountID, 10, 10).WillReturnRows(row)
+				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(e.AccountID).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				row := sqlmock.NewRows(columns).AddRow(e.ID, e.CreatedAt, e.UpdatedAt, e.AccountID, e.Amount, e.Direction, e.Reason, e.ReferenceID, e.Balance)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(e.AccountID, 10, 10).WillReturnRows(row)
+				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(e.AccountID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewLedgerRepository(db)
+			tc.prepare(mock)
+			res, total, err := repo.FindByAccountID(context.TODO(), e.AccountID, 10, 2)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Len(t, res, 0)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.Equal(t, int64(11), total)
+			}
+		})
+	}
+}
+
+func Test_LedgerRepo_SumByAccountID(t *testing.T) {
+	accountID := sample.NewAccount().ID
+	query := `SELECT COALESCE(SUM(CASE WHEN direction = $1 THEN amount ELSE -amount END), 0) FROM account_transactions WHERE account_id = $2`
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(domain.LedgerDirectionCredit, accountID).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(domain.LedgerDirectionCredit, accountID).WillReturnRows(sqlmock.NewRows([]string{"sum"}).AddRow("990.5"))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewLedgerRepository(db)
+			tc.prepare(mock)
+			res, err := repo.SumByAccountID(context.TODO(), accountID)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, res.Equal(decimal.RequireFromString("990.5")))
+			}
+		})
+	}
+}
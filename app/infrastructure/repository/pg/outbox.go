@@ -0,0 +1,89 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+)
+
+type outboxRepository struct {
+	db *sql.DB
+}
+
+func NewOutboxRepository(db *sql.DB) *outboxRepository {
+	return &outboxRepository{
+		db: db,
+	}
+}
+
+func (r *outboxRepository) Store(ctx context.Context, m *domain.OutboxMessage) (err error) {
+	query := `INSERT INTO outbox (id,created_at,updated_at,topic,payload,attempts,last_error,next_attempt_at,delivered_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, m.ID, m.CreatedAt, m.UpdatedAt, m.Topic, m.Payload, m.Attempts, m.LastError, m.NextAttemptAt, m.DeliveredAt)
+	if err != nil {
+		return
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if affect != 1 {
+		err = fmt.Errorf("Weird Behavior. Total Affected: %d", affect)
+		return
+	}
+	return
+}
+
+func (r *outboxRepository) FindPending(ctx context.Context, limit int) (res []*domain.OutboxMessage, err error) {
+	query := `SELECT id,created_at,updated_at,topic,payload,attempts,last_error,next_attempt_at,delivered_at,locked_until FROM outbox WHERE delivered_at IS NULL ORDER BY created_at, id LIMIT $1 FOR UPDATE`
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, limit)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	res = make([]*domain.OutboxMessage, 0)
+	for rows.Next() {
+		m := new(domain.OutboxMessage)
+		err = rows.Scan(
+			&m.ID,
+			&m.CreatedAt,
+			&m.UpdatedAt,
+			&m.Topic,
+			&m.Payload,
+			&m.Attempts,
+			&m.LastError,
+			&m.NextAttemptAt,
+			&m.DeliveredAt,
+			&m.LockedUntil,
+		)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, m)
+	}
+	return
+}
+
+func (r *outboxRepository) Update(ctx context.Context, m *domain.OutboxMessage) (err error) {
+	query := `UPDATE outbox SET updated_at=$1,attempts=$2,last_error=$3,next_attempt_at=$4,delivered_at=$5,locked_until=$6 WHERE id = $7`
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, time.Now(), m.Attempts, m.LastError, m.NextAttemptAt, m.DeliveredAt, m.LockedUntil, m.ID)
+	if err != nil {
+		return
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if affect != 1 {
+		err = fmt.Errorf("Weird Behavior. Total Affected: %d", affect)
+		return
+	}
+	return
+}
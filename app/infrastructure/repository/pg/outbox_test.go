@@ -0,0 +1,173 @@
+package pg_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/pg"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OutboxRepo_Store(t *testing.T) {
+	m := sample.NewOutboxMessage()
+	query := `INSERT INTO outbox (id,created_at,updated_at,topic,payload,attempts,last_error,next_attempt_at,delivered_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`
+	testCases := []struct {
+		name        string
+		arg         *domain.OutboxMessage
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			arg:         m,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(m.ID, m.CreatedAt, m.UpdatedAt, m.Topic, m.Payload, m.Attempts, m.LastError, m.NextAttemptAt, m.DeliveredAt).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_returns_invalid_number_of_affected_row",
+			arg:         m,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(m.ID, m.CreatedAt, m.UpdatedAt, m.Topic, m.Payload, m.Attempts, m.LastError, m.NextAttemptAt, m.DeliveredAt).WillReturnResult(sqlmock.NewResult(1, 2))
+			},
+		},
+		{
+			name: "success",
+			arg:  m,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(m.ID, m.CreatedAt, m.UpdatedAt, m.Topic, m.Payload, m.Attempts, m.LastError, m.NextAttemptAt, m.DeliveredAt).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewOutboxRepository(db)
+			tc.prepare(mock)
+			err = repo.Store(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_OutboxRepo_FindPending(t *testing.T) {
+	m := sample.NewOutboxMessage()
+	query := `SELECT id,created_at,updated_at,topic,payload,attempts,last_error,next_attempt_at,delivered_at,locked_until FROM outbox WHERE delivered_at IS NULL ORDER BY created_at, id LIMIT $1 FOR UPDATE`
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(10).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_scan_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				row := sqlmock.NewRows([]string{"id", "topic"}).AddRow(m.ID, m.Topic)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(10).WillReturnRows(row)
+			},
+		},
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "topic", "payload", "attempts", "last_error", "next_attempt_at", "delivered_at", "locked_until"}).
+					AddRow(m.ID, m.CreatedAt, m.UpdatedAt, m.Topic, m.Payload, m.Attempts, m.LastError, m.NextAttemptAt, nil, nil)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(10).WillReturnRows(row)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewOutboxRepository(db)
+			tc.prepare(mock)
+			res, err := repo.FindPending(context.TODO(), 10)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+			}
+		})
+	}
+}
+
+func Test_OutboxRepo_Update(t *testing.T) {
+	m := sample.NewOutboxMessage()
+	m.Deliver()
+	query := `UPDATE outbox SET updated_at=$1,attempts=$2,last_error=$3,next_attempt_at=$4,delivered_at=$5,locked_until=$6 WHERE id = $7`
+	testCases := []struct {
+		name        string
+		arg         *domain.OutboxMessage
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			arg:         m,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(sqlmock.AnyArg(), m.Attempts, m.LastError, m.NextAttemptAt, m.DeliveredAt, m.LockedUntil, m.ID).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_returns_invalid_number_of_affected_row",
+			arg:         m,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(sqlmock.AnyArg(), m.Attempts, m.LastError, m.NextAttemptAt, m.DeliveredAt, m.LockedUntil, m.ID).WillReturnResult(sqlmock.NewResult(1, 0))
+			},
+		},
+		{
+			name: "success",
+			arg:  m,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(sqlmock.AnyArg(), m.Attempts, m.LastError, m.NextAttemptAt, m.DeliveredAt, m.LockedUntil, m.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewOutboxRepository(db)
+			tc.prepare(mock)
+			err = repo.Update(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -4,10 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/lib/pq"
 )
 
+const storeColumns = `id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at`
+
 type storeRepository struct {
 	db *sql.DB
 }
@@ -18,48 +23,61 @@ func NewStoreRepository(db *sql.DB) *storeRepository {
 	}
 }
 
+// scanStore reads a row selected with storeColumns, followed by extra
+func scanStore(rows *sql.Rows, extra ...interface{}) (*domain.Store, error) {
+	s := &domain.Store{}
+
+	var lat float64
+	var lng float64
+	dest := []interface{}{
+		&s.ID,
+		&s.CreatedAt,
+		&s.UpdatedAt,
+		&s.Name,
+		&s.Status,
+		&s.Description,
+		&s.AccountID,
+		&s.CategoryID,
+		&s.UserID,
+		&s.Image,
+		&s.Images,
+		&s.Tags,
+		&lat,
+		&lng,
+		&s.Version,
+		&s.DeletedAt,
+	}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	s.Position = domain.Position{
+		Lat: lat,
+		Lng: lng,
+	}
+	return s, nil
+}
+
 func (r *storeRepository) getAll(ctx context.Context, query string, args ...interface{}) (res []*domain.Store, err error) {
-	rows, err := r.db.QueryContext(ctx, query, args...)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
 	if err != nil {
 		return
 	}
+	defer rows.Close()
+
 	res = make([]*domain.Store, 0)
 	for rows.Next() {
-		s := &domain.Store{}
-
-		var lat float64
-		var lng float64
-		err = rows.Scan(
-			&s.ID,
-			&s.CreatedAt,
-			&s.UpdatedAt,
-			&s.Name,
-			&s.Status,
-			&s.Description,
-			&s.AccountID,
-			&s.CategoryID,
-			&s.UserID,
-			&s.Image,
-			&s.Tags,
-			&lat,
-			&lng,
-		)
+		s, err := scanStore(rows)
 		if err != nil {
 			return nil, err
 		}
-		s.Position = domain.Position{
-			Lat: lat,
-			Lng: lng,
-		}
-		defer rows.Close()
 		res = append(res, s)
 	}
 	return
 }
 
-func (r *storeRepository) Store(ctx context.Context, s *domain.Store) (err error) {
-	query := `INSERT INTO stores (id,created_at,updated_at,name,description,status,user_id,account_id,category_id,image,tags,lat,lng) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`
-	res, err := r.db.ExecContext(ctx, query, s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Image, s.Tags, s.Position.Lat, s.Position.Lng)
+func (r *storeRepository) Create(ctx context.Context, s *domain.Store) (err error) {
+	query := `INSERT INTO stores (id,created_at,updated_at,name,description,status,user_id,account_id,category_id,image,images,tags,lat,lng,version) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)`
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version)
 	if err != nil {
 		return
 	}
@@ -77,7 +95,7 @@ func (r *storeRepository) Store(ctx context.Context, s *domain.Store) (err error
 }
 
 func (r *storeRepository) FindByID(ctx context.Context, id string) (res *domain.Store, err error) {
-	query := `SELECT * FROM stores WHERE id = $1 ORDER BY id`
+	query := `SELECT ` + storeColumns + ` FROM stores WHERE id = $1 AND deleted_at IS NULL ORDER BY id`
 	list, err := r.getAll(ctx, query, id)
 	if err != nil {
 		return
@@ -91,8 +109,31 @@ func (r *storeRepository) FindByID(ctx context.Context, id string) (res *domain.
 	return
 }
 
+// FindDeletedByID returns a store only while it is deleted
+func (r *storeRepository) FindDeletedByID(ctx context.Context, id string) (res *domain.Store, err error) {
+	query := `SELECT ` + storeColumns + ` FROM stores WHERE id = $1 AND deleted_at IS NOT NULL ORDER BY id`
+	list, err := r.getAll(ctx, query, id)
+	if err != nil {
+		return
+	}
+
+	if len(list) > 0 {
+		res = list[0]
+	} else {
+		return nil, domain.ErrNotFound
+	}
+	return
+}
+
+// FindDeleted returns up to limit of the stores deleted before a time,
+// oldest deletion first
+func (r *storeRepository) FindDeleted(ctx context.Context, before time.Time, limit int) (res domain.Stores, err error) {
+	query := fmt.Sprintf(`SELECT %s FROM stores WHERE deleted_at < $1 ORDER BY deleted_at, id LIMIT %d`, storeColumns, limit)
+	return r.getAll(ctx, query, before)
+}
+
 func (r *storeRepository) FindByName(ctx context.Context, name string) (res *domain.Store, err error) {
-	query := `SELECT * FROM stores WHERE name = $1 ORDER BY id`
+	query := `SELECT ` + storeColumns + ` FROM stores WHERE name = $1 AND deleted_at IS NULL ORDER BY id`
 	list, err := r.getAll(ctx, query, name)
 	if err != nil {
 		return
@@ -106,18 +147,171 @@ func (r *storeRepository) FindByName(ctx context.Context, name string) (res *dom
 	return
 }
 
-func (r *storeRepository) FindAll(ctx context.Context, sort string, limit, page int) (res []*domain.Store, total int64, err error) {
-	offset := (page - 1) * limit
+// categoryDescendantsCondition matches the stores of a category and of all
+// the categories below it
+const categoryDescendantsCondition = `category_id IN (
+	WITH RECURSIVE descendants AS (
+		SELECT id FROM categories WHERE id = $%d
+		UNION
+		SELECT c.id FROM categories c JOIN descendants d ON c.parent_id = d.id
+	)
+	SELECT id FROM descendants
+)`
+
+// openAtCondition matches the stores with an interval of their opening
+// hours holding a time, in their timezone. The intervals opening the day
+// before are checked too, for the ones running past midnight, and the ones
+// opening on a closure are skipped.
+const openAtCondition = `EXISTS (
+	SELECT 1 FROM store_hours sh
+	CROSS JOIN LATERAL (SELECT $%d::timestamptz AT TIME ZONE sh.timezone AS local_at) l
+	JOIN store_opening_hours oh ON oh.store_id = sh.store_id
+	CROSS JOIN LATERAL (SELECT l.local_at::date - k AS day FROM generate_series(0, 1) AS k) d
+	WHERE sh.store_id = stores.id
+	AND oh.weekday = EXTRACT(DOW FROM d.day)
+	AND l.local_at >= d.day + oh.opens
+	AND l.local_at < d.day + oh.opens + CASE WHEN oh.closes > oh.opens THEN oh.closes - oh.opens ELSE oh.closes - oh.opens + interval '1 day' END
+	AND NOT EXISTS (SELECT 1 FROM store_closures sc WHERE sc.store_id = sh.store_id AND d.day BETWEEN sc.from_date AND sc.to_date)
+)`
 
-	query := fmt.Sprintf(`SELECT * FROM stores ORDER BY %s OFFSET %d LIMIT %d`, sort, offset, limit)
-	countQuery := `SELECT count(1) FROM stores`
+// storeFilterClause builds the WHERE clause of filter, numbering its
+// placeholders from $1. The deleted stores are left out unless the filter
+// includes them.
+func storeFilterClause(filter *domain.StoreFilter) (clause string, args []interface{}) {
+	var conditions []string
+	if filter == nil || !filter.IncludeDeleted {
+		conditions = append(conditions, "deleted_at IS NULL")
+	}
+	if filter == nil {
+		return " WHERE " + strings.Join(conditions, " AND "), nil
+	}
+
+	add := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.Status != "" {
+		add("status = $%d", filter.Status)
+	}
+	if filter.CategoryID != "" && filter.IncludeDescendants {
+		add(categoryDescendantsCondition, filter.CategoryID)
+	} else if filter.CategoryID != "" {
+		add("category_id = $%d", filter.CategoryID)
+	}
+	if filter.UserID != "" {
+		add("user_id = $%d", filter.UserID)
+	}
+	if len(filter.AnyTags) > 0 {
+		add("tags && $%d", pq.StringArray(filter.AnyTags))
+	}
+	if len(filter.AllTags) > 0 {
+		add("tags @> $%d", pq.StringArray(filter.AllTags))
+	}
+	if !filter.CreatedFrom.IsZero() {
+		add("created_at >= $%d", filter.CreatedFrom)
+	}
+	if !filter.CreatedTo.IsZero() {
+		add("created_at < $%d", filter.CreatedTo)
+	}
+	if filter.NamePrefix != "" {
+		add(`name ILIKE $%d ESCAPE '\'`, likePrefix(filter.NamePrefix))
+	}
+	if !filter.OpenAt.IsZero() {
+		add(openAtCondition, filter.OpenAt)
+	}
+
+	if len(conditions) > 0 {
+		clause = " WHERE " + strings.Join(conditions, " AND ")
+	}
+	return
+}
+
+// whereAnd adds condition to the WHERE clause where, which may be empty
+func whereAnd(where, condition string) string {
+	if where == "" {
+		return " WHERE " + condition
+	}
+	return where + " AND " + condition
+}
+
+// likePrefix escapes the LIKE wildcards of prefix and matches anything after it
+func likePrefix(prefix string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix) + "%"
+}
+
+// orderBy builds the ORDER BY list of sort, ending with the id to keep the
+// order stable. Backward reverses every direction.
+func orderBy(sort domain.SortSpec, backward bool) string {
+	fields := append(append(domain.SortSpec{}, sort...), domain.SortField{Column: "id"})
+	items := make([]string, 0, len(fields))
+	for _, field := range fields {
+		direction := " ASC"
+		if field.Desc != backward {
+			direction = " DESC"
+		}
+		items = append(items, pq.QuoteIdentifier(field.Column)+direction)
+	}
+	return strings.Join(items, ",")
+}
 
-	res, err = r.getAll(ctx, query)
+// keysetClause builds the condition selecting the rows after cursor in the
+// order of sort, numbering its placeholders after the first n
+func keysetClause(sort domain.SortSpec, cursor *domain.Cursor, n int) (clause string, args []interface{}) {
+	fields := append(append(domain.SortSpec{}, sort...), domain.SortField{Column: "id"})
+	values := append(append([]interface{}{}, cursor.Values...), cursor.ID)
+
+	terms := make([]string, len(fields))
+	for i, field := range fields {
+		args = append(args, values[i])
+
+		operator := ">"
+		if field.Desc != cursor.Backward {
+			operator = "<"
+		}
+
+		conditions := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, fmt.Sprintf("%s = $%d", pq.QuoteIdentifier(fields[j].Column), n+j+1))
+		}
+		conditions = append(conditions, fmt.Sprintf("%s %s $%d", pq.QuoteIdentifier(field.Column), operator, n+i+1))
+		terms[i] = "(" + strings.Join(conditions, " AND ") + ")"
+	}
+
+	clause = "(" + strings.Join(terms, " OR ") + ")"
+	return
+}
+
+// FindAll returns a page of the stores matching filter, in the order of sort.
+// With a cursor the page starts right after it and page is ignored.
+func (r *storeRepository) FindAll(ctx context.Context, filter *domain.StoreFilter, sort domain.SortSpec, cursor *domain.Cursor, limit, page int) (res []*domain.Store, total int64, err error) {
+	where, args := storeFilterClause(filter)
+	countQuery := `SELECT count(1) FROM stores` + where
+	countArgs := args
+
+	var query string
+	if cursor != nil {
+		keyset, keysetArgs := keysetClause(sort, cursor, len(args))
+		where = whereAnd(where, keyset)
+		args = append(args, keysetArgs...)
+		query = fmt.Sprintf(`SELECT %s FROM stores%s ORDER BY %s LIMIT %d`, storeColumns, where, orderBy(sort, cursor.Backward), limit)
+	} else {
+		offset := (page - 1) * limit
+		query = fmt.Sprintf(`SELECT %s FROM stores%s ORDER BY %s OFFSET %d LIMIT %d`, storeColumns, where, orderBy(sort, false), offset, limit)
+	}
+
+	res, err = r.getAll(ctx, query, args...)
 	if err != nil {
 		return
 	}
 
-	err = r.db.QueryRowContext(ctx, countQuery).Scan(&total)
+	if cursor != nil && cursor.Backward {
+		for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+			res[i], res[j] = res[j], res[i]
+		}
+	}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, countQuery, countArgs...).Scan(&total)
 	if err != nil {
 		res = make([]*domain.Store, 0)
 		return
@@ -126,9 +320,79 @@ func (r *storeRepository) FindAll(ctx context.Context, sort string, limit, page
 	return
 }
 
+// FindNearby returns the stores matching filter within radiusKm of position,
+// nearest first. The earth_box condition lets postgres use the GiST index on
+// ll_to_earth(lat, lng) before the exact distance is checked.
+func (r *storeRepository) FindNearby(ctx context.Context, position domain.Position, radiusKm float64, filter *domain.StoreFilter, limit int) (res domain.NearbyStores, err error) {
+	where, args := storeFilterClause(filter)
+	n := len(args)
+	geo := fmt.Sprintf(`earth_box(ll_to_earth($%d, $%d), $%d) @> ll_to_earth(lat::float8, lng::float8) AND earth_distance(ll_to_earth($%d, $%d), ll_to_earth(lat::float8, lng::float8)) <= $%d`, n+1, n+2, n+3, n+1, n+2, n+3)
+	where = whereAnd(where, geo)
+	args = append(args, position.Lat, position.Lng, radiusKm*1000)
+
+	query := fmt.Sprintf(`SELECT %s, earth_distance(ll_to_earth($%d, $%d), ll_to_earth(lat::float8, lng::float8)) / 1000 AS distance FROM stores%s ORDER BY distance, id LIMIT %d`, storeColumns, n+1, n+2, where, limit)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	res = make(domain.NearbyStores, 0)
+	for rows.Next() {
+		var distance float64
+		s, err := scanStore(rows, &distance)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &domain.NearbyStore{Store: s, Distance: distance})
+	}
+	return
+}
+
+// snippetOptions configures the ts_headline excerpts of the search results,
+// turned into HTML by domain.NewSnippet
+const snippetOptions = `StartSel=` + domain.SnippetStartSel + `, StopSel=` + domain.SnippetStopSel + `, MaxFragments=2, MaxWords=20, MinWords=5`
+
+// Search returns a page of the stores matching filter whose name, tags or
+// description match query, ranked by the weighted search_vector
+func (r *storeRepository) Search(ctx context.Context, query string, filter *domain.StoreFilter, limit, page int) (res domain.StoreSearchResults, total int64, err error) {
+	where, args := storeFilterClause(filter)
+	tsquery := fmt.Sprintf(`websearch_to_tsquery('simple', $%d)`, len(args)+1)
+	where = whereAnd(where, "search_vector @@ "+tsquery)
+	args = append(args, query)
+
+	offset := (page - 1) * limit
+	searchQuery := fmt.Sprintf(`SELECT %s, ts_rank(search_vector, %s) AS rank, ts_headline('simple', name || ' ' || coalesce(description, ''), %s, '%s') AS snippet FROM stores%s ORDER BY rank DESC, id OFFSET %d LIMIT %d`, storeColumns, tsquery, tsquery, snippetOptions, where, offset, limit)
+	rows, err := conn(ctx, r.db).QueryContext(ctx, searchQuery, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	res = make(domain.StoreSearchResults, 0)
+	for rows.Next() {
+		result := &domain.StoreSearchResult{}
+		result.Store, err = scanStore(rows, &result.Rank, &result.Snippet)
+		if err != nil {
+			return nil, 0, err
+		}
+		result.Snippet = domain.NewSnippet(result.Snippet)
+		res = append(res, result)
+	}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, `SELECT count(1) FROM stores`+where, args...).Scan(&total)
+	if err != nil {
+		res = make(domain.StoreSearchResults, 0)
+		return
+	}
+	return
+}
+
+// Update saves s only if its version was not changed since it was read, and
+// bumps the version on success
 func (r *storeRepository) Update(ctx context.Context, s *domain.Store) (err error) {
-	query := `UPDATE stores SET created_at=$1,updated_at=$2,name=$3,description=$4,status=$5,user_id=$6,account_id=$7,category_id=$8,tags=$9,lat=$10,lng=$11,image=$12 WHERE id = $13`
-	res, err := r.db.ExecContext(ctx, query, s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Tags, s.Position.Lat, s.Position.Lng, s.Image, s.ID)
+	query := `UPDATE stores SET created_at=$1,updated_at=$2,name=$3,description=$4,status=$5,user_id=$6,account_id=$7,category_id=$8,tags=$9,lat=$10,lng=$11,image=$12,images=$13,deleted_at=$14,version=version+1 WHERE id = $15 AND version = $16`
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Tags, s.Position.Lat, s.Position.Lng, s.Image, s.Images, s.DeletedAt, s.ID, s.Version)
 	if err != nil {
 		return
 	}
@@ -138,16 +402,23 @@ func (r *storeRepository) Update(ctx context.Context, s *domain.Store) (err erro
 		return
 	}
 
+	if affect == 0 {
+		err = domain.ErrConflict
+		return
+	}
+
 	if affect != 1 {
 		err = fmt.Errorf("Weird  Behavior. Total Affected: %d", affect)
 		return
 	}
+
+	s.Version++
 	return
 }
 
 func (r *storeRepository) Delete(ctx context.Context, id string) (err error) {
 	query := `DELETE FROM stores WHERE id = $1`
-	res, err := r.db.ExecContext(ctx, query, id)
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, id)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,112 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/lib/pq"
+)
+
+type storeHoursRepository struct {
+	db *sql.DB
+}
+
+func NewStoreHoursRepository(db *sql.DB) *storeHoursRepository {
+	return &storeHoursRepository{
+		db: db,
+	}
+}
+
+// Save replaces the opening hours of a store. It runs several statements, so
+// it is meant to be called within a transaction.
+func (r *storeHoursRepository) Save(ctx context.Context, h *domain.StoreHours) (err error) {
+	db := conn(ctx, r.db)
+
+	query := `INSERT INTO store_hours (store_id,timezone,updated_at) VALUES ($1,$2,$3) ON CONFLICT (store_id) DO UPDATE SET timezone = EXCLUDED.timezone, updated_at = EXCLUDED.updated_at`
+	_, err = db.ExecContext(ctx, query, h.StoreID, h.Timezone, h.UpdatedAt)
+	if err != nil {
+		return
+	}
+
+	_, err = db.ExecContext(ctx, `DELETE FROM store_opening_hours WHERE store_id = $1`, h.StoreID)
+	if err != nil {
+		return
+	}
+	for _, o := range h.Weekly {
+		_, err = db.ExecContext(ctx, `INSERT INTO store_opening_hours (store_id,weekday,opens,closes) VALUES ($1,$2,$3,$4)`, h.StoreID, o.Weekday, o.Opens, o.Closes)
+		if err != nil {
+			return
+		}
+	}
+
+	_, err = db.ExecContext(ctx, `DELETE FROM store_closures WHERE store_id = $1`, h.StoreID)
+	if err != nil {
+		return
+	}
+	for _, c := range h.Closures {
+		_, err = db.ExecContext(ctx, `INSERT INTO store_closures (store_id,from_date,to_date,reason) VALUES ($1,$2,$3,$4)`, h.StoreID, c.From, c.To, c.Reason)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// FindByStoreIDs returns the opening hours of the given stores, skipping the
+// ones without any.
+func (r *storeHoursRepository) FindByStoreIDs(ctx context.Context, storeIDs []string) (res []*domain.StoreHours, err error) {
+	res = make([]*domain.StoreHours, 0)
+	if len(storeIDs) == 0 {
+		return
+	}
+
+	db := conn(ctx, r.db)
+	ids := pq.StringArray(storeIDs)
+	hours := make(map[string]*domain.StoreHours)
+
+	rows, err := db.QueryContext(ctx, `SELECT store_id,timezone,updated_at FROM store_hours WHERE store_id = ANY($1) ORDER BY store_id`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		h := &domain.StoreHours{Weekly: make([]*domain.OpeningHours, 0), Closures: make([]*domain.StoreClosure, 0)}
+		if err = rows.Scan(&h.StoreID, &h.Timezone, &h.UpdatedAt); err != nil {
+			return nil, err
+		}
+		hours[h.StoreID] = h
+		res = append(res, h)
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT store_id,weekday,to_char(opens, 'HH24:MI'),to_char(closes, 'HH24:MI') FROM store_opening_hours WHERE store_id = ANY($1) ORDER BY store_id, weekday, opens`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		o := new(domain.OpeningHours)
+		if err = rows.Scan(&o.StoreID, &o.Weekday, &o.Opens, &o.Closes); err != nil {
+			return nil, err
+		}
+		if h, ok := hours[o.StoreID]; ok {
+			h.Weekly = append(h.Weekly, o)
+		}
+	}
+
+	rows, err = db.QueryContext(ctx, `SELECT store_id,to_char(from_date, 'YYYY-MM-DD'),to_char(to_date, 'YYYY-MM-DD'),COALESCE(reason,'') FROM store_closures WHERE store_id = ANY($1) ORDER BY store_id, from_date`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		c := new(domain.StoreClosure)
+		if err = rows.Scan(&c.StoreID, &c.From, &c.To, &c.Reason); err != nil {
+			return nil, err
+		}
+		if h, ok := hours[c.StoreID]; ok {
+			h.Closures = append(h.Closures, c)
+		}
+	}
+	return
+}
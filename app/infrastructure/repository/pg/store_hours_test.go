@@ -0,0 +1,166 @@
+package pg_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/pg"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StoreHoursRepo_Save(t *testing.T) {
+	h := domain.NewStoreHours(sample.NewStoreHoursRequest())
+	h.Closures[0].To = h.Closures[0].From
+	upsertQuery := `INSERT INTO store_hours (store_id,timezone,updated_at) VALUES ($1,$2,$3) ON CONFLICT (store_id) DO UPDATE SET timezone = EXCLUDED.timezone, updated_at = EXCLUDED.updated_at`
+	deleteWeeklyQuery := `DELETE FROM store_opening_hours WHERE store_id = $1`
+	weeklyQuery := `INSERT INTO store_opening_hours (store_id,weekday,opens,closes) VALUES ($1,$2,$3,$4)`
+	deleteClosuresQuery := `DELETE FROM store_closures WHERE store_id = $1`
+	closuresQuery := `INSERT INTO store_closures (store_id,from_date,to_date,reason) VALUES ($1,$2,$3,$4)`
+
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_upsert_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(upsertQuery)).WithArgs(h.StoreID, h.Timezone, h.UpdatedAt).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_insert_interval_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(upsertQuery)).WithArgs(h.StoreID, h.Timezone, h.UpdatedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(deleteWeeklyQuery)).WithArgs(h.StoreID).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(regexp.QuoteMeta(weeklyQuery)).WithArgs(h.StoreID, 1, "09:00", "13:00").WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(upsertQuery)).WithArgs(h.StoreID, h.Timezone, h.UpdatedAt).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(deleteWeeklyQuery)).WithArgs(h.StoreID).WillReturnResult(sqlmock.NewResult(0, 2))
+				for _, o := range h.Weekly {
+					mock.ExpectExec(regexp.QuoteMeta(weeklyQuery)).WithArgs(h.StoreID, o.Weekday, o.Opens, o.Closes).WillReturnResult(sqlmock.NewResult(0, 1))
+				}
+				mock.ExpectExec(regexp.QuoteMeta(deleteClosuresQuery)).WithArgs(h.StoreID).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(closuresQuery)).WithArgs(h.StoreID, "2021-12-25", "2021-12-25", "Christmas").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewStoreHoursRepository(db)
+			tc.prepare(mock)
+			err = repo.Save(context.TODO(), h)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_StoreHoursRepo_FindByStoreIDs(t *testing.T) {
+	h := sample.NewStoreHours()
+	ids := []string{h.StoreID, "a6b3f0b2-58a6-4f3e-8f2a-4cf1f5f4d1c7"}
+	hoursQuery := `SELECT store_id,timezone,updated_at FROM store_hours WHERE store_id = ANY($1) ORDER BY store_id`
+	weeklyQuery := `SELECT store_id,weekday,to_char(opens, 'HH24:MI'),to_char(closes, 'HH24:MI') FROM store_opening_hours WHERE store_id = ANY($1) ORDER BY store_id, weekday, opens`
+	closuresQuery := `SELECT store_id,to_char(from_date, 'YYYY-MM-DD'),to_char(to_date, 'YYYY-MM-DD'),COALESCE(reason,'') FROM store_closures WHERE store_id = ANY($1) ORDER BY store_id, from_date`
+
+	testCases := []struct {
+		name          string
+		arg           []string
+		expectedErr   bool
+		prepare       func(mock sqlmock.Sqlmock)
+		checkResponse func(t *testing.T, res []*domain.StoreHours)
+	}{
+		{
+			name:    "success_without_ids",
+			prepare: func(mock sqlmock.Sqlmock) {},
+			checkResponse: func(t *testing.T, res []*domain.StoreHours) {
+				assert.Empty(t, res)
+			},
+		},
+		{
+			name:        "failure_find_hours_returns_error",
+			arg:         ids,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(hoursQuery)).WithArgs(pq.StringArray(ids)).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_find_closures_returns_error",
+			arg:         ids,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(hoursQuery)).WithArgs(pq.StringArray(ids)).
+					WillReturnRows(sqlmock.NewRows([]string{"store_id", "timezone", "updated_at"}).AddRow(h.StoreID, h.Timezone, h.UpdatedAt))
+				mock.ExpectQuery(regexp.QuoteMeta(weeklyQuery)).WithArgs(pq.StringArray(ids)).
+					WillReturnRows(sqlmock.NewRows([]string{"store_id", "weekday", "opens", "closes"}))
+				mock.ExpectQuery(regexp.QuoteMeta(closuresQuery)).WithArgs(pq.StringArray(ids)).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name: "success",
+			arg:  ids,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(hoursQuery)).WithArgs(pq.StringArray(ids)).
+					WillReturnRows(sqlmock.NewRows([]string{"store_id", "timezone", "updated_at"}).AddRow(h.StoreID, h.Timezone, h.UpdatedAt))
+				mock.ExpectQuery(regexp.QuoteMeta(weeklyQuery)).WithArgs(pq.StringArray(ids)).
+					WillReturnRows(sqlmock.NewRows([]string{"store_id", "weekday", "opens", "closes"}).
+						AddRow(h.StoreID, 1, "09:00", "13:00").
+						AddRow(h.StoreID, 5, "20:00", "02:00"))
+				mock.ExpectQuery(regexp.QuoteMeta(closuresQuery)).WithArgs(pq.StringArray(ids)).
+					WillReturnRows(sqlmock.NewRows([]string{"store_id", "from_date", "to_date", "reason"}).
+						AddRow(h.StoreID, "2021-12-25", "2021-12-26", "Christmas"))
+			},
+			checkResponse: func(t *testing.T, res []*domain.StoreHours) {
+				assert.Len(t, res, 1)
+				assert.Equal(t, h.StoreID, res[0].StoreID)
+				assert.Equal(t, h.Timezone, res[0].Timezone)
+				assert.Len(t, res[0].Weekly, 2)
+				assert.Equal(t, "02:00", res[0].Weekly[1].Closes)
+				assert.Len(t, res[0].Closures, 1)
+				assert.Equal(t, "2021-12-26", res[0].Closures[0].To)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewStoreHoursRepository(db)
+			tc.prepare(mock)
+			res, err := repo.FindByStoreIDs(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				tc.checkResponse(t, res)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+)
+
+type storeStatusHistoryRepository struct {
+	db *sql.DB
+}
+
+func NewStoreStatusHistoryRepository(db *sql.DB) *storeStatusHistoryRepository {
+	return &storeStatusHistoryRepository{
+		db: db,
+	}
+}
+
+func (r *storeStatusHistoryRepository) Store(ctx context.Context, c *domain.StoreStatusChange) (err error) {
+	query := `INSERT INTO store_status_history (id,store_id,from_status,to_status,actor_id,reason,expires_at,created_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, c.ID, c.StoreID, c.FromStatus, c.ToStatus, c.ActorID, c.Reason, c.ExpiresAt, c.CreatedAt)
+	if err != nil {
+		return
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if affect != 1 {
+		err = fmt.Errorf("Weird Behavior. Total Affected: %d", affect)
+		return
+	}
+	return
+}
+
+func (r *storeStatusHistoryRepository) FindByStoreID(ctx context.Context, storeID string, limit, page int) (res domain.StoreStatusHistory, total int64, err error) {
+	query := `SELECT id,store_id,COALESCE(from_status,''),to_status,actor_id,COALESCE(reason,''),expires_at,created_at,release_attempts,release_retry_at FROM store_status_history WHERE store_id = $1 ORDER BY created_at DESC, id OFFSET $2 LIMIT $3`
+	countQuery := `SELECT count(1) FROM store_status_history WHERE store_id = $1`
+
+	res, err = r.fetch(ctx, query, storeID, (page-1)*limit, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	err = conn(ctx, r.db).QueryRowContext(ctx, countQuery, storeID).Scan(&total)
+	if err != nil {
+		res = make(domain.StoreStatusHistory, 0)
+		return
+	}
+	return
+}
+
+// FindExpired returns the latest change of the stores, but the deleted ones,
+// still in a status that expired at now, oldest expiry first. The expired
+// changes are found through the expires_at index, then checked to be the
+// latest of their store; those whose release failed wait for their retry.
+func (r *storeStatusHistoryRepository) FindExpired(ctx context.Context, now time.Time, limit int) (res domain.StoreStatusHistory, err error) {
+	query := `SELECT h.id,h.store_id,COALESCE(h.from_status,''),h.to_status,h.actor_id,COALESCE(h.reason,''),h.expires_at,h.created_at,h.release_attempts,h.release_retry_at FROM store_status_history h JOIN stores s ON s.id = h.store_id AND s.status = h.to_status AND s.deleted_at IS NULL WHERE h.expires_at <= $1 AND (h.release_retry_at IS NULL OR h.release_retry_at <= $1) AND NOT EXISTS (SELECT 1 FROM store_status_history l WHERE l.store_id = h.store_id AND (l.created_at > h.created_at OR (l.created_at = h.created_at AND l.id < h.id))) ORDER BY h.expires_at LIMIT $2`
+	return r.fetch(ctx, query, now, limit)
+}
+
+// UpdateRelease saves the release attempts of an expired change
+func (r *storeStatusHistoryRepository) UpdateRelease(ctx context.Context, c *domain.StoreStatusChange) (err error) {
+	query := `UPDATE store_status_history SET release_attempts=$1,release_retry_at=$2 WHERE id = $3`
+	res, err := conn(ctx, r.db).ExecContext(ctx, query, c.ReleaseAttempts, c.ReleaseRetryAt, c.ID)
+	if err != nil {
+		return
+	}
+
+	affect, err := res.RowsAffected()
+	if err != nil {
+		return
+	}
+
+	if affect != 1 {
+		err = fmt.Errorf("Weird Behavior. Total Affected: %d", affect)
+		return
+	}
+	return
+}
+
+func (r *storeStatusHistoryRepository) fetch(ctx context.Context, query string, args ...interface{}) (res domain.StoreStatusHistory, err error) {
+	rows, err := conn(ctx, r.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return
+	}
+	defer rows.Close()
+
+	res = make(domain.StoreStatusHistory, 0)
+	for rows.Next() {
+		c := new(domain.StoreStatusChange)
+		err = rows.Scan(
+			&c.ID,
+			&c.StoreID,
+			&c.FromStatus,
+			&c.ToStatus,
+			&c.ActorID,
+			&c.Reason,
+			&c.ExpiresAt,
+			&c.CreatedAt,
+			&c.ReleaseAttempts,
+			&c.ReleaseRetryAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, c)
+	}
+	return
+}
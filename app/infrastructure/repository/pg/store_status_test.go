@@ -0,0 +1,237 @@
+package pg_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/pg"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_StoreStatusHistoryRepo_Store(t *testing.T) {
+	c := sample.NewStoreStatusChange()
+	query := `INSERT INTO store_status_history (id,store_id,from_status,to_status,actor_id,reason,expires_at,created_at) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`
+	testCases := []struct {
+		name        string
+		arg         *domain.StoreStatusChange
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			arg:         c,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ID, c.StoreID, c.FromStatus, c.ToStatus, c.ActorID, c.Reason, c.ExpiresAt, c.CreatedAt).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_returns_invalid_number_of_affected_row",
+			arg:         c,
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ID, c.StoreID, c.FromStatus, c.ToStatus, c.ActorID, c.Reason, c.ExpiresAt, c.CreatedAt).WillReturnResult(sqlmock.NewResult(1, 2))
+			},
+		},
+		{
+			name: "success",
+			arg:  c,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ID, c.StoreID, c.FromStatus, c.ToStatus, c.ActorID, c.Reason, c.ExpiresAt, c.CreatedAt).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewStoreStatusHistoryRepository(db)
+			tc.prepare(mock)
+			err = repo.Store(context.TODO(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func Test_StoreStatusHistoryRepo_FindByStoreID(t *testing.T) {
+	c := sample.NewStoreStatusChange()
+	query := `SELECT id,store_id,COALESCE(from_status,''),to_status,actor_id,COALESCE(reason,''),expires_at,created_at,release_attempts,release_retry_at FROM store_status_history WHERE store_id = $1 ORDER BY created_at DESC, id OFFSET $2 LIMIT $3`
+	countQuery := `SELECT count(1) FROM store_status_history WHERE store_id = $1`
+	columns := []string{"id", "store_id", "from_status", "to_status", "actor_id", "reason", "expires_at", "created_at", "release_attempts", "release_retry_at"}
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(c.StoreID, 10, 10).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_count_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				row := sqlmock.NewRows(columns).AddRow(c.ID, c.StoreID, c.FromStatus, c.ToStatus, c.ActorID, c.Reason, *c.ExpiresAt, c.CreatedAt, c.ReleaseAttempts, c.ReleaseRetryAt)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(c.StoreID, 10, 10).WillReturnRows(row)
+				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(c.StoreID).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				row := sqlmock.NewRows(columns).AddRow(c.ID, c.StoreID, c.FromStatus, c.ToStatus, c.ActorID, c.Reason, *c.ExpiresAt, c.CreatedAt, c.ReleaseAttempts, c.ReleaseRetryAt)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(c.StoreID, 10, 10).WillReturnRows(row)
+				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(c.StoreID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(11))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewStoreStatusHistoryRepository(db)
+			tc.prepare(mock)
+			res, total, err := repo.FindByStoreID(context.TODO(), c.StoreID, 10, 2)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Len(t, res, 0)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.Equal(t, int64(11), total)
+				assert.True(t, c.ExpiresAt.Equal(*res[0].ExpiresAt))
+			}
+		})
+	}
+}
+
+func Test_StoreStatusHistoryRepo_FindExpired(t *testing.T) {
+	c := sample.NewStoreStatusChange()
+	now := time.Now()
+	query := `SELECT h.id,h.store_id,COALESCE(h.from_status,''),h.to_status,h.actor_id,COALESCE(h.reason,''),h.expires_at,h.created_at,h.release_attempts,h.release_retry_at FROM store_status_history h JOIN stores s ON s.id = h.store_id AND s.status = h.to_status AND s.deleted_at IS NULL WHERE h.expires_at <= $1 AND (h.release_retry_at IS NULL OR h.release_retry_at <= $1) AND NOT EXISTS (SELECT 1 FROM store_status_history l WHERE l.store_id = h.store_id AND (l.created_at > h.created_at OR (l.created_at = h.created_at AND l.id < h.id))) ORDER BY h.expires_at LIMIT $2`
+	columns := []string{"id", "store_id", "from_status", "to_status", "actor_id", "reason", "expires_at", "created_at", "release_attempts", "release_retry_at"}
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(now, 100).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				row := sqlmock.NewRows(columns).AddRow(c.ID, c.StoreID, c.FromStatus, c.ToStatus, c.ActorID, c.Reason, *c.ExpiresAt, c.CreatedAt, c.ReleaseAttempts, c.ReleaseRetryAt)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(now, 100).WillReturnRows(row)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewStoreStatusHistoryRepository(db)
+			tc.prepare(mock)
+			res, err := repo.FindExpired(context.TODO(), now, 100)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Len(t, res, 0)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.Equal(t, c.StoreID, res[0].StoreID)
+			}
+		})
+	}
+}
+
+func Test_StoreStatusHistoryRepo_FindExpired_SkipsDeletedStores(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+		if !regexp.MustCompile(`JOIN stores s ON [^W]*AND s\.deleted_at IS NULL WHERE`).MatchString(actualSQL) {
+			return errors.New("the expired changes of deleted stores are not left out")
+		}
+		return nil
+	})))
+	assert.NoError(t, err)
+	repo := pg.NewStoreStatusHistoryRepository(db)
+	mock.ExpectQuery("").WillReturnRows(sqlmock.NewRows([]string{"id", "store_id", "from_status", "to_status", "actor_id", "reason", "expires_at", "created_at", "release_attempts", "release_retry_at"}))
+
+	res, err := repo.FindExpired(context.TODO(), time.Now(), 100)
+	assert.NoError(t, err)
+	assert.Len(t, res, 0)
+}
+
+func Test_StoreStatusHistoryRepo_UpdateRelease(t *testing.T) {
+	c := sample.NewStoreStatusChange()
+	c.FailRelease(time.Minute)
+	query := `UPDATE store_status_history SET release_attempts=$1,release_retry_at=$2 WHERE id = $3`
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ReleaseAttempts, c.ReleaseRetryAt, c.ID).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_returns_invalid_number_of_affected_row",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ReleaseAttempts, c.ReleaseRetryAt, c.ID).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+		},
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(c.ReleaseAttempts, c.ReleaseRetryAt, c.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewStoreStatusHistoryRepository(db)
+			tc.prepare(mock)
+			err = repo.UpdateRelease(context.TODO(), c)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -2,20 +2,23 @@ package pg_test
 
 import (
 	"context"
+	"database/sql/driver"
 	"errors"
 	"fmt"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/EdlanioJ/kbu-store/app/domain"
 	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/pg"
 	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/lib/pq"
 	uuid "github.com/satori/go.uuid"
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_StoreRepo_Store(t *testing.T) {
+func Test_StoreRepo_Create(t *testing.T) {
 	s := sample.NewStore()
 	testCases := []struct {
 		name        string
@@ -28,8 +31,8 @@ func Test_StoreRepo_Store(t *testing.T) {
 			arg:         s,
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `INSERT INTO stores (id,created_at,updated_at,name,description,status,user_id,account_id,category_id,image,tags,lat,lng) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Image, s.Tags, s.Position.Lat, s.Position.Lng).WillReturnError(errors.New("unexpected error"))
+				query := `INSERT INTO stores (id,created_at,updated_at,name,description,status,user_id,account_id,category_id,image,images,tags,lat,lng,version) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)`
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version).WillReturnError(errors.New("unexpected error"))
 			},
 		},
 		{
@@ -37,8 +40,8 @@ func Test_StoreRepo_Store(t *testing.T) {
 			arg:         s,
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `INSERT INTO stores (id,created_at,updated_at,name,description,status,user_id,account_id,category_id,image,tags,lat,lng) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Image, s.Tags, s.Position.Lat, s.Position.Lng).WillReturnResult(sqlmock.NewErrorResult(errors.New("unexpected error")))
+				query := `INSERT INTO stores (id,created_at,updated_at,name,description,status,user_id,account_id,category_id,image,images,tags,lat,lng,version) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)`
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version).WillReturnResult(sqlmock.NewErrorResult(errors.New("unexpected error")))
 			},
 		},
 		{
@@ -46,16 +49,16 @@ func Test_StoreRepo_Store(t *testing.T) {
 			arg:         s,
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `INSERT INTO stores (id,created_at,updated_at,name,description,status,user_id,account_id,category_id,image,tags,lat,lng) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Image, s.Tags, s.Position.Lat, s.Position.Lng).WillReturnResult(sqlmock.NewResult(1, 2))
+				query := `INSERT INTO stores (id,created_at,updated_at,name,description,status,user_id,account_id,category_id,image,images,tags,lat,lng,version) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)`
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version).WillReturnResult(sqlmock.NewResult(1, 2))
 			},
 		},
 		{
 			name: "success",
 			arg:  s,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `INSERT INTO stores (id,created_at,updated_at,name,description,status,user_id,account_id,category_id,image,tags,lat,lng) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13)`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Image, s.Tags, s.Position.Lat, s.Position.Lng).WillReturnResult(sqlmock.NewResult(1, 1))
+				query := `INSERT INTO stores (id,created_at,updated_at,name,description,status,user_id,account_id,category_id,image,images,tags,lat,lng,version) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15)`
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version).WillReturnResult(sqlmock.NewResult(1, 1))
 			},
 		},
 	}
@@ -68,7 +71,7 @@ func Test_StoreRepo_Store(t *testing.T) {
 			assert.NoError(t, err)
 			repo := pg.NewStoreRepository(db)
 			tc.prepare(mock)
-			err = repo.Store(context.TODO(), tc.arg)
+			err = repo.Create(context.TODO(), tc.arg)
 			if tc.expectedErr {
 				assert.Error(t, err)
 			} else {
@@ -92,7 +95,7 @@ func Test_StoreRepo_FindByID(t *testing.T) {
 			arg:         id,
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `SELECT * FROM stores WHERE id = $1 ORDER BY id`
+				query := `SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE id = $1 AND deleted_at IS NULL ORDER BY id`
 				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(id).WillReturnError(errors.New("unexpected error"))
 			},
 		},
@@ -105,7 +108,7 @@ func Test_StoreRepo_FindByID(t *testing.T) {
 					NewRows([]string{"uuid", "s_created_at", "s_updated_at", "s_name", "s_status", "s_lng"}).
 					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Position.Lng)
 
-				query := `SELECT * FROM stores WHERE id = $1 ORDER BY id`
+				query := `SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE id = $1 AND deleted_at IS NULL ORDER BY id`
 				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(id).WillReturnRows(row)
 			},
 		},
@@ -115,9 +118,9 @@ func Test_StoreRepo_FindByID(t *testing.T) {
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
 				row := sqlmock.
-					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "tags", "lat", "lng"})
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"})
 
-				query := `SELECT * FROM stores WHERE id = $1 ORDER BY id`
+				query := `SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE id = $1 AND deleted_at IS NULL ORDER BY id`
 				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(id).WillReturnRows(row)
 			},
 		},
@@ -126,10 +129,10 @@ func Test_StoreRepo_FindByID(t *testing.T) {
 			arg:  id,
 			prepare: func(mock sqlmock.Sqlmock) {
 				row := sqlmock.
-					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "tags", "lat", "lng"}).
-					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Tags, s.Position.Lat, s.Position.Lng)
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt)
 
-				query := `SELECT * FROM stores WHERE id = $1 ORDER BY id`
+				query := `SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE id = $1 AND deleted_at IS NULL ORDER BY id`
 				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(id).WillReturnRows(row)
 			},
 		},
@@ -170,7 +173,7 @@ func Test_StoreRepo_FindByName(t *testing.T) {
 			arg:         name,
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `SELECT * FROM stores WHERE name = $1 ORDER BY id`
+				query := `SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE name = $1 AND deleted_at IS NULL ORDER BY id`
 				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(name).WillReturnError(errors.New("unexpected error"))
 			},
 		},
@@ -180,9 +183,9 @@ func Test_StoreRepo_FindByName(t *testing.T) {
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
 				row := sqlmock.
-					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "tags", "lat", "lng"})
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"})
 
-				query := `SELECT * FROM stores WHERE name = $1 ORDER BY id`
+				query := `SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE name = $1 AND deleted_at IS NULL ORDER BY id`
 				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(name).WillReturnRows(row)
 			},
 		},
@@ -191,10 +194,10 @@ func Test_StoreRepo_FindByName(t *testing.T) {
 			arg:  name,
 			prepare: func(mock sqlmock.Sqlmock) {
 				row := sqlmock.
-					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "tags", "lat", "lng"}).
-					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Tags, s.Position.Lat, s.Position.Lng)
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt)
 
-				query := `SELECT * FROM stores WHERE name = $1 ORDER BY id`
+				query := `SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE name = $1 AND deleted_at IS NULL ORDER BY id`
 				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(name).WillReturnRows(row)
 			},
 		},
@@ -220,18 +223,104 @@ func Test_StoreRepo_FindByName(t *testing.T) {
 		})
 	}
 }
+func Test_StoreRepo_FindDeletedByID(t *testing.T) {
+	s := sample.NewStore()
+	deletedAt := time.Now().Add(-time.Hour)
+	s.DeletedAt = &deletedAt
+	query := `SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE id = $1 AND deleted_at IS NOT NULL ORDER BY id`
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_not_deleted",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(s.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}))
+			},
+		},
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt)
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(s.ID).WillReturnRows(row)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewStoreRepository(db)
+			tc.prepare(mock)
+			res, err := repo.FindDeletedByID(context.TODO(), s.ID)
+			if tc.expectedErr {
+				assert.ErrorIs(t, err, domain.ErrNotFound)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, s, res)
+			}
+		})
+	}
+}
+
+func Test_StoreRepo_FindDeleted(t *testing.T) {
+	s := sample.NewStore()
+	deletedAt := time.Now().Add(-48 * time.Hour)
+	s.DeletedAt = &deletedAt
+	before := time.Now().Add(-24 * time.Hour)
+	query := `SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE deleted_at < $1 ORDER BY deleted_at, id LIMIT 10`
+
+	t.Run("failure_query_returns_error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(before).WillReturnError(errors.New("unexpected error"))
+
+		res, err := pg.NewStoreRepository(db).FindDeleted(context.TODO(), before, 10)
+		assert.Error(t, err)
+		assert.Empty(t, res)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		assert.NoError(t, err)
+		row := sqlmock.
+			NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}).
+			AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt)
+		mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(before).WillReturnRows(row)
+
+		res, err := pg.NewStoreRepository(db).FindDeleted(context.TODO(), before, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, domain.Stores{s}, res)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func Test_StoreRepo_FindAll(t *testing.T) {
 	s := sample.NewStore()
 
 	page := 1
 	limit := 10
-	sort := "created_at"
+	sort := domain.SortSpec{{Column: "name"}, {Column: "created_at", Desc: true}}
+	order := `"name" ASC,"created_at" DESC,"id" ASC`
+	cursor := domain.NewStoreCursor(s, sort, false)
+	createdFrom := time.Now().Add(-time.Hour)
+	createdTo := time.Now()
 
 	testCases := []struct {
 		name        string
 		page        int
 		limit       int
-		sort        string
+		sort        domain.SortSpec
+		filter      *domain.StoreFilter
+		cursor      *domain.Cursor
 		expectedErr bool
 		prepare     func(mock sqlmock.Sqlmock)
 	}{
@@ -243,7 +332,7 @@ func Test_StoreRepo_FindAll(t *testing.T) {
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
 				offset := (page - 1) * limit
-				query := fmt.Sprintf(`SELECT * FROM stores ORDER BY %s OFFSET %d LIMIT %d`, sort, offset, limit)
+				query := fmt.Sprintf(`SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE deleted_at IS NULL ORDER BY %s OFFSET %d LIMIT %d`, order, offset, limit)
 				mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(errors.New("unexpected error"))
 			},
 		},
@@ -255,17 +344,132 @@ func Test_StoreRepo_FindAll(t *testing.T) {
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
 				offset := (page - 1) * limit
-				query := fmt.Sprintf(`SELECT * FROM stores ORDER BY %s OFFSET %d LIMIT %d`, sort, offset, limit)
-				countQuery := `SELECT count(1) FROM stores`
+				query := fmt.Sprintf(`SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE deleted_at IS NULL ORDER BY %s OFFSET %d LIMIT %d`, order, offset, limit)
+				countQuery := `SELECT count(1) FROM stores WHERE deleted_at IS NULL`
 
 				row := sqlmock.
-					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "tags", "lat", "lng"}).
-					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Tags, s.Position.Lat, s.Position.Lng)
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt)
 
 				mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(row)
 				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).WillReturnError(errors.New("unexpected error"))
 			},
 		},
+		{
+			name:  "success_with_filter",
+			page:  page,
+			limit: limit,
+			sort:  sort,
+			filter: &domain.StoreFilter{
+				Status:      domain.StoreStatusActive,
+				CategoryID:  s.CategoryID,
+				UserID:      s.UserID,
+				AnyTags:     []string{"tag001"},
+				AllTags:     []string{"tag001", "tag002"},
+				CreatedFrom: createdFrom,
+				CreatedTo:   createdTo,
+				NamePrefix:  "50%_off",
+			},
+			prepare: func(mock sqlmock.Sqlmock) {
+				offset := (page - 1) * limit
+				where := ` WHERE deleted_at IS NULL AND status = $1 AND category_id = $2 AND user_id = $3 AND tags && $4 AND tags @> $5 AND created_at >= $6 AND created_at < $7 AND name ILIKE $8 ESCAPE '\'`
+				query := fmt.Sprintf(`SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores%s ORDER BY %s OFFSET %d LIMIT %d`, where, order, offset, limit)
+				countQuery := `SELECT count(1) FROM stores` + where
+				args := []driver.Value{domain.StoreStatusActive, s.CategoryID, s.UserID, pq.StringArray{"tag001"}, pq.StringArray{"tag001", "tag002"}, createdFrom, createdTo, `50\%\_off%`}
+
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt)
+
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(args...).WillReturnRows(row)
+				countRow := sqlmock.NewRows([]string{"count"}).AddRow(1)
+				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(args...).WillReturnRows(countRow)
+			},
+		},
+		{
+			name:   "success_including_descendants",
+			page:   page,
+			limit:  limit,
+			sort:   sort,
+			filter: &domain.StoreFilter{CategoryID: s.CategoryID, IncludeDescendants: true},
+			prepare: func(mock sqlmock.Sqlmock) {
+				where := `WHERE deleted_at IS NULL AND category_id IN \(\s*WITH RECURSIVE descendants AS \(\s*` +
+					`SELECT id FROM categories WHERE id = \$1\s*UNION\s*` +
+					`SELECT c\.id FROM categories c JOIN descendants d ON c\.parent_id = d\.id\s*\)\s*` +
+					`SELECT id FROM descendants\s*\)`
+
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt)
+
+				mock.ExpectQuery(`FROM stores ` + where + ` ORDER BY`).WithArgs(s.CategoryID).WillReturnRows(row)
+				countRow := sqlmock.NewRows([]string{"count"}).AddRow(1)
+				mock.ExpectQuery(`SELECT count\(1\) FROM stores ` + where + `$`).WithArgs(s.CategoryID).WillReturnRows(countRow)
+			},
+		},
+		{
+			name:   "success_open_at",
+			page:   page,
+			limit:  limit,
+			sort:   sort,
+			filter: &domain.StoreFilter{OpenAt: createdTo},
+			prepare: func(mock sqlmock.Sqlmock) {
+				where := `WHERE deleted_at IS NULL AND EXISTS \(\s*SELECT 1 FROM store_hours sh\s*` +
+					`CROSS JOIN LATERAL \(SELECT \$1::timestamptz AT TIME ZONE sh\.timezone AS local_at\) l\s*` +
+					`JOIN store_opening_hours oh ON oh\.store_id = sh\.store_id.*` +
+					`WHERE sh\.store_id = stores\.id.*` +
+					`AND NOT EXISTS \(SELECT 1 FROM store_closures sc WHERE sc\.store_id = sh\.store_id AND d\.day BETWEEN sc\.from_date AND sc\.to_date\)\s*\)`
+
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt)
+
+				mock.ExpectQuery(`(?s)FROM stores ` + where + ` ORDER BY`).WithArgs(createdTo).WillReturnRows(row)
+				countRow := sqlmock.NewRows([]string{"count"}).AddRow(1)
+				mock.ExpectQuery(`(?s)SELECT count\(1\) FROM stores ` + where + `$`).WithArgs(createdTo).WillReturnRows(countRow)
+			},
+		},
+		{
+			name:   "success_after_cursor",
+			page:   page,
+			limit:  limit,
+			sort:   sort,
+			filter: &domain.StoreFilter{Status: domain.StoreStatusActive},
+			cursor: cursor,
+			prepare: func(mock sqlmock.Sqlmock) {
+				keyset := `("name" > $2) OR ("name" = $2 AND "created_at" < $3) OR ("name" = $2 AND "created_at" = $3 AND "id" > $4)`
+				query := fmt.Sprintf(`SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE deleted_at IS NULL AND status = $1 AND (%s) ORDER BY %s LIMIT %d`, keyset, order, limit)
+				countQuery := `SELECT count(1) FROM stores WHERE deleted_at IS NULL AND status = $1`
+
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt)
+
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(domain.StoreStatusActive, s.Name, s.CreatedAt, s.ID).WillReturnRows(row)
+				countRow := sqlmock.NewRows([]string{"count"}).AddRow(1)
+				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(domain.StoreStatusActive).WillReturnRows(countRow)
+			},
+		},
+		{
+			name:   "success_before_cursor",
+			page:   page,
+			limit:  limit,
+			sort:   sort,
+			cursor: domain.NewStoreCursor(s, sort, true),
+			prepare: func(mock sqlmock.Sqlmock) {
+				keyset := `("name" < $1) OR ("name" = $1 AND "created_at" > $2) OR ("name" = $1 AND "created_at" = $2 AND "id" < $3)`
+				query := fmt.Sprintf(`SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE deleted_at IS NULL AND (%s) ORDER BY "name" DESC,"created_at" ASC,"id" DESC LIMIT %d`, keyset, limit)
+				countQuery := `SELECT count(1) FROM stores WHERE deleted_at IS NULL`
+
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt)
+
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(s.Name, s.CreatedAt, s.ID).WillReturnRows(row)
+				countRow := sqlmock.NewRows([]string{"count"}).AddRow(1)
+				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).WillReturnRows(countRow)
+			},
+		},
 		{
 			name:  "success",
 			page:  page,
@@ -273,18 +477,38 @@ func Test_StoreRepo_FindAll(t *testing.T) {
 			sort:  sort,
 			prepare: func(mock sqlmock.Sqlmock) {
 				offset := (page - 1) * limit
-				query := fmt.Sprintf(`SELECT * FROM stores ORDER BY %s OFFSET %d LIMIT %d`, sort, offset, limit)
-				countQuery := `SELECT count(1) FROM stores`
+				query := fmt.Sprintf(`SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE deleted_at IS NULL ORDER BY %s OFFSET %d LIMIT %d`, order, offset, limit)
+				countQuery := `SELECT count(1) FROM stores WHERE deleted_at IS NULL`
 
 				row := sqlmock.
-					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "tags", "lat", "lng"}).
-					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Tags, s.Position.Lat, s.Position.Lng)
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt)
 
 				mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(row)
 				countRow := sqlmock.NewRows([]string{"count"}).AddRow(1)
 				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).WillReturnRows(countRow)
 			},
 		},
+		{
+			name:   "success_including_deleted",
+			page:   page,
+			limit:  limit,
+			sort:   sort,
+			filter: &domain.StoreFilter{CategoryID: s.CategoryID, IncludeDeleted: true},
+			prepare: func(mock sqlmock.Sqlmock) {
+				offset := (page - 1) * limit
+				query := fmt.Sprintf(`SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at FROM stores WHERE category_id = $1 ORDER BY %s OFFSET %d LIMIT %d`, order, offset, limit)
+				countQuery := `SELECT count(1) FROM stores WHERE category_id = $1`
+
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, time.Now())
+
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(s.CategoryID).WillReturnRows(row)
+				countRow := sqlmock.NewRows([]string{"count"}).AddRow(1)
+				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(s.CategoryID).WillReturnRows(countRow)
+			},
+		},
 	}
 
 	for i := range testCases {
@@ -295,7 +519,7 @@ func Test_StoreRepo_FindAll(t *testing.T) {
 			assert.NoError(t, err)
 			repo := pg.NewStoreRepository(db)
 			tc.prepare(mock)
-			res, count, err := repo.FindAll(context.TODO(), tc.sort, tc.limit, tc.page)
+			res, count, err := repo.FindAll(context.TODO(), tc.filter, tc.sort, tc.cursor, tc.limit, tc.page)
 
 			if tc.expectedErr {
 				assert.Error(t, err)
@@ -310,47 +534,121 @@ func Test_StoreRepo_FindAll(t *testing.T) {
 	}
 }
 
-func Test_StoreRepo_Update(t *testing.T) {
+func Test_StoreRepo_FindNearby(t *testing.T) {
 	s := sample.NewStore()
+	position := domain.Position{Lat: -8.8383, Lng: 13.2344}
+	origin := `ll_to_earth(%[1]s, %[2]s)`
+	geo := `earth_box(` + origin + `, %[3]s) @> ll_to_earth(lat::float8, lng::float8) AND earth_distance(` + origin + `, ll_to_earth(lat::float8, lng::float8)) <= %[3]s`
+	selectNearby := `SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at, earth_distance(` + origin + `, ll_to_earth(lat::float8, lng::float8)) / 1000 AS distance FROM stores WHERE deleted_at IS NULL AND `
+
 	testCases := []struct {
 		name        string
-		arg         *domain.Store
+		filter      *domain.StoreFilter
 		expectedErr bool
 		prepare     func(mock sqlmock.Sqlmock)
 	}{
 		{
-			name:        "failure_exec_query_returns_error",
-			arg:         s,
+			name:        "failure_query_returns_error",
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `UPDATE stores SET created_at=$1,updated_at=$2,name=$3,description=$4,status=$5,user_id=$6,account_id=$7,category_id=$8,tags=$9,lat=$10,lng=$11,image=$12 WHERE id = $13`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Tags, s.Position.Lat, s.Position.Lng, s.Image, s.ID).WillReturnError(errors.New("unexpected error"))
+				query := fmt.Sprintf(selectNearby+geo+` ORDER BY distance, id LIMIT 20`, "$1", "$2", "$3")
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(position.Lat, position.Lng, 5000.0).
+					WillReturnError(errors.New("unexpected error"))
 			},
 		},
 		{
-			name:        "failure_get_affected_row_returns_error",
-			arg:         s,
+			name:   "success_with_filter",
+			filter: &domain.StoreFilter{Status: domain.StoreStatusActive, CategoryID: s.CategoryID},
+			prepare: func(mock sqlmock.Sqlmock) {
+				query := fmt.Sprintf(selectNearby+`status = $1 AND category_id = $2 AND `+geo+` ORDER BY distance, id LIMIT 20`, "$3", "$4", "$5")
+
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at", "distance"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt, 1.5)
+
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(domain.StoreStatusActive, s.CategoryID, position.Lat, position.Lng, 5000.0).
+					WillReturnRows(row)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewStoreRepository(db)
+			tc.prepare(mock)
+			res, err := repo.FindNearby(context.TODO(), position, 5, tc.filter, 20)
+
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Len(t, res, 0)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.Equal(t, s.ID, res[0].ID)
+				assert.Equal(t, 1.5, res[0].Distance)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_StoreRepo_Search(t *testing.T) {
+	s := sample.NewStore()
+	selectSearch := `SELECT id,created_at,updated_at,name,status,description,account_id,category_id,user_id,image,images,tags,lat,lng,version,deleted_at, ts_rank(search_vector, %[1]s) AS rank, ts_headline('simple', name || ' ' || coalesce(description, ''), %[1]s, '` + "StartSel=\x02, StopSel=\x03, MaxFragments=2, MaxWords=20, MinWords=5" + `') AS snippet FROM stores`
+
+	testCases := []struct {
+		name        string
+		filter      *domain.StoreFilter
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_search_returns_error",
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `UPDATE stores SET created_at=$1,updated_at=$2,name=$3,description=$4,status=$5,user_id=$6,account_id=$7,category_id=$8,tags=$9,lat=$10,lng=$11,image=$12 WHERE id = $13`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Tags, s.Position.Lat, s.Position.Lng, s.Image, s.ID).WillReturnResult(sqlmock.NewErrorResult(errors.New("unexpected error")))
+				query := fmt.Sprintf(selectSearch, `websearch_to_tsquery('simple', $1)`) + ` WHERE deleted_at IS NULL AND search_vector @@ websearch_to_tsquery('simple', $1) ORDER BY rank DESC, id OFFSET 10 LIMIT 10`
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs("coffee shop").
+					WillReturnError(errors.New("unexpected error"))
 			},
 		},
 		{
-			name:        "failure_returns_nvalid_number_of_affected_rows",
-			arg:         s,
+			name:        "failure_count_returns_error",
 			expectedErr: true,
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `UPDATE stores SET created_at=$1,updated_at=$2,name=$3,description=$4,status=$5,user_id=$6,account_id=$7,category_id=$8,tags=$9,lat=$10,lng=$11,image=$12 WHERE id = $13`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Tags, s.Position.Lat, s.Position.Lng, s.Image, s.ID).WillReturnResult(sqlmock.NewResult(1, 2))
+				query := fmt.Sprintf(selectSearch, `websearch_to_tsquery('simple', $1)`) + ` WHERE deleted_at IS NULL AND search_vector @@ websearch_to_tsquery('simple', $1) ORDER BY rank DESC, id OFFSET 10 LIMIT 10`
+				countQuery := `SELECT count(1) FROM stores WHERE deleted_at IS NULL AND search_vector @@ websearch_to_tsquery('simple', $1)`
+
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at", "rank", "snippet"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt, 0.6, "\x02coffee\x03 <\x02shop\x03>")
+
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs("coffee shop").WillReturnRows(row)
+				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs("coffee shop").WillReturnError(errors.New("unexpected error"))
 			},
 		},
 		{
-			name: "success",
-			arg:  s,
+			name:   "success_with_filter",
+			filter: &domain.StoreFilter{Status: domain.StoreStatusActive, CategoryID: s.CategoryID},
 			prepare: func(mock sqlmock.Sqlmock) {
-				query := `UPDATE stores SET created_at=$1,updated_at=$2,name=$3,description=$4,status=$5,user_id=$6,account_id=$7,category_id=$8,tags=$9,lat=$10,lng=$11,image=$12 WHERE id = $13`
-				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Tags, s.Position.Lat, s.Position.Lng, s.Image, s.ID).WillReturnResult(sqlmock.NewResult(1, 1))
+				where := ` WHERE deleted_at IS NULL AND status = $1 AND category_id = $2 AND search_vector @@ websearch_to_tsquery('simple', $3)`
+				query := fmt.Sprintf(selectSearch, `websearch_to_tsquery('simple', $3)`) + where + ` ORDER BY rank DESC, id OFFSET 10 LIMIT 10`
+				countQuery := `SELECT count(1) FROM stores` + where
+				args := []driver.Value{domain.StoreStatusActive, s.CategoryID, "coffee shop"}
+
+				row := sqlmock.
+					NewRows([]string{"id", "created_at", "updated_at", "name", "status", "description", "account_id", "category_id", "user_id", "image", "images", "tags", "lat", "lng", "version", "deleted_at", "rank", "snippet"}).
+					AddRow(s.ID, s.CreatedAt, s.UpdatedAt, s.Name, s.Status, s.Description, s.AccountID, s.CategoryID, s.UserID, s.Image, s.Images, s.Tags, s.Position.Lat, s.Position.Lng, s.Version, s.DeletedAt, 0.6, "\x02coffee\x03 <\x02shop\x03>")
+
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WithArgs(args...).WillReturnRows(row)
+				countRow := sqlmock.NewRows([]string{"count"}).AddRow(11)
+				mock.ExpectQuery(regexp.QuoteMeta(countQuery)).WithArgs(args...).WillReturnRows(countRow)
 			},
 		},
 	}
@@ -363,12 +661,90 @@ func Test_StoreRepo_Update(t *testing.T) {
 			assert.NoError(t, err)
 			repo := pg.NewStoreRepository(db)
 			tc.prepare(mock)
-			err = repo.Update(context.TODO(), tc.arg)
+			res, count, err := repo.Search(context.TODO(), "coffee shop", tc.filter, 10, 2)
+
 			if tc.expectedErr {
 				assert.Error(t, err)
+				assert.Equal(t, int64(0), count)
+				assert.Len(t, res, 0)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(11), count)
+				assert.Len(t, res, 1)
+				assert.Equal(t, s.ID, res[0].ID)
+				assert.Equal(t, 0.6, res[0].Rank)
+				assert.Equal(t, "<b>coffee</b> &lt;<b>shop</b>&gt;", res[0].Snippet)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_StoreRepo_Update(t *testing.T) {
+	query := `UPDATE stores SET created_at=$1,updated_at=$2,name=$3,description=$4,status=$5,user_id=$6,account_id=$7,category_id=$8,tags=$9,lat=$10,lng=$11,image=$12,images=$13,deleted_at=$14,version=version+1 WHERE id = $15 AND version = $16`
+	testCases := []struct {
+		name        string
+		expectedErr error
+		version     int
+		prepare     func(mock sqlmock.Sqlmock, s *domain.Store)
+	}{
+		{
+			name:        "failure_exec_query_returns_error",
+			expectedErr: errors.New("unexpected error"),
+			version:     1,
+			prepare: func(mock sqlmock.Sqlmock, s *domain.Store) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Tags, s.Position.Lat, s.Position.Lng, s.Image, s.Images, s.DeletedAt, s.ID, s.Version).WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_get_affected_row_returns_error",
+			expectedErr: errors.New("unexpected error"),
+			version:     1,
+			prepare: func(mock sqlmock.Sqlmock, s *domain.Store) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Tags, s.Position.Lat, s.Position.Lng, s.Image, s.Images, s.DeletedAt, s.ID, s.Version).WillReturnResult(sqlmock.NewErrorResult(errors.New("unexpected error")))
+			},
+		},
+		{
+			name:        "failure_version_mismatch_returns_conflict",
+			expectedErr: domain.ErrConflict,
+			version:     1,
+			prepare: func(mock sqlmock.Sqlmock, s *domain.Store) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Tags, s.Position.Lat, s.Position.Lng, s.Image, s.Images, s.DeletedAt, s.ID, s.Version).WillReturnResult(sqlmock.NewResult(1, 0))
+			},
+		},
+		{
+			name:        "failure_returns_nvalid_number_of_affected_rows",
+			expectedErr: errors.New("Weird  Behavior. Total Affected: 2"),
+			version:     1,
+			prepare: func(mock sqlmock.Sqlmock, s *domain.Store) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Tags, s.Position.Lat, s.Position.Lng, s.Image, s.Images, s.DeletedAt, s.ID, s.Version).WillReturnResult(sqlmock.NewResult(1, 2))
+			},
+		},
+		{
+			name:    "success",
+			version: 2,
+			prepare: func(mock sqlmock.Sqlmock, s *domain.Store) {
+				mock.ExpectExec(regexp.QuoteMeta(query)).WithArgs(s.CreatedAt, s.UpdatedAt, s.Name, s.Description, s.Status, s.UserID, s.AccountID, s.CategoryID, s.Tags, s.Position.Lat, s.Position.Lng, s.Image, s.Images, s.DeletedAt, s.ID, s.Version).WillReturnResult(sqlmock.NewResult(1, 1))
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			repo := pg.NewStoreRepository(db)
+			s := sample.NewStore()
+			tc.prepare(mock, s)
+			err = repo.Update(context.TODO(), s)
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
 			} else {
 				assert.NoError(t, err)
 			}
+			assert.Equal(t, tc.version, s.Version)
 		})
 	}
 }
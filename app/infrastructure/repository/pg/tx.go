@@ -0,0 +1,95 @@
+package pg
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type txKey struct{}
+
+// savepointKey holds the depth of the savepoints of the transaction
+type savepointKey struct{}
+
+// executor is implemented by both *sql.DB and *sql.Tx
+type executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+type txManager struct {
+	db *sql.DB
+}
+
+func NewTxManager(db *sql.DB) *txManager {
+	return &txManager{
+		db: db,
+	}
+}
+
+// WithinTransaction runs fn inside a transaction, committing when it returns
+// nil and rolling back otherwise. Nested calls run in a savepoint of the outer
+// transaction, only rolled back to when they fail.
+func (m *txManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return m.withinSavepoint(ctx, tx, fn)
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	err = fn(context.WithValue(ctx, txKey{}, tx))
+	if err != nil {
+		_ = tx.Rollback()
+		return
+	}
+
+	return tx.Commit()
+}
+
+// withinSavepoint runs fn in a savepoint of tx, releasing it when fn returns
+// nil and rolling back to it otherwise
+func (m *txManager) withinSavepoint(ctx context.Context, tx *sql.Tx, fn func(ctx context.Context) error) (err error) {
+	depth, _ := ctx.Value(savepointKey{}).(int)
+	depth++
+	name := fmt.Sprintf("sp%d", depth)
+
+	_, err = tx.ExecContext(ctx, "SAVEPOINT "+name)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+			panic(p)
+		}
+	}()
+
+	err = fn(context.WithValue(ctx, savepointKey{}, depth))
+	if err != nil {
+		_, _ = tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+		return
+	}
+
+	_, err = tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name)
+	return
+}
+
+// conn returns the transaction bound to ctx, or db when there is none
+func conn(ctx context.Context, db *sql.DB) executor {
+	if tx, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return tx
+	}
+	return db
+}
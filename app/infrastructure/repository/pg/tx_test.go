@@ -0,0 +1,134 @@
+package pg_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/repository/pg"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_TxManager(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:        "failure_begin_returns_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin().WillReturnError(errors.New("unexpected error"))
+			},
+		},
+		{
+			name:        "failure_rollback_on_error",
+			expectedErr: true,
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO accounts`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO stores`)).WillReturnError(errors.New("unexpected error"))
+				mock.ExpectRollback()
+			},
+		},
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO accounts`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO stores`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			txManager := pg.NewTxManager(db)
+			accountRepo := pg.NewAccountRepository(db)
+			storeRepo := pg.NewStoreRepository(db)
+			tc.prepare(mock)
+			err = txManager.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+				if err := accountRepo.Store(ctx, sample.NewAccount()); err != nil {
+					return err
+				}
+				return storeRepo.Create(ctx, sample.NewStore())
+			})
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func Test_TxManager_Nested(t *testing.T) {
+	testCases := []struct {
+		name    string
+		nested  error
+		prepare func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name:   "rollback_to_the_savepoint",
+			nested: errors.New("unexpected error"),
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO accounts`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`SAVEPOINT sp1`)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO stores`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`ROLLBACK TO SAVEPOINT sp1`)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "release_the_savepoint",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO accounts`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`SAVEPOINT sp1`)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(regexp.QuoteMeta(`INSERT INTO stores`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta(`RELEASE SAVEPOINT sp1`)).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			db, mock, err := sqlmock.New()
+			assert.NoError(t, err)
+			txManager := pg.NewTxManager(db)
+			accountRepo := pg.NewAccountRepository(db)
+			storeRepo := pg.NewStoreRepository(db)
+			tc.prepare(mock)
+			var nestedErr error
+			err = txManager.WithinTransaction(context.TODO(), func(ctx context.Context) error {
+				if err := accountRepo.Store(ctx, sample.NewAccount()); err != nil {
+					return err
+				}
+				nestedErr = txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+					if err := storeRepo.Create(ctx, sample.NewStore()); err != nil {
+						return err
+					}
+					return tc.nested
+				})
+				return nil
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, tc.nested, nestedErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/opentracing/opentracing-go"
+)
+
+// LocalStorage keeps each blob in a file under Root, named after its key
+type LocalStorage struct {
+	Root string
+}
+
+func NewLocalStorage(root string) *LocalStorage {
+	return &LocalStorage{Root: root}
+}
+
+// file returns the path of the file of key, which never leaves Root
+func (s *LocalStorage) file(key string) string {
+	return filepath.Join(s.Root, filepath.FromSlash(path.Clean("/"+key)))
+}
+
+// Put writes body to a temporary file renamed to the file of key once
+// complete, so a blob is never read half written
+func (s *LocalStorage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "LocalStorage.Put")
+	defer span.Finish()
+
+	name := s.file(key)
+	err := os.MkdirAll(filepath.Dir(name), 0755)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = io.Copy(tmp, body)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+
+	err = os.Chmod(tmp.Name(), 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+// Delete removes the file of key. A missing file is not an error.
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	span, _ := opentracing.StartSpanFromContext(ctx, "LocalStorage.Delete")
+	defer span.Finish()
+
+	err := os.Remove(s.file(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,65 @@
+package storage_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/EdlanioJ/kbu-store/app/infrastructure/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_LocalStorage(t *testing.T) {
+	t.Parallel()
+
+	t.Run("put", func(t *testing.T) {
+		root := t.TempDir()
+		s := storage.NewLocalStorage(root)
+
+		err := s.Put(context.TODO(), "stores/1/2/original.jpg", strings.NewReader("image"), 5, "image/jpeg")
+		assert.NoError(t, err)
+
+		data, err := ioutil.ReadFile(filepath.Join(root, "stores", "1", "2", "original.jpg"))
+		assert.NoError(t, err)
+		assert.Equal(t, "image", string(data))
+
+		files, _ := ioutil.ReadDir(filepath.Join(root, "stores", "1", "2"))
+		assert.Len(t, files, 1)
+	})
+
+	t.Run("put_stays_under_root", func(t *testing.T) {
+		root := t.TempDir()
+		s := storage.NewLocalStorage(filepath.Join(root, "blobs"))
+
+		err := s.Put(context.TODO(), "../../escaped.jpg", strings.NewReader("image"), 5, "image/jpeg")
+		assert.NoError(t, err)
+
+		_, err = os.Stat(filepath.Join(root, "blobs", "escaped.jpg"))
+		assert.NoError(t, err)
+		_, err = os.Stat(filepath.Join(root, "escaped.jpg"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		root := t.TempDir()
+		s := storage.NewLocalStorage(root)
+
+		err := s.Put(context.TODO(), "stores/1/2/original.jpg", strings.NewReader("image"), 5, "image/jpeg")
+		assert.NoError(t, err)
+
+		err = s.Delete(context.TODO(), "stores/1/2/original.jpg")
+		assert.NoError(t, err)
+		_, err = os.Stat(filepath.Join(root, "stores", "1", "2", "original.jpg"))
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("delete_missing", func(t *testing.T) {
+		s := storage.NewLocalStorage(t.TempDir())
+
+		err := s.Delete(context.TODO(), "stores/1/2/original.jpg")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,199 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/config"
+	"github.com/opentracing/opentracing-go"
+)
+
+// unsignedPayload leaves the request body out of the signature, so uploads
+// are streamed without being hashed first
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// S3Storage keeps each blob as an object of a bucket of an S3 compatible
+// service, such as AWS S3 or MinIO. Requests are signed with AWS Signature
+// Version 4.
+type S3Storage struct {
+	Endpoint  *url.URL
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	PathStyle bool
+	Client    *http.Client
+	Now       func() time.Time
+}
+
+// NewS3Storage returns the storage of the bucket of cfg. Without an
+// endpoint, the AWS S3 endpoint of the region is used.
+func NewS3Storage(cfg config.Storage) (*S3Storage, error) {
+	if cfg.Bucket == "" {
+		return nil, errors.New("s3 storage bucket is required")
+	}
+
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", cfg.Region)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Storage{
+		Endpoint:  u,
+		Region:    cfg.Region,
+		Bucket:    cfg.Bucket,
+		AccessKey: cfg.AccessKey,
+		SecretKey: cfg.SecretKey,
+		PathStyle: cfg.PathStyle,
+		Client:    http.DefaultClient,
+		Now:       time.Now,
+	}, nil
+}
+
+// objectURL returns the URL of the object of key, the bucket being either
+// the first path segment or a subdomain of the endpoint
+func (s *S3Storage) objectURL(key string) *url.URL {
+	u := *s.Endpoint
+	segments := strings.Split(strings.TrimPrefix(key, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	escaped := strings.Join(segments, "/")
+
+	if s.PathStyle {
+		u.RawPath = strings.TrimSuffix(u.Path, "/") + "/" + url.PathEscape(s.Bucket) + "/" + escaped
+	} else {
+		u.Host = s.Bucket + "." + u.Host
+		u.RawPath = strings.TrimSuffix(u.Path, "/") + "/" + escaped
+	}
+	u.Path, _ = url.PathUnescape(u.RawPath)
+	return &u
+}
+
+// Put uploads body as the object of key
+func (s *S3Storage) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "S3Storage.Put")
+	defer span.Finish()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+
+	return s.do(req, key, http.StatusOK)
+}
+
+// Delete removes the object of key. A missing object is not an error.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	span, ctx := opentracing.StartSpanFromContext(ctx, "S3Storage.Delete")
+	defer span.Finish()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return err
+	}
+
+	return s.do(req, key, http.StatusNoContent, http.StatusOK, http.StatusNotFound)
+}
+
+// do signs and sends req, failing unless it is answered with one of expected
+func (s *S3Storage) do(req *http.Request, key string, expected ...int) error {
+	s.sign(req, unsignedPayload)
+
+	res, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	for _, status := range expected {
+		if res.StatusCode == status {
+			return nil
+		}
+	}
+
+	msg, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("s3 %s %s: %s %s", req.Method, key, res.Status, strings.TrimSpace(string(msg)))
+}
+
+// sign sets the Authorization header of req, signing its headers along with
+// payloadHash, the hex encoded SHA256 of its body
+func (s *S3Storage) sign(req *http.Request, payloadHash string) {
+	now := s.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	scope := strings.Join([]string{amzDate[:8], s.Region, "s3", "aws4_request"}, "/")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		hashHex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.SecretKey), amzDate[:8])
+	key = hmacSHA256(key, s.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.AccessKey, scope, signedHeaders, signature,
+	))
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
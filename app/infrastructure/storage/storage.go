@@ -0,0 +1,26 @@
+package storage
+
+import (
+	"fmt"
+
+	"github.com/EdlanioJ/kbu-store/app/config"
+	"github.com/EdlanioJ/kbu-store/app/domain"
+)
+
+const (
+	// DriverLocal keeps the blobs in the local filesystem
+	DriverLocal = "local"
+	// DriverS3 keeps the blobs in an S3 compatible bucket
+	DriverS3 = "s3"
+)
+
+// NewBlobStorage returns the blob storage of the configured driver
+func NewBlobStorage(cfg *config.Config) (domain.BlobStorage, error) {
+	switch cfg.Storage.Driver {
+	case DriverLocal:
+		return NewLocalStorage(cfg.Storage.Path), nil
+	case DriverS3:
+		return NewS3Storage(cfg.Storage)
+	}
+	return nil, fmt.Errorf("unknown storage driver %q", cfg.Storage.Driver)
+}
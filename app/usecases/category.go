@@ -8,15 +8,37 @@ import (
 	"github.com/opentracing/opentracing-go"
 )
 
+// cascadeBatchSize is how many stores are loaded at once when a category
+// status change cascades to its stores
+const cascadeBatchSize = 100
+
 type CategoryUsecase struct {
-	categoryRepo   domain.CategoryRepository
-	contextTimeout time.Duration
+	categoryRepo     domain.CategoryRepository
+	storeRepo        domain.StoreRepository
+	historyRepo      domain.StoreStatusHistoryRepository
+	outboxRepo       domain.OutboxRepository
+	txManager        domain.TxManager
+	contextTimeout   time.Duration
+	UpdateStoreTopic string
+	Cascade          domain.CategoryCascadePolicy
 }
 
-func NewCategoryUsecase(c domain.CategoryRepository, t time.Duration) *CategoryUsecase {
+func NewCategoryUsecase(
+	c domain.CategoryRepository,
+	s domain.StoreRepository,
+	h domain.StoreStatusHistoryRepository,
+	o domain.OutboxRepository,
+	tx domain.TxManager,
+	t time.Duration,
+) *CategoryUsecase {
 	return &CategoryUsecase{
 		categoryRepo:   c,
+		storeRepo:      s,
+		historyRepo:    h,
+		outboxRepo:     o,
+		txManager:      tx,
 		contextTimeout: t,
+		Cascade:        domain.CategoryCascadePolicy{Mode: domain.CategoryCascadeNone},
 	}
 }
 
@@ -27,6 +49,11 @@ func (u *CategoryUsecase) Create(c context.Context, category *domain.Category) (
 	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryUsecãse.Store")
 	defer span.Finish()
 
+	err = u.checkParent(ctx, category)
+	if err != nil {
+		return
+	}
+
 	err = u.categoryRepo.Store(ctx, category)
 	return
 }
@@ -43,5 +70,295 @@ func (u *CategoryUsecase) Update(c context.Context, category *domain.Category) (
 		return
 	}
 
+	err = u.checkParent(ctx, category)
+	if err != nil {
+		return
+	}
+
 	return u.categoryRepo.Update(ctx, category)
 }
+
+// checkParent makes sure the parent of category exists, and is neither the
+// category itself nor one of its descendants
+func (u *CategoryUsecase) checkParent(ctx context.Context, category *domain.Category) error {
+	visited := map[string]bool{}
+	for parentID := category.ParentID; parentID != nil; {
+		if *parentID == category.ID || visited[*parentID] {
+			return domain.ErrCategoryCycle
+		}
+		visited[*parentID] = true
+
+		parent, err := u.categoryRepo.FindByID(ctx, *parentID)
+		if err != nil {
+			return err
+		}
+		parentID = parent.ParentID
+	}
+	return nil
+}
+
+func (u *CategoryUsecase) Get(c context.Context, id string) (res *domain.Category, err error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryUsecãse.Get")
+	defer span.Finish()
+
+	res, err = u.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// List returns a page of the categories, all of them or only those in status
+func (u *CategoryUsecase) List(c context.Context, status string, limit, page int) (res domain.Categories, total int64, err error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryUsecãse.List")
+	defer span.Finish()
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	res, total, err = u.categoryRepo.FindAll(ctx, status, limit, page)
+	if err != nil {
+		return nil, 0, err
+	}
+	return
+}
+
+// Tree returns every category arranged under its parent
+func (u *CategoryUsecase) Tree(c context.Context) (res domain.CategoryTree, err error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryUsecãse.Tree")
+	defer span.Finish()
+
+	categories, err := u.categoryRepo.FindTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return domain.NewCategoryTree(categories), nil
+}
+
+// The reasons of the store status changes made by the cascade of a category
+// status change
+const (
+	categoryActivatedReason = "category activated"
+	categoryDisabledReason  = "category disabled"
+)
+
+// Activate activates a category, for an admin. When the cascade policy restores stores, the
+// ones suspended along with the category, or one of its ancestors, are resumed too.
+func (u *CategoryUsecase) Activate(c context.Context, id string) (err error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryUsecãse.Activate")
+	defer span.Finish()
+
+	err = authorizeAdmin(ctx)
+	if err != nil {
+		return
+	}
+
+	category, err := u.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = category.Activate()
+	if err != nil {
+		return
+	}
+
+	err = u.categoryRepo.Update(ctx, category)
+	if err != nil {
+		return
+	}
+
+	if u.Cascade.Mode != domain.CategoryCascadeSuspend || !u.Cascade.Restore {
+		return
+	}
+
+	active := map[string]bool{id: true}
+	return u.cascade(ctx, id, domain.StoreStatusSuspended, domain.StoreActionResume, categoryActivatedReason, func(ctx context.Context, store *domain.Store) (bool, error) {
+		return u.suspendedWithCategory(ctx, store, active)
+	})
+}
+
+// suspendedWithCategory tells whether store was last suspended by the
+// cascade of a category, and its own category is active. Stores suspended
+// for another reason, or within a sub-category still disabled, stay
+// suspended. active caches the status of the categories already checked.
+func (u *CategoryUsecase) suspendedWithCategory(ctx context.Context, store *domain.Store, active map[string]bool) (bool, error) {
+	history, _, err := u.historyRepo.FindByStoreID(ctx, store.ID, 1, 1)
+	if err != nil {
+		return false, err
+	}
+	if len(history) == 0 || history[0].ToStatus != store.Status || history[0].Reason != categoryDisabledReason {
+		return false, nil
+	}
+
+	ok, checked := active[store.CategoryID]
+	if !checked {
+		category, err := u.categoryRepo.FindByID(ctx, store.CategoryID)
+		if err != nil {
+			return false, err
+		}
+		ok = category.Status == domain.CategoryStatusActive
+		active[store.CategoryID] = ok
+	}
+	return ok, nil
+}
+
+// Disable disables a category, for an admin. When the cascade policy suspends stores, the
+// active stores of the category and of its sub-categories are suspended too.
+func (u *CategoryUsecase) Disable(c context.Context, id string) (err error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryUsecãse.Disable")
+	defer span.Finish()
+
+	err = authorizeAdmin(ctx)
+	if err != nil {
+		return
+	}
+
+	category, err := u.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = category.Disable()
+	if err != nil {
+		return
+	}
+
+	err = u.categoryRepo.Update(ctx, category)
+	if err != nil {
+		return
+	}
+
+	if u.Cascade.Mode != domain.CategoryCascadeSuspend {
+		return
+	}
+	return u.cascade(ctx, id, domain.StoreStatusActive, domain.StoreActionSuspend, categoryDisabledReason, nil)
+}
+
+// cascade applies action to every store in status of the category and of its
+// descendants that eligible accepts, or all of them when it is nil, recording
+// the status change for reason and a store updated event for each of them.
+// The stores are walked in id order, and every batch is committed on its own
+// so a large category never holds the locks of all of its stores at once: on
+// failure, the batches before stay applied.
+func (u *CategoryUsecase) cascade(ctx context.Context, categoryID, status, action, reason string, eligible func(context.Context, *domain.Store) (bool, error)) error {
+	filter := &domain.StoreFilter{CategoryID: categoryID, IncludeDescendants: true, Status: status}
+	var cursor *domain.Cursor
+	for {
+		stores, _, err := u.storeRepo.FindAll(ctx, filter, nil, cursor, cascadeBatchSize, 1)
+		if err != nil {
+			return err
+		}
+
+		batch := make(domain.Stores, 0, len(stores))
+		for _, store := range stores {
+			ok := true
+			if eligible != nil {
+				if ok, err = eligible(ctx, store); err != nil {
+					return err
+				}
+			}
+			if ok {
+				batch = append(batch, store)
+			}
+		}
+
+		err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+			for _, store := range batch {
+				if err := u.apply(ctx, store, action, reason); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(stores) < cascadeBatchSize {
+			return nil
+		}
+		cursor = domain.NewStoreCursor(stores[len(stores)-1], nil, false)
+	}
+}
+
+// apply applies action to store on behalf of the system, recording the
+// status change for reason and a store updated event
+func (u *CategoryUsecase) apply(ctx context.Context, store *domain.Store, action, reason string) error {
+	from := store.Status
+	if err := store.Apply(action); err != nil {
+		return err
+	}
+	store.UpdatedAt = time.Now()
+
+	if err := u.storeRepo.Update(ctx, store); err != nil {
+		return err
+	}
+
+	history := domain.NewStoreStatusChange(store, from, domain.StatusActorSystem, reason, nil)
+	if err := u.historyRepo.Store(ctx, history); err != nil {
+		return err
+	}
+
+	event := domain.NewStoreUpdatedEvent(store, domain.StoreChanges{
+		"status": domain.FieldChange{From: from, To: store.Status},
+	})
+	return u.outboxRepo.Store(ctx, domain.NewOutboxMessage(u.UpdateStoreTopic, event.ToJson()))
+}
+
+// Delete removes a category with no sub-category, and no store belonging to
+// it, for an admin
+func (u *CategoryUsecase) Delete(c context.Context, id string) (err error) {
+	ctx, cancel := context.WithTimeout(c, u.contextTimeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "categoryUsecãse.Delete")
+	defer span.Finish()
+
+	err = authorizeAdmin(ctx)
+	if err != nil {
+		return
+	}
+
+	_, err = u.categoryRepo.FindByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	children, err := u.categoryRepo.CountChildren(ctx, id)
+	if err != nil {
+		return
+	}
+	if children > 0 {
+		return domain.ErrInUse
+	}
+
+	_, total, err := u.storeRepo.FindAll(ctx, &domain.StoreFilter{CategoryID: id, IncludeDeleted: true}, nil, nil, 1, 1)
+	if err != nil {
+		return
+	}
+	if total > 0 {
+		return domain.ErrInUse
+	}
+
+	return u.categoryRepo.Delete(ctx, id)
+}
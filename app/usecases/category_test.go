@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -45,7 +46,7 @@ func Test_CategoryUsecase_Create(t *testing.T) {
 			t.Parallel()
 			categoryRepo := new(mocks.CategoryRepository)
 			tc.prepare(categoryRepo)
-			u := usecases.NewCategoryUsecase(categoryRepo, time.Second*2)
+			u := usecases.NewCategoryUsecase(categoryRepo, nil, nil, nil, nil, time.Second*2)
 			fmt.Println(tc.arg)
 			err := u.Create(context.TODO(), tc.arg)
 			if tc.expectedErr {
@@ -104,7 +105,7 @@ func Test_CategoryUsecase_Update(t *testing.T) {
 			t.Parallel()
 			categoryRepo := new(mocks.CategoryRepository)
 			tc.prepare(categoryRepo)
-			u := usecases.NewCategoryUsecase(categoryRepo, time.Second*2)
+			u := usecases.NewCategoryUsecase(categoryRepo, nil, nil, nil, nil, time.Second*2)
 			err := u.Update(context.TODO(), tc.arg)
 			if tc.expectedErr {
 				assert.Error(t, err)
@@ -115,3 +116,624 @@ func Test_CategoryUsecase_Update(t *testing.T) {
 		})
 	}
 }
+
+func Test_CategoryUsecase_Get(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(categoryRepo *mocks.CategoryRepository)
+	}{
+		{
+			name:        "failure_find_category_by_id_returns_error",
+			expectedErr: true,
+			prepare: func(categoryRepo *mocks.CategoryRepository) {
+				categoryRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name: "success",
+			prepare: func(categoryRepo *mocks.CategoryRepository) {
+				categoryRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewCategory(), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryRepo := new(mocks.CategoryRepository)
+			tc.prepare(categoryRepo)
+			u := usecases.NewCategoryUsecase(categoryRepo, nil, nil, nil, nil, time.Second*2)
+			res, err := u.Get(context.TODO(), "id")
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, res)
+			}
+			categoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryUsecase_List(t *testing.T) {
+	testCases := []struct {
+		name        string
+		status      string
+		limit       int
+		page        int
+		expectedErr bool
+		prepare     func(categoryRepo *mocks.CategoryRepository)
+	}{
+		{
+			name:        "failure_find_all_returns_error",
+			expectedErr: true,
+			prepare: func(categoryRepo *mocks.CategoryRepository) {
+				categoryRepo.On("FindAll", mock.Anything, "", 10, 1).Return(nil, int64(0), errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:   "success",
+			status: domain.CategoryStatusActive,
+			limit:  5,
+			page:   2,
+			prepare: func(categoryRepo *mocks.CategoryRepository) {
+				categoryRepo.On("FindAll", mock.Anything, domain.CategoryStatusActive, 5, 2).Return(domain.Categories{sample.NewCategory()}, int64(6), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryRepo := new(mocks.CategoryRepository)
+			tc.prepare(categoryRepo)
+			u := usecases.NewCategoryUsecase(categoryRepo, nil, nil, nil, nil, time.Second*2)
+			res, total, err := u.List(context.TODO(), tc.status, tc.limit, tc.page)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+				assert.Equal(t, int64(0), total)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.Equal(t, int64(6), total)
+			}
+			categoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryUsecase_Tree(t *testing.T) {
+	parent := sample.NewCategory()
+	child := sample.NewCategory()
+	child.ParentID = &parent.ID
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(categoryRepo *mocks.CategoryRepository)
+	}{
+		{
+			name:        "failure_find_tree_returns_error",
+			expectedErr: true,
+			prepare: func(categoryRepo *mocks.CategoryRepository) {
+				categoryRepo.On("FindTree", mock.Anything).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name: "success",
+			prepare: func(categoryRepo *mocks.CategoryRepository) {
+				categoryRepo.On("FindTree", mock.Anything).Return(domain.Categories{child, parent}, nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryRepo := new(mocks.CategoryRepository)
+			tc.prepare(categoryRepo)
+			u := usecases.NewCategoryUsecase(categoryRepo, nil, nil, nil, nil, time.Second*2)
+			res, err := u.Tree(context.TODO())
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.Equal(t, parent.ID, res[0].ID)
+				assert.Len(t, res[0].Children, 1)
+			}
+			categoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryUsecase_Update_Parent(t *testing.T) {
+	root := sample.NewCategory()
+	middle := sample.NewCategory()
+	middle.ParentID = &root.ID
+
+	testCases := []struct {
+		name        string
+		arg         func() *domain.Category
+		expectedErr error
+		prepare     func(categoryRepo *mocks.CategoryRepository)
+	}{
+		{
+			name: "failure_parent_is_itself",
+			arg: func() *domain.Category {
+				category := sample.NewCategory()
+				category.ParentID = &category.ID
+				return category
+			},
+			expectedErr: domain.ErrCategoryCycle,
+			prepare: func(categoryRepo *mocks.CategoryRepository) {
+				categoryRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewCategory(), nil).Once()
+			},
+		},
+		{
+			name: "failure_parent_is_a_descendant",
+			arg: func() *domain.Category {
+				category := *root
+				category.ParentID = &middle.ID
+				return &category
+			},
+			expectedErr: domain.ErrCategoryCycle,
+			prepare: func(categoryRepo *mocks.CategoryRepository) {
+				categoryRepo.On("FindByID", mock.Anything, root.ID).Return(root, nil).Once()
+				categoryRepo.On("FindByID", mock.Anything, middle.ID).Return(middle, nil).Once()
+			},
+		},
+		{
+			name: "failure_parent_not_found",
+			arg: func() *domain.Category {
+				category := sample.NewCategory()
+				category.ParentID = &middle.ID
+				return category
+			},
+			expectedErr: domain.ErrNotFound,
+			prepare: func(categoryRepo *mocks.CategoryRepository) {
+				categoryRepo.On("FindByID", mock.Anything, middle.ID).Return(nil, domain.ErrNotFound).Once()
+				categoryRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewCategory(), nil).Once()
+			},
+		},
+		{
+			name: "success",
+			arg: func() *domain.Category {
+				category := sample.NewCategory()
+				category.ParentID = &middle.ID
+				return category
+			},
+			prepare: func(categoryRepo *mocks.CategoryRepository) {
+				categoryRepo.On("FindByID", mock.Anything, middle.ID).Return(middle, nil).Once()
+				categoryRepo.On("FindByID", mock.Anything, root.ID).Return(root, nil).Once()
+				categoryRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewCategory(), nil).Once()
+				categoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryRepo := new(mocks.CategoryRepository)
+			tc.prepare(categoryRepo)
+			u := usecases.NewCategoryUsecase(categoryRepo, nil, nil, nil, nil, time.Second*2)
+			err := u.Update(context.TODO(), tc.arg())
+			assert.ErrorIs(t, err, tc.expectedErr)
+			categoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryUsecase_Activate(t *testing.T) {
+	type fields struct {
+		categoryRepo *mocks.CategoryRepository
+		storeRepo    *mocks.StoreRepository
+		historyRepo  *mocks.StoreStatusHistoryRepository
+		outboxRepo   *mocks.OutboxRepository
+	}
+	suspendedStore := func(categoryID string) *domain.Store {
+		store := sample.NewStore()
+		store.Status = domain.StoreStatusSuspended
+		store.CategoryID = categoryID
+		return store
+	}
+	suspension := func(store *domain.Store, reason string) domain.StoreStatusHistory {
+		change := domain.NewStoreStatusChange(store, domain.StoreStatusActive, domain.StatusActorSystem, reason, nil)
+		return domain.StoreStatusHistory{change}
+	}
+	restore := domain.CategoryCascadePolicy{Mode: domain.CategoryCascadeSuspend, Restore: true}
+	filter := &domain.StoreFilter{CategoryID: "id", IncludeDescendants: true, Status: domain.StoreStatusSuspended}
+
+	testCases := []struct {
+		name        string
+		cascade     domain.CategoryCascadePolicy
+		expectedErr error
+		prepare     func(f fields)
+	}{
+		{
+			name:        "failure_not_admin",
+			expectedErr: domain.ErrForbidden,
+			prepare:     func(f fields) {},
+		},
+		{
+			name:        "failure_find_category_by_id_returns_error",
+			expectedErr: domain.ErrNotFound,
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:        "failure_already_actived",
+			expectedErr: domain.ErrActived,
+			prepare: func(f fields) {
+				category := sample.NewCategory()
+				category.Status = domain.CategoryStatusActive
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(category, nil).Once()
+			},
+		},
+		{
+			name:        "failure_update_category_returns_error",
+			expectedErr: domain.ErrConflict,
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.Anything).Return(domain.ErrConflict).Once()
+			},
+		},
+		{
+			name:        "failure_find_stores_returns_error",
+			cascade:     restore,
+			expectedErr: domain.ErrInternal,
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.storeRepo.On("FindAll", mock.Anything, filter, mock.Anything, mock.Anything, 100, 1).Return(nil, int64(0), domain.ErrInternal).Once()
+			},
+		},
+		{
+			name: "success_without_cascade",
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.Category) bool {
+					return c.Status == domain.CategoryStatusActive
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:    "success_without_restore",
+			cascade: domain.CategoryCascadePolicy{Mode: domain.CategoryCascadeSuspend},
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:    "success_restores_suspended_stores",
+			cascade: restore,
+			prepare: func(f fields) {
+				suspended := suspendedStore("id")
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.storeRepo.On("FindAll", mock.Anything, filter, mock.Anything, (*domain.Cursor)(nil), 100, 1).Return(domain.Stores{suspended}, int64(1), nil).Once()
+				f.historyRepo.On("FindByStoreID", mock.Anything, suspended.ID, 1, 1).Return(suspension(suspended, "category disabled"), int64(1), nil).Once()
+				f.storeRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.Status == domain.StoreStatusActive
+				})).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.MatchedBy(func(c *domain.StoreStatusChange) bool {
+					return c.ActorID == domain.StatusActorSystem && c.Reason == "category activated"
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					return m.Topic == "store.update" && strings.Contains(m.Payload, `"status":{"from":"suspended","to":"active"}`)
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:    "success_restores_the_stores_of_active_sub_categories",
+			cascade: restore,
+			prepare: func(f fields) {
+				child := sample.NewCategory()
+				child.Status = domain.CategoryStatusActive
+				suspended := suspendedStore(child.ID)
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.storeRepo.On("FindAll", mock.Anything, filter, mock.Anything, (*domain.Cursor)(nil), 100, 1).Return(domain.Stores{suspended}, int64(1), nil).Once()
+				f.historyRepo.On("FindByStoreID", mock.Anything, suspended.ID, 1, 1).Return(suspension(suspended, "category disabled"), int64(1), nil).Once()
+				f.categoryRepo.On("FindByID", mock.Anything, child.ID).Return(child, nil).Once()
+				f.storeRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.ID == suspended.ID && s.Status == domain.StoreStatusActive
+				})).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:    "success_leaves_the_stores_of_disabled_sub_categories_suspended",
+			cascade: restore,
+			prepare: func(f fields) {
+				child := sample.NewCategory()
+				suspended := suspendedStore(child.ID)
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.storeRepo.On("FindAll", mock.Anything, filter, mock.Anything, (*domain.Cursor)(nil), 100, 1).Return(domain.Stores{suspended}, int64(1), nil).Once()
+				f.historyRepo.On("FindByStoreID", mock.Anything, suspended.ID, 1, 1).Return(suspension(suspended, "category disabled"), int64(1), nil).Once()
+				f.categoryRepo.On("FindByID", mock.Anything, child.ID).Return(child, nil).Once()
+			},
+		},
+		{
+			name:    "success_leaves_stores_suspended_for_another_reason",
+			cascade: restore,
+			prepare: func(f fields) {
+				suspended := suspendedStore("id")
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.storeRepo.On("FindAll", mock.Anything, filter, mock.Anything, (*domain.Cursor)(nil), 100, 1).Return(domain.Stores{suspended}, int64(1), nil).Once()
+				f.historyRepo.On("FindByStoreID", mock.Anything, suspended.ID, 1, 1).Return(suspension(suspended, "unpaid fees"), int64(1), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryRepo := new(mocks.CategoryRepository)
+			storeRepo := new(mocks.StoreRepository)
+			historyRepo := new(mocks.StoreStatusHistoryRepository)
+			outboxRepo := new(mocks.OutboxRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(fields{categoryRepo, storeRepo, historyRepo, outboxRepo})
+			u := usecases.NewCategoryUsecase(categoryRepo, storeRepo, historyRepo, outboxRepo, txManager, time.Second*2)
+			u.UpdateStoreTopic = "store.update"
+			if tc.cascade.Mode != "" {
+				u.Cascade = tc.cascade
+			}
+			ctx := adminContext()
+			if tc.name == "failure_not_admin" {
+				ctx = userContext("user_id")
+			}
+			err := u.Activate(ctx, "id")
+			assert.ErrorIs(t, err, tc.expectedErr)
+			categoryRepo.AssertExpectations(t)
+			storeRepo.AssertExpectations(t)
+			historyRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryUsecase_Disable(t *testing.T) {
+	type fields struct {
+		categoryRepo *mocks.CategoryRepository
+		storeRepo    *mocks.StoreRepository
+		historyRepo  *mocks.StoreStatusHistoryRepository
+		outboxRepo   *mocks.OutboxRepository
+	}
+	suspend := domain.CategoryCascadePolicy{Mode: domain.CategoryCascadeSuspend}
+	filter := &domain.StoreFilter{CategoryID: "id", IncludeDescendants: true, Status: domain.StoreStatusActive}
+	activeStores := func(n int) (stores domain.Stores) {
+		for i := 0; i < n; i++ {
+			store := sample.NewStore()
+			store.Status = domain.StoreStatusActive
+			stores = append(stores, store)
+		}
+		return
+	}
+
+	testCases := []struct {
+		name        string
+		cascade     domain.CategoryCascadePolicy
+		expectedErr error
+		prepare     func(f fields)
+	}{
+		{
+			name:        "failure_not_admin",
+			expectedErr: domain.ErrForbidden,
+			prepare:     func(f fields) {},
+		},
+		{
+			name:        "failure_find_category_by_id_returns_error",
+			expectedErr: domain.ErrNotFound,
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:        "failure_already_disabled",
+			expectedErr: domain.ErrInactived,
+			prepare: func(f fields) {
+				category := sample.NewCategory()
+				category.Status = domain.CategoryStatusDisable
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(category, nil).Once()
+			},
+		},
+		{
+			name:        "failure_update_store_returns_error",
+			cascade:     suspend,
+			expectedErr: domain.ErrConflict,
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.storeRepo.On("FindAll", mock.Anything, filter, mock.Anything, mock.Anything, 100, 1).Return(activeStores(1), int64(1), nil).Once()
+				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(domain.ErrConflict).Once()
+			},
+		},
+		{
+			name:        "failure_store_outbox_message_returns_error",
+			cascade:     suspend,
+			expectedErr: domain.ErrInternal,
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.storeRepo.On("FindAll", mock.Anything, filter, mock.Anything, mock.Anything, 100, 1).Return(activeStores(1), int64(1), nil).Once()
+				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(domain.ErrInternal).Once()
+			},
+		},
+		{
+			name: "success_without_cascade",
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.MatchedBy(func(c *domain.Category) bool {
+					return c.Status == domain.CategoryStatusDisable
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:    "success_suspends_the_stores_of_sub_categories",
+			cascade: suspend,
+			prepare: func(f fields) {
+				child := sample.NewCategory()
+				stores := activeStores(1)
+				stores[0].CategoryID = child.ID
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.storeRepo.On("FindAll", mock.Anything, filter, mock.Anything, (*domain.Cursor)(nil), 100, 1).Return(stores, int64(1), nil).Once()
+				f.storeRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.CategoryID == child.ID && s.Status == domain.StoreStatusSuspended
+				})).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:    "success_suspends_active_stores_in_batches",
+			cascade: suspend,
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				f.categoryRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				first := activeStores(100)
+				f.storeRepo.On("FindAll", mock.Anything, filter, mock.Anything, (*domain.Cursor)(nil), 100, 1).Return(first, int64(102), nil).Once()
+				f.storeRepo.On("FindAll", mock.Anything, filter, mock.Anything, mock.MatchedBy(func(c *domain.Cursor) bool {
+					return c != nil && c.ID == first[99].ID && !c.Backward
+				}), 100, 1).Return(activeStores(2), int64(2), nil).Once()
+				f.storeRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.Status == domain.StoreStatusSuspended
+				})).Return(nil).Times(102)
+				f.historyRepo.On("Store", mock.Anything, mock.MatchedBy(func(c *domain.StoreStatusChange) bool {
+					return c.ActorID == domain.StatusActorSystem && c.Reason == "category disabled"
+				})).Return(nil).Times(102)
+				f.outboxRepo.On("Store", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					return m.Topic == "store.update" && strings.Contains(m.Payload, `"status":{"from":"active","to":"suspended"}`)
+				})).Return(nil).Times(102)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryRepo := new(mocks.CategoryRepository)
+			storeRepo := new(mocks.StoreRepository)
+			historyRepo := new(mocks.StoreStatusHistoryRepository)
+			outboxRepo := new(mocks.OutboxRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(fields{categoryRepo, storeRepo, historyRepo, outboxRepo})
+			u := usecases.NewCategoryUsecase(categoryRepo, storeRepo, historyRepo, outboxRepo, txManager, time.Second*2)
+			u.UpdateStoreTopic = "store.update"
+			if tc.cascade.Mode != "" {
+				u.Cascade = tc.cascade
+			}
+			ctx := adminContext()
+			if tc.name == "failure_not_admin" {
+				ctx = userContext("user_id")
+			}
+			err := u.Disable(ctx, "id")
+			assert.ErrorIs(t, err, tc.expectedErr)
+			categoryRepo.AssertExpectations(t)
+			storeRepo.AssertExpectations(t)
+			historyRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_CategoryUsecase_Delete(t *testing.T) {
+	testCases := []struct {
+		name        string
+		expectedErr error
+		prepare     func(categoryRepo *mocks.CategoryRepository, storeRepo *mocks.StoreRepository)
+	}{
+		{
+			name:        "failure_not_admin",
+			expectedErr: domain.ErrForbidden,
+			prepare:     func(categoryRepo *mocks.CategoryRepository, storeRepo *mocks.StoreRepository) {},
+		},
+		{
+			name:        "failure_find_category_by_id_returns_error",
+			expectedErr: domain.ErrNotFound,
+			prepare: func(categoryRepo *mocks.CategoryRepository, storeRepo *mocks.StoreRepository) {
+				categoryRepo.On("FindByID", mock.Anything, "id").Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:        "failure_category_has_children",
+			expectedErr: domain.ErrInUse,
+			prepare: func(categoryRepo *mocks.CategoryRepository, storeRepo *mocks.StoreRepository) {
+				categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				categoryRepo.On("CountChildren", mock.Anything, "id").Return(int64(2), nil).Once()
+			},
+		},
+		{
+			name:        "failure_category_has_stores",
+			expectedErr: domain.ErrInUse,
+			prepare: func(categoryRepo *mocks.CategoryRepository, storeRepo *mocks.StoreRepository) {
+				categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				categoryRepo.On("CountChildren", mock.Anything, "id").Return(int64(0), nil).Once()
+				storeRepo.On("FindAll", mock.Anything, &domain.StoreFilter{CategoryID: "id", IncludeDeleted: true}, mock.Anything, mock.Anything, 1, 1).Return(domain.Stores{sample.NewStore()}, int64(3), nil).Once()
+			},
+		},
+		{
+			name:        "failure_delete_returns_error",
+			expectedErr: errors.New("Unexpected Error"),
+			prepare: func(categoryRepo *mocks.CategoryRepository, storeRepo *mocks.StoreRepository) {
+				categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				categoryRepo.On("CountChildren", mock.Anything, "id").Return(int64(0), nil).Once()
+				storeRepo.On("FindAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything, 1, 1).Return(domain.Stores{}, int64(0), nil).Once()
+				categoryRepo.On("Delete", mock.Anything, "id").Return(errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name: "success",
+			prepare: func(categoryRepo *mocks.CategoryRepository, storeRepo *mocks.StoreRepository) {
+				categoryRepo.On("FindByID", mock.Anything, "id").Return(sample.NewCategory(), nil).Once()
+				categoryRepo.On("CountChildren", mock.Anything, "id").Return(int64(0), nil).Once()
+				storeRepo.On("FindAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything, 1, 1).Return(domain.Stores{}, int64(0), nil).Once()
+				categoryRepo.On("Delete", mock.Anything, "id").Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			categoryRepo := new(mocks.CategoryRepository)
+			storeRepo := new(mocks.StoreRepository)
+			tc.prepare(categoryRepo, storeRepo)
+			u := usecases.NewCategoryUsecase(categoryRepo, storeRepo, nil, nil, nil, time.Second*2)
+			ctx := adminContext()
+			if tc.name == "failure_not_admin" {
+				ctx = userContext("user_id")
+			}
+			err := u.Delete(ctx, "id")
+			if tc.expectedErr != nil {
+				assert.EqualError(t, err, tc.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			categoryRepo.AssertExpectations(t)
+			storeRepo.AssertExpectations(t)
+		})
+	}
+}
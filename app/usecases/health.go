@@ -0,0 +1,97 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+)
+
+type HealthUsecase struct {
+	mu      sync.RWMutex
+	checks  map[string]domain.HealthCheck
+	timeout time.Duration
+}
+
+func NewHealthUsecase(timeout time.Duration) *HealthUsecase {
+	return &HealthUsecase{
+		checks:  make(map[string]domain.HealthCheck),
+		timeout: timeout,
+	}
+}
+
+// Register adds check to the readiness checks, reported under name
+func (u *HealthUsecase) Register(name string, check domain.HealthCheck) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.checks[name] = check
+}
+
+// Readiness runs every check concurrently. A check still running after the
+// timeout is reported down.
+func (u *HealthUsecase) Readiness(c context.Context) *domain.HealthReport {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	u.mu.RLock()
+	checks := make(map[string]domain.HealthCheck, len(u.checks))
+	for name, check := range u.checks {
+		checks[name] = check
+	}
+	u.mu.RUnlock()
+
+	report := &domain.HealthReport{
+		Status:       domain.HealthStatusUp,
+		Dependencies: make(map[string]domain.DependencyHealth, len(checks)),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check domain.HealthCheck) {
+			defer wg.Done()
+
+			health := runCheck(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Dependencies[name] = health
+			if health.Status != domain.HealthStatusUp {
+				report.Status = domain.HealthStatusDown
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+// runCheck runs check, giving up once ctx is done
+func runCheck(ctx context.Context, check domain.HealthCheck) domain.DependencyHealth {
+	start := time.Now()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	health := domain.DependencyHealth{
+		Status:  domain.HealthStatusUp,
+		Latency: time.Since(start).String(),
+	}
+	if err != nil {
+		health.Status = domain.HealthStatusDown
+		health.Error = err.Error()
+	}
+	return health
+}
@@ -0,0 +1,74 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/usecases"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_HealthUsecase_Readiness(t *testing.T) {
+	up := func(ctx context.Context) error { return nil }
+	down := func(ctx context.Context) error { return errors.New("connection refused") }
+	hang := func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}
+
+	testCases := []struct {
+		name   string
+		checks map[string]domain.HealthCheck
+		status string
+		errors map[string]string
+	}{
+		{
+			name:   "no_checks",
+			status: domain.HealthStatusUp,
+		},
+		{
+			name:   "every_check_up",
+			checks: map[string]domain.HealthCheck{"postgres": up, "kafka": up},
+			status: domain.HealthStatusUp,
+		},
+		{
+			name:   "failing_check",
+			checks: map[string]domain.HealthCheck{"postgres": up, "kafka": down},
+			status: domain.HealthStatusDown,
+			errors: map[string]string{"kafka": "connection refused"},
+		},
+		{
+			name:   "check_timed_out",
+			checks: map[string]domain.HealthCheck{"postgres": hang},
+			status: domain.HealthStatusDown,
+			errors: map[string]string{"postgres": context.DeadlineExceeded.Error()},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			u := usecases.NewHealthUsecase(50 * time.Millisecond)
+			for name, check := range tc.checks {
+				u.Register(name, check)
+			}
+
+			report := u.Readiness(context.TODO())
+
+			assert.Equal(t, tc.status, report.Status)
+			assert.Len(t, report.Dependencies, len(tc.checks))
+			for name, dependency := range report.Dependencies {
+				assert.Equal(t, tc.errors[name], dependency.Error)
+				if tc.errors[name] == "" {
+					assert.Equal(t, domain.HealthStatusUp, dependency.Status)
+				} else {
+					assert.Equal(t, domain.HealthStatusDown, dependency.Status)
+				}
+			}
+		})
+	}
+}
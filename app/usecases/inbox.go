@@ -0,0 +1,58 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/opentracing/opentracing-go"
+)
+
+type InboxUsecase struct {
+	inboxRepo domain.InboxRepository
+	txManager domain.TxManager
+	timeout   time.Duration
+}
+
+func NewInboxUsecase(
+	inboxRepo domain.InboxRepository,
+	txManager domain.TxManager,
+	timeout time.Duration,
+) *InboxUsecase {
+	return &InboxUsecase{
+		inboxRepo: inboxRepo,
+		txManager: txManager,
+		timeout:   timeout,
+	}
+}
+
+// Process runs handle for the message with the idempotency key, unless it
+// was already processed. It reports whether handle ran. The key is recorded
+// in the same transaction as the changes made by handle, so a failed handle
+// leaves the message to be processed again.
+func (u *InboxUsecase) Process(c context.Context, key, topic string, handle func(ctx context.Context) error) (processed bool, err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "InboxUsecase.Process")
+	defer span.Finish()
+
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		exists, err := u.inboxRepo.Exists(ctx, key)
+		if err != nil || exists {
+			return err
+		}
+
+		err = handle(ctx)
+		if err != nil {
+			return err
+		}
+
+		processed = true
+		return u.inboxRepo.Store(ctx, domain.NewInboxMessage(key, topic))
+	})
+	if err != nil {
+		processed = false
+	}
+	return
+}
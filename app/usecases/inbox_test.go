@@ -0,0 +1,96 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/usecases"
+	"github.com/EdlanioJ/kbu-store/app/utils/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_InboxUsecase_Process(t *testing.T) {
+	key := "store.catetory.create:key"
+	topic := "store.catetory.create"
+	testCases := []struct {
+		name        string
+		handleErr   error
+		handled     bool
+		processed   bool
+		expectedErr bool
+		prepare     func(inboxRepo *mocks.InboxRepository)
+	}{
+		{
+			name:        "failure_exists_returns_error",
+			expectedErr: true,
+			prepare: func(inboxRepo *mocks.InboxRepository) {
+				inboxRepo.On("Exists", mock.Anything, key).Return(false, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name: "success_duplicate_is_skipped",
+			prepare: func(inboxRepo *mocks.InboxRepository) {
+				inboxRepo.On("Exists", mock.Anything, key).Return(true, nil).Once()
+			},
+		},
+		{
+			name:        "failure_handle_returns_error",
+			handleErr:   errors.New("Unexpected Error"),
+			handled:     true,
+			expectedErr: true,
+			prepare: func(inboxRepo *mocks.InboxRepository) {
+				inboxRepo.On("Exists", mock.Anything, key).Return(false, nil).Once()
+			},
+		},
+		{
+			name:        "failure_store_returns_error",
+			handled:     true,
+			expectedErr: true,
+			prepare: func(inboxRepo *mocks.InboxRepository) {
+				inboxRepo.On("Exists", mock.Anything, key).Return(false, nil).Once()
+				inboxRepo.On("Store", mock.Anything, mock.AnythingOfType("*domain.InboxMessage")).Return(errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:      "success",
+			handled:   true,
+			processed: true,
+			prepare: func(inboxRepo *mocks.InboxRepository) {
+				inboxRepo.On("Exists", mock.Anything, key).Return(false, nil).Once()
+				inboxRepo.On("Store", mock.Anything, mock.MatchedBy(func(m *domain.InboxMessage) bool {
+					return m.ID == key && m.Topic == topic
+				})).Return(nil).Once()
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			inboxRepo := new(mocks.InboxRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(inboxRepo)
+			u := usecases.NewInboxUsecase(inboxRepo, txManager, time.Second*2)
+
+			handled := false
+			processed, err := u.Process(context.TODO(), key, topic, func(ctx context.Context) error {
+				handled = true
+				return tc.handleErr
+			})
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.handled, handled)
+			assert.Equal(t, tc.processed, processed)
+			inboxRepo.AssertExpectations(t)
+		})
+	}
+}
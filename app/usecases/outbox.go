@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/interfaces"
+	"github.com/opentracing/opentracing-go"
+)
+
+type OutboxUsecase struct {
+	outboxRepo  domain.OutboxRepository
+	txManager   domain.TxManager
+	msgProducer interfaces.MessengerProducer
+	timeout     time.Duration
+	BatchSize   int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+func NewOutboxUsecase(
+	outboxRepo domain.OutboxRepository,
+	txManager domain.TxManager,
+	msgProducer interfaces.MessengerProducer,
+	timeout time.Duration,
+) *OutboxUsecase {
+	return &OutboxUsecase{
+		outboxRepo:  outboxRepo,
+		txManager:   txManager,
+		msgProducer: msgProducer,
+		timeout:     timeout,
+		BatchSize:   100,
+		Backoff:     time.Second,
+		MaxBackoff:  5 * time.Minute,
+	}
+}
+
+// Relay publishes the oldest pending outbox messages, in the order they
+// were stored, and returns how many were delivered. A message waiting for
+// its retry, or leased to another relay, holds back every message after it,
+// and the relay stops at the first failed publish, so that events are never
+// delivered out of order. Messages are delivered at least once: a relay
+// stopped between a publish and its update leaves the message to be
+// published again once its lease is over.
+func (u *OutboxUsecase) Relay(c context.Context) (delivered int, err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "OutboxUsecase.Relay")
+	defer span.Finish()
+
+	messages, err := u.claim(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, msg := range messages {
+		publishErr := u.msgProducer.Publish(ctx, msg.Payload, msg.Topic)
+		if publishErr != nil {
+			msg.Fail(publishErr, u.backoff(msg.Attempts))
+		} else {
+			msg.Deliver()
+		}
+
+		err = u.outboxRepo.Update(ctx, msg)
+		if err != nil {
+			return
+		}
+
+		if publishErr != nil {
+			return delivered, publishErr
+		}
+		delivered++
+	}
+
+	return
+}
+
+// claim leases the due messages at the head of the outbox for the relay
+// timeout, in a transaction of its own, so that they are published without
+// holding a lock or a connection, while other relays leave them alone
+func (u *OutboxUsecase) claim(ctx context.Context) (messages []*domain.OutboxMessage, err error) {
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		pending, err := u.outboxRepo.FindPending(ctx, u.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, msg := range pending {
+			if !msg.Due(now) {
+				break
+			}
+
+			msg.Lease(now.Add(u.timeout))
+			err = u.outboxRepo.Update(ctx, msg)
+			if err != nil {
+				return err
+			}
+			messages = append(messages, msg)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// backoff doubles the retry delay on every failed attempt, up to MaxBackoff
+func (u *OutboxUsecase) backoff(attempts int) time.Duration {
+	d := u.Backoff << uint(attempts)
+	if d <= 0 || d > u.MaxBackoff {
+		return u.MaxBackoff
+	}
+	return d
+}
@@ -0,0 +1,148 @@
+package usecases_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	"github.com/EdlanioJ/kbu-store/app/usecases"
+	"github.com/EdlanioJ/kbu-store/app/utils/mocks"
+	"github.com/EdlanioJ/kbu-store/app/utils/sample"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_OutboxUsecase_Relay(t *testing.T) {
+	type fields struct {
+		outboxRepo  *mocks.OutboxRepository
+		msgProducer *mocks.MessengerProducer
+	}
+	testCases := []struct {
+		name        string
+		delivered   int
+		expectedErr bool
+		prepare     func(f fields)
+	}{
+		{
+			name:        "failure_find_pending_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				f.outboxRepo.On("FindPending", mock.Anything, 100).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:        "failure_lease_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				msg := sample.NewOutboxMessage()
+				f.outboxRepo.On("FindPending", mock.Anything, 100).Return([]*domain.OutboxMessage{msg}, nil).Once()
+				f.outboxRepo.On("Update", mock.Anything, msg).Return(errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name: "head_waiting_for_retry_holds_back_the_batch",
+			prepare: func(f fields) {
+				head := sample.NewOutboxMessage()
+				head.Fail(errors.New("broker unavailable"), time.Minute)
+				next := sample.NewOutboxMessage()
+				f.outboxRepo.On("FindPending", mock.Anything, 100).Return([]*domain.OutboxMessage{head, next}, nil).Once()
+			},
+		},
+		{
+			name: "head_leased_to_another_relay_holds_back_the_batch",
+			prepare: func(f fields) {
+				head := sample.NewOutboxMessage()
+				head.Lease(time.Now().Add(time.Minute))
+				next := sample.NewOutboxMessage()
+				f.outboxRepo.On("FindPending", mock.Anything, 100).Return([]*domain.OutboxMessage{head, next}, nil).Once()
+			},
+		},
+		{
+			name:      "message_waiting_for_retry_holds_back_the_next_ones",
+			delivered: 1,
+			prepare: func(f fields) {
+				head := sample.NewOutboxMessage()
+				waiting := sample.NewOutboxMessage()
+				waiting.Fail(errors.New("broker unavailable"), time.Minute)
+				next := sample.NewOutboxMessage()
+				f.outboxRepo.On("FindPending", mock.Anything, 100).Return([]*domain.OutboxMessage{head, waiting, next}, nil).Once()
+				f.outboxRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					return m.ID == head.ID && m.LockedUntil != nil
+				})).Return(nil).Once()
+				f.msgProducer.On("Publish", mock.Anything, head.Payload, head.Topic).Return(nil).Once()
+				f.outboxRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					return m.ID == head.ID && m.DeliveredAt != nil && m.LockedUntil == nil
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:        "failure_publish_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				msg := sample.NewOutboxMessage()
+				next := sample.NewOutboxMessage()
+				f.outboxRepo.On("FindPending", mock.Anything, 100).Return([]*domain.OutboxMessage{msg, next}, nil).Once()
+				f.outboxRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					return m.LockedUntil != nil
+				})).Return(nil).Twice()
+				f.msgProducer.On("Publish", mock.Anything, msg.Payload, msg.Topic).Return(errors.New("Unexpected Error")).Once()
+				f.outboxRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					return m.ID == msg.ID && m.Attempts == 1 && m.DeliveredAt == nil && m.LockedUntil == nil
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:        "failure_update_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				msg := sample.NewOutboxMessage()
+				f.outboxRepo.On("FindPending", mock.Anything, 100).Return([]*domain.OutboxMessage{msg}, nil).Once()
+				f.outboxRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					return m.LockedUntil != nil
+				})).Return(nil).Once()
+				f.msgProducer.On("Publish", mock.Anything, msg.Payload, msg.Topic).Return(nil).Once()
+				f.outboxRepo.On("Update", mock.Anything, msg).Return(errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:      "success",
+			delivered: 2,
+			prepare: func(f fields) {
+				msg := sample.NewOutboxMessage()
+				next := sample.NewOutboxMessage()
+				f.outboxRepo.On("FindPending", mock.Anything, 100).Return([]*domain.OutboxMessage{msg, next}, nil).Once()
+				f.outboxRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					return m.LockedUntil != nil
+				})).Return(nil).Twice()
+				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil).Twice()
+				f.outboxRepo.On("Update", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					return m.DeliveredAt != nil
+				})).Return(nil).Twice()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			outboxRepo := new(mocks.OutboxRepository)
+			msgProducer := new(mocks.MessengerProducer)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(fields{outboxRepo, msgProducer})
+			u := usecases.NewOutboxUsecase(outboxRepo, txManager, msgProducer, time.Second*2)
+			delivered, err := u.Relay(context.TODO())
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.delivered, delivered)
+			outboxRepo.AssertExpectations(t)
+			msgProducer.AssertExpectations(t)
+		})
+	}
+}
@@ -1,42 +1,145 @@
 package usecases
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
 	"time"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
-	"github.com/EdlanioJ/kbu-store/app/interfaces"
+	"github.com/go-playground/validator/v10"
 	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+	"github.com/opentracing/opentracing-go/log"
+	uuid "github.com/satori/go.uuid"
 	"github.com/shopspring/decimal"
 )
 
 type StoreUsecase struct {
-	storeRepo        domain.StoreRepository
-	accountRepo      domain.AccountRepository
-	categoryRepo     domain.CategoryRepository
-	msgProducer      interfaces.MessengerProducer
-	timeout          time.Duration
-	NewStoreTopic    string
-	UpdateStoreTopic string
-	DeleteStoreTopic string
+	storeRepo           domain.StoreRepository
+	accountRepo         domain.AccountRepository
+	ledgerRepo          domain.LedgerRepository
+	historyRepo         domain.StoreStatusHistoryRepository
+	hoursRepo           domain.StoreHoursRepository
+	categoryRepo        domain.CategoryRepository
+	outboxRepo          domain.OutboxRepository
+	txManager           domain.TxManager
+	blobStorage         domain.BlobStorage
+	imageProcessor      domain.ImageProcessor
+	validate            *validator.Validate
+	timeout             time.Duration
+	NewStoreTopic       string
+	UpdateStoreTopic    string
+	DeleteStoreTopic    string
+	BalanceUpdatedTopic string
+	CursorSecret        []byte
+	ExpiryBatchSize     int
+	ReleaseBackoff      time.Duration
+	MaxReleaseBackoff   time.Duration
+	PurgeBatchSize      int
+	ExportBatchSize     int
+	MaxImageSize        int64
+	UploadTimeout       time.Duration
 }
 
 func NewStoreUsecase(
 	storeRepo domain.StoreRepository,
 	accountRepo domain.AccountRepository,
+	ledgerRepo domain.LedgerRepository,
+	historyRepo domain.StoreStatusHistoryRepository,
+	hoursRepo domain.StoreHoursRepository,
 	categoryRepo domain.CategoryRepository,
-	msgProducer interfaces.MessengerProducer,
+	outboxRepo domain.OutboxRepository,
+	txManager domain.TxManager,
+	blobStorage domain.BlobStorage,
+	imageProcessor domain.ImageProcessor,
 	timeout time.Duration,
 ) *StoreUsecase {
 	return &StoreUsecase{
-		storeRepo:    storeRepo,
-		accountRepo:  accountRepo,
-		categoryRepo: categoryRepo,
-		msgProducer:  msgProducer,
-		timeout:      timeout,
+		storeRepo:         storeRepo,
+		accountRepo:       accountRepo,
+		ledgerRepo:        ledgerRepo,
+		historyRepo:       historyRepo,
+		hoursRepo:         hoursRepo,
+		categoryRepo:      categoryRepo,
+		outboxRepo:        outboxRepo,
+		txManager:         txManager,
+		blobStorage:       blobStorage,
+		imageProcessor:    imageProcessor,
+		validate:          validator.New(),
+		timeout:           timeout,
+		ExpiryBatchSize:   100,
+		ReleaseBackoff:    time.Minute,
+		MaxReleaseBackoff: time.Hour,
+		PurgeBatchSize:    100,
+		ExportBatchSize:   500,
+		MaxImageSize:      domain.DefaultMaxImageSize,
+		UploadTimeout:     30 * time.Second,
 	}
 }
 
+// publish records the store snapshot in the outbox, to be relayed to topic
+func (u *StoreUsecase) publish(ctx context.Context, topic string, store *domain.Store) error {
+	return u.outboxRepo.Store(ctx, domain.NewOutboxMessage(topic, store.ToJson()))
+}
+
+// withHours loads the opening hours of stores, and whether they are open now
+func (u *StoreUsecase) withHours(ctx context.Context, stores ...*domain.Store) error {
+	if len(stores) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(stores))
+	for _, store := range stores {
+		ids = append(ids, store.ID)
+	}
+
+	list, err := u.hoursRepo.FindByStoreIDs(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	hours := make(map[string]*domain.StoreHours, len(list))
+	for _, h := range list {
+		hours[h.StoreID] = h
+	}
+
+	now := time.Now()
+	for _, store := range stores {
+		store.SetHours(hours[store.ID], now)
+	}
+	return nil
+}
+
+// authorizeOwner fails unless the caller of ctx owns store or is an admin
+func authorizeOwner(ctx context.Context, store *domain.Store) error {
+	claims, ok := domain.ClaimsFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+	if !claims.CanManage(store) {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// authorizeAdmin fails unless the caller of ctx is an admin
+func authorizeAdmin(ctx context.Context) error {
+	claims, ok := domain.ClaimsFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+	if !claims.IsAdmin() {
+		return domain.ErrForbidden
+	}
+	return nil
+}
+
+// Store creates a store owned by the caller of c
 func (u *StoreUsecase) Store(c context.Context, createParam *domain.CreateStoreRequest) (err error) {
 	ctx, cancel := context.WithTimeout(c, u.timeout)
 	defer cancel()
@@ -44,6 +147,12 @@ func (u *StoreUsecase) Store(c context.Context, createParam *domain.CreateStoreR
 	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecase.Store")
 	defer span.Finish()
 
+	claims, ok := domain.ClaimsFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+	createParam.UserID = claims.UserID
+
 	category, err := u.categoryRepo.FindByID(ctx, createParam.CategoryID)
 	if err != nil {
 		return err
@@ -53,23 +162,36 @@ func (u *StoreUsecase) Store(c context.Context, createParam *domain.CreateStoreR
 		return domain.ErrNotFound
 	}
 
+	return u.create(ctx, claims, createParam)
+}
+
+// create stores a new store for createParam, with its account, on behalf of
+// the caller holding claims
+func (u *StoreUsecase) create(ctx context.Context, claims *domain.Claims, createParam *domain.CreateStoreRequest) error {
 	account := domain.NewAccount()
 	account.Balance = decimal.NewFromFloat(0)
 
-	err = u.accountRepo.Store(ctx, account)
-	if err != nil {
-		return err
-	}
-
 	store := domain.NewStore(createParam)
+	store.AccountID = account.ID
 
-	err = u.storeRepo.Create(ctx, store)
-	if err != nil {
-		return err
-	}
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := u.accountRepo.Store(ctx, account)
+		if err != nil {
+			return err
+		}
 
-	storeJson := store.ToJson()
-	return u.msgProducer.Publish(ctx, string(storeJson), u.NewStoreTopic)
+		err = u.storeRepo.Create(ctx, store)
+		if err != nil {
+			return err
+		}
+
+		err = u.historyRepo.Store(ctx, domain.NewStoreStatusChange(store, "", claims.UserID, "", nil))
+		if err != nil {
+			return err
+		}
+
+		return u.publish(ctx, u.NewStoreTopic, store)
+	})
 }
 
 func (u *StoreUsecase) Get(c context.Context, id string) (res *domain.Store, err error) {
@@ -84,10 +206,16 @@ func (u *StoreUsecase) Get(c context.Context, id string) (res *domain.Store, err
 		return
 	}
 
+	err = u.withHours(ctx, res)
+	if err != nil {
+		return nil, err
+	}
 	return
 }
 
-func (u *StoreUsecase) Index(c context.Context, sort string, limit, page int) (res domain.Stores, total int64, err error) {
+// Index returns a page of the stores matching filter. With a cursor, the page
+// continues the listing from it and page is ignored.
+func (u *StoreUsecase) Index(c context.Context, filter *domain.StoreFilter, sort, cursor string, limit, page int) (res *domain.StorePage, err error) {
 	ctx, cancel := context.WithTimeout(c, u.timeout)
 	defer cancel()
 
@@ -98,97 +226,378 @@ func (u *StoreUsecase) Index(c context.Context, sort string, limit, page int) (r
 		limit = 10
 	}
 	if sort == "" {
-		sort = "created_at DESC"
+		sort = "-created_at"
 	}
 	if page <= 0 {
 		page = 1
 	}
 
-	res, total, err = u.storeRepo.FindAll(ctx, sort, limit, page)
+	spec, err := domain.ParseSort(sort, domain.StoreSortColumns)
 	if err != nil {
-		total = 0
 		return
 	}
 
+	var after *domain.Cursor
+	if cursor != "" {
+		after, err = domain.DecodeStoreCursor(cursor, spec, u.CursorSecret)
+		if err != nil {
+			return
+		}
+	}
+
+	if after == nil {
+		stores, total, err := u.storeRepo.FindAll(ctx, filter, spec, nil, limit, page)
+		if err != nil {
+			return nil, err
+		}
+		if err = u.withHours(ctx, stores...); err != nil {
+			return nil, err
+		}
+
+		hasNext := int64(page*limit) < total
+		return u.newStorePage(stores, total, spec, hasNext, page > 1), nil
+	}
+
+	// one more store than asked tells whether the listing goes on
+	stores, total, err := u.storeRepo.FindAll(ctx, filter, spec, after, limit+1, page)
+	if err != nil {
+		return nil, err
+	}
+
+	hasMore := len(stores) > limit
+	if hasMore && after.Backward {
+		stores = stores[1:]
+	} else if hasMore {
+		stores = stores[:limit]
+	}
+	if err = u.withHours(ctx, stores...); err != nil {
+		return nil, err
+	}
+
+	if after.Backward {
+		return u.newStorePage(stores, total, spec, true, hasMore), nil
+	}
+	return u.newStorePage(stores, total, spec, hasMore, true), nil
+}
+
+// Nearby returns the stores within the requested radius, nearest first
+func (u *StoreUsecase) Nearby(c context.Context, param *domain.NearbyStoreRequest) (res domain.NearbyStores, err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecãse.Nearby")
+	defer span.Finish()
+
+	limit := param.Limit
+	if limit <= 0 {
+		limit = 20
+	}
+
+	position := domain.Position{Lat: param.Lat, Lng: param.Lng}
+	res, err = u.storeRepo.FindNearby(ctx, position, param.RadiusKm, param.Filter, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	stores := make([]*domain.Store, 0, len(res))
+	for _, nearby := range res {
+		stores = append(stores, nearby.Store)
+	}
+	err = u.withHours(ctx, stores...)
+	if err != nil {
+		return nil, err
+	}
 	return
 }
 
-func (u *StoreUsecase) Block(c context.Context, id string) (err error) {
+// Search returns a page of the stores matching the search, most relevant first
+func (u *StoreUsecase) Search(c context.Context, param *domain.SearchStoreRequest) (res *domain.StoreSearchPage, err error) {
 	ctx, cancel := context.WithTimeout(c, u.timeout)
 	defer cancel()
 
-	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecãse.Block")
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecãse.Search")
 	defer span.Finish()
 
-	store, err := u.storeRepo.FindByID(ctx, id)
+	limit := param.Limit
+	if limit <= 0 {
+		limit = 10
+	}
+	page := param.Page
+	if page <= 0 {
+		page = 1
+	}
+
+	results, total, err := u.storeRepo.Search(ctx, param.Query, param.Filter, limit, page)
+	if err != nil {
+		return nil, err
+	}
+
+	stores := make([]*domain.Store, 0, len(results))
+	for _, result := range results {
+		stores = append(stores, result.Store)
+	}
+	err = u.withHours(ctx, stores...)
+	if err != nil {
+		return nil, err
+	}
+
+	res = &domain.StoreSearchPage{
+		Results: results,
+		Total:   total,
+	}
+	return
+}
+
+func (u *StoreUsecase) newStorePage(stores domain.Stores, total int64, sort domain.SortSpec, hasNext, hasPrev bool) *domain.StorePage {
+	page := &domain.StorePage{
+		Stores: stores,
+		Total:  total,
+	}
+	if len(stores) == 0 {
+		return page
+	}
+
+	if hasNext {
+		page.NextCursor = domain.EncodeCursor(domain.NewStoreCursor(stores[len(stores)-1], sort, false), u.CursorSecret)
+	}
+	if hasPrev {
+		page.PrevCursor = domain.EncodeCursor(domain.NewStoreCursor(stores[0], sort, true), u.CursorSecret)
+	}
+	return page
+}
+
+// ChangeStatus moves a store through the lifecycle transition of
+// param.Action, when its actor is the caller
+func (u *StoreUsecase) ChangeStatus(c context.Context, param *domain.StoreStatusRequest) (err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecãse.ChangeStatus")
+	defer span.Finish()
+
+	transition, err := domain.StoreLifecycle.Find(param.Action)
 	if err != nil {
 		return err
 	}
 
-	err = store.Block()
+	err = transition.Check(param, time.Now())
 	if err != nil {
 		return err
 	}
 
-	err = u.storeRepo.Update(ctx, store)
+	store, err := u.storeRepo.FindByID(ctx, param.ID)
 	if err != nil {
 		return err
 	}
 
-	storeJson := store.ToJson()
-	return u.msgProducer.Publish(ctx, string(storeJson), u.UpdateStoreTopic)
+	claims, ok := domain.ClaimsFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+	if !claims.CanApply(transition, store) {
+		return domain.ErrForbidden
+	}
+
+	return u.changeStatus(ctx, store, transition.Action, param.Reason, param.ExpiresAt)
 }
 
-func (u *StoreUsecase) Active(c context.Context, id string) (err error) {
+// Actions returns the transitions the caller may apply to a store in its
+// current status
+func (u *StoreUsecase) Actions(c context.Context, id string) (res domain.StoreTransitions, err error) {
 	ctx, cancel := context.WithTimeout(c, u.timeout)
 	defer cancel()
 
-	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecãse.Activate")
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecãse.Actions")
 	defer span.Finish()
 
 	store, err := u.storeRepo.FindByID(ctx, id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	err = authorizeOwner(ctx, store)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, _ := domain.ClaimsFromContext(ctx)
+	res = make(domain.StoreTransitions, 0)
+	for _, transition := range domain.StoreLifecycle.From(store.Status) {
+		if claims.CanApply(transition, store) {
+			res = append(res, transition)
+		}
 	}
+	return
+}
 
-	err = store.Activate()
+// changeStatus applies action to store and records the transition, made by
+// the caller of ctx, along with the store snapshot
+func (u *StoreUsecase) changeStatus(ctx context.Context, store *domain.Store, action, reason string, expiresAt *time.Time) error {
+	from := store.Status
+	previous, err := u.statusBefore(ctx, store, action)
 	if err != nil {
 		return err
 	}
 
-	err = u.storeRepo.Update(ctx, store)
+	err = store.Lift(action, previous)
 	if err != nil {
 		return err
 	}
 
-	storeJson := store.ToJson()
-	return u.msgProducer.Publish(ctx, string(storeJson), u.UpdateStoreTopic)
+	history := domain.NewStoreStatusChange(store, from, actorID(ctx), reason, expiresAt)
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := u.storeRepo.Update(ctx, store)
+		if err != nil {
+			return err
+		}
+
+		err = u.historyRepo.Store(ctx, history)
+		if err != nil {
+			return err
+		}
+
+		return u.publish(ctx, u.UpdateStoreTopic, store)
+	})
 }
 
-func (u *StoreUsecase) Disable(c context.Context, id string) (err error) {
+// statusBefore returns the status the store had before its last change, when
+// action restores it. A store suspended with its category goes back to
+// active if the category was activated since, as the resume of the category
+// left it out.
+func (u *StoreUsecase) statusBefore(ctx context.Context, store *domain.Store, action string) (string, error) {
+	transition, err := domain.StoreLifecycle.Find(action)
+	if err != nil || !transition.Restores || !transition.Allows(store.Status) {
+		return "", nil
+	}
+
+	history, _, err := u.historyRepo.FindByStoreID(ctx, store.ID, 1, 1)
+	if err != nil {
+		return "", err
+	}
+	if len(history) == 0 || history[0].ToStatus != store.Status {
+		return "", nil
+	}
+
+	previous := history[0].FromStatus
+	if previous != domain.StoreStatusSuspended {
+		return previous, nil
+	}
+
+	category, err := u.categoryRepo.FindByID(ctx, store.CategoryID)
+	if err != nil {
+		return "", err
+	}
+	if category.Status == domain.CategoryStatusActive {
+		return "", nil
+	}
+	return previous, nil
+}
+
+// actorID returns the user id of the caller of ctx, or the system actor when
+// the service acts on its own
+func actorID(ctx context.Context) string {
+	claims, ok := domain.ClaimsFromContext(ctx)
+	if !ok {
+		return domain.StatusActorSystem
+	}
+	return claims.UserID
+}
+
+// StatusHistory returns a page of the status changes of a store, newest
+// first
+func (u *StoreUsecase) StatusHistory(c context.Context, id string, limit, page int) (res domain.StoreStatusHistory, total int64, err error) {
 	ctx, cancel := context.WithTimeout(c, u.timeout)
 	defer cancel()
 
-	span, ctx := opentracing.StartSpanFromContext(ctx, "storeUsecãse.Disable")
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecãse.StatusHistory")
 	defer span.Finish()
 
+	if limit <= 0 {
+		limit = 10
+	}
+	if page <= 0 {
+		page = 1
+	}
+
 	store, err := u.storeRepo.FindByID(ctx, id)
 	if err != nil {
-		return err
+		return
+	}
+
+	err = authorizeOwner(ctx, store)
+	if err != nil {
+		return
+	}
+
+	return u.historyRepo.FindByStoreID(ctx, store.ID, limit, page)
+}
+
+// ReleaseExpired moves back a batch of the stores whose temporary block or
+// disable expired, and returns how many were released. A store failing to be
+// released is skipped, so it does not hold back the next ones, and reported
+// in the returned error; it is left out of the next batches until its retry,
+// after a backoff doubling on every failed attempt.
+func (u *StoreUsecase) ReleaseExpired(c context.Context) (released int, err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecãse.ReleaseExpired")
+	defer span.Finish()
+
+	expired, err := u.historyRepo.FindExpired(ctx, time.Now(), u.ExpiryBatchSize)
+	if err != nil {
+		return
+	}
+
+	var failures []string
+	for _, change := range expired {
+		if ctx.Err() != nil {
+			return released, ctx.Err()
+		}
+
+		err := u.release(ctx, change)
+		if err != nil {
+			ext.LogError(span, err, log.String("store_id", change.StoreID))
+			failures = append(failures, change.StoreID+": "+err.Error())
+
+			change.FailRelease(u.releaseBackoff(change.ReleaseAttempts))
+			if err := u.historyRepo.UpdateRelease(ctx, change); err != nil {
+				ext.LogError(span, err, log.String("store_id", change.StoreID))
+			}
+			continue
+		}
+		released++
+	}
+
+	if len(failures) > 0 {
+		err = fmt.Errorf("%d expired stores not released: %s", len(failures), strings.Join(failures, "; "))
+	}
+	return
+}
+
+// releaseBackoff doubles the delay before the next release on every failed
+// attempt, up to MaxReleaseBackoff
+func (u *StoreUsecase) releaseBackoff(attempts int) time.Duration {
+	d := u.ReleaseBackoff << uint(attempts)
+	if d <= 0 || d > u.MaxReleaseBackoff {
+		return u.MaxReleaseBackoff
 	}
+	return d
+}
 
-	err = store.Disable()
+// release moves back the store of an expired status change
+func (u *StoreUsecase) release(ctx context.Context, change *domain.StoreStatusChange) error {
+	store, err := u.storeRepo.FindByID(ctx, change.StoreID)
 	if err != nil {
 		return err
 	}
 
-	err = u.storeRepo.Update(ctx, store)
+	revert, err := domain.StoreLifecycle.RevertOf(change.ToStatus)
 	if err != nil {
 		return err
 	}
 
-	storeJson := store.ToJson()
-	return u.msgProducer.Publish(ctx, string(storeJson), u.UpdateStoreTopic)
+	return u.changeStatus(ctx, store, revert.Action, change.ToStatus+" expired", nil)
 }
 
 func (u *StoreUsecase) Update(c context.Context, updateParam *domain.UpdateStoreRequest) (err error) {
@@ -198,23 +607,50 @@ func (u *StoreUsecase) Update(c context.Context, updateParam *domain.UpdateStore
 	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecãse.Update")
 	defer span.Finish()
 
-	store := new(domain.Store)
-	store.FromUpdateRequest(updateParam)
-
-	_, err = u.storeRepo.FindByID(ctx, store.ID)
+	store, err := u.storeRepo.FindByID(ctx, updateParam.ID)
 	if err != nil {
 		return err
 	}
 
-	err = u.storeRepo.Update(ctx, store)
+	err = authorizeOwner(ctx, store)
 	if err != nil {
 		return err
 	}
 
-	storeJson := store.ToJson()
-	return u.msgProducer.Publish(ctx, string(storeJson), u.UpdateStoreTopic)
+	if updateParam.Version != 0 && updateParam.Version != store.Version {
+		return domain.ErrConflict
+	}
+
+	changes := store.FromUpdateRequest(updateParam)
+	if len(changes) == 0 {
+		return
+	}
+
+	if _, ok := changes["category_id"]; ok {
+		category, err := u.categoryRepo.FindByID(ctx, store.CategoryID)
+		if err != nil {
+			return err
+		}
+
+		if category.Status != domain.CategoryStatusActive {
+			return domain.ErrNotFound
+		}
+	}
+
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := u.storeRepo.Update(ctx, store)
+		if err != nil {
+			return err
+		}
+
+		event := domain.NewStoreUpdatedEvent(store, changes)
+		return u.outboxRepo.Store(ctx, domain.NewOutboxMessage(u.UpdateStoreTopic, event.ToJson()))
+	})
 }
 
+// Delete marks a store owned by the caller as deleted, and publishes its
+// snapshot to DeleteStoreTopic. The store is left out of the listings and
+// kept until Restore or Purge.
 func (u *StoreUsecase) Delete(c context.Context, id string) (err error) {
 	ctx, cancel := context.WithTimeout(c, u.timeout)
 	defer cancel()
@@ -227,15 +663,569 @@ func (u *StoreUsecase) Delete(c context.Context, id string) (err error) {
 		return
 	}
 
-	err = u.storeRepo.Delete(ctx, id)
+	err = authorizeOwner(ctx, store)
 	if err != nil {
-		return err
+		return
+	}
+
+	store.MarkDeleted(time.Now())
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := u.storeRepo.Update(ctx, store)
+		if err != nil {
+			return err
+		}
+
+		return u.publish(ctx, u.DeleteStoreTopic, store)
+	})
+}
+
+// Restore brings back a deleted store owned by the caller, before it is
+// purged, and publishes its snapshot to NewStoreTopic again
+func (u *StoreUsecase) Restore(c context.Context, id string) (err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecase.Restore")
+	defer span.Finish()
+
+	store, err := u.storeRepo.FindDeletedByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = authorizeOwner(ctx, store)
+	if err != nil {
+		return
+	}
+
+	store.Restore()
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := u.storeRepo.Update(ctx, store)
+		if err != nil {
+			return err
+		}
+
+		return u.publish(ctx, u.NewStoreTopic, store)
+	})
+}
+
+// Purge permanently removes a batch of the stores deleted more than
+// olderThan ago, with their account and images, and returns how many were
+// purged. The ledger of a purged account is kept, closed by an "account
+// purged" entry. A store failing to be purged is left for the next run,
+// without stopping the others.
+func (u *StoreUsecase) Purge(c context.Context, olderThan time.Duration) (purged int, err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecase.Purge")
+	defer span.Finish()
+
+	deleted, err := u.storeRepo.FindDeleted(ctx, time.Now().Add(-olderThan), u.PurgeBatchSize)
+	if err != nil {
+		return
+	}
+
+	var failures []string
+	for _, store := range deleted {
+		if ctx.Err() != nil {
+			return purged, ctx.Err()
+		}
+
+		err := u.purge(ctx, store)
+		if err != nil {
+			ext.LogError(span, err, log.String("store_id", store.ID))
+			failures = append(failures, store.ID+": "+err.Error())
+			continue
+		}
+
+		u.deleteBlobs(ctx, span, store.Images)
+		purged++
+	}
+
+	if len(failures) > 0 {
+		err = fmt.Errorf("%d deleted stores not purged: %s", len(failures), strings.Join(failures, "; "))
+	}
+	return
+}
+
+// purge removes store and its account, closing the ledger of the account
+func (u *StoreUsecase) purge(ctx context.Context, store *domain.Store) error {
+	return u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		account, err := u.accountRepo.FindForUpdate(ctx, store.AccountID)
+		if err != nil {
+			return err
+		}
+
+		err = u.storeRepo.Delete(ctx, store.ID)
+		if err != nil {
+			return err
+		}
+
+		err = u.ledgerRepo.Store(ctx, domain.NewClosingLedgerEntry(account, store.ID))
+		if err != nil {
+			return err
+		}
+
+		return u.accountRepo.Delete(ctx, account.ID)
+	})
+}
+
+// UploadImage stores the variants of the image uploaded to a store, and
+// makes the store reference them in place of its previous image
+func (u *StoreUsecase) UploadImage(c context.Context, param *domain.StoreImageRequest) (res *domain.Store, err error) {
+	ctx, cancel := context.WithTimeout(c, u.UploadTimeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecase.UploadImage")
+	defer span.Finish()
+
+	err = domain.CheckImage(param.ContentType, param.Size, u.MaxImageSize)
+	if err != nil {
+		return
+	}
+
+	store, err := u.storeRepo.FindByID(ctx, param.ID)
+	if err != nil {
+		return
+	}
+
+	err = authorizeOwner(ctx, store)
+	if err != nil {
+		return
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(param.Body, u.MaxImageSize+1))
+	if err != nil {
+		return
+	}
+	if int64(len(data)) > u.MaxImageSize {
+		return nil, domain.ErrImageTooLarge
+	}
+
+	images, err := u.imageProcessor.Process(ctx, bytes.NewReader(data), param.ContentType, domain.StoreImageVariants)
+	if err != nil {
+		return
+	}
+
+	uploadID := uuid.NewV4().String()
+	keys := make(domain.StoreImages)
+	for _, image := range images {
+		key := domain.StoreImageKey(store.ID, uploadID, image.Variant, image.ContentType)
+		err = u.blobStorage.Put(ctx, key, bytes.NewReader(image.Data), int64(len(image.Data)), image.ContentType)
+		if err != nil {
+			u.deleteBlobs(ctx, span, keys)
+			return nil, err
+		}
+		keys[image.Variant] = key
+	}
+
+	previous := store.Images
+	changes := store.SetImages(keys)
+
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := u.storeRepo.Update(ctx, store)
+		if err != nil {
+			return err
+		}
+
+		event := domain.NewStoreUpdatedEvent(store, changes)
+		return u.outboxRepo.Store(ctx, domain.NewOutboxMessage(u.UpdateStoreTopic, event.ToJson()))
+	})
+	if err != nil {
+		u.deleteBlobs(ctx, span, keys)
+		return nil, err
+	}
+
+	u.deleteBlobs(ctx, span, previous)
+	if err := u.withHours(ctx, store); err != nil {
+		ext.LogError(span, err)
+	}
+	return store, nil
+}
+
+// SetHours replaces the opening hours of a store owned by the caller
+func (u *StoreUsecase) SetHours(c context.Context, param *domain.StoreHoursRequest) (res *domain.Store, err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecase.SetHours")
+	defer span.Finish()
+
+	err = param.Check()
+	if err != nil {
+		return
+	}
+
+	store, err := u.storeRepo.FindByID(ctx, param.ID)
+	if err != nil {
+		return
+	}
+
+	err = authorizeOwner(ctx, store)
+	if err != nil {
+		return
+	}
+
+	err = u.withHours(ctx, store)
+	if err != nil {
+		return
+	}
+
+	hours := domain.NewStoreHours(param)
+	changes := domain.StoreChanges{"hours": domain.FieldChange{From: store.Hours, To: hours}}
+	store.SetHours(hours, time.Now())
+
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := u.hoursRepo.Save(ctx, hours)
+		if err != nil {
+			return err
+		}
+
+		event := domain.NewStoreUpdatedEvent(store, changes)
+		return u.outboxRepo.Store(ctx, domain.NewOutboxMessage(u.UpdateStoreTopic, event.ToJson()))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// deleteBlobs removes the blobs of images. Failures are only logged to span,
+// the blobs are left behind.
+func (u *StoreUsecase) deleteBlobs(ctx context.Context, span opentracing.Span, images domain.StoreImages) {
+	for _, key := range images {
+		if err := u.blobStorage.Delete(ctx, key); err != nil {
+			ext.LogError(span, err)
+		}
+	}
+}
+
+// Import creates the stores of a CSV or NDJSON file, owned by the caller.
+// Each row is created in its own transaction; the rows breaking the
+// CreateStoreRequest rules, or of a category that is not active, are left
+// out and reported.
+func (u *StoreUsecase) Import(c context.Context, param *domain.StoreImportRequest) (res *domain.StoreImportReport, err error) {
+	span, ctx := opentracing.StartSpanFromContext(c, "StoreUsecase.Import")
+	defer span.Finish()
+
+	claims, ok := domain.ClaimsFromContext(ctx)
+	if !ok {
+		return nil, domain.ErrUnauthorized
+	}
+
+	decoder, err := domain.NewStoreDecoder(param.Format, param.Body)
+	if err != nil {
+		return
+	}
+
+	res = domain.NewStoreImportReport(param.DryRun)
+	categories := make(map[string]bool)
+	for {
+		row, err := decoder.Decode()
+		if err == io.EOF {
+			break
+		}
+		res.Rows++
+
+		var rowErr *domain.StoreRowError
+		if errors.As(err, &rowErr) {
+			res.Fail(res.Rows, rowErr)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		row.UserID = claims.UserID
+		rowErrs, err := u.checkImportRow(ctx, row, categories)
+		if err != nil {
+			return nil, err
+		}
+		if len(rowErrs) > 0 {
+			res.Fail(res.Rows, rowErrs...)
+			continue
+		}
+
+		if !param.DryRun {
+			err = u.importRow(ctx, claims, row)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if err != nil {
+				ext.LogError(span, err)
+				res.Fail(res.Rows, &domain.StoreRowError{Message: err.Error()})
+				continue
+			}
+		}
+		res.Imported++
+	}
+	return res, nil
+}
+
+// checkImportRow returns the reasons row cannot be imported. categories
+// caches whether the categories found so far are active.
+func (u *StoreUsecase) checkImportRow(ctx context.Context, row *domain.CreateStoreRequest, categories map[string]bool) (rowErrs []*domain.StoreRowError, err error) {
+	err = u.validate.StructCtx(ctx, row)
+	if validationErrs, ok := err.(validator.ValidationErrors); ok {
+		for _, e := range validationErrs {
+			rowErrs = append(rowErrs, &domain.StoreRowError{Field: e.Field(), Message: e.Error()})
+		}
+		return rowErrs, nil
+	}
+	if err != nil {
+		return
+	}
+
+	active, ok := categories[row.CategoryID]
+	if !ok {
+		category, err := u.categoryRepo.FindByID(ctx, row.CategoryID)
+		if err != nil && !errors.Is(err, domain.ErrNotFound) {
+			return nil, err
+		}
+		active = err == nil && category.Status == domain.CategoryStatusActive
+		categories[row.CategoryID] = active
+	}
+	if !active {
+		rowErrs = append(rowErrs, &domain.StoreRowError{Field: "CategoryID", Message: "category " + domain.ErrNotFound.Error()})
+	}
+	return rowErrs, nil
+}
+
+func (u *StoreUsecase) importRow(c context.Context, claims *domain.Claims, row *domain.CreateStoreRequest) error {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	return u.create(ctx, claims, row)
+}
+
+// Export writes every store but the deleted ones to w, as CSV or NDJSON,
+// fetching them ExportBatchSize at a time. Only admins may export.
+func (u *StoreUsecase) Export(c context.Context, format string, w io.Writer) (err error) {
+	span, ctx := opentracing.StartSpanFromContext(c, "StoreUsecase.Export")
+	defer span.Finish()
+
+	err = authorizeAdmin(ctx)
+	if err != nil {
+		return
+	}
+
+	encoder, err := domain.NewStoreEncoder(format, w)
+	if err != nil {
+		return
 	}
-	err = u.accountRepo.Delete(ctx, store.AccountID)
+
+	sort := domain.SortSpec{{Column: "created_at"}}
+	var after *domain.Cursor
+	for {
+		stores, err := u.exportBatch(ctx, sort, after)
+		if err != nil {
+			return err
+		}
+
+		for _, store := range stores {
+			err = encoder.Encode(store)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = encoder.Flush()
+		if err != nil || len(stores) < u.ExportBatchSize {
+			return err
+		}
+		after = domain.NewStoreCursor(stores[len(stores)-1], sort, false)
+	}
+}
+
+func (u *StoreUsecase) exportBatch(c context.Context, sort domain.SortSpec, after *domain.Cursor) (domain.Stores, error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	stores, _, err := u.storeRepo.FindAll(ctx, nil, sort, after, u.ExportBatchSize, 1)
+	return stores, err
+}
+
+func (u *StoreUsecase) GetAccount(c context.Context, id string) (res *domain.Account, err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecase.GetAccount")
+	defer span.Finish()
+
+	store, err := u.storeRepo.FindByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = authorizeOwner(ctx, store)
+	if err != nil {
+		return
+	}
+
+	return u.accountRepo.FindByID(ctx, store.AccountID)
+}
+
+func (u *StoreUsecase) Deposit(c context.Context, param *domain.AccountOperationRequest) (res *domain.Account, err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecase.Deposit")
+	defer span.Finish()
+
+	return u.updateBalance(ctx, domain.AccountOperationDeposit, param)
+}
+
+func (u *StoreUsecase) Withdraw(c context.Context, param *domain.AccountOperationRequest) (res *domain.Account, err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecase.Withdraw")
+	defer span.Finish()
+
+	return u.updateBalance(ctx, domain.AccountOperationWithdraw, param)
+}
+
+// updateBalance applies operation to the store's account and records the
+// ledger entry and the balance update in the same transaction
+func (u *StoreUsecase) updateBalance(ctx context.Context, operation string, param *domain.AccountOperationRequest) (res *domain.Account, err error) {
+	store, err := u.storeRepo.FindByID(ctx, param.ID)
+	if err != nil {
+		return
+	}
+
+	err = authorizeOwner(ctx, store)
+	if err != nil {
+		return
+	}
+
+	reason := param.Reason
+	if reason == "" {
+		reason = operation
+	}
+
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		// the lock keeps a concurrent operation from changing the balance
+		// between this read and the update
+		account, err := u.accountRepo.FindForUpdate(ctx, store.AccountID)
+		if err != nil {
+			return err
+		}
+
+		direction := domain.LedgerDirectionCredit
+		if operation == domain.AccountOperationWithdraw {
+			direction = domain.LedgerDirectionDebit
+			err = account.Withdraw(param.Amount)
+		} else {
+			err = account.Deposit(param.Amount)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = u.accountRepo.Update(ctx, account)
+		if err != nil {
+			return err
+		}
+
+		// the entry records the balance of the locked row, so the running
+		// balances of the ledger follow one another
+		entry := domain.NewLedgerEntry(account, direction, param.Amount, reason, param.ReferenceID)
+		err = u.ledgerRepo.Store(ctx, entry)
+		if err != nil {
+			return err
+		}
+
+		update := &domain.BalanceUpdate{
+			StoreID:       store.ID,
+			AccountID:     account.ID,
+			TransactionID: entry.ID,
+			Operation:     operation,
+			Amount:        param.Amount,
+			Balance:       account.Balance,
+			UpdatedAt:     account.UpdatedAt,
+		}
+		res = account
+		return u.outboxRepo.Store(ctx, domain.NewOutboxMessage(u.BalanceUpdatedTopic, update.ToJson()))
+	})
+	if err != nil {
+		res = nil
+	}
+
+	return
+}
+
+func (u *StoreUsecase) ListTransactions(c context.Context, id string, limit, page int) (res domain.LedgerEntries, total int64, err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecase.ListTransactions")
+	defer span.Finish()
+
+	if limit <= 0 {
+		limit = 10
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	store, err := u.storeRepo.FindByID(ctx, id)
 	if err != nil {
 		return
 	}
 
-	storeJson := store.ToJson()
-	return u.msgProducer.Publish(ctx, string(storeJson), u.DeleteStoreTopic)
+	err = authorizeOwner(ctx, store)
+	if err != nil {
+		return
+	}
+
+	res, total, err = u.ledgerRepo.FindByAccountID(ctx, store.AccountID, limit, page)
+	if err != nil {
+		total = 0
+		return
+	}
+
+	return
+}
+
+// ReconcileAccount recomputes the balance of the store's account from its
+// ledger and compares it with the stored balance
+func (u *StoreUsecase) ReconcileAccount(c context.Context, id string) (res *domain.Reconciliation, err error) {
+	ctx, cancel := context.WithTimeout(c, u.timeout)
+	defer cancel()
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "StoreUsecase.ReconcileAccount")
+	defer span.Finish()
+
+	store, err := u.storeRepo.FindByID(ctx, id)
+	if err != nil {
+		return
+	}
+
+	err = authorizeOwner(ctx, store)
+	if err != nil {
+		return
+	}
+
+	// with the account locked, no operation commits between reading its
+	// balance and summing its ledger
+	err = u.txManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		account, err := u.accountRepo.FindForUpdate(ctx, store.AccountID)
+		if err != nil {
+			return err
+		}
+
+		sum, err := u.ledgerRepo.SumByAccountID(ctx, account.ID)
+		if err != nil {
+			return err
+		}
+
+		res = domain.NewReconciliation(account, sum)
+		return nil
+	})
+	if err != nil {
+		res = nil
+	}
+
+	return
 }
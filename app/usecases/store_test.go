@@ -1,8 +1,12 @@
 package usecases_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,10 +15,28 @@ import (
 	"github.com/EdlanioJ/kbu-store/app/utils/mocks"
 	"github.com/EdlanioJ/kbu-store/app/utils/sample"
 	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+func withinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// adminContext returns the context of a call made by an admin
+func adminContext() context.Context {
+	return domain.ContextWithClaims(context.TODO(), &domain.Claims{
+		UserID: uuid.NewV4().String(),
+		Roles:  []string{domain.RoleAdmin},
+	})
+}
+
+// userContext returns the context of a call made by the user without roles
+func userContext(userID string) context.Context {
+	return domain.ContextWithClaims(context.TODO(), &domain.Claims{UserID: userID})
+}
+
 func Test_StoreUsecase_Create(t *testing.T) {
 	arg := sample.NewCreateStoreRequest()
 	validCategory := sample.NewCategory()
@@ -26,7 +48,8 @@ func Test_StoreUsecase_Create(t *testing.T) {
 		storeRepo    *mocks.StoreRepository
 		accountRepo  *mocks.AccountRepository
 		categoryRepo *mocks.CategoryRepository
-		msgProducer  *mocks.MessengerProducer
+		historyRepo  *mocks.StoreStatusHistoryRepository
+		outboxRepo   *mocks.OutboxRepository
 	}
 	testCases := []struct {
 		name        string
@@ -70,7 +93,18 @@ func Test_StoreUsecase_Create(t *testing.T) {
 			},
 		},
 		{
-			name:        "failure_produce_returns_error",
+			name:        "failure_store_status_change_returns_error",
+			arg:         arg,
+			expectedErr: true,
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, arg.CategoryID).Return(validCategory, nil).Once()
+				f.accountRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				f.storeRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.Anything).Return(errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:        "failure_store_outbox_message_returns_error",
 			arg:         arg,
 			expectedErr: true,
 			prepare: func(f fields) {
@@ -78,7 +112,8 @@ func Test_StoreUsecase_Create(t *testing.T) {
 				f.categoryRepo.On("FindByID", mock.Anything, arg.CategoryID).Return(validCategory, nil).Once()
 				f.accountRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
 				f.storeRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
-				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(errors.New("Unexpected Error"))
+				f.historyRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(errors.New("Unexpected Error")).Once()
 			},
 		},
 		{
@@ -87,9 +122,17 @@ func Test_StoreUsecase_Create(t *testing.T) {
 			expectedErr: false,
 			prepare: func(f fields) {
 				f.categoryRepo.On("FindByID", mock.Anything, arg.CategoryID).Return(validCategory, nil).Once()
-				f.accountRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
-				f.storeRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
-				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+				var accountID string
+				f.accountRepo.On("Store", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					accountID = args.Get(1).(*domain.Account).ID
+				}).Return(nil).Once()
+				f.storeRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.AccountID != "" && s.AccountID == accountID
+				})).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.MatchedBy(func(c *domain.StoreStatusChange) bool {
+					return c.FromStatus == "" && c.ToStatus == domain.StoreStatusPending
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
 			},
 		},
 	}
@@ -101,12 +144,15 @@ func Test_StoreUsecase_Create(t *testing.T) {
 			accountRepo := new(mocks.AccountRepository)
 			categoryRepo := new(mocks.CategoryRepository)
 			storeRepo := new(mocks.StoreRepository)
-			msgProducer := new(mocks.MessengerProducer)
-			f := fields{storeRepo, accountRepo, categoryRepo, msgProducer}
+			historyRepo := new(mocks.StoreStatusHistoryRepository)
+			outboxRepo := new(mocks.OutboxRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			f := fields{storeRepo, accountRepo, categoryRepo, historyRepo, outboxRepo}
 			tc.prepare(f)
-			u := usecases.NewStoreUsecase(storeRepo, accountRepo, categoryRepo, msgProducer, time.Second*2)
+			u := usecases.NewStoreUsecase(storeRepo, accountRepo, nil, historyRepo, nil, categoryRepo, outboxRepo, txManager, nil, nil, time.Second*2)
 
-			err := u.Store(context.TODO(), tc.arg)
+			err := u.Store(adminContext(), tc.arg)
 			if tc.expectedErr {
 				assert.Error(t, err)
 			} else {
@@ -115,31 +161,46 @@ func Test_StoreUsecase_Create(t *testing.T) {
 			accountRepo.AssertExpectations(t)
 			categoryRepo.AssertExpectations(t)
 			storeRepo.AssertExpectations(t)
+			historyRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
 		})
 	}
 }
 
 func Test_StoreUsecase_Get(t *testing.T) {
+	hours := sample.NewStoreHours()
 	testCases := []struct {
 		name        string
 		arg         string
 		expectedErr bool
-		prepare     func(storeRepo *mocks.StoreRepository)
+		prepare     func(storeRepo *mocks.StoreRepository, hoursRepo *mocks.StoreHoursRepository)
 	}{
 		{
 			name:        "Failure_find_store_by_id_returns_error",
 			arg:         uuid.NewV4().String(),
 			expectedErr: true,
-			prepare: func(storeRepo *mocks.StoreRepository) {
+			prepare: func(storeRepo *mocks.StoreRepository, hoursRepo *mocks.StoreHoursRepository) {
 				storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("Unexpected Error")).Once()
 			},
 		},
+		{
+			name:        "failure_find_hours_returns_error",
+			arg:         uuid.NewV4().String(),
+			expectedErr: true,
+			prepare: func(storeRepo *mocks.StoreRepository, hoursRepo *mocks.StoreHoursRepository) {
+				s := sample.NewStore()
+				storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(s, nil).Once()
+				hoursRepo.On("FindByStoreIDs", mock.Anything, []string{s.ID}).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
 		{
 			name: "success",
 			arg:  uuid.NewV4().String(),
-			prepare: func(storeRepo *mocks.StoreRepository) {
+			prepare: func(storeRepo *mocks.StoreRepository, hoursRepo *mocks.StoreHoursRepository) {
 				s := sample.NewStore()
+				s.ID = hours.StoreID
 				storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(s, nil).Once()
+				hoursRepo.On("FindByStoreIDs", mock.Anything, []string{s.ID}).Return([]*domain.StoreHours{hours}, nil).Once()
 			},
 		},
 	}
@@ -149,8 +210,9 @@ func Test_StoreUsecase_Get(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			storeRepo := new(mocks.StoreRepository)
-			tc.prepare(storeRepo)
-			u := usecases.NewStoreUsecase(storeRepo, nil, nil, nil, time.Second*2)
+			hoursRepo := new(mocks.StoreHoursRepository)
+			tc.prepare(storeRepo, hoursRepo)
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, nil, hoursRepo, nil, nil, nil, nil, nil, time.Second*2)
 			res, err := u.Get(context.TODO(), tc.arg)
 
 			if tc.expectedErr {
@@ -159,41 +221,146 @@ func Test_StoreUsecase_Get(t *testing.T) {
 			} else {
 				assert.NoError(t, err)
 				assert.NotNil(t, res)
+				assert.Equal(t, hours, res.Hours)
+				assert.True(t, res.IsOpenNow)
+				assert.Nil(t, res.NextChangeAt)
 			}
 			storeRepo.AssertExpectations(t)
+			hoursRepo.AssertExpectations(t)
 		})
 	}
 }
 
 func Test_StoreUsecase_Index(t *testing.T) {
+	secret := []byte("secret")
+	sort := domain.SortSpec{{Column: "created_at", Desc: true}}
+	stores := domain.Stores{sample.NewStore(), sample.NewStore(), sample.NewStore()}
+	after := domain.EncodeCursor(domain.NewStoreCursor(stores[0], sort, false), secret)
+	before := domain.EncodeCursor(domain.NewStoreCursor(stores[2], sort, true), secret)
+
+	type args struct {
+		sort   string
+		cursor string
+		limit  int
+		page   int
+	}
 	testCases := []struct {
 		name          string
-		args          sample.HttpListRequest
-		expectedErr   bool
+		args          args
+		expectedErr   error
 		prepare       func(storeRepo *mocks.StoreRepository)
-		checkResponse func(t *testing.T, res domain.Stores, count int64, err error)
+		checkResponse func(t *testing.T, res *domain.StorePage)
 	}{
 		{
 			name:        "failure_find_all_returns_error",
-			args:        sample.HttpListRequest{},
-			expectedErr: true,
+			args:        args{},
+			expectedErr: errors.New("Unexpected Error"),
 			prepare: func(storeRepo *mocks.StoreRepository) {
-				storeRepo.On("FindAll", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"), mock.AnythingOfType("int")).
+				storeRepo.On("FindAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.AnythingOfType("int"), mock.AnythingOfType("int")).
 					Return(nil, int64(0), errors.New("Unexpected Error")).
 					Once()
 			},
 		},
 		{
-			name: "success",
-			args: sample.HttpListRequest{},
+			name:        "failure_invalid_sort",
+			args:        args{sort: "id; DROP TABLE stores"},
+			expectedErr: domain.ErrInvalidSort,
+			prepare:     func(storeRepo *mocks.StoreRepository) {},
+		},
+		{
+			name:        "failure_invalid_cursor",
+			args:        args{cursor: "not-a-cursor"},
+			expectedErr: domain.ErrInvalidCursor,
+			prepare:     func(storeRepo *mocks.StoreRepository) {},
+		},
+		{
+			name:        "failure_cursor_of_another_sort",
+			args:        args{sort: "name", cursor: after},
+			expectedErr: domain.ErrInvalidCursor,
+			prepare:     func(storeRepo *mocks.StoreRepository) {},
+		},
+		{
+			name: "success_with_sort",
+			args: args{sort: "name,-updated_at"},
 			prepare: func(storeRepo *mocks.StoreRepository) {
-				store := sample.NewStore()
-				stores := make(domain.Stores, 0)
-				stores = append(stores, store)
-				storeRepo.On("FindAll", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int"), mock.AnythingOfType("int")).
-					Return(stores, int64(1), nil).
+				spec := domain.SortSpec{{Column: "name"}, {Column: "updated_at", Desc: true}}
+				storeRepo.On("FindAll", mock.Anything, mock.Anything, spec, (*domain.Cursor)(nil), 10, 1).
+					Return(stores[:1], int64(1), nil).
+					Once()
+			},
+			checkResponse: func(t *testing.T, res *domain.StorePage) {
+				assert.Len(t, res.Stores, 1)
+				assert.Empty(t, res.NextCursor)
+				assert.Empty(t, res.PrevCursor)
+			},
+		},
+		{
+			name: "success_page",
+			args: args{limit: 2, page: 2},
+			prepare: func(storeRepo *mocks.StoreRepository) {
+				storeRepo.On("FindAll", mock.Anything, mock.Anything, sort, (*domain.Cursor)(nil), 2, 2).
+					Return(stores[:2], int64(5), nil).
+					Once()
+			},
+			checkResponse: func(t *testing.T, res *domain.StorePage) {
+				assert.Len(t, res.Stores, 2)
+				assert.Equal(t, int64(5), res.Total)
+				next, err := domain.DecodeStoreCursor(res.NextCursor, sort, secret)
+				assert.NoError(t, err)
+				assert.Equal(t, stores[1].ID, next.ID)
+				prev, err := domain.DecodeStoreCursor(res.PrevCursor, sort, secret)
+				assert.NoError(t, err)
+				assert.Equal(t, stores[0].ID, prev.ID)
+				assert.True(t, prev.Backward)
+			},
+		},
+		{
+			name: "success_after_cursor",
+			args: args{cursor: after, limit: 2},
+			prepare: func(storeRepo *mocks.StoreRepository) {
+				storeRepo.On("FindAll", mock.Anything, mock.Anything, sort, mock.MatchedBy(func(c *domain.Cursor) bool {
+					return c.ID == stores[0].ID && !c.Backward
+				}), 3, 1).
+					Return(stores, int64(10), nil).
+					Once()
+			},
+			checkResponse: func(t *testing.T, res *domain.StorePage) {
+				assert.Len(t, res.Stores, 2)
+				assert.Equal(t, stores[0].ID, res.Stores[0].ID)
+				assert.NotEmpty(t, res.NextCursor)
+				assert.NotEmpty(t, res.PrevCursor)
+			},
+		},
+		{
+			name: "success_before_cursor_reaching_first_page",
+			args: args{cursor: before, limit: 2},
+			prepare: func(storeRepo *mocks.StoreRepository) {
+				storeRepo.On("FindAll", mock.Anything, mock.Anything, sort, mock.MatchedBy(func(c *domain.Cursor) bool {
+					return c.ID == stores[2].ID && c.Backward
+				}), 3, 1).
+					Return(stores[:2], int64(10), nil).
+					Once()
+			},
+			checkResponse: func(t *testing.T, res *domain.StorePage) {
+				assert.Len(t, res.Stores, 2)
+				assert.NotEmpty(t, res.NextCursor)
+				assert.Empty(t, res.PrevCursor)
+			},
+		},
+		{
+			name: "success_before_cursor",
+			args: args{cursor: before, limit: 1},
+			prepare: func(storeRepo *mocks.StoreRepository) {
+				storeRepo.On("FindAll", mock.Anything, mock.Anything, sort, mock.Anything, 2, 1).
+					Return(stores[:2], int64(10), nil).
 					Once()
 			},
+			checkResponse: func(t *testing.T, res *domain.StorePage) {
+				assert.Len(t, res.Stores, 1)
+				assert.Equal(t, stores[1].ID, res.Stores[0].ID)
+				assert.NotEmpty(t, res.NextCursor)
+				assert.NotEmpty(t, res.PrevCursor)
+			},
 		},
 	}
 
@@ -203,17 +370,18 @@ func Test_StoreUsecase_Index(t *testing.T) {
 			t.Parallel()
 			storeRepo := new(mocks.StoreRepository)
 			tc.prepare(storeRepo)
-			u := usecases.NewStoreUsecase(storeRepo, nil, nil, nil, time.Second*2)
-			res, count, err := u.Index(context.TODO(), tc.args.Sort, tc.args.Limit, tc.args.Page)
+			hoursRepo := new(mocks.StoreHoursRepository)
+			hoursRepo.On("FindByStoreIDs", mock.Anything, mock.Anything).Return([]*domain.StoreHours{}, nil).Maybe()
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, nil, hoursRepo, nil, nil, nil, nil, nil, time.Second*2)
+			u.CursorSecret = secret
+			res, err := u.Index(context.TODO(), nil, tc.args.sort, tc.args.cursor, tc.args.limit, tc.args.page)
 
-			if tc.expectedErr {
-				assert.Len(t, res, 0)
-				assert.Equal(t, count, int64(0))
-				assert.Error(t, err)
+			if tc.expectedErr != nil {
+				assert.Nil(t, res)
+				assert.EqualError(t, err, tc.expectedErr.Error())
 			} else {
-				assert.Len(t, res, 1)
-				assert.Equal(t, count, int64(1))
 				assert.NoError(t, err)
+				tc.checkResponse(t, res)
 			}
 
 			storeRepo.AssertExpectations(t)
@@ -221,75 +389,42 @@ func Test_StoreUsecase_Index(t *testing.T) {
 	}
 }
 
-func Test_StoreUsecase_Block(t *testing.T) {
-	type fields struct {
-		storeRepo   *mocks.StoreRepository
-		msgProducer *mocks.MessengerProducer
+func Test_StoreUsecase_Nearby(t *testing.T) {
+	position := domain.Position{Lat: -8.8383, Lng: 13.2344}
+	stores := domain.NearbyStores{
+		{Store: sample.NewStore(), Distance: 0.5},
+		{Store: sample.NewStore(), Distance: 1.2},
 	}
+
 	testCases := []struct {
 		name        string
-		arg         string
+		arg         *domain.NearbyStoreRequest
 		expectedErr bool
-		prepare     func(f fields)
+		prepare     func(storeRepo *mocks.StoreRepository)
 	}{
 		{
-			name:        "failure_find_store_by_id_returns_error",
-			arg:         uuid.NewV4().String(),
-			expectedErr: true,
-			prepare: func(f fields) {
-				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("Unexpected Error")).Once()
-			},
-		},
-		{
-			name:        "failure_block_method_returns_error",
-			arg:         uuid.NewV4().String(),
-			expectedErr: true,
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusBlock
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
-			},
-		},
-		{
-			name:        "failure_update_store_returns_error",
-			arg:         uuid.NewV4().String(),
-			expectedErr: true,
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusActive
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
-				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(errors.New("Unexpected Error")).Once()
-			},
-		},
-		{
-			name:        "failure_publish_msg_returns_error",
-			arg:         uuid.NewV4().String(),
+			name:        "failure_find_nearby_returns_error",
+			arg:         &domain.NearbyStoreRequest{Lat: position.Lat, Lng: position.Lng, RadiusKm: 5},
 			expectedErr: true,
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusActive
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
-				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
-				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(errors.New("Unexpected Error"))
+			prepare: func(storeRepo *mocks.StoreRepository) {
+				storeRepo.On("FindNearby", mock.Anything, position, 5.0, (*domain.StoreFilter)(nil), 20).
+					Return(nil, errors.New("Unexpected Error")).
+					Once()
 			},
 		},
 		{
 			name: "success",
-			arg:  uuid.NewV4().String(),
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusActive
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
-				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
-				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+			arg: &domain.NearbyStoreRequest{
+				Lat:      position.Lat,
+				Lng:      position.Lng,
+				RadiusKm: 2,
+				Limit:    5,
+				Filter:   &domain.StoreFilter{Status: domain.StoreStatusActive},
+			},
+			prepare: func(storeRepo *mocks.StoreRepository) {
+				storeRepo.On("FindNearby", mock.Anything, position, 2.0, &domain.StoreFilter{Status: domain.StoreStatusActive}, 5).
+					Return(stores, nil).
+					Once()
 			},
 		},
 	}
@@ -299,89 +434,57 @@ func Test_StoreUsecase_Block(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			storeRepo := new(mocks.StoreRepository)
-			msgProducer := new(mocks.MessengerProducer)
-			f := fields{storeRepo, msgProducer}
-			tc.prepare(f)
-			u := usecases.NewStoreUsecase(storeRepo, nil, nil, msgProducer, time.Second*2)
-			err := u.Block(context.TODO(), tc.arg)
+			tc.prepare(storeRepo)
+			hoursRepo := new(mocks.StoreHoursRepository)
+			hoursRepo.On("FindByStoreIDs", mock.Anything, mock.Anything).Return([]*domain.StoreHours{}, nil).Maybe()
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, nil, hoursRepo, nil, nil, nil, nil, nil, time.Second*2)
+			res, err := u.Nearby(context.TODO(), tc.arg)
+
 			if tc.expectedErr {
 				assert.Error(t, err)
+				assert.Nil(t, res)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, stores, res)
 			}
+			storeRepo.AssertExpectations(t)
 		})
 	}
 }
 
-func Test_StoreUsecase_Active(t *testing.T) {
-	type fields struct {
-		storeRepo   *mocks.StoreRepository
-		msgProducer *mocks.MessengerProducer
+func Test_StoreUsecase_Search(t *testing.T) {
+	results := domain.StoreSearchResults{
+		{Store: sample.NewStore(), Rank: 0.6, Snippet: "<b>store</b> 001"},
 	}
+
 	testCases := []struct {
 		name        string
-		arg         string
+		arg         *domain.SearchStoreRequest
 		expectedErr bool
-		prepare     func(f fields)
+		prepare     func(storeRepo *mocks.StoreRepository)
 	}{
 		{
-			name:        "failure_find_store_by_id_returns_error",
-			arg:         uuid.NewV4().String(),
-			expectedErr: true,
-			prepare: func(f fields) {
-				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("Unexpected Error")).Once()
-			},
-		},
-		{
-			name:        "failure_activate_method_returns_error",
-			arg:         uuid.NewV4().String(),
-			expectedErr: true,
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusActive
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
-			},
-		},
-		{
-			name:        "failure_update_store_returns_error",
-			arg:         uuid.NewV4().String(),
-			expectedErr: true,
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusPending
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
-				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(errors.New("Unexpected Error")).Once()
-			},
-		},
-		{
-			name:        "failure_publish_msg_returns_error",
-			arg:         uuid.NewV4().String(),
+			name:        "failure_search_returns_error",
+			arg:         &domain.SearchStoreRequest{Query: "store"},
 			expectedErr: true,
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusPending
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
-				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
-				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(errors.New("Unexpected Error"))
+			prepare: func(storeRepo *mocks.StoreRepository) {
+				storeRepo.On("Search", mock.Anything, "store", (*domain.StoreFilter)(nil), 10, 1).
+					Return(nil, int64(0), errors.New("Unexpected Error")).
+					Once()
 			},
 		},
 		{
 			name: "success",
-			arg:  uuid.NewV4().String(),
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusPending
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
-				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
-				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+			arg: &domain.SearchStoreRequest{
+				Query:  "store",
+				Limit:  5,
+				Page:   2,
+				Filter: &domain.StoreFilter{Status: domain.StoreStatusActive},
+			},
+			prepare: func(storeRepo *mocks.StoreRepository) {
+				storeRepo.On("Search", mock.Anything, "store", &domain.StoreFilter{Status: domain.StoreStatusActive}, 5, 2).
+					Return(results, int64(6), nil).
+					Once()
 			},
 		},
 	}
@@ -391,122 +494,351 @@ func Test_StoreUsecase_Active(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			storeRepo := new(mocks.StoreRepository)
-			msgProducer := new(mocks.MessengerProducer)
-			f := fields{storeRepo, msgProducer}
-			tc.prepare(f)
-			u := usecases.NewStoreUsecase(storeRepo, nil, nil, msgProducer, time.Second*2)
-			err := u.Active(context.TODO(), tc.arg)
+			tc.prepare(storeRepo)
+			hoursRepo := new(mocks.StoreHoursRepository)
+			hoursRepo.On("FindByStoreIDs", mock.Anything, mock.Anything).Return([]*domain.StoreHours{}, nil).Maybe()
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, nil, hoursRepo, nil, nil, nil, nil, nil, time.Second*2)
+			res, err := u.Search(context.TODO(), tc.arg)
+
 			if tc.expectedErr {
 				assert.Error(t, err)
+				assert.Nil(t, res)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, results, res.Results)
+				assert.Equal(t, int64(6), res.Total)
 			}
 			storeRepo.AssertExpectations(t)
-			msgProducer.AssertExpectations(t)
 		})
 	}
 }
 
-func Test_StoreUsecase_Disable(t *testing.T) {
+func Test_StoreUsecase_ChangeStatus(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
 	type fields struct {
-		storeRepo   *mocks.StoreRepository
-		msgProducer *mocks.MessengerProducer
+		storeRepo    *mocks.StoreRepository
+		historyRepo  *mocks.StoreStatusHistoryRepository
+		outboxRepo   *mocks.OutboxRepository
+		categoryRepo *mocks.CategoryRepository
+	}
+	storeIn := func(f fields, status string) *domain.Store {
+		store := sample.NewStore()
+		store.Status = status
+		f.storeRepo.
+			On("FindByID", mock.Anything, mock.AnythingOfType("string")).
+			Return(store, nil).Once()
+		return store
+	}
+	blockedFrom := func(f fields, status string) *domain.Store {
+		store := storeIn(f, domain.StoreStatusBlock)
+		block := sample.NewStoreStatusChange()
+		block.FromStatus = status
+		f.historyRepo.On("FindByStoreID", mock.Anything, store.ID, 1, 1).Return(domain.StoreStatusHistory{block}, int64(2), nil).Once()
+		f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+		f.historyRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+		f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+		return store
 	}
 	testCases := []struct {
 		name        string
-		arg         string
-		expectedErr bool
-		prepare     func(f fields)
+		arg         *domain.StoreStatusRequest
+		expectedErr error
+		to          string
+		prepare     func(f fields) *domain.Store
 	}{
+		{
+			name:        "failure_unknown_action",
+			arg:         &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: "close"},
+			expectedErr: domain.ErrUnknownAction,
+			prepare:     func(f fields) *domain.Store { return nil },
+		},
+		{
+			name:        "failure_missing_reason",
+			arg:         &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionReject},
+			expectedErr: domain.ErrReasonRequired,
+			prepare:     func(f fields) *domain.Store { return nil },
+		},
+		{
+			name:        "failure_expiry_in_the_past",
+			arg:         &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionBlock, Reason: "spam", ExpiresAt: &past},
+			expectedErr: domain.ErrInvalidExpiry,
+			prepare:     func(f fields) *domain.Store { return nil },
+		},
+		{
+			name:        "failure_expiry_not_allowed",
+			arg:         &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionApprove, ExpiresAt: &future},
+			expectedErr: domain.ErrNotExpirable,
+			prepare:     func(f fields) *domain.Store { return nil },
+		},
 		{
 			name:        "failure_find_store_by_id_returns_error",
-			arg:         uuid.NewV4().String(),
-			expectedErr: true,
-			prepare: func(f fields) {
-				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("Unexpected Error")).Once()
-
+			arg:         &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionBlock, Reason: "spam"},
+			expectedErr: domain.ErrNotFound,
+			prepare: func(f fields) *domain.Store {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+				return nil
 			},
 		},
 		{
-			name:        "failure_disable_method_returns_error",
-			arg:         uuid.NewV4().String(),
-			expectedErr: true,
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusDisable
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
+			name:        "failure_system_action",
+			arg:         &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionSuspend},
+			expectedErr: domain.ErrForbidden,
+			prepare: func(f fields) *domain.Store {
+				return storeIn(f, domain.StoreStatusActive)
+			},
+		},
+		{
+			name:        "failure_status_does_not_allow_action",
+			arg:         &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionActivate},
+			expectedErr: domain.ErrInvalidTransition,
+			prepare: func(f fields) *domain.Store {
+				return storeIn(f, domain.StoreStatusBlock)
 			},
 		},
 		{
 			name:        "failure_update_store_returns_error",
-			arg:         uuid.NewV4().String(),
-			expectedErr: true,
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusActive
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
-				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(errors.New("Unexpected Error")).Once()
+			arg:         &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionBlock, Reason: "spam"},
+			expectedErr: domain.ErrConflict,
+			prepare: func(f fields) *domain.Store {
+				store := storeIn(f, domain.StoreStatusActive)
+				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(domain.ErrConflict).Once()
+				return store
 			},
 		},
 		{
-			name:        "failure_publish_msg_returns_error",
-			arg:         uuid.NewV4().String(),
-			expectedErr: true,
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusActive
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
+			name:        "failure_store_status_change_returns_error",
+			arg:         &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionBlock, Reason: "spam"},
+			expectedErr: domain.ErrInternal,
+			prepare: func(f fields) *domain.Store {
+				store := storeIn(f, domain.StoreStatusActive)
 				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
-				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(errors.New("Unexpected Error"))
+				f.historyRepo.On("Store", mock.Anything, mock.Anything).Return(domain.ErrInternal).Once()
+				return store
 			},
 		},
 		{
-			name: "success",
-			arg:  uuid.NewV4().String(),
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusActive
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
+			name:        "failure_store_outbox_message_returns_error",
+			arg:         &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionBlock, Reason: "spam"},
+			expectedErr: domain.ErrInternal,
+			prepare: func(f fields) *domain.Store {
+				store := storeIn(f, domain.StoreStatusActive)
 				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
-				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+				f.historyRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(domain.ErrInternal).Once()
+				return store
 			},
 		},
-	}
-
-	for i := range testCases {
-		tc := testCases[i]
+		{
+			name: "success_approve",
+			arg:  &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionApprove},
+			to:   domain.StoreStatusActive,
+			prepare: func(f fields) *domain.Store {
+				store := storeIn(f, domain.StoreStatusPending)
+				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				return store
+			},
+		},
+		{
+			name: "success_reject",
+			arg:  &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionReject, Reason: "incomplete"},
+			to:   domain.StoreStatusRejected,
+			prepare: func(f fields) *domain.Store {
+				store := storeIn(f, domain.StoreStatusPending)
+				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.MatchedBy(func(c *domain.StoreStatusChange) bool {
+					return c.FromStatus == domain.StoreStatusPending && c.ToStatus == domain.StoreStatusRejected && c.Reason == "incomplete"
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				return store
+			},
+		},
+		{
+			name: "success_block_until",
+			arg:  &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionBlock, Reason: "spam", ExpiresAt: &future},
+			to:   domain.StoreStatusBlock,
+			prepare: func(f fields) *domain.Store {
+				store := storeIn(f, domain.StoreStatusDisable)
+				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.MatchedBy(func(c *domain.StoreStatusChange) bool {
+					return c.ExpiresAt != nil && c.ExpiresAt.Equal(future)
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				return store
+			},
+		},
+		{
+			name:        "failure_find_status_history_returns_error",
+			arg:         &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionUnblock},
+			expectedErr: domain.ErrInternal,
+			prepare: func(f fields) *domain.Store {
+				store := storeIn(f, domain.StoreStatusBlock)
+				f.historyRepo.On("FindByStoreID", mock.Anything, store.ID, 1, 1).Return(nil, int64(0), domain.ErrInternal).Once()
+				return store
+			},
+		},
+		{
+			name: "success_unblock_without_history",
+			arg:  &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionUnblock},
+			to:   domain.StoreStatusActive,
+			prepare: func(f fields) *domain.Store {
+				store := storeIn(f, domain.StoreStatusBlock)
+				f.historyRepo.On("FindByStoreID", mock.Anything, store.ID, 1, 1).Return(domain.StoreStatusHistory{}, int64(0), nil).Once()
+				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				return store
+			},
+		},
+		{
+			name: "success_unblock_active",
+			arg:  &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionUnblock},
+			to:   domain.StoreStatusActive,
+			prepare: func(f fields) *domain.Store {
+				return blockedFrom(f, domain.StoreStatusActive)
+			},
+		},
+		{
+			name: "success_unblock_disabled",
+			arg:  &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionUnblock},
+			to:   domain.StoreStatusDisable,
+			prepare: func(f fields) *domain.Store {
+				return blockedFrom(f, domain.StoreStatusDisable)
+			},
+		},
+		{
+			name: "success_unblock_suspended_with_category_disabled",
+			arg:  &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionUnblock},
+			to:   domain.StoreStatusSuspended,
+			prepare: func(f fields) *domain.Store {
+				store := blockedFrom(f, domain.StoreStatusSuspended)
+				category := sample.NewCategory()
+				category.Status = domain.CategoryStatusDisable
+				f.categoryRepo.On("FindByID", mock.Anything, store.CategoryID).Return(category, nil).Once()
+				return store
+			},
+		},
+		{
+			name: "success_unblock_suspended_with_category_activated_since",
+			arg:  &domain.StoreStatusRequest{ID: uuid.NewV4().String(), Action: domain.StoreActionUnblock},
+			to:   domain.StoreStatusActive,
+			prepare: func(f fields) *domain.Store {
+				store := blockedFrom(f, domain.StoreStatusSuspended)
+				category := sample.NewCategory()
+				category.Status = domain.CategoryStatusActive
+				f.categoryRepo.On("FindByID", mock.Anything, store.CategoryID).Return(category, nil).Once()
+				return store
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			storeRepo := new(mocks.StoreRepository)
-			msgProducer := new(mocks.MessengerProducer)
-			f := fields{storeRepo, msgProducer}
-			tc.prepare(f)
-			u := usecases.NewStoreUsecase(storeRepo, nil, nil, msgProducer, time.Second*2)
-			err := u.Disable(context.TODO(), tc.arg)
-			if tc.expectedErr {
-				assert.Error(t, err)
+			historyRepo := new(mocks.StoreStatusHistoryRepository)
+			outboxRepo := new(mocks.OutboxRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			categoryRepo := new(mocks.CategoryRepository)
+			f := fields{storeRepo, historyRepo, outboxRepo, categoryRepo}
+			store := tc.prepare(f)
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, historyRepo, nil, categoryRepo, outboxRepo, txManager, nil, nil, time.Second*2)
+			err := u.ChangeStatus(adminContext(), tc.arg)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.to, store.Status)
+			}
+			storeRepo.AssertExpectations(t)
+			historyRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
+			categoryRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_Actions(t *testing.T) {
+	owner := uuid.NewV4().String()
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		status      string
+		expected    []string
+		expectedErr error
+	}{
+		{
+			name:        "failure_not_owner",
+			ctx:         userContext(uuid.NewV4().String()),
+			status:      domain.StoreStatusActive,
+			expectedErr: domain.ErrForbidden,
+		},
+		{
+			name:     "owner_of_active_store",
+			ctx:      userContext(owner),
+			status:   domain.StoreStatusActive,
+			expected: []string{domain.StoreActionDisable},
+		},
+		{
+			name:     "owner_of_pending_store",
+			ctx:      userContext(owner),
+			status:   domain.StoreStatusPending,
+			expected: []string{},
+		},
+		{
+			name:     "admin_of_pending_store",
+			ctx:      adminContext(),
+			status:   domain.StoreStatusPending,
+			expected: []string{domain.StoreActionApprove, domain.StoreActionReject},
+		},
+		{
+			name:     "admin_of_active_store",
+			ctx:      adminContext(),
+			status:   domain.StoreStatusActive,
+			expected: []string{domain.StoreActionDisable, domain.StoreActionBlock},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			store := sample.NewStore()
+			store.UserID = owner
+			store.Status = tc.status
+			storeRepo := new(mocks.StoreRepository)
+			storeRepo.On("FindByID", mock.Anything, store.ID).Return(store, nil).Once()
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, time.Second*2)
+			res, err := u.Actions(tc.ctx, store.ID)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, res)
 			} else {
 				assert.NoError(t, err)
+				actions := []string{}
+				for _, transition := range res {
+					actions = append(actions, transition.Action)
+				}
+				assert.Equal(t, tc.expected, actions)
 			}
 			storeRepo.AssertExpectations(t)
-			msgProducer.AssertExpectations(t)
 		})
 	}
 }
 
 func Test_StoreUsecase_Update(t *testing.T) {
 	type fields struct {
-		storeRepo   *mocks.StoreRepository
-		msgProducer *mocks.MessengerProducer
+		storeRepo    *mocks.StoreRepository
+		categoryRepo *mocks.CategoryRepository
+		outboxRepo   *mocks.OutboxRepository
 	}
+
+	name := "Store 002"
+	categoryID := uuid.NewV4().String()
 	testCases := []struct {
 		name        string
 		arg         *domain.UpdateStoreRequest
@@ -521,6 +853,15 @@ func Test_StoreUsecase_Update(t *testing.T) {
 				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("Unexpected Error")).Once()
 			},
 		},
+		{
+			name:        "failure_version_mismatch_returns_conflict",
+			arg:         &domain.UpdateStoreRequest{ID: uuid.NewV4().String(), Version: 3},
+			expectedErr: true,
+			prepare: func(f fields) {
+				foundStore := sample.NewStore()
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(foundStore, nil).Once()
+			},
+		},
 		{
 			name:        "failure_update_store_returns_error",
 			arg:         sample.NewUpdateStoreRequest(),
@@ -532,24 +873,79 @@ func Test_StoreUsecase_Update(t *testing.T) {
 			},
 		},
 		{
-			name:        "failure_publish_msg_returns_error",
+			name:        "failure_store_outbox_message_returns_error",
 			arg:         sample.NewUpdateStoreRequest(),
 			expectedErr: true,
 			prepare: func(f fields) {
 				foundStore := sample.NewStore()
 				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(foundStore, nil).Once()
 				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
-				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(errors.New("Unexpected Error"))
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:        "failure_find_category_returns_error",
+			arg:         &domain.UpdateStoreRequest{ID: uuid.NewV4().String(), CategoryID: &categoryID},
+			expectedErr: true,
+			prepare: func(f fields) {
+				foundStore := sample.NewStore()
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(foundStore, nil).Once()
+				f.categoryRepo.On("FindByID", mock.Anything, categoryID).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:        "failure_category_not_active",
+			arg:         &domain.UpdateStoreRequest{ID: uuid.NewV4().String(), CategoryID: &categoryID},
+			expectedErr: true,
+			prepare: func(f fields) {
+				foundStore := sample.NewStore()
+				category := sample.NewCategory()
+				category.Status = domain.CategoryStatusDisable
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(foundStore, nil).Once()
+				f.categoryRepo.On("FindByID", mock.Anything, categoryID).Return(category, nil).Once()
+			},
+		},
+		{
+			name: "success_nothing_changed",
+			arg:  &domain.UpdateStoreRequest{ID: uuid.NewV4().String()},
+			prepare: func(f fields) {
+				foundStore := sample.NewStore()
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(foundStore, nil).Once()
+			},
+		},
+		{
+			name: "success_category_changed",
+			arg:  &domain.UpdateStoreRequest{ID: uuid.NewV4().String(), CategoryID: &categoryID},
+			prepare: func(f fields) {
+				foundStore := sample.NewStore()
+				category := sample.NewCategory()
+				category.Status = domain.CategoryStatusActive
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(foundStore, nil).Once()
+				f.categoryRepo.On("FindByID", mock.Anything, categoryID).Return(category, nil).Once()
+				f.storeRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.CategoryID == categoryID
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
 			},
 		},
 		{
 			name: "success",
-			arg:  sample.NewUpdateStoreRequest(),
+			arg:  &domain.UpdateStoreRequest{ID: uuid.NewV4().String(), Name: &name, Version: 1},
 			prepare: func(f fields) {
 				foundStore := sample.NewStore()
+				foundStore.Status = domain.StoreStatusActive
 				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(foundStore, nil).Once()
-				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
-				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+				f.storeRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.Name == name && s.Status == domain.StoreStatusActive && s.Description == foundStore.Description && s.Version == 1
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					event := new(domain.StoreUpdatedEvent)
+					if err := json.Unmarshal([]byte(m.Payload), event); err != nil {
+						return false
+					}
+					change, ok := event.Changes["name"]
+					return len(event.Changes) == 1 && ok && change.To == name && event.Store.ID == foundStore.ID
+				})).Return(nil).Once()
 			},
 		},
 	}
@@ -559,27 +955,317 @@ func Test_StoreUsecase_Update(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 			storeRepo := new(mocks.StoreRepository)
-			msgProducer := new(mocks.MessengerProducer)
-			f := fields{storeRepo, msgProducer}
+			categoryRepo := new(mocks.CategoryRepository)
+			outboxRepo := new(mocks.OutboxRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			f := fields{storeRepo, categoryRepo, outboxRepo}
 			tc.prepare(f)
-			u := usecases.NewStoreUsecase(storeRepo, nil, nil, msgProducer, time.Second*2)
-			err := u.Update(context.TODO(), tc.arg)
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, nil, nil, categoryRepo, outboxRepo, txManager, nil, nil, time.Second*2)
+			err := u.Update(adminContext(), tc.arg)
 			if tc.expectedErr {
 				assert.Error(t, err)
 			} else {
 				assert.NoError(t, err)
 			}
 			storeRepo.AssertExpectations(t)
-			msgProducer.AssertExpectations(t)
+			categoryRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_UploadImage(t *testing.T) {
+	type fields struct {
+		storeRepo      *mocks.StoreRepository
+		outboxRepo     *mocks.OutboxRepository
+		blobStorage    *mocks.BlobStorage
+		imageProcessor *mocks.ImageProcessor
+	}
+
+	testCases := []struct {
+		name        string
+		arg         func() *domain.StoreImageRequest
+		ctx         func(store *domain.Store) context.Context
+		expectedErr error
+		prepare     func(f fields, store *domain.Store)
+	}{
+		{
+			name: "failure_unsupported_content_type",
+			arg: func() *domain.StoreImageRequest {
+				param := sample.NewStoreImageRequest()
+				param.ContentType = "image/svg+xml"
+				return param
+			},
+			expectedErr: domain.ErrUnsupportedImage,
+			prepare:     func(f fields, store *domain.Store) {},
+		},
+		{
+			name: "failure_declared_size_too_large",
+			arg: func() *domain.StoreImageRequest {
+				param := sample.NewStoreImageRequest()
+				param.Size = domain.DefaultMaxImageSize + 1
+				return param
+			},
+			expectedErr: domain.ErrImageTooLarge,
+			prepare:     func(f fields, store *domain.Store) {},
+		},
+		{
+			name:        "failure_find_store_by_id_returns_error",
+			arg:         sample.NewStoreImageRequest,
+			expectedErr: domain.ErrNotFound,
+			prepare: func(f fields, store *domain.Store) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name: "failure_not_owner",
+			arg:  sample.NewStoreImageRequest,
+			ctx: func(store *domain.Store) context.Context {
+				return userContext(uuid.NewV4().String())
+			},
+			expectedErr: domain.ErrForbidden,
+			prepare: func(f fields, store *domain.Store) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+			},
+		},
+		{
+			name: "failure_body_too_large",
+			arg: func() *domain.StoreImageRequest {
+				param := sample.NewStoreImageRequest()
+				param.Size = 0
+				param.Body = strings.NewReader(strings.Repeat("x", int(domain.DefaultMaxImageSize)+1))
+				return param
+			},
+			expectedErr: domain.ErrImageTooLarge,
+			prepare: func(f fields, store *domain.Store) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+			},
+		},
+		{
+			name:        "failure_process_returns_error",
+			arg:         sample.NewStoreImageRequest,
+			expectedErr: domain.ErrUnsupportedImage,
+			prepare: func(f fields, store *domain.Store) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+				f.imageProcessor.On("Process", mock.Anything, mock.Anything, "image/jpeg", domain.StoreImageVariants).Return(nil, domain.ErrUnsupportedImage).Once()
+			},
+		},
+		{
+			name:        "failure_put_deletes_stored_variants",
+			arg:         sample.NewStoreImageRequest,
+			expectedErr: domain.ErrInternal,
+			prepare: func(f fields, store *domain.Store) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+				f.imageProcessor.On("Process", mock.Anything, mock.Anything, "image/jpeg", domain.StoreImageVariants).Return(sample.NewEncodedImages(), nil).Once()
+				f.blobStorage.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasSuffix(key, "/original.jpg")
+				}), mock.Anything, int64(8), "image/jpeg").Return(nil).Once()
+				f.blobStorage.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasSuffix(key, "/medium.jpg")
+				}), mock.Anything, int64(6), "image/jpeg").Return(domain.ErrInternal).Once()
+				f.blobStorage.On("Delete", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasSuffix(key, "/original.jpg")
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:        "failure_update_store_deletes_stored_variants",
+			arg:         sample.NewStoreImageRequest,
+			expectedErr: domain.ErrConflict,
+			prepare: func(f fields, store *domain.Store) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+				f.imageProcessor.On("Process", mock.Anything, mock.Anything, "image/jpeg", domain.StoreImageVariants).Return(sample.NewEncodedImages(), nil).Once()
+				f.blobStorage.On("Put", mock.Anything, mock.AnythingOfType("string"), mock.Anything, mock.Anything, "image/jpeg").Return(nil).Times(3)
+				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(domain.ErrConflict).Once()
+				f.blobStorage.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil).Times(3)
+			},
+		},
+		{
+			name: "success",
+			arg:  sample.NewStoreImageRequest,
+			prepare: func(f fields, store *domain.Store) {
+				store.Images = domain.StoreImages{domain.StoreImageOriginal: "stores/old/original.jpg"}
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+				f.imageProcessor.On("Process", mock.Anything, mock.Anything, "image/jpeg", domain.StoreImageVariants).Return(sample.NewEncodedImages(), nil).Once()
+				f.blobStorage.On("Put", mock.Anything, mock.MatchedBy(func(key string) bool {
+					return strings.HasPrefix(key, "stores/"+store.ID+"/")
+				}), mock.Anything, mock.Anything, "image/jpeg").Return(nil).Times(3)
+				f.storeRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return len(s.Images) == 3 && s.Image == s.Images[domain.StoreImageOriginal] && strings.HasSuffix(s.Image, "/original.jpg")
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					event := new(domain.StoreUpdatedEvent)
+					if err := json.Unmarshal([]byte(m.Payload), event); err != nil {
+						return false
+					}
+					_, ok := event.Changes["images"]
+					return ok && event.Store.ID == store.ID
+				})).Return(nil).Once()
+				f.blobStorage.On("Delete", mock.Anything, "stores/old/original.jpg").Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			store := sample.NewStore()
+			storeRepo := new(mocks.StoreRepository)
+			outboxRepo := new(mocks.OutboxRepository)
+			blobStorage := new(mocks.BlobStorage)
+			imageProcessor := new(mocks.ImageProcessor)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(fields{storeRepo, outboxRepo, blobStorage, imageProcessor}, store)
+
+			ctx := adminContext()
+			if tc.ctx != nil {
+				ctx = tc.ctx(store)
+			}
+			hoursRepo := new(mocks.StoreHoursRepository)
+			hoursRepo.On("FindByStoreIDs", mock.Anything, mock.Anything).Return([]*domain.StoreHours{}, nil).Maybe()
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, nil, hoursRepo, nil, outboxRepo, txManager, blobStorage, imageProcessor, time.Second*2)
+			res, err := u.UploadImage(ctx, tc.arg())
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, store.ID, res.ID)
+			}
+			storeRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
+			blobStorage.AssertExpectations(t)
+			imageProcessor.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_SetHours(t *testing.T) {
+	type fields struct {
+		storeRepo  *mocks.StoreRepository
+		hoursRepo  *mocks.StoreHoursRepository
+		outboxRepo *mocks.OutboxRepository
+	}
+
+	testCases := []struct {
+		name        string
+		arg         func() *domain.StoreHoursRequest
+		ctx         func(store *domain.Store) context.Context
+		expectedErr error
+		prepare     func(f fields, store *domain.Store)
+	}{
+		{
+			name: "failure_invalid_timezone",
+			arg: func() *domain.StoreHoursRequest {
+				param := sample.NewStoreHoursRequest()
+				param.Timezone = "Mars/Olympus"
+				return param
+			},
+			expectedErr: domain.ErrInvalidTimezone,
+			prepare:     func(f fields, store *domain.Store) {},
+		},
+		{
+			name: "failure_invalid_hours",
+			arg: func() *domain.StoreHoursRequest {
+				param := sample.NewStoreHoursRequest()
+				param.Weekly[0].Opens = "9am"
+				return param
+			},
+			expectedErr: domain.ErrInvalidHours,
+			prepare:     func(f fields, store *domain.Store) {},
+		},
+		{
+			name:        "failure_find_store_by_id_returns_error",
+			arg:         sample.NewStoreHoursRequest,
+			expectedErr: domain.ErrNotFound,
+			prepare: func(f fields, store *domain.Store) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name: "failure_not_owner",
+			arg:  sample.NewStoreHoursRequest,
+			ctx: func(store *domain.Store) context.Context {
+				return userContext(uuid.NewV4().String())
+			},
+			expectedErr: domain.ErrForbidden,
+			prepare: func(f fields, store *domain.Store) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+			},
+		},
+		{
+			name:        "failure_save_returns_error",
+			arg:         sample.NewStoreHoursRequest,
+			expectedErr: domain.ErrInternal,
+			prepare: func(f fields, store *domain.Store) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+				f.hoursRepo.On("FindByStoreIDs", mock.Anything, []string{store.ID}).Return([]*domain.StoreHours{}, nil).Once()
+				f.hoursRepo.On("Save", mock.Anything, mock.AnythingOfType("*domain.StoreHours")).Return(domain.ErrInternal).Once()
+			},
+		},
+		{
+			name: "success",
+			arg:  sample.NewStoreHoursRequest,
+			ctx: func(store *domain.Store) context.Context {
+				return userContext(store.UserID)
+			},
+			prepare: func(f fields, store *domain.Store) {
+				previous := sample.NewStoreHours()
+				previous.StoreID = store.ID
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+				f.hoursRepo.On("FindByStoreIDs", mock.Anything, []string{store.ID}).Return([]*domain.StoreHours{previous}, nil).Once()
+				f.hoursRepo.On("Save", mock.Anything, mock.MatchedBy(func(h *domain.StoreHours) bool {
+					return h.Timezone == "Africa/Luanda" && len(h.Weekly) == 3 && h.Closures[0].To == "2021-12-25"
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					var event domain.StoreUpdatedEvent
+					_ = json.Unmarshal([]byte(m.Payload), &event)
+					return event.Hours != nil && event.Changes["hours"].From != nil
+				})).Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			store := sample.NewStore()
+			storeRepo := new(mocks.StoreRepository)
+			hoursRepo := new(mocks.StoreHoursRepository)
+			outboxRepo := new(mocks.OutboxRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(fields{storeRepo, hoursRepo, outboxRepo}, store)
+
+			ctx := adminContext()
+			if tc.ctx != nil {
+				ctx = tc.ctx(store)
+			}
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, nil, hoursRepo, nil, outboxRepo, txManager, nil, nil, time.Second*2)
+			res, err := u.SetHours(ctx, tc.arg())
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "Africa/Luanda", res.Hours.Timezone)
+				assert.NotNil(t, res.NextChangeAt)
+			}
+			storeRepo.AssertExpectations(t)
+			hoursRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
 		})
 	}
 }
 
 func Test_StoreUsecase_Delete(t *testing.T) {
 	type fields struct {
-		storeRepo   *mocks.StoreRepository
-		accountRepo *mocks.AccountRepository
-		msgProducer *mocks.MessengerProducer
+		storeRepo  *mocks.StoreRepository
+		outboxRepo *mocks.OutboxRepository
 	}
 
 	testCases := []struct {
@@ -597,20 +1283,7 @@ func Test_StoreUsecase_Delete(t *testing.T) {
 			},
 		},
 		{
-			name:        "failure_delete_store_returns_error",
-			arg:         uuid.NewV4().String(),
-			expectedErr: true,
-			prepare: func(f fields) {
-				store := sample.NewStore()
-				store.Status = domain.StoreStatusActive
-				f.storeRepo.
-					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
-					Return(store, nil).Once()
-				f.storeRepo.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(errors.New("Unexpected Error")).Once()
-			},
-		},
-		{
-			name:        "failure_delete_account_returns_error",
+			name:        "failure_update_store_returns_error",
 			arg:         uuid.NewV4().String(),
 			expectedErr: true,
 			prepare: func(f fields) {
@@ -619,12 +1292,11 @@ func Test_StoreUsecase_Delete(t *testing.T) {
 				f.storeRepo.
 					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
 					Return(store, nil).Once()
-				f.storeRepo.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
-				f.accountRepo.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(errors.New("Unexpected Error")).Once()
+				f.storeRepo.On("Update", mock.Anything, store).Return(errors.New("Unexpected Error")).Once()
 			},
 		},
 		{
-			name:        "failure_publish_msg_returns_error",
+			name:        "failure_store_outbox_message_returns_error",
 			arg:         uuid.NewV4().String(),
 			expectedErr: true,
 			prepare: func(f fields) {
@@ -633,9 +1305,8 @@ func Test_StoreUsecase_Delete(t *testing.T) {
 				f.storeRepo.
 					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
 					Return(store, nil).Once()
-				f.storeRepo.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
-				f.accountRepo.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
-				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(errors.New("Unexpected Error"))
+				f.storeRepo.On("Update", mock.Anything, store).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(errors.New("Unexpected Error")).Once()
 			},
 		},
 		{
@@ -647,9 +1318,12 @@ func Test_StoreUsecase_Delete(t *testing.T) {
 				f.storeRepo.
 					On("FindByID", mock.Anything, mock.AnythingOfType("string")).
 					Return(store, nil).Once()
-				f.storeRepo.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
-				f.accountRepo.On("Delete", mock.Anything, mock.AnythingOfType("string")).Return(nil).Once()
-				f.msgProducer.On("Publish", mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("string")).Return(nil)
+				f.storeRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.DeletedAt != nil
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					return m.Topic == "store.delete" && strings.Contains(m.Payload, `"deleted_at"`)
+				})).Return(nil).Once()
 			},
 		},
 	}
@@ -660,11 +1334,13 @@ func Test_StoreUsecase_Delete(t *testing.T) {
 			t.Parallel()
 			storeRepo := new(mocks.StoreRepository)
 			accountRepo := new(mocks.AccountRepository)
-			msgProducer := new(mocks.MessengerProducer)
-			f := fields{storeRepo, accountRepo, msgProducer}
-			tc.prepare(f)
-			u := usecases.NewStoreUsecase(storeRepo, accountRepo, nil, msgProducer, time.Second*2)
-			err := u.Delete(context.TODO(), tc.arg)
+			outboxRepo := new(mocks.OutboxRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(fields{storeRepo, outboxRepo})
+			u := usecases.NewStoreUsecase(storeRepo, accountRepo, nil, nil, nil, nil, outboxRepo, txManager, nil, nil, time.Second*2)
+			u.DeleteStoreTopic = "store.delete"
+			err := u.Delete(adminContext(), tc.arg)
 			if tc.expectedErr {
 				assert.Error(t, err)
 			} else {
@@ -673,7 +1349,1190 @@ func Test_StoreUsecase_Delete(t *testing.T) {
 
 			storeRepo.AssertExpectations(t)
 			accountRepo.AssertExpectations(t)
-			msgProducer.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_Restore(t *testing.T) {
+	type fields struct {
+		storeRepo  *mocks.StoreRepository
+		outboxRepo *mocks.OutboxRepository
+	}
+
+	testCases := []struct {
+		name        string
+		arg         string
+		expectedErr bool
+		prepare     func(f fields)
+	}{
+		{
+			name:        "failure_find_deleted_store_by_id_returns_error",
+			arg:         uuid.NewV4().String(),
+			expectedErr: true,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindDeletedByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:        "failure_update_store_returns_error",
+			arg:         uuid.NewV4().String(),
+			expectedErr: true,
+			prepare: func(f fields) {
+				store := sample.NewStore()
+				store.MarkDeleted(time.Now())
+				f.storeRepo.On("FindDeletedByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+				f.storeRepo.On("Update", mock.Anything, store).Return(errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name: "success",
+			arg:  uuid.NewV4().String(),
+			prepare: func(f fields) {
+				store := sample.NewStore()
+				store.MarkDeleted(time.Now())
+				f.storeRepo.On("FindDeletedByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+				f.storeRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.DeletedAt == nil
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					return m.Topic == "store.new" && !strings.Contains(m.Payload, `"deleted_at"`)
+				})).Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeRepo := new(mocks.StoreRepository)
+			outboxRepo := new(mocks.OutboxRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(fields{storeRepo, outboxRepo})
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, nil, nil, nil, outboxRepo, txManager, nil, nil, time.Second*2)
+			u.NewStoreTopic = "store.new"
+			err := u.Restore(adminContext(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			storeRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_Purge(t *testing.T) {
+	type fields struct {
+		storeRepo   *mocks.StoreRepository
+		accountRepo *mocks.AccountRepository
+		ledgerRepo  *mocks.LedgerRepository
+		blobStorage *mocks.BlobStorage
+	}
+	accountOf := func(store *domain.Store, balance int64) *domain.Account {
+		account := sample.NewAccount()
+		account.ID = store.AccountID
+		account.Balance = decimal.NewFromInt(balance)
+		return account
+	}
+	testCases := []struct {
+		name        string
+		purged      int
+		expectedErr bool
+		prepare     func(f fields)
+	}{
+		{
+			name:        "failure_find_deleted_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindDeleted", mock.Anything, mock.AnythingOfType("time.Time"), 100).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:        "failure_store_ledger_entry_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				store := sample.NewStore()
+				f.storeRepo.On("FindDeleted", mock.Anything, mock.AnythingOfType("time.Time"), 100).Return(domain.Stores{store}, nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, store.AccountID).Return(accountOf(store, 0), nil).Once()
+				f.storeRepo.On("Delete", mock.Anything, store.ID).Return(nil).Once()
+				f.ledgerRepo.On("Store", mock.Anything, mock.Anything).Return(errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:        "failure_skips_the_store_failing_to_be_purged",
+			purged:      1,
+			expectedErr: true,
+			prepare: func(f fields) {
+				failing := sample.NewStore()
+				store := sample.NewStore()
+				f.storeRepo.On("FindDeleted", mock.Anything, mock.AnythingOfType("time.Time"), 100).Return(domain.Stores{failing, store}, nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, failing.AccountID).Return(accountOf(failing, 0), nil).Once()
+				f.storeRepo.On("Delete", mock.Anything, failing.ID).Return(nil).Once()
+				f.ledgerRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Twice()
+				f.accountRepo.On("Delete", mock.Anything, failing.AccountID).Return(errors.New("Unexpected Error")).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, store.AccountID).Return(accountOf(store, 0), nil).Once()
+				f.storeRepo.On("Delete", mock.Anything, store.ID).Return(nil).Once()
+				f.accountRepo.On("Delete", mock.Anything, store.AccountID).Return(nil).Once()
+			},
+		},
+		{
+			name:   "success",
+			purged: 2,
+			prepare: func(f fields) {
+				store := sample.NewStore()
+				store.Images = domain.StoreImages{domain.StoreImageOriginal: "stores/old/original.jpg"}
+				other := sample.NewStore()
+				f.storeRepo.On("FindDeleted", mock.Anything, mock.AnythingOfType("time.Time"), 100).Return(domain.Stores{store, other}, nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, store.AccountID).Return(accountOf(store, 12), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, other.AccountID).Return(accountOf(other, 0), nil).Once()
+				f.storeRepo.On("Delete", mock.Anything, store.ID).Return(nil).Once()
+				f.storeRepo.On("Delete", mock.Anything, other.ID).Return(nil).Once()
+				f.ledgerRepo.On("Store", mock.Anything, mock.MatchedBy(func(e *domain.LedgerEntry) bool {
+					return e.AccountID == store.AccountID && e.ReferenceID == store.ID && e.Reason == domain.LedgerReasonAccountPurged &&
+						e.Direction == domain.LedgerDirectionDebit && e.Amount.Equal(decimal.NewFromInt(12)) && e.Balance.IsZero()
+				})).Return(nil).Once()
+				f.ledgerRepo.On("Store", mock.Anything, mock.MatchedBy(func(e *domain.LedgerEntry) bool {
+					return e.AccountID == other.AccountID && e.Amount.IsZero()
+				})).Return(nil).Once()
+				f.accountRepo.On("Delete", mock.Anything, store.AccountID).Return(nil).Once()
+				f.accountRepo.On("Delete", mock.Anything, other.AccountID).Return(nil).Once()
+				f.blobStorage.On("Delete", mock.Anything, "stores/old/original.jpg").Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeRepo := new(mocks.StoreRepository)
+			accountRepo := new(mocks.AccountRepository)
+			ledgerRepo := new(mocks.LedgerRepository)
+			outboxRepo := new(mocks.OutboxRepository)
+			blobStorage := new(mocks.BlobStorage)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(fields{storeRepo, accountRepo, ledgerRepo, blobStorage})
+			u := usecases.NewStoreUsecase(storeRepo, accountRepo, ledgerRepo, nil, nil, nil, outboxRepo, txManager, blobStorage, nil, time.Second*2)
+			purged, err := u.Purge(context.TODO(), 30*24*time.Hour)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.purged, purged)
+			storeRepo.AssertExpectations(t)
+			accountRepo.AssertExpectations(t)
+			ledgerRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
+			blobStorage.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_GetAccount(t *testing.T) {
+	type fields struct {
+		storeRepo   *mocks.StoreRepository
+		accountRepo *mocks.AccountRepository
+	}
+
+	testCases := []struct {
+		name        string
+		arg         string
+		expectedErr bool
+		prepare     func(f fields)
+	}{
+		{
+			name:        "failure_find_store_by_id_returns_error",
+			arg:         uuid.NewV4().String(),
+			expectedErr: true,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:        "failure_find_account_by_id_returns_error",
+			arg:         uuid.NewV4().String(),
+			expectedErr: true,
+			prepare: func(f fields) {
+				store := sample.NewStore()
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+				f.accountRepo.On("FindByID", mock.Anything, store.AccountID).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name: "success",
+			arg:  uuid.NewV4().String(),
+			prepare: func(f fields) {
+				store := sample.NewStore()
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+				f.accountRepo.On("FindByID", mock.Anything, store.AccountID).Return(sample.NewAccount(), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeRepo := new(mocks.StoreRepository)
+			accountRepo := new(mocks.AccountRepository)
+			tc.prepare(fields{storeRepo, accountRepo})
+			u := usecases.NewStoreUsecase(storeRepo, accountRepo, nil, nil, nil, nil, nil, nil, nil, nil, time.Second*2)
+			res, err := u.GetAccount(adminContext(), tc.arg)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, res)
+			}
+
+			storeRepo.AssertExpectations(t)
+			accountRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_UpdateBalance(t *testing.T) {
+	type fields struct {
+		storeRepo   *mocks.StoreRepository
+		accountRepo *mocks.AccountRepository
+		ledgerRepo  *mocks.LedgerRepository
+		outboxRepo  *mocks.OutboxRepository
+	}
+
+	testCases := []struct {
+		name        string
+		operation   string
+		amount      decimal.Decimal
+		expectedErr error
+		balance     decimal.Decimal
+		prepare     func(f fields)
+	}{
+		{
+			name:        "failure_find_store_by_id_returns_error",
+			operation:   domain.AccountOperationDeposit,
+			amount:      decimal.NewFromInt(10),
+			expectedErr: domain.ErrNotFound,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:        "failure_find_account_by_id_returns_error",
+			operation:   domain.AccountOperationDeposit,
+			amount:      decimal.NewFromInt(10),
+			expectedErr: domain.ErrNotFound,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(nil, domain.ErrNotFound).Once()
+			},
+		},
+		{
+			name:        "failure_invalid_amount",
+			operation:   domain.AccountOperationDeposit,
+			amount:      decimal.NewFromInt(-10),
+			expectedErr: domain.ErrInvalidAmount,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil).Once()
+			},
+		},
+		{
+			name:        "failure_amount_beyond_the_balance_scale",
+			operation:   domain.AccountOperationDeposit,
+			amount:      decimal.RequireFromString("0.000000001"),
+			expectedErr: domain.ErrInvalidAmount,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil).Once()
+			},
+		},
+		{
+			name:        "failure_insufficient_balance",
+			operation:   domain.AccountOperationWithdraw,
+			amount:      decimal.NewFromInt(1001),
+			expectedErr: domain.ErrInsufficientBalance,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil).Once()
+			},
+		},
+		{
+			name:        "failure_update_account_returns_error",
+			operation:   domain.AccountOperationDeposit,
+			amount:      decimal.NewFromInt(10),
+			expectedErr: domain.ErrInternal,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil).Once()
+				f.accountRepo.On("Update", mock.Anything, mock.Anything).Return(domain.ErrInternal).Once()
+			},
+		},
+		{
+			name:        "failure_store_ledger_entry_returns_error",
+			operation:   domain.AccountOperationDeposit,
+			amount:      decimal.NewFromInt(10),
+			expectedErr: domain.ErrInternal,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil).Once()
+				f.accountRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.ledgerRepo.On("Store", mock.Anything, mock.Anything).Return(domain.ErrInternal).Once()
+			},
+		},
+		{
+			name:        "failure_store_outbox_message_returns_error",
+			operation:   domain.AccountOperationDeposit,
+			amount:      decimal.NewFromInt(10),
+			expectedErr: domain.ErrInternal,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil).Once()
+				f.accountRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.ledgerRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(domain.ErrInternal).Once()
+			},
+		},
+		{
+			name:      "success_deposit",
+			operation: domain.AccountOperationDeposit,
+			amount:    decimal.RequireFromString("10.25"),
+			balance:   decimal.RequireFromString("1010.25"),
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil).Once()
+				f.accountRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.ledgerRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.MatchedBy(func(m *domain.OutboxMessage) bool {
+					return m.Topic == "store.balance.updated"
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name:      "success_withdraw",
+			operation: domain.AccountOperationWithdraw,
+			amount:    decimal.RequireFromString("0.01"),
+			balance:   decimal.RequireFromString("999.99"),
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil).Once()
+				f.accountRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.ledgerRepo.On("Store", mock.Anything, mock.MatchedBy(func(e *domain.LedgerEntry) bool {
+					return e.Direction == domain.LedgerDirectionDebit &&
+						e.Reason == domain.AccountOperationWithdraw &&
+						e.Balance.Equal(decimal.RequireFromString("999.99"))
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeRepo := new(mocks.StoreRepository)
+			accountRepo := new(mocks.AccountRepository)
+			ledgerRepo := new(mocks.LedgerRepository)
+			outboxRepo := new(mocks.OutboxRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(fields{storeRepo, accountRepo, ledgerRepo, outboxRepo})
+			u := usecases.NewStoreUsecase(storeRepo, accountRepo, ledgerRepo, nil, nil, nil, outboxRepo, txManager, nil, nil, time.Second*2)
+			u.BalanceUpdatedTopic = "store.balance.updated"
+
+			param := &domain.AccountOperationRequest{ID: uuid.NewV4().String(), Amount: tc.amount}
+			var res *domain.Account
+			var err error
+			if tc.operation == domain.AccountOperationWithdraw {
+				res, err = u.Withdraw(adminContext(), param)
+			} else {
+				res, err = u.Deposit(adminContext(), param)
+			}
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, res.Balance.Equal(tc.balance))
+			}
+
+			storeRepo.AssertExpectations(t)
+			accountRepo.AssertExpectations(t)
+			ledgerRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// lockingAccounts is an in memory AccountRepository whose FindForUpdate
+// holds the lock of the row until the end of the transaction, as postgres
+// does
+type lockingAccounts struct {
+	domain.AccountRepository
+	row     sync.Mutex
+	mu      sync.Mutex
+	account domain.Account
+}
+
+type lockingTxKey struct{}
+
+// lockingTxManager runs fn and then releases the row locks taken in it
+type lockingTxManager struct{}
+
+func (lockingTxManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	var unlock []func()
+	err := fn(context.WithValue(ctx, lockingTxKey{}, &unlock))
+	for _, f := range unlock {
+		f()
+	}
+	return err
+}
+
+func (r *lockingAccounts) FindByID(ctx context.Context, id string) (*domain.Account, error) {
+	r.mu.Lock()
+	account := r.account
+	r.mu.Unlock()
+	// leaves the other operations the time to read the same balance
+	time.Sleep(time.Millisecond)
+	return &account, nil
+}
+
+func (r *lockingAccounts) FindForUpdate(ctx context.Context, id string) (*domain.Account, error) {
+	unlock := ctx.Value(lockingTxKey{}).(*[]func())
+	r.row.Lock()
+	*unlock = append(*unlock, r.row.Unlock)
+	return r.FindByID(ctx, id)
+}
+
+func (r *lockingAccounts) Update(ctx context.Context, account *domain.Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.account = *account
+	return nil
+}
+
+func Test_StoreUsecase_UpdateBalance_Concurrent(t *testing.T) {
+	const withdrawals = 20
+	store := sample.NewStore()
+	accountRepo := &lockingAccounts{account: *sample.NewAccount()}
+	storeRepo := new(mocks.StoreRepository)
+	storeRepo.On("FindByID", mock.Anything, store.ID).Return(store, nil)
+	var balances []string
+	ledgerRepo := new(mocks.LedgerRepository)
+	ledgerRepo.On("Store", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			// called with the row locked
+			balances = append(balances, args.Get(1).(*domain.LedgerEntry).Balance.String())
+		}).
+		Return(nil)
+	outboxRepo := new(mocks.OutboxRepository)
+	outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil)
+	u := usecases.NewStoreUsecase(storeRepo, accountRepo, ledgerRepo, nil, nil, nil, outboxRepo, lockingTxManager{}, nil, nil, time.Second*2)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, withdrawals)
+	for i := 0; i < withdrawals; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := u.Withdraw(adminContext(), &domain.AccountOperationRequest{ID: store.ID, Amount: decimal.NewFromInt(100)})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		if err == nil {
+			succeeded++
+			continue
+		}
+		assert.ErrorIs(t, err, domain.ErrInsufficientBalance)
+	}
+	assert.Equal(t, 10, succeeded)
+	assert.True(t, accountRepo.account.Balance.IsZero(), accountRepo.account.Balance.String())
+	assert.Equal(t, []string{"900", "800", "700", "600", "500", "400", "300", "200", "100", "0"}, balances)
+}
+
+func Test_StoreUsecase_ListTransactions(t *testing.T) {
+	type fields struct {
+		storeRepo  *mocks.StoreRepository
+		ledgerRepo *mocks.LedgerRepository
+	}
+
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(f fields)
+	}{
+		{
+			name:        "failure_find_store_by_id_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:        "failure_find_by_account_id_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				store := sample.NewStore()
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+				f.ledgerRepo.On("FindByAccountID", mock.Anything, store.AccountID, 10, 1).Return(nil, int64(0), errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name: "success",
+			prepare: func(f fields) {
+				store := sample.NewStore()
+				entries := domain.LedgerEntries{sample.NewLedgerEntry()}
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(store, nil).Once()
+				f.ledgerRepo.On("FindByAccountID", mock.Anything, store.AccountID, 10, 1).Return(entries, int64(1), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeRepo := new(mocks.StoreRepository)
+			ledgerRepo := new(mocks.LedgerRepository)
+			tc.prepare(fields{storeRepo, ledgerRepo})
+			u := usecases.NewStoreUsecase(storeRepo, nil, ledgerRepo, nil, nil, nil, nil, nil, nil, nil, time.Second*2)
+			res, total, err := u.ListTransactions(adminContext(), uuid.NewV4().String(), 0, 0)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Equal(t, int64(0), total)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, res, 1)
+				assert.Equal(t, int64(1), total)
+			}
+
+			storeRepo.AssertExpectations(t)
+			ledgerRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_ReconcileAccount(t *testing.T) {
+	type fields struct {
+		storeRepo   *mocks.StoreRepository
+		accountRepo *mocks.AccountRepository
+		ledgerRepo  *mocks.LedgerRepository
+	}
+
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		consistent  bool
+		prepare     func(f fields)
+	}{
+		{
+			name:        "failure_find_store_by_id_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:        "failure_find_account_by_id_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:        "failure_sum_by_account_id_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil).Once()
+				f.ledgerRepo.On("SumByAccountID", mock.Anything, mock.AnythingOfType("string")).Return(decimal.Zero, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name: "success_inconsistent",
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil).Once()
+				f.ledgerRepo.On("SumByAccountID", mock.Anything, mock.AnythingOfType("string")).Return(decimal.NewFromInt(990), nil).Once()
+			},
+		},
+		{
+			name:       "success_consistent",
+			consistent: true,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewStore(), nil).Once()
+				f.accountRepo.On("FindForUpdate", mock.Anything, mock.AnythingOfType("string")).Return(sample.NewAccount(), nil).Once()
+				f.ledgerRepo.On("SumByAccountID", mock.Anything, mock.AnythingOfType("string")).Return(decimal.NewFromInt(1000), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeRepo := new(mocks.StoreRepository)
+			accountRepo := new(mocks.AccountRepository)
+			ledgerRepo := new(mocks.LedgerRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(fields{storeRepo, accountRepo, ledgerRepo})
+			u := usecases.NewStoreUsecase(storeRepo, accountRepo, ledgerRepo, nil, nil, nil, nil, txManager, nil, nil, time.Second*2)
+			res, err := u.ReconcileAccount(adminContext(), uuid.NewV4().String())
+			if tc.expectedErr {
+				assert.Error(t, err)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.consistent, res.Consistent)
+			}
+
+			storeRepo.AssertExpectations(t)
+			accountRepo.AssertExpectations(t)
+			ledgerRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_StatusHistory(t *testing.T) {
+	store := sample.NewStore()
+	history := domain.StoreStatusHistory{sample.NewStoreStatusChange()}
+	type fields struct {
+		storeRepo   *mocks.StoreRepository
+		historyRepo *mocks.StoreStatusHistoryRepository
+	}
+	testCases := []struct {
+		name        string
+		expectedErr bool
+		prepare     func(f fields)
+	}{
+		{
+			name:        "failure_find_store_by_id_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, store.ID).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:        "failure_find_history_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, store.ID).Return(store, nil).Once()
+				f.historyRepo.On("FindByStoreID", mock.Anything, store.ID, 10, 1).Return(nil, int64(0), errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name: "success",
+			prepare: func(f fields) {
+				f.storeRepo.On("FindByID", mock.Anything, store.ID).Return(store, nil).Once()
+				f.historyRepo.On("FindByStoreID", mock.Anything, store.ID, 10, 1).Return(history, int64(1), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeRepo := new(mocks.StoreRepository)
+			historyRepo := new(mocks.StoreStatusHistoryRepository)
+			tc.prepare(fields{storeRepo, historyRepo})
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, historyRepo, nil, nil, nil, nil, nil, nil, time.Second*2)
+			res, total, err := u.StatusHistory(adminContext(), store.ID, 0, 0)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, history, res)
+				assert.Equal(t, int64(1), total)
+			}
+			storeRepo.AssertExpectations(t)
+			historyRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_ReleaseExpired(t *testing.T) {
+	type fields struct {
+		storeRepo   *mocks.StoreRepository
+		historyRepo *mocks.StoreStatusHistoryRepository
+		outboxRepo  *mocks.OutboxRepository
+	}
+	testCases := []struct {
+		name        string
+		released    int
+		expectedErr bool
+		prepare     func(f fields)
+	}{
+		{
+			name:        "failure_find_expired_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				f.historyRepo.On("FindExpired", mock.Anything, mock.AnythingOfType("time.Time"), 100).Return(nil, errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:        "failure_find_store_by_id_returns_error",
+			expectedErr: true,
+			prepare: func(f fields) {
+				change := sample.NewStoreStatusChange()
+				f.historyRepo.On("FindExpired", mock.Anything, mock.AnythingOfType("time.Time"), 100).Return(domain.StoreStatusHistory{change}, nil).Once()
+				f.storeRepo.On("FindByID", mock.Anything, change.StoreID).Return(nil, errors.New("Unexpected Error")).Once()
+				f.historyRepo.On("UpdateRelease", mock.Anything, change).Return(nil).Once()
+			},
+		},
+		{
+			name:        "failure_skips_the_store_failing_to_be_released",
+			released:    1,
+			expectedErr: true,
+			prepare: func(f fields) {
+				failing := sample.NewStoreStatusChange()
+				change := sample.NewStoreStatusChange()
+				store := sample.NewStore()
+				store.ID = change.StoreID
+				store.Status = domain.StoreStatusBlock
+				f.historyRepo.On("FindExpired", mock.Anything, mock.AnythingOfType("time.Time"), 100).Return(domain.StoreStatusHistory{failing, change}, nil).Once()
+				f.storeRepo.On("FindByID", mock.Anything, failing.StoreID).Return(nil, domain.ErrNotFound).Once()
+				f.historyRepo.On("UpdateRelease", mock.Anything, mock.MatchedBy(func(c *domain.StoreStatusChange) bool {
+					return c.ID == failing.ID && c.ReleaseAttempts == 1 && c.ReleaseRetryAt.After(time.Now().Add(59*time.Second))
+				})).Return(nil).Once()
+				f.storeRepo.On("FindByID", mock.Anything, change.StoreID).Return(store, nil).Once()
+				f.historyRepo.On("FindByStoreID", mock.Anything, store.ID, 1, 1).Return(domain.StoreStatusHistory{change}, int64(1), nil).Once()
+				f.storeRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:        "failure_backs_off_on_every_failed_release",
+			expectedErr: true,
+			prepare: func(f fields) {
+				failing := sample.NewStoreStatusChange()
+				failing.ReleaseAttempts = 3
+				f.historyRepo.On("FindExpired", mock.Anything, mock.AnythingOfType("time.Time"), 100).Return(domain.StoreStatusHistory{failing}, nil).Once()
+				f.storeRepo.On("FindByID", mock.Anything, failing.StoreID).Return(nil, domain.ErrNotFound).Once()
+				f.historyRepo.On("UpdateRelease", mock.Anything, mock.MatchedBy(func(c *domain.StoreStatusChange) bool {
+					return c.ReleaseAttempts == 4 && c.ReleaseRetryAt.After(time.Now().Add(7*time.Minute+59*time.Second))
+				})).Return(errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:     "success_disabled",
+			released: 1,
+			prepare: func(f fields) {
+				change := sample.NewStoreStatusChange()
+				change.ToStatus = domain.StoreStatusDisable
+				store := sample.NewStore()
+				store.ID = change.StoreID
+				store.Status = domain.StoreStatusDisable
+				f.historyRepo.On("FindExpired", mock.Anything, mock.AnythingOfType("time.Time"), 100).Return(domain.StoreStatusHistory{change}, nil).Once()
+				f.storeRepo.On("FindByID", mock.Anything, change.StoreID).Return(store, nil).Once()
+				f.storeRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.Status == domain.StoreStatusActive
+				})).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.MatchedBy(func(c *domain.StoreStatusChange) bool {
+					return c.Reason == "disable expired"
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+		{
+			name:     "success",
+			released: 1,
+			prepare: func(f fields) {
+				change := sample.NewStoreStatusChange()
+				store := sample.NewStore()
+				store.ID = change.StoreID
+				store.Status = domain.StoreStatusBlock
+				f.historyRepo.On("FindExpired", mock.Anything, mock.AnythingOfType("time.Time"), 100).Return(domain.StoreStatusHistory{change}, nil).Once()
+				f.storeRepo.On("FindByID", mock.Anything, change.StoreID).Return(store, nil).Once()
+				f.historyRepo.On("FindByStoreID", mock.Anything, store.ID, 1, 1).Return(domain.StoreStatusHistory{change}, int64(1), nil).Once()
+				f.storeRepo.On("Update", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.Status == domain.StoreStatusActive
+				})).Return(nil).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.MatchedBy(func(c *domain.StoreStatusChange) bool {
+					return c.ActorID == domain.StatusActorSystem && c.FromStatus == domain.StoreStatusBlock && c.ToStatus == domain.StoreStatusActive
+				})).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeRepo := new(mocks.StoreRepository)
+			historyRepo := new(mocks.StoreStatusHistoryRepository)
+			outboxRepo := new(mocks.OutboxRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(fields{storeRepo, historyRepo, outboxRepo})
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, historyRepo, nil, nil, outboxRepo, txManager, nil, nil, time.Second*2)
+			released, err := u.ReleaseExpired(context.TODO())
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.released, released)
+			storeRepo.AssertExpectations(t)
+			historyRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_Import(t *testing.T) {
+	owner := uuid.NewV4().String()
+	category := sample.NewCategory()
+	category.Status = domain.CategoryStatusActive
+	disabled := sample.NewCategory()
+	disabled.Status = domain.CategoryStatusDisable
+	missing := uuid.NewV4().String()
+	unexpected := errors.New("Unexpected Error")
+	header := "name,description,category_id,tags,latitude,longitude\n"
+	valid := "store 001,first store," + category.ID + ",coffee|bakery,-8.8383,13.2344\n"
+
+	type fields struct {
+		storeRepo    *mocks.StoreRepository
+		accountRepo  *mocks.AccountRepository
+		historyRepo  *mocks.StoreStatusHistoryRepository
+		categoryRepo *mocks.CategoryRepository
+		outboxRepo   *mocks.OutboxRepository
+	}
+	testCases := []struct {
+		name          string
+		ctx           context.Context
+		arg           *domain.StoreImportRequest
+		expectedErr   error
+		prepare       func(f fields)
+		checkResponse func(t *testing.T, res *domain.StoreImportReport)
+	}{
+		{
+			name:        "failure_without_claims",
+			ctx:         context.TODO(),
+			arg:         &domain.StoreImportRequest{Format: domain.StoreFormatCSV, Body: strings.NewReader(header + valid)},
+			expectedErr: domain.ErrUnauthorized,
+			prepare:     func(f fields) {},
+		},
+		{
+			name:        "failure_unknown_format",
+			ctx:         userContext(owner),
+			arg:         &domain.StoreImportRequest{Format: "xml", Body: strings.NewReader(header + valid)},
+			expectedErr: domain.ErrUnknownFormat,
+			prepare:     func(f fields) {},
+		},
+		{
+			name:        "failure_invalid_header",
+			ctx:         userContext(owner),
+			arg:         &domain.StoreImportRequest{Format: domain.StoreFormatCSV, Body: strings.NewReader("name,tags\n")},
+			expectedErr: domain.ErrInvalidImportHeader,
+			prepare:     func(f fields) {},
+		},
+		{
+			name: "failure_find_category_returns_error",
+			ctx:  userContext(owner),
+			arg:  &domain.StoreImportRequest{Format: domain.StoreFormatCSV, Body: strings.NewReader(header + valid)},
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, category.ID).Return(nil, unexpected).Once()
+			},
+			expectedErr: unexpected,
+		},
+		{
+			name: "success_dry_run",
+			ctx:  userContext(owner),
+			arg: &domain.StoreImportRequest{
+				Format: domain.StoreFormatCSV,
+				Body: strings.NewReader(header + valid +
+					"st,first store," + category.ID + ",,,\n" +
+					"store 003,third store,invalid_id,,,\n" +
+					"store 004,fourth store," + disabled.ID + ",,,\n" +
+					"store 005,fifth store," + category.ID + ",,north,\n" +
+					"store 006,sixth store," + missing + ",,,\n" +
+					"store 007,seventh store," + missing + ",,,\n"),
+				DryRun: true,
+			},
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, category.ID).Return(category, nil).Once()
+				f.categoryRepo.On("FindByID", mock.Anything, disabled.ID).Return(disabled, nil).Once()
+				f.categoryRepo.On("FindByID", mock.Anything, missing).Return(nil, domain.ErrNotFound).Once()
+			},
+			checkResponse: func(t *testing.T, res *domain.StoreImportReport) {
+				assert.True(t, res.DryRun)
+				assert.Equal(t, 7, res.Rows)
+				assert.Equal(t, 1, res.Imported)
+				assert.Equal(t, 6, res.Failed)
+				assert.Len(t, res.Errors, 6)
+				assert.Equal(t, 2, res.Errors[0].Row)
+				assert.Equal(t, "Name", res.Errors[0].Field)
+				assert.Equal(t, "CategoryID", res.Errors[1].Field)
+				assert.Equal(t, 4, res.Errors[2].Row)
+				assert.Equal(t, "CategoryID", res.Errors[2].Field)
+				assert.Equal(t, "latitude", res.Errors[3].Field)
+				assert.Equal(t, 7, res.Errors[5].Row)
+				assert.Equal(t, "CategoryID", res.Errors[5].Field)
+			},
+		},
+		{
+			name: "success",
+			ctx:  userContext(owner),
+			arg: &domain.StoreImportRequest{
+				Format: domain.StoreFormatNDJSON,
+				Body: strings.NewReader(`{"name":"store 001","description":"first store","category_id":"` + category.ID + `"}` + "\n" +
+					`{"name":"store 002","description":"second store","category_id":"` + category.ID + `"}` + "\n"),
+			},
+			prepare: func(f fields) {
+				f.categoryRepo.On("FindByID", mock.Anything, category.ID).Return(category, nil).Once()
+				f.accountRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Twice()
+				f.storeRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.UserID == owner && s.CategoryID == category.ID
+				})).Return(nil).Once()
+				f.storeRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("Unexpected Error")).Once()
+				f.historyRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+				f.outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil).Once()
+			},
+			checkResponse: func(t *testing.T, res *domain.StoreImportReport) {
+				assert.False(t, res.DryRun)
+				assert.Equal(t, 2, res.Rows)
+				assert.Equal(t, 1, res.Imported)
+				assert.Equal(t, 1, res.Failed)
+				assert.Equal(t, 2, res.Errors[0].Row)
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeRepo := new(mocks.StoreRepository)
+			accountRepo := new(mocks.AccountRepository)
+			historyRepo := new(mocks.StoreStatusHistoryRepository)
+			categoryRepo := new(mocks.CategoryRepository)
+			outboxRepo := new(mocks.OutboxRepository)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			tc.prepare(fields{storeRepo, accountRepo, historyRepo, categoryRepo, outboxRepo})
+			u := usecases.NewStoreUsecase(storeRepo, accountRepo, nil, historyRepo, nil, categoryRepo, outboxRepo, txManager, nil, nil, time.Second*2)
+			res, err := u.Import(tc.ctx, tc.arg)
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+				assert.Nil(t, res)
+			} else {
+				assert.NoError(t, err)
+				tc.checkResponse(t, res)
+			}
+			storeRepo.AssertExpectations(t)
+			accountRepo.AssertExpectations(t)
+			categoryRepo.AssertExpectations(t)
+			outboxRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_Export(t *testing.T) {
+	testCases := []struct {
+		name        string
+		ctx         context.Context
+		format      string
+		expectedErr bool
+		lines       int
+		prepare     func(storeRepo *mocks.StoreRepository)
+	}{
+		{
+			name:        "failure_not_admin",
+			ctx:         userContext(uuid.NewV4().String()),
+			format:      domain.StoreFormatCSV,
+			expectedErr: true,
+			prepare:     func(storeRepo *mocks.StoreRepository) {},
+		},
+		{
+			name:        "failure_unknown_format",
+			ctx:         adminContext(),
+			format:      "xml",
+			expectedErr: true,
+			prepare:     func(storeRepo *mocks.StoreRepository) {},
+		},
+		{
+			name:        "failure_find_all_returns_error",
+			ctx:         adminContext(),
+			format:      domain.StoreFormatNDJSON,
+			expectedErr: true,
+			prepare: func(storeRepo *mocks.StoreRepository) {
+				storeRepo.On("FindAll", mock.Anything, mock.Anything, mock.Anything, mock.Anything, 2, 1).Return(nil, int64(0), errors.New("Unexpected Error")).Once()
+			},
+		},
+		{
+			name:   "success",
+			ctx:    adminContext(),
+			format: domain.StoreFormatCSV,
+			lines:  4,
+			prepare: func(storeRepo *mocks.StoreRepository) {
+				first := domain.Stores{sample.NewStore(), sample.NewStore()}
+				storeRepo.On("FindAll", mock.Anything, (*domain.StoreFilter)(nil), mock.Anything, (*domain.Cursor)(nil), 2, 1).Return(first, int64(3), nil).Once()
+				storeRepo.On("FindAll", mock.Anything, (*domain.StoreFilter)(nil), mock.Anything, mock.MatchedBy(func(c *domain.Cursor) bool {
+					return c != nil && c.ID == first[1].ID && !c.Backward
+				}), 2, 1).Return(domain.Stores{sample.NewStore()}, int64(3), nil).Once()
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeRepo := new(mocks.StoreRepository)
+			tc.prepare(storeRepo)
+			u := usecases.NewStoreUsecase(storeRepo, nil, nil, nil, nil, nil, nil, nil, nil, nil, time.Second*2)
+			u.ExportBatchSize = 2
+			var buf bytes.Buffer
+			err := u.Export(tc.ctx, tc.format, &buf)
+			if tc.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), tc.lines)
+			}
+			storeRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func Test_StoreUsecase_Authorization(t *testing.T) {
+	owner := uuid.NewV4().String()
+	activeCategory := sample.NewCategory()
+	activeCategory.Status = domain.CategoryStatusActive
+
+	testCases := []struct {
+		name        string
+		expectedErr error
+		run         func(u *usecases.StoreUsecase, storeRepo *mocks.StoreRepository) error
+	}{
+		{
+			name:        "create_without_claims",
+			expectedErr: domain.ErrUnauthorized,
+			run: func(u *usecases.StoreUsecase, storeRepo *mocks.StoreRepository) error {
+				return u.Store(context.TODO(), sample.NewCreateStoreRequest())
+			},
+		},
+		{
+			name: "create_owned_by_caller",
+			run: func(u *usecases.StoreUsecase, storeRepo *mocks.StoreRepository) error {
+				storeRepo.On("Create", mock.Anything, mock.MatchedBy(func(s *domain.Store) bool {
+					return s.UserID == owner
+				})).Return(nil).Once()
+				arg := sample.NewCreateStoreRequest()
+				arg.UserID = uuid.NewV4().String()
+				return u.Store(userContext(owner), arg)
+			},
+		},
+		{
+			name:        "block_by_user",
+			expectedErr: domain.ErrForbidden,
+			run: func(u *usecases.StoreUsecase, storeRepo *mocks.StoreRepository) error {
+				store := sample.NewStore()
+				store.UserID = owner
+				store.Status = domain.StoreStatusActive
+				storeRepo.On("FindByID", mock.Anything, store.ID).Return(store, nil).Once()
+				return u.ChangeStatus(userContext(owner), &domain.StoreStatusRequest{ID: store.ID, Action: domain.StoreActionBlock, Reason: "spam"})
+			},
+		},
+		{
+			name:        "activate_without_claims",
+			expectedErr: domain.ErrUnauthorized,
+			run: func(u *usecases.StoreUsecase, storeRepo *mocks.StoreRepository) error {
+				store := sample.NewStore()
+				store.Status = domain.StoreStatusDisable
+				storeRepo.On("FindByID", mock.Anything, store.ID).Return(store, nil).Once()
+				return u.ChangeStatus(context.TODO(), &domain.StoreStatusRequest{ID: store.ID, Action: domain.StoreActionActivate})
+			},
+		},
+		{
+			name:        "update_by_other_user",
+			expectedErr: domain.ErrForbidden,
+			run: func(u *usecases.StoreUsecase, storeRepo *mocks.StoreRepository) error {
+				store := sample.NewStore()
+				store.UserID = owner
+				storeRepo.On("FindByID", mock.Anything, store.ID).Return(store, nil).Once()
+				arg := sample.NewUpdateStoreRequest()
+				arg.ID = store.ID
+				return u.Update(userContext(uuid.NewV4().String()), arg)
+			},
+		},
+		{
+			name:        "delete_by_other_user",
+			expectedErr: domain.ErrForbidden,
+			run: func(u *usecases.StoreUsecase, storeRepo *mocks.StoreRepository) error {
+				store := sample.NewStore()
+				store.UserID = owner
+				storeRepo.On("FindByID", mock.Anything, store.ID).Return(store, nil).Once()
+				return u.Delete(userContext(uuid.NewV4().String()), store.ID)
+			},
+		},
+		{
+			name:        "restore_by_other_user",
+			expectedErr: domain.ErrForbidden,
+			run: func(u *usecases.StoreUsecase, storeRepo *mocks.StoreRepository) error {
+				store := sample.NewStore()
+				store.UserID = owner
+				store.MarkDeleted(time.Now())
+				storeRepo.On("FindDeletedByID", mock.Anything, store.ID).Return(store, nil).Once()
+				return u.Restore(userContext(uuid.NewV4().String()), store.ID)
+			},
+		},
+		{
+			name: "disable_by_owner",
+			run: func(u *usecases.StoreUsecase, storeRepo *mocks.StoreRepository) error {
+				store := sample.NewStore()
+				store.UserID = owner
+				store.Status = domain.StoreStatusActive
+				storeRepo.On("FindByID", mock.Anything, store.ID).Return(store, nil).Once()
+				storeRepo.On("Update", mock.Anything, store).Return(nil).Once()
+				return u.ChangeStatus(userContext(owner), &domain.StoreStatusRequest{ID: store.ID, Action: domain.StoreActionDisable})
+			},
+		},
+	}
+
+	for i := range testCases {
+		tc := testCases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			storeRepo := new(mocks.StoreRepository)
+			accountRepo := new(mocks.AccountRepository)
+			accountRepo.On("Store", mock.Anything, mock.Anything).Return(nil)
+			categoryRepo := new(mocks.CategoryRepository)
+			categoryRepo.On("FindByID", mock.Anything, mock.Anything).Return(activeCategory, nil)
+			historyRepo := new(mocks.StoreStatusHistoryRepository)
+			historyRepo.On("Store", mock.Anything, mock.Anything).Return(nil)
+			outboxRepo := new(mocks.OutboxRepository)
+			outboxRepo.On("Store", mock.Anything, mock.Anything).Return(nil)
+			txManager := new(mocks.TxManager)
+			txManager.On("WithinTransaction", mock.Anything, mock.Anything).Return(withinTransaction)
+			u := usecases.NewStoreUsecase(storeRepo, accountRepo, nil, historyRepo, nil, categoryRepo, outboxRepo, txManager, nil, nil, time.Second*2)
+
+			err := tc.run(u, storeRepo)
+
+			if tc.expectedErr != nil {
+				assert.ErrorIs(t, err, tc.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			storeRepo.AssertExpectations(t)
 		})
 	}
 }
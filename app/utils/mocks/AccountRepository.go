@@ -51,6 +51,29 @@ func (_m *AccountRepository) FindByID(ctx context.Context, id string) (*domain.A
 	return r0, r1
 }
 
+// FindForUpdate provides a mock function with given fields: ctx, id
+func (_m *AccountRepository) FindForUpdate(ctx context.Context, id string) (*domain.Account, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Account
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Account); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Store provides a mock function with given fields: ctx, account
 func (_m *AccountRepository) Store(ctx context.Context, account *domain.Account) error {
 	ret := _m.Called(ctx, account)
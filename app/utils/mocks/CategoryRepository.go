@@ -14,6 +14,71 @@ type CategoryRepository struct {
 	mock.Mock
 }
 
+// CountChildren provides a mock function with given fields: ctx, id
+func (_m *CategoryRepository) CountChildren(ctx context.Context, id string) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *CategoryRepository) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FindAll provides a mock function with given fields: ctx, status, limit, page
+func (_m *CategoryRepository) FindAll(ctx context.Context, status string, limit int, page int) (domain.Categories, int64, error) {
+	ret := _m.Called(ctx, status, limit, page)
+
+	var r0 domain.Categories
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) domain.Categories); ok {
+		r0 = rf(ctx, status, limit, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.Categories)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
+		r1 = rf(ctx, status, limit, page)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, status, limit, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // FindByID provides a mock function with given fields: ctx, id
 func (_m *CategoryRepository) FindByID(ctx context.Context, id string) (*domain.Category, error) {
 	ret := _m.Called(ctx, id)
@@ -37,6 +102,29 @@ func (_m *CategoryRepository) FindByID(ctx context.Context, id string) (*domain.
 	return r0, r1
 }
 
+// FindTree provides a mock function with given fields: ctx
+func (_m *CategoryRepository) FindTree(ctx context.Context) (domain.Categories, error) {
+	ret := _m.Called(ctx)
+
+	var r0 domain.Categories
+	if rf, ok := ret.Get(0).(func(context.Context) domain.Categories); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.Categories)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Store provides a mock function with given fields: ctx, Category
 func (_m *CategoryRepository) Store(ctx context.Context, Category *domain.Category) error {
 	ret := _m.Called(ctx, Category)
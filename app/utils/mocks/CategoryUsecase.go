@@ -14,6 +14,20 @@ type CategoryUsecase struct {
 	mock.Mock
 }
 
+// Activate provides a mock function with given fields: ctx, id
+func (_m *CategoryUsecase) Activate(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Create provides a mock function with given fields: ctx, Category
 func (_m *CategoryUsecase) Create(ctx context.Context, Category *domain.Category) error {
 	ret := _m.Called(ctx, Category)
@@ -28,6 +42,110 @@ func (_m *CategoryUsecase) Create(ctx context.Context, Category *domain.Category
 	return r0
 }
 
+// Delete provides a mock function with given fields: ctx, id
+func (_m *CategoryUsecase) Delete(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Disable provides a mock function with given fields: ctx, id
+func (_m *CategoryUsecase) Disable(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *CategoryUsecase) Get(ctx context.Context, id string) (*domain.Category, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Category
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Category); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Category)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// List provides a mock function with given fields: ctx, status, limit, page
+func (_m *CategoryUsecase) List(ctx context.Context, status string, limit int, page int) (domain.Categories, int64, error) {
+	ret := _m.Called(ctx, status, limit, page)
+
+	var r0 domain.Categories
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) domain.Categories); ok {
+		r0 = rf(ctx, status, limit, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.Categories)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
+		r1 = rf(ctx, status, limit, page)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, status, limit, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Tree provides a mock function with given fields: ctx
+func (_m *CategoryUsecase) Tree(ctx context.Context) (domain.CategoryTree, error) {
+	ret := _m.Called(ctx)
+
+	var r0 domain.CategoryTree
+	if rf, ok := ret.Get(0).(func(context.Context) domain.CategoryTree); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.CategoryTree)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Update provides a mock function with given fields: ctx, Category
 func (_m *CategoryUsecase) Update(ctx context.Context, Category *domain.Category) error {
 	ret := _m.Called(ctx, Category)
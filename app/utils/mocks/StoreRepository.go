@@ -7,6 +7,8 @@ import (
 
 	domain "github.com/EdlanioJ/kbu-store/app/domain"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // StoreRepository is an autogenerated mock type for the StoreRepository type
@@ -42,13 +44,13 @@ func (_m *StoreRepository) Delete(ctx context.Context, id string) error {
 	return r0
 }
 
-// FindAll provides a mock function with given fields: ctx, sort, limit, page
-func (_m *StoreRepository) FindAll(ctx context.Context, sort string, limit int, page int) (domain.Stores, int64, error) {
-	ret := _m.Called(ctx, sort, limit, page)
+// FindAll provides a mock function with given fields: ctx, filter, sort, cursor, limit, page
+func (_m *StoreRepository) FindAll(ctx context.Context, filter *domain.StoreFilter, sort domain.SortSpec, cursor *domain.Cursor, limit int, page int) (domain.Stores, int64, error) {
+	ret := _m.Called(ctx, filter, sort, cursor, limit, page)
 
 	var r0 domain.Stores
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) domain.Stores); ok {
-		r0 = rf(ctx, sort, limit, page)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.StoreFilter, domain.SortSpec, *domain.Cursor, int, int) domain.Stores); ok {
+		r0 = rf(ctx, filter, sort, cursor, limit, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(domain.Stores)
@@ -56,15 +58,15 @@ func (_m *StoreRepository) FindAll(ctx context.Context, sort string, limit int,
 	}
 
 	var r1 int64
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
-		r1 = rf(ctx, sort, limit, page)
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.StoreFilter, domain.SortSpec, *domain.Cursor, int, int) int64); ok {
+		r1 = rf(ctx, filter, sort, cursor, limit, page)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
 	var r2 error
-	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
-		r2 = rf(ctx, sort, limit, page)
+	if rf, ok := ret.Get(2).(func(context.Context, *domain.StoreFilter, domain.SortSpec, *domain.Cursor, int, int) error); ok {
+		r2 = rf(ctx, filter, sort, cursor, limit, page)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -118,6 +120,105 @@ func (_m *StoreRepository) FindByName(ctx context.Context, name string) (*domain
 	return r0, r1
 }
 
+// FindDeleted provides a mock function with given fields: ctx, before, limit
+func (_m *StoreRepository) FindDeleted(ctx context.Context, before time.Time, limit int) (domain.Stores, error) {
+	ret := _m.Called(ctx, before, limit)
+
+	var r0 domain.Stores
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) domain.Stores); ok {
+		r0 = rf(ctx, before, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.Stores)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, before, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDeletedByID provides a mock function with given fields: ctx, id
+func (_m *StoreRepository) FindDeletedByID(ctx context.Context, id string) (*domain.Store, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Store
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Store); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Store)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindNearby provides a mock function with given fields: ctx, position, radiusKm, filter, limit
+func (_m *StoreRepository) FindNearby(ctx context.Context, position domain.Position, radiusKm float64, filter *domain.StoreFilter, limit int) (domain.NearbyStores, error) {
+	ret := _m.Called(ctx, position, radiusKm, filter, limit)
+
+	var r0 domain.NearbyStores
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Position, float64, *domain.StoreFilter, int) domain.NearbyStores); ok {
+		r0 = rf(ctx, position, radiusKm, filter, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.NearbyStores)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, domain.Position, float64, *domain.StoreFilter, int) error); ok {
+		r1 = rf(ctx, position, radiusKm, filter, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Search provides a mock function with given fields: ctx, query, filter, limit, page
+func (_m *StoreRepository) Search(ctx context.Context, query string, filter *domain.StoreFilter, limit int, page int) (domain.StoreSearchResults, int64, error) {
+	ret := _m.Called(ctx, query, filter, limit, page)
+
+	var r0 domain.StoreSearchResults
+	if rf, ok := ret.Get(0).(func(context.Context, string, *domain.StoreFilter, int, int) domain.StoreSearchResults); ok {
+		r0 = rf(ctx, query, filter, limit, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.StoreSearchResults)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, string, *domain.StoreFilter, int, int) int64); ok {
+		r1 = rf(ctx, query, filter, limit, page)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, *domain.StoreFilter, int, int) error); ok {
+		r2 = rf(ctx, query, filter, limit, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Update provides a mock function with given fields: ctx, store
 func (_m *StoreRepository) Update(ctx context.Context, store *domain.Store) error {
 	ret := _m.Called(ctx, store)
@@ -6,6 +6,9 @@ import (
 	context "context"
 
 	domain "github.com/EdlanioJ/kbu-store/app/domain"
+
+	io "io"
+
 	mock "github.com/stretchr/testify/mock"
 )
 
@@ -14,27 +17,36 @@ type StoreUsecase struct {
 	mock.Mock
 }
 
-// Active provides a mock function with given fields: ctx, id
-func (_m *StoreUsecase) Active(ctx context.Context, id string) error {
+// Actions provides a mock function with given fields: ctx, id
+func (_m *StoreUsecase) Actions(ctx context.Context, id string) (domain.StoreTransitions, error) {
 	ret := _m.Called(ctx, id)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+	var r0 domain.StoreTransitions
+	if rf, ok := ret.Get(0).(func(context.Context, string) domain.StoreTransitions); ok {
 		r0 = rf(ctx, id)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.StoreTransitions)
+		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// Block provides a mock function with given fields: ctx, id
-func (_m *StoreUsecase) Block(ctx context.Context, id string) error {
-	ret := _m.Called(ctx, id)
+// ChangeStatus provides a mock function with given fields: ctx, param
+func (_m *StoreUsecase) ChangeStatus(ctx context.Context, param *domain.StoreStatusRequest) error {
+	ret := _m.Called(ctx, param)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.StoreStatusRequest) error); ok {
+		r0 = rf(ctx, param)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -56,13 +68,36 @@ func (_m *StoreUsecase) Delete(ctx context.Context, id string) error {
 	return r0
 }
 
-// Disable provides a mock function with given fields: ctx, id
-func (_m *StoreUsecase) Disable(ctx context.Context, id string) error {
-	ret := _m.Called(ctx, id)
+// Deposit provides a mock function with given fields: ctx, param
+func (_m *StoreUsecase) Deposit(ctx context.Context, param *domain.AccountOperationRequest) (*domain.Account, error) {
+	ret := _m.Called(ctx, param)
+
+	var r0 *domain.Account
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AccountOperationRequest) *domain.Account); ok {
+		r0 = rf(ctx, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.AccountOperationRequest) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Export provides a mock function with given fields: ctx, format, w
+func (_m *StoreUsecase) Export(ctx context.Context, format string, w io.Writer) error {
+	ret := _m.Called(ctx, format, w)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Writer) error); ok {
+		r0 = rf(ctx, format, w)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -93,29 +128,234 @@ func (_m *StoreUsecase) Get(ctx context.Context, id string) (*domain.Store, erro
 	return r0, r1
 }
 
-// Index provides a mock function with given fields: ctx, sort, limit, page
-func (_m *StoreUsecase) Index(ctx context.Context, sort string, limit int, page int) (domain.Stores, int64, error) {
-	ret := _m.Called(ctx, sort, limit, page)
+// GetAccount provides a mock function with given fields: ctx, id
+func (_m *StoreUsecase) GetAccount(ctx context.Context, id string) (*domain.Account, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Account
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Account); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Import provides a mock function with given fields: ctx, param
+func (_m *StoreUsecase) Import(ctx context.Context, param *domain.StoreImportRequest) (*domain.StoreImportReport, error) {
+	ret := _m.Called(ctx, param)
+
+	var r0 *domain.StoreImportReport
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.StoreImportRequest) *domain.StoreImportReport); ok {
+		r0 = rf(ctx, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.StoreImportReport)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.StoreImportRequest) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Index provides a mock function with given fields: ctx, filter, sort, cursor, limit, page
+func (_m *StoreUsecase) Index(ctx context.Context, filter *domain.StoreFilter, sort string, cursor string, limit int, page int) (*domain.StorePage, error) {
+	ret := _m.Called(ctx, filter, sort, cursor, limit, page)
+
+	var r0 *domain.StorePage
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.StoreFilter, string, string, int, int) *domain.StorePage); ok {
+		r0 = rf(ctx, filter, sort, cursor, limit, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.StorePage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.StoreFilter, string, string, int, int) error); ok {
+		r1 = rf(ctx, filter, sort, cursor, limit, page)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTransactions provides a mock function with given fields: ctx, id, limit, page
+func (_m *StoreUsecase) ListTransactions(ctx context.Context, id string, limit int, page int) (domain.LedgerEntries, int64, error) {
+	ret := _m.Called(ctx, id, limit, page)
 
-	var r0 domain.Stores
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) domain.Stores); ok {
-		r0 = rf(ctx, sort, limit, page)
+	var r0 domain.LedgerEntries
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) domain.LedgerEntries); ok {
+		r0 = rf(ctx, id, limit, page)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(domain.Stores)
+			r0 = ret.Get(0).(domain.LedgerEntries)
 		}
 	}
 
 	var r1 int64
 	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
-		r1 = rf(ctx, sort, limit, page)
+		r1 = rf(ctx, id, limit, page)
 	} else {
 		r1 = ret.Get(1).(int64)
 	}
 
 	var r2 error
 	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
-		r2 = rf(ctx, sort, limit, page)
+		r2 = rf(ctx, id, limit, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Nearby provides a mock function with given fields: ctx, param
+func (_m *StoreUsecase) Nearby(ctx context.Context, param *domain.NearbyStoreRequest) (domain.NearbyStores, error) {
+	ret := _m.Called(ctx, param)
+
+	var r0 domain.NearbyStores
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.NearbyStoreRequest) domain.NearbyStores); ok {
+		r0 = rf(ctx, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.NearbyStores)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.NearbyStoreRequest) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReconcileAccount provides a mock function with given fields: ctx, id
+func (_m *StoreUsecase) ReconcileAccount(ctx context.Context, id string) (*domain.Reconciliation, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *domain.Reconciliation
+	if rf, ok := ret.Get(0).(func(context.Context, string) *domain.Reconciliation); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Reconciliation)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Restore provides a mock function with given fields: ctx, id
+func (_m *StoreUsecase) Restore(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Search provides a mock function with given fields: ctx, param
+func (_m *StoreUsecase) Search(ctx context.Context, param *domain.SearchStoreRequest) (*domain.StoreSearchPage, error) {
+	ret := _m.Called(ctx, param)
+
+	var r0 *domain.StoreSearchPage
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.SearchStoreRequest) *domain.StoreSearchPage); ok {
+		r0 = rf(ctx, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.StoreSearchPage)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.SearchStoreRequest) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetHours provides a mock function with given fields: ctx, param
+func (_m *StoreUsecase) SetHours(ctx context.Context, param *domain.StoreHoursRequest) (*domain.Store, error) {
+	ret := _m.Called(ctx, param)
+
+	var r0 *domain.Store
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.StoreHoursRequest) *domain.Store); ok {
+		r0 = rf(ctx, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Store)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.StoreHoursRequest) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StatusHistory provides a mock function with given fields: ctx, id, limit, page
+func (_m *StoreUsecase) StatusHistory(ctx context.Context, id string, limit int, page int) (domain.StoreStatusHistory, int64, error) {
+	ret := _m.Called(ctx, id, limit, page)
+
+	var r0 domain.StoreStatusHistory
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) domain.StoreStatusHistory); ok {
+		r0 = rf(ctx, id, limit, page)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(domain.StoreStatusHistory)
+		}
+	}
+
+	var r1 int64
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
+		r1 = rf(ctx, id, limit, page)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, id, limit, page)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -150,3 +390,49 @@ func (_m *StoreUsecase) Update(ctx context.Context, param *domain.UpdateStoreReq
 
 	return r0
 }
+
+// UploadImage provides a mock function with given fields: ctx, param
+func (_m *StoreUsecase) UploadImage(ctx context.Context, param *domain.StoreImageRequest) (*domain.Store, error) {
+	ret := _m.Called(ctx, param)
+
+	var r0 *domain.Store
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.StoreImageRequest) *domain.Store); ok {
+		r0 = rf(ctx, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Store)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.StoreImageRequest) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Withdraw provides a mock function with given fields: ctx, param
+func (_m *StoreUsecase) Withdraw(ctx context.Context, param *domain.AccountOperationRequest) (*domain.Account, error) {
+	ret := _m.Called(ctx, param)
+
+	var r0 *domain.Account
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AccountOperationRequest) *domain.Account); ok {
+		r0 = rf(ctx, param)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Account)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.AccountOperationRequest) error); ok {
+		r1 = rf(ctx, param)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,16 @@
+package sample
+
+import (
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	uuid "github.com/satori/go.uuid"
+)
+
+func NewInboxMessage() *domain.InboxMessage {
+	msg := new(domain.InboxMessage)
+	msg.ID = "store.catetory.create:" + uuid.NewV4().String()
+	msg.Topic = "store.catetory.create"
+	msg.CreatedAt = time.Now()
+	return msg
+}
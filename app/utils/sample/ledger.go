@@ -0,0 +1,22 @@
+package sample
+
+import (
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	uuid "github.com/satori/go.uuid"
+	"github.com/shopspring/decimal"
+)
+
+func NewLedgerEntry() *domain.LedgerEntry {
+	entry := new(domain.LedgerEntry)
+	entry.ID = uuid.NewV4().String()
+	entry.CreatedAt = time.Now()
+	entry.AccountID = uuid.NewV4().String()
+	entry.Amount = decimal.NewFromFloat(10)
+	entry.Direction = domain.LedgerDirectionCredit
+	entry.Reason = domain.AccountOperationDeposit
+	entry.Balance = decimal.NewFromFloat(1010)
+
+	return entry
+}
@@ -0,0 +1,18 @@
+package sample
+
+import (
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	uuid "github.com/satori/go.uuid"
+)
+
+func NewOutboxMessage() *domain.OutboxMessage {
+	msg := new(domain.OutboxMessage)
+	msg.ID = uuid.NewV4().String()
+	msg.Topic = "store.new"
+	msg.Payload = `{"name":"Store 001"}`
+	msg.CreatedAt = time.Now()
+	msg.NextAttemptAt = msg.CreatedAt
+	return msg
+}
@@ -1,6 +1,7 @@
 package sample
 
 import (
+	"strings"
 	"time"
 
 	"github.com/EdlanioJ/kbu-store/app/domain"
@@ -27,6 +28,7 @@ func NewStore() *domain.Store {
 			Lat: -8.8368200,
 			Lng: 13.2343200,
 		},
+		Version: 1,
 	}
 
 	return store
@@ -73,7 +75,6 @@ func NewCreateStoreRequest() *domain.CreateStoreRequest {
 		Name:        "Store 001",
 		Description: "Store description",
 		CategoryID:  uuid.NewV4().String(),
-		UserID:      uuid.NewV4().String(),
 		Tags:        []string{"tag001", "tag002"},
 		Lat:         -8.8867698,
 		Lng:         13.4771186,
@@ -81,15 +82,18 @@ func NewCreateStoreRequest() *domain.CreateStoreRequest {
 }
 
 func NewUpdateStoreRequest() *domain.UpdateStoreRequest {
+	name := "store 002"
+	description := "description 002"
+	tags := []string{"tag002", "tag003"}
+	lat := -8.8867698
+	lng := 13.4771186
 	return &domain.UpdateStoreRequest{
 		ID:          uuid.NewV4().String(),
-		Name:        "store 002",
-		Description: "description 002",
-		Image:       "image.png",
-		CategoryID:  uuid.NewV4().String(),
-		Tags:        []string{"tag002", "tag003"},
-		Lat:         -8.8867698,
-		Lng:         13.4771186,
+		Name:        &name,
+		Description: &description,
+		Tags:        &tags,
+		Lat:         &lat,
+		Lng:         &lng,
 	}
 }
 
@@ -124,3 +128,55 @@ func NewStoreUsecaseRequest() StoreUsecaseCreateRequest {
 		Tags:        []string{"tag002", "tag003"},
 	}
 }
+
+func NewStoreImageRequest() *domain.StoreImageRequest {
+	return &domain.StoreImageRequest{
+		ID:          uuid.NewV4().String(),
+		ContentType: "image/jpeg",
+		Size:        5,
+		Body:        strings.NewReader("image"),
+	}
+}
+
+func NewEncodedImages() []*domain.EncodedImage {
+	images := make([]*domain.EncodedImage, 0, len(domain.StoreImageVariants))
+	for _, variant := range domain.StoreImageVariants {
+		images = append(images, &domain.EncodedImage{
+			Variant:     variant.Name,
+			ContentType: "image/jpeg",
+			Width:       variant.Width,
+			Height:      variant.Height,
+			Data:        []byte(variant.Name),
+		})
+	}
+	return images
+}
+
+func NewStoreHours() *domain.StoreHours {
+	storeID := uuid.NewV4().String()
+	hours := &domain.StoreHours{
+		StoreID:   storeID,
+		Timezone:  "Africa/Luanda",
+		Closures:  make([]*domain.StoreClosure, 0),
+		UpdatedAt: time.Now(),
+	}
+	for weekday := 0; weekday < 7; weekday++ {
+		hours.Weekly = append(hours.Weekly, &domain.OpeningHours{StoreID: storeID, Weekday: weekday, Opens: "00:00", Closes: "24:00"})
+	}
+	return hours
+}
+
+func NewStoreHoursRequest() *domain.StoreHoursRequest {
+	return &domain.StoreHoursRequest{
+		ID:       uuid.NewV4().String(),
+		Timezone: "Africa/Luanda",
+		Weekly: []*domain.OpeningHours{
+			{Weekday: 1, Opens: "09:00", Closes: "13:00"},
+			{Weekday: 1, Opens: "14:00", Closes: "18:00"},
+			{Weekday: 5, Opens: "20:00", Closes: "02:00"},
+		},
+		Closures: []*domain.StoreClosure{
+			{From: "2021-12-25", Reason: "Christmas"},
+		},
+	}
+}
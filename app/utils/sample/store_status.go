@@ -0,0 +1,24 @@
+package sample
+
+import (
+	"time"
+
+	"github.com/EdlanioJ/kbu-store/app/domain"
+	uuid "github.com/satori/go.uuid"
+)
+
+func NewStoreStatusChange() *domain.StoreStatusChange {
+	expiresAt := time.Now().Add(time.Hour)
+
+	change := new(domain.StoreStatusChange)
+	change.ID = uuid.NewV4().String()
+	change.StoreID = uuid.NewV4().String()
+	change.FromStatus = domain.StoreStatusActive
+	change.ToStatus = domain.StoreStatusBlock
+	change.ActorID = uuid.NewV4().String()
+	change.Reason = "fraudulent listings"
+	change.ExpiresAt = &expiresAt
+	change.CreatedAt = time.Now()
+
+	return change
+}